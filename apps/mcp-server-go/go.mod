@@ -4,6 +4,12 @@ go 1.24.0
 
 toolchain go1.24.12
 
-require github.com/google/uuid v1.6.0
+require (
+	github.com/google/uuid v1.6.0
+	pathfinder-wasm v0.0.0
+)
 
 require gonum.org/v1/gonum v0.17.0 // indirect
+
+// The simulation engine is shared with the WASM build; see wasm/engine.
+replace pathfinder-wasm => ../../wasm
@@ -0,0 +1,647 @@
+// Package extract turns short natural-language descriptions of a financial
+// situation into draft changes against FullSimulationParams.
+//
+// The extractor is deterministic and rule-based: it splits the text into
+// clauses, finds dollar amounts, ages, frequencies, account names and a few
+// life events in each clause, and maps them onto field paths (the argument
+// names run_simulation_packet reads) or engine FinancialEvents.
+// Every change carries a confidence and the span of text it came from, so a
+// client can show the draft for confirmation without another LLM round-trip.
+package extract
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"pathfinder-wasm/engine"
+)
+
+// Field paths are the run_simulation_packet argument names
+const (
+	FieldCurrentAge            = "currentAge"
+	FieldExpectedIncome        = "expectedIncome"
+	FieldAnnualSpending        = "annualSpending"
+	FieldCashBalance           = "cashBalance"
+	FieldTaxableBalance        = "taxableBalance"
+	FieldRetirement401kBalance = "retirement401kBalance"
+	FieldRothBalance           = "rothBalance"
+	FieldFiveTwoNineBalance    = "fiveTwoNineBalance"
+	FieldContribution401k      = "contribution401k"
+	FieldContributionRoth      = "contributionRoth"
+	FieldContributionHSA       = "contributionHSA"
+	FieldRetirementAge         = "retirementAge"
+	FieldSabbaticalMonthOffset = "sabbaticalMonthOffset"
+	FieldSabbaticalMonths      = "sabbaticalMonths"
+	FieldSocialSecurityAge     = "socialSecurityAge"
+	FieldSocialSecurityBenefit = "socialSecurityBenefit"
+	FieldEvents                = "events"
+)
+
+// Span locates the source text of a change (byte offsets into the input)
+type Span struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Text  string `json:"text"`
+}
+
+// Change is one proposed edit to the simulation parameters
+type Change struct {
+	FieldPath  string      `json:"fieldPath"`           // run_simulation_packet argument, or "events" to append Value
+	Value      interface{} `json:"value"`               // float64/int for scalar fields, engine.FinancialEvent for events
+	EventType  string      `json:"eventType,omitempty"` // Set when FieldPath is "events"
+	Confidence float64     `json:"confidence"`          // 0-1
+	Source     Span        `json:"source"`
+	Note       string      `json:"note,omitempty"` // Assumption made while extracting
+}
+
+// Result is the extractor output
+type Result struct {
+	Changes    []Change `json:"changes"`
+	Confidence float64  `json:"confidence"` // Mean change confidence; 0 when nothing was found
+	RawText    string   `json:"rawText"`
+}
+
+type frequency int
+
+const (
+	freqUnknown frequency = iota
+	freqMonthly
+	freqAnnual
+)
+
+type accountKind int
+
+const (
+	accountNone accountKind = iota
+	accountTaxDeferred
+	accountRoth
+	accountHSA
+	accountTaxable
+	accountCash
+	account529
+)
+
+type accountIntent int
+
+const (
+	intentNone accountIntent = iota
+	intentBalance
+	intentContribution
+)
+
+// clause is a fragment of the input with its byte offset
+type clause struct {
+	text  string
+	lower string
+	start int
+}
+
+func (c clause) span() Span {
+	return Span{Start: c.start, End: c.start + len(c.text), Text: c.text}
+}
+
+type amount struct {
+	value float64
+	start int // Offset within the clause
+}
+
+var (
+	clauseSep = regexp.MustCompile(`[;!?\n]+|\.(?:\s+|$)|,\s+(?:and\s+|but\s+)?|\s+(?:and|but|plus)\s+`)
+
+	// Account names containing digits are masked before amounts are scanned so
+	// "401k" is never read as $401,000
+	accountDigits = regexp.MustCompile(`(?i)\b40[13]\s*\(?[kb]\)?|\b529\b`)
+
+	moneyPattern = regexp.MustCompile(`(?i)\$\s*(\d[\d,]*(?:\.\d+)?)\s*(k|mm|m|million|thousand|grand)?\b|\b(\d[\d,]*(?:\.\d+)?)\s*(k|mm|m|million|thousand|grand)\b|\b(\d[\d,]*(?:\.\d+)?)\s*(?:dollars|bucks)\b|\b(\d{1,3}(?:,\d{3})+|\d{4,})\b`)
+
+	monthlyPattern = regexp.MustCompile(`(?i)(?:\b(?:per|a|each|every)\s+|/\s*)(?:month|mo)\b|\bmonthly\b`)
+	annualPattern  = regexp.MustCompile(`(?i)(?:\b(?:per|a|each|every)\s+|/\s*)(?:year|yr)\b|\b(?:annually|yearly|annual|per annum)\b`)
+
+	currentAgePatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\bi'?m\s+(\d{2})\b`),
+		regexp.MustCompile(`(?i)\bi am\s+(\d{2})\b`),
+		regexp.MustCompile(`(?i)\b(\d{2})\s*(?:years?|yrs?)[\s-]*old\b`),
+		regexp.MustCompile(`(?i)\b(?:aged|my age is|current age(?: is)?)\s+(\d{2})\b`),
+	}
+	targetAgePattern = regexp.MustCompile(`(?i)\b(?:at|by|when i'?m|when i am|starting at|from)\s+(?:age\s+)?(\d{2})\b`)
+	relativePattern  = regexp.MustCompile(`(?i)\bin\s+(\d+|a|an|one|two|three|four|five|six|seven|eight|nine|ten|twelve|eighteen)\s+(years?|months?)\b`)
+	durationPattern  = regexp.MustCompile(`(?i)\b(\d+|a|an|one|two|three|four|five|six|seven|eight|nine|ten|twelve|eighteen)[\s-]+(years?|months?)\b(?:[\s-]+long)?`)
+	percentPattern   = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*%\s*down`)
+
+	sabbaticalPattern = regexp.MustCompile(`(?i)\b(?:sabbatical|career break|mini[- ]retirement|time off work|year off|months off)\b`)
+	housePattern      = regexp.MustCompile(`(?i)\b(?:buy|buying|purchase|purchasing|get)\b.*\b(?:house|home|condo|townhouse|property|place)\b`)
+	retirePattern     = regexp.MustCompile(`(?i)\bretir(?:e|ing|ement)\b`)
+	socialSecPattern  = regexp.MustCompile(`(?i)\bsocial security\b|\bss benefits?\b`)
+	pensionPattern    = regexp.MustCompile(`(?i)\bpension\b`)
+	incomePattern     = regexp.MustCompile(`(?i)\b(?:make|making|made|earn|earning|earns|salary|income|paid|bring in|take home|gross)\b`)
+	spendingPattern   = regexp.MustCompile(`(?i)\b(?:spend|spending|spent|expenses?|cost of living|budget|burn)\b`)
+	contribPattern    = regexp.MustCompile(`(?i)\b(?:contribute|contributing|contribution|put|putting|save|saving|invest|investing|deposit|depositing|add|adding|max(?:ing)? out|maxing)\b`)
+	balancePattern    = regexp.MustCompile(`(?i)\b(?:have|has|had|got|balance|worth|sitting|holding|hold|saved up|in savings)\b`)
+	maxOutPattern     = regexp.MustCompile(`(?i)\bmax(?:ing|ed)?\s*out\b|\bthe max\b`)
+
+	accountPatterns = []struct {
+		kind    accountKind
+		pattern *regexp.Regexp
+	}{
+		// Order matters: "roth ira" must match Roth before the generic IRA rule
+		{accountRoth, regexp.MustCompile(`(?i)\broth\b`)},
+		{accountHSA, regexp.MustCompile(`(?i)\bhsa\b|\bhealth savings\b`)},
+		{account529, regexp.MustCompile(`(?i)\b529\b|\bcollege (?:fund|savings)\b`)},
+		{accountTaxDeferred, regexp.MustCompile(`(?i)\b40[13]\s*\(?[kb]\)?|\btraditional ira\b|\bira\b|\bpre-?tax\b|\btax[- ]deferred\b|\bretirement accounts?\b`)},
+		{accountTaxable, regexp.MustCompile(`(?i)\bbrokerage\b|\btaxable\b|\bindex funds?\b|\bstocks?\b|\bvanguard\b|\bfidelity\b|\bschwab\b`)},
+		{accountCash, regexp.MustCompile(`(?i)\bcash\b|\bchecking\b|\bsavings(?: account)?\b|\bemergency fund\b|\bhigh[- ]yield\b|\bbank\b`)},
+	}
+
+	wordNumbers = map[string]int{
+		"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+		"seven": 7, "eight": 8, "nine": 9, "ten": 10, "twelve": 12, "eighteen": 18,
+	}
+)
+
+// extractor carries state across clauses
+type extractor struct {
+	changes    []Change
+	currentAge int
+	year       int // Contribution limits for "max out"
+
+	// Account clauses without a verb ("and $50k in Roth") inherit these
+	lastIntent accountIntent
+	lastFreq   frequency
+}
+
+// Extract parses text into draft parameter changes; "max out" reads the
+// contribution limits of year
+func Extract(text string, year int) Result {
+	clauses := splitClauses(text)
+	x := &extractor{year: year}
+
+	// Ages anchor "at 60" and "in 5 years" conversions, so find the current age first
+	for _, c := range clauses {
+		x.scanCurrentAge(c)
+	}
+	for _, c := range clauses {
+		x.scanClause(c)
+	}
+
+	result := Result{Changes: x.changes, RawText: text}
+	if result.Changes == nil {
+		result.Changes = []Change{}
+	}
+	if len(result.Changes) > 0 {
+		total := 0.0
+		for _, ch := range result.Changes {
+			total += ch.Confidence
+		}
+		result.Confidence = roundConfidence(total / float64(len(result.Changes)))
+	}
+	return result
+}
+
+func splitClauses(text string) []clause {
+	var clauses []clause
+	start := 0
+	for _, loc := range clauseSep.FindAllStringIndex(text, -1) {
+		clauses = appendClause(clauses, text, start, loc[0])
+		start = loc[1]
+	}
+	return appendClause(clauses, text, start, len(text))
+}
+
+func appendClause(clauses []clause, text string, start, end int) []clause {
+	raw := text[start:end]
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return clauses
+	}
+	offset := start + strings.Index(raw, trimmed)
+	return append(clauses, clause{text: trimmed, lower: strings.ToLower(trimmed), start: offset})
+}
+
+func (x *extractor) add(c clause, field string, value interface{}, confidence float64, note string) {
+	ch := Change{
+		FieldPath:  field,
+		Value:      value,
+		Confidence: roundConfidence(confidence),
+		Source:     c.span(),
+		Note:       note,
+	}
+	if ev, ok := value.(engine.FinancialEvent); ok {
+		ch.EventType = ev.Type
+	}
+	x.changes = append(x.changes, ch)
+}
+
+func (x *extractor) scanCurrentAge(c clause) {
+	if x.currentAge > 0 {
+		return
+	}
+	for _, p := range currentAgePatterns {
+		if m := p.FindStringSubmatch(c.lower); m != nil {
+			if age, err := strconv.Atoi(m[1]); err == nil && age >= 16 && age <= 100 {
+				x.currentAge = age
+				x.add(c, FieldCurrentAge, age, 0.95, "")
+				return
+			}
+		}
+	}
+}
+
+func (x *extractor) scanClause(c clause) {
+	amounts := findAmounts(c.text)
+	freq := detectFrequency(c.lower)
+
+	switch {
+	case sabbaticalPattern.MatchString(c.lower):
+		x.sabbatical(c)
+	case housePattern.MatchString(c.lower) && len(amounts) > 0:
+		x.housePurchase(c, amounts[0])
+	case retirePattern.MatchString(c.lower) && !socialSecPattern.MatchString(c.lower) && !pensionPattern.MatchString(c.lower):
+		x.retirement(c)
+	case socialSecPattern.MatchString(c.lower):
+		x.socialSecurity(c, amounts, freq)
+	case pensionPattern.MatchString(c.lower) && len(amounts) > 0:
+		x.pension(c, amounts[0], freq)
+	case detectAccount(c.lower) != accountNone && (len(amounts) > 0 || maxOutPattern.MatchString(c.lower)):
+		x.account(c, amounts, freq)
+	case incomePattern.MatchString(c.lower) && len(amounts) > 0:
+		x.cashFlow(c, FieldExpectedIncome, amounts[0], freq)
+	case spendingPattern.MatchString(c.lower) && len(amounts) > 0:
+		x.cashFlow(c, FieldAnnualSpending, amounts[0], freq)
+	}
+}
+
+// cashFlow handles annual income and spending figures
+func (x *extractor) cashFlow(c clause, field string, a amount, freq frequency) {
+	value, confidence, note := annualize(a.value, freq)
+	if field == FieldExpectedIncome && strings.Contains(c.lower, "take home") {
+		confidence -= 0.15
+		note = joinNotes(note, "take-home pay treated as gross income")
+	}
+	x.add(c, field, value, confidence, note)
+}
+
+// annualize converts an amount to a yearly figure; small amounts with no stated
+// frequency are read as monthly
+func annualize(value float64, freq frequency) (float64, float64, string) {
+	switch freq {
+	case freqMonthly:
+		return value * 12, 0.9, ""
+	case freqAnnual:
+		return value, 0.9, ""
+	}
+	if value < 20000 {
+		return value * 12, 0.55, "no frequency given; small amount assumed monthly"
+	}
+	return value, 0.75, "no frequency given; assumed annual"
+}
+
+func (x *extractor) account(c clause, amounts []amount, freq frequency) {
+	kind := detectAccount(c.lower)
+	intent := intentNone
+	switch {
+	case maxOutPattern.MatchString(c.lower) || contribPattern.MatchString(c.lower) && (freq != freqUnknown || !balancePattern.MatchString(c.lower)):
+		intent = intentContribution
+	case balancePattern.MatchString(c.lower):
+		intent = intentBalance
+	case freq != freqUnknown:
+		intent = intentContribution
+	}
+
+	inherited := false
+	if intent == intentNone {
+		intent = x.lastIntent
+		inherited = true
+		if freq == freqUnknown {
+			freq = x.lastFreq
+		}
+	}
+	if intent == intentNone {
+		intent = intentBalance
+	}
+	x.lastIntent, x.lastFreq = intent, freq
+
+	if intent == intentBalance {
+		if len(amounts) == 0 {
+			return
+		}
+		field := balanceField(kind)
+		if field == "" {
+			return
+		}
+		confidence := 0.85
+		if inherited {
+			confidence = 0.75
+		}
+		x.add(c, field, amounts[0].value, confidence, "")
+		return
+	}
+
+	field := contributionField(kind)
+	if field == "" {
+		if kind == accountTaxable && len(amounts) > 0 {
+			x.taxableContribution(c, amounts[0], freq, inherited)
+		}
+		return
+	}
+	if len(amounts) == 0 {
+		if !maxOutPattern.MatchString(c.lower) {
+			return
+		}
+		limits, err := engine.LookupTaxYear(x.year, engine.FilingStatusSingle, 0, engine.TaxLawScheduleCurrentLaw)
+		if err != nil {
+			return
+		}
+		limit := map[string]float64{
+			FieldContribution401k: limits.ContributionLimits.DeferredContributionLimit,
+			FieldContributionRoth: limits.ContributionLimits.IRAContributionLimit,
+			FieldContributionHSA:  limits.ContributionLimits.HSAIndividualLimit,
+		}[field]
+		note := fmt.Sprintf("\"max out\" read as the %d annual limit", x.year)
+		if limits.Projected {
+			note = fmt.Sprintf("\"max out\" read as the %d annual limit, held at the latest published year", x.year)
+		}
+		x.add(c, field, limit, 0.7, note)
+		return
+	}
+	value, confidence, note := annualize(amounts[0].value, freq)
+	if inherited {
+		confidence -= 0.1
+	}
+	x.add(c, field, value, confidence, note)
+}
+
+// taxableContribution has no scalar field, so it becomes a scheduled contribution event
+func (x *extractor) taxableContribution(c clause, a amount, freq frequency, inherited bool) {
+	annual, confidence, note := annualize(a.value, freq)
+	if inherited {
+		confidence -= 0.1
+	}
+	target := "taxable"
+	x.add(c, FieldEvents, engine.FinancialEvent{
+		ID:                fmt.Sprintf("extracted-taxable-contribution-%d", c.start),
+		Type:              "SCHEDULED_CONTRIBUTION",
+		Description:       "Brokerage contribution",
+		Amount:            annual / 12,
+		Frequency:         "monthly",
+		TargetAccountType: &target,
+	}, confidence, note)
+}
+
+func balanceField(kind accountKind) string {
+	switch kind {
+	case accountTaxDeferred:
+		return FieldRetirement401kBalance
+	case accountRoth:
+		return FieldRothBalance
+	case accountTaxable:
+		return FieldTaxableBalance
+	case accountCash:
+		return FieldCashBalance
+	case account529:
+		return FieldFiveTwoNineBalance
+	}
+	// No HSA balance field on FullSimulationParams
+	return ""
+}
+
+func contributionField(kind accountKind) string {
+	switch kind {
+	case accountTaxDeferred:
+		return FieldContribution401k
+	case accountRoth:
+		return FieldContributionRoth
+	case accountHSA:
+		return FieldContributionHSA
+	}
+	return ""
+}
+
+func (x *extractor) retirement(c clause) {
+	if age, ok := targetAge(c.lower); ok {
+		x.add(c, FieldRetirementAge, age, 0.9, "")
+		return
+	}
+	if months, ok := relativeMonths(c.lower); ok && x.currentAge > 0 {
+		x.add(c, FieldRetirementAge, x.currentAge+months/12, 0.8, "relative to stated current age")
+	}
+}
+
+func (x *extractor) socialSecurity(c clause, amounts []amount, freq frequency) {
+	if age, ok := targetAge(c.lower); ok {
+		x.add(c, FieldSocialSecurityAge, age, 0.9, "")
+	}
+	if len(amounts) == 0 {
+		return
+	}
+	// The benefit field is monthly
+	value, confidence, note := amounts[0].value, 0.9, ""
+	switch freq {
+	case freqAnnual:
+		value /= 12
+	case freqUnknown:
+		if value >= 10000 {
+			value /= 12
+			confidence, note = 0.6, "no frequency given; large amount assumed annual"
+		} else {
+			confidence, note = 0.75, "no frequency given; assumed monthly"
+		}
+	}
+	x.add(c, FieldSocialSecurityBenefit, value, confidence, note)
+}
+
+func (x *extractor) pension(c clause, a amount, freq frequency) {
+	monthly, confidence, note := a.value, 0.85, ""
+	switch freq {
+	case freqAnnual:
+		monthly /= 12
+	case freqUnknown:
+		if monthly >= 10000 {
+			monthly /= 12
+			confidence, note = 0.6, "no frequency given; large amount assumed annual"
+		} else {
+			confidence, note = 0.7, "no frequency given; assumed monthly"
+		}
+	}
+	offset, offsetConfidence, offsetNote := x.startMonth(c)
+	x.add(c, FieldEvents, engine.FinancialEvent{
+		ID:          fmt.Sprintf("extracted-pension-%d", c.start),
+		Type:        "PENSION_INCOME",
+		Description: "Pension income",
+		MonthOffset: offset,
+		Amount:      monthly,
+		Frequency:   "monthly",
+	}, minFloat(confidence, offsetConfidence), joinNotes(note, offsetNote))
+}
+
+func (x *extractor) housePurchase(c clause, a amount) {
+	downPayment := 0.20
+	downNote := "down payment assumed 20%"
+	if m := percentPattern.FindStringSubmatch(c.lower); m != nil {
+		if pct, err := strconv.ParseFloat(m[1], 64); err == nil && pct > 0 && pct <= 100 {
+			downPayment = pct / 100
+			downNote = ""
+		}
+	}
+	offset, confidence, offsetNote := x.startMonth(c)
+	x.add(c, FieldEvents, engine.FinancialEvent{
+		ID:          fmt.Sprintf("extracted-home-purchase-%d", c.start),
+		Type:        "REAL_ESTATE_PURCHASE",
+		Description: "Home purchase",
+		MonthOffset: offset,
+		Amount:      a.value,
+		Metadata: map[string]interface{}{
+			"propertyDetails": map[string]interface{}{
+				"downPaymentPercent": downPayment,
+			},
+		},
+	}, minFloat(0.85, confidence), joinNotes(offsetNote, downNote))
+}
+
+func (x *extractor) sabbatical(c clause) {
+	months := 12
+	confidence := 0.6
+	note := "length not given; assumed 12 months"
+	// Strip the "in N years" start phrase so it is not read as the length
+	rest := relativePattern.ReplaceAllString(c.lower, "")
+	if m := durationPattern.FindStringSubmatch(rest); m != nil {
+		months = toMonths(m[1], m[2])
+		confidence, note = 0.85, ""
+	}
+	offset, offsetConfidence, offsetNote := x.startMonth(c)
+	x.add(c, FieldSabbaticalMonths, months, confidence, note)
+	x.add(c, FieldSabbaticalMonthOffset, offset, offsetConfidence, offsetNote)
+}
+
+// startMonth resolves when an event begins from "in N years", "next year" or "at 55"
+func (x *extractor) startMonth(c clause) (int, float64, string) {
+	if months, ok := relativeMonths(c.lower); ok {
+		return months, 0.85, ""
+	}
+	if strings.Contains(c.lower, "next year") {
+		return 12, 0.8, ""
+	}
+	if age, ok := targetAge(c.lower); ok {
+		if x.currentAge > 0 && age >= x.currentAge {
+			return (age - x.currentAge) * 12, 0.85, ""
+		}
+		return 0, 0.4, fmt.Sprintf("starts at age %d but current age is unknown; placed at month 0", age)
+	}
+	return 0, 0.6, "no start date given; placed at month 0"
+}
+
+func findAmounts(text string) []amount {
+	masked := accountDigits.ReplaceAllStringFunc(text, func(s string) string {
+		return strings.Repeat("#", len(s))
+	})
+	var amounts []amount
+	for _, m := range moneyPattern.FindAllStringSubmatchIndex(masked, -1) {
+		var digits, suffix string
+		for g := 1; g < len(m)/2; g++ {
+			if m[2*g] < 0 {
+				continue
+			}
+			part := masked[m[2*g]:m[2*g+1]]
+			if digits == "" {
+				digits = part
+			} else {
+				suffix = part
+			}
+		}
+		value, err := strconv.ParseFloat(strings.ReplaceAll(digits, ",", ""), 64)
+		if err != nil {
+			continue
+		}
+		// Bare four-digit numbers that look like calendar years are not money
+		if suffix == "" && !strings.Contains(masked[m[0]:m[1]], "$") && !strings.Contains(digits, ",") &&
+			value >= 1900 && value <= 2100 {
+			continue
+		}
+		switch strings.ToLower(suffix) {
+		case "k", "thousand", "grand":
+			value *= 1e3
+		case "m", "mm", "million":
+			value *= 1e6
+		}
+		amounts = append(amounts, amount{value: value, start: m[0]})
+	}
+	return amounts
+}
+
+func detectFrequency(lower string) frequency {
+	switch {
+	case monthlyPattern.MatchString(lower):
+		return freqMonthly
+	case annualPattern.MatchString(lower):
+		return freqAnnual
+	}
+	return freqUnknown
+}
+
+func detectAccount(lower string) accountKind {
+	for _, a := range accountPatterns {
+		if a.pattern.MatchString(lower) {
+			return a.kind
+		}
+	}
+	return accountNone
+}
+
+func targetAge(lower string) (int, bool) {
+	m := targetAgePattern.FindStringSubmatch(lower)
+	if m == nil {
+		return 0, false
+	}
+	age, err := strconv.Atoi(m[1])
+	if err != nil || age < 16 || age > 100 {
+		return 0, false
+	}
+	return age, true
+}
+
+func relativeMonths(lower string) (int, bool) {
+	m := relativePattern.FindStringSubmatch(lower)
+	if m == nil {
+		return 0, false
+	}
+	return toMonths(m[1], m[2]), true
+}
+
+func toMonths(count, unit string) int {
+	n, err := strconv.Atoi(count)
+	if err != nil {
+		n = wordNumbers[count]
+	}
+	if strings.HasPrefix(unit, "year") {
+		return n * 12
+	}
+	return n
+}
+
+func joinNotes(notes ...string) string {
+	nonEmpty := notes[:0]
+	for _, n := range notes {
+		if n != "" {
+			nonEmpty = append(nonEmpty, n)
+		}
+	}
+	return strings.Join(nonEmpty, "; ")
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func roundConfidence(c float64) float64 {
+	if c < 0 {
+		c = 0
+	}
+	if c > 1 {
+		c = 1
+	}
+	return float64(int(c*100+0.5)) / 100
+}
@@ -0,0 +1,119 @@
+package extract
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"pathfinder-wasm/engine"
+)
+
+// goldenChange is the comparable part of a Change; events compare on type, amount and start month
+type goldenChange struct {
+	FieldPath   string  `json:"fieldPath"`
+	Value       float64 `json:"value,omitempty"`
+	EventType   string  `json:"eventType,omitempty"`
+	Amount      float64 `json:"amount,omitempty"`
+	MonthOffset int     `json:"monthOffset,omitempty"`
+}
+
+type goldenCase struct {
+	Text string         `json:"text"`
+	Want []goldenChange `json:"want"`
+}
+
+func toGolden(t *testing.T, ch Change) goldenChange {
+	t.Helper()
+	g := goldenChange{FieldPath: ch.FieldPath, EventType: ch.EventType}
+	switch v := ch.Value.(type) {
+	case int:
+		g.Value = float64(v)
+	case float64:
+		g.Value = v
+	case engine.FinancialEvent:
+		g.Amount = v.Amount
+		g.MonthOffset = v.MonthOffset
+	default:
+		t.Fatalf("unexpected value type %T for %s", ch.Value, ch.FieldPath)
+	}
+	return g
+}
+
+// TestExtractGoldenCorpus checks every phrase in testdata/golden.json
+func TestExtractGoldenCorpus(t *testing.T) {
+	data, err := os.ReadFile("testdata/golden.json")
+	if err != nil {
+		t.Fatalf("read golden corpus: %v", err)
+	}
+	var cases []goldenCase
+	if err := json.Unmarshal(data, &cases); err != nil {
+		t.Fatalf("parse golden corpus: %v", err)
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Text, func(t *testing.T) {
+			result := Extract(tc.Text, 2024)
+
+			got := make([]goldenChange, len(result.Changes))
+			for i, ch := range result.Changes {
+				got[i] = toGolden(t, ch)
+			}
+			if len(got) != len(tc.Want) {
+				t.Fatalf("got %d changes %+v, want %d %+v", len(got), got, len(tc.Want), tc.Want)
+			}
+			for i := range tc.Want {
+				if got[i] != tc.Want[i] {
+					t.Errorf("change %d: got %+v, want %+v", i, got[i], tc.Want[i])
+				}
+			}
+		})
+	}
+}
+
+// TestExtractSpansAndConfidence verifies spans point back into the input
+func TestExtractSpansAndConfidence(t *testing.T) {
+	text := "I'm 40, I make $120k a year and spend $5k"
+	result := Extract(text, 2024)
+
+	if len(result.Changes) != 3 {
+		t.Fatalf("expected 3 changes, got %+v", result.Changes)
+	}
+	for _, ch := range result.Changes {
+		if text[ch.Source.Start:ch.Source.End] != ch.Source.Text {
+			t.Errorf("%s: span [%d,%d) does not match %q", ch.FieldPath, ch.Source.Start, ch.Source.End, ch.Source.Text)
+		}
+		if ch.Confidence <= 0 || ch.Confidence > 1 {
+			t.Errorf("%s: confidence %.2f out of range", ch.FieldPath, ch.Confidence)
+		}
+	}
+
+	// "$5k" has no frequency, so it is read as monthly with reduced confidence
+	spending := result.Changes[2]
+	if spending.FieldPath != FieldAnnualSpending || spending.Value != 60000.0 {
+		t.Errorf("expected annualSpending 60000, got %+v", spending)
+	}
+	if spending.Confidence >= result.Changes[1].Confidence || spending.Note == "" {
+		t.Errorf("expected inferred frequency to lower confidence and add a note, got %+v", spending)
+	}
+	if result.Confidence <= 0 {
+		t.Errorf("expected overall confidence > 0, got %.2f", result.Confidence)
+	}
+}
+
+// TestExtractEmpty verifies unrecognized text yields an empty, non-nil change list
+func TestExtractEmpty(t *testing.T) {
+	result := Extract("hello there", 2024)
+	if result.Changes == nil || len(result.Changes) != 0 || result.Confidence != 0 {
+		t.Errorf("expected no changes and zero confidence, got %+v", result)
+	}
+}
+
+// TestExtractMaxOutUsesTaxYear verifies "max out" reads the plan year's contribution limits
+func TestExtractMaxOutUsesTaxYear(t *testing.T) {
+	for year, want := range map[int][2]float64{2024: {23000, 4150}, 2025: {23500, 4300}} {
+		changes := Extract("I max out my 401k; I max out my HSA", year).Changes
+		if len(changes) != 2 || changes[0].Value != want[0] || changes[1].Value != want[1] {
+			t.Errorf("%d: got %+v, want 401k %.0f and HSA %.0f", year, changes, want[0], want[1])
+		}
+	}
+}
@@ -1,10 +1,15 @@
 package mcp
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 
+	"github.com/areumfire/mcp-server-go/internal/extract"
 	"github.com/areumfire/mcp-server-go/internal/simulation"
+	"pathfinder-wasm/engine"
 )
 
 // Tool definitions
@@ -24,7 +29,7 @@ CRITICAL RESPONSE GUIDELINES (you MUST follow these when presenting results):
 - FRAME as "under these assumptions, simulations show..." not "you are/will be..."
 
 DESIGN CONTEXT (why we do things this way):
-- Default to age 80: Unless user specifies otherwise, simulate until age 80.
+- Default to age 80: Unless user specifies otherwise, simulate until age 80. With longevity=true each path instead draws a lifespan from a period life table and ends at death.
 - P10/P50/P75 not P10/P90: We avoid P90 ("tail theater") because extreme percentiles are noisy.
 - Asymmetric range (10th to 75th): Downside risk matters more than upside luck.
 - "Constraint" not "failure": Running out of money triggers spending adjustment, not catastrophe.
@@ -37,73 +42,103 @@ SIMULATION TIERS:
 - (future) gold: Complete simulation with all features`,
 		InputSchema: map[string]interface{}{
 			"type": "object",
-			"properties": map[string]interface{}{
-				"investableAssets": map[string]interface{}{
-					"type":        "number",
-					"description": "Total investable assets in dollars (for basic tier, or split across accounts for bronze)",
-				},
-				"annualSpending": map[string]interface{}{
-					"type":        "number",
-					"description": "Annual spending in dollars",
-				},
-				"currentAge": map[string]interface{}{
-					"type":        "number",
-					"description": "Current age in years. Simulation is capped at age 80.",
-				},
-				"expectedIncome": map[string]interface{}{
-					"type":        "number",
-					"description": "Expected annual income in dollars",
-				},
-				"seed": map[string]interface{}{
-					"type":        "number",
-					"description": "Random seed for deterministic simulation (integer)",
-				},
-				"startYear": map[string]interface{}{
-					"type":        "number",
-					"description": "Calendar year to start simulation",
-				},
-				"horizonMonths": map[string]interface{}{
-					"type":        "number",
-					"description": "Simulation horizon in months (default: until age 80)",
-				},
-				"mcPaths": map[string]interface{}{
-					"type":        "number",
-					"description": "Number of Monte Carlo paths (default: 100)",
-				},
+			"properties": planProperties(map[string]interface{}{
 				"tier": map[string]interface{}{
 					"type":        "string",
 					"description": "Simulation tier: 'basic' (~2ms, no taxes), 'bronze' (~3ms, simplified), or 'full' (~500ms, complete WASM-parity simulation). Default: full",
 					"enum":        []string{"basic", "bronze", "full"},
 				},
-				"cashBalance": map[string]interface{}{
-					"type":        "number",
-					"description": "Cash/checking balance in dollars (bronze tier)",
+			}),
+			"required": []string{
+				"investableAssets",
+				"annualSpending",
+				"currentAge",
+				"expectedIncome",
+				"seed",
+				"startYear",
+			},
+		},
+		Annotations: &ToolAnnotations{
+			ReadOnlyHint:    true,
+			DestructiveHint: false,
+			OpenWorldHint:   false,
+		},
+	},
+	{
+		Name: "extract_financial_changes",
+		Description: `Extract structured financial changes from natural language text. Returns proposed draft changes with field paths, values, and confidence levels. Use this to parse user statements like "I make $100k and spend $60k per year".
+
+Deterministic and offline: recognizes dollar amounts, ages, frequencies ("per month", "a year"), accounts (401k, Roth, HSA, 529, brokerage, cash) and life events (retire at, buy a house, sabbatical, pension, Social Security). Each change has a fieldPath (a run_simulation_packet argument such as expectedIncome or retirement401kBalance, or "events" for an event to append), a confidence, the source text span, and a note when an assumption was made.`,
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"text": map[string]interface{}{
+					"type":        "string",
+					"description": "Natural language text describing financial situation or changes",
 				},
-				"taxableBalance": map[string]interface{}{
+				"startYear": map[string]interface{}{
 					"type":        "number",
-					"description": "Taxable brokerage account balance (bronze tier)",
+					"description": "Calendar year the plan starts; \"max out\" reads that year's contribution limits. Default: 2024",
+				},
+			},
+			"required": []string{"text"},
+		},
+		Annotations: &ToolAnnotations{
+			ReadOnlyHint:    true,
+			DestructiveHint: false,
+			OpenWorldHint:   false,
+		},
+	},
+	{
+		Name: "solve_goal",
+		Description: `Solve for the plan input that just meets a Monte Carlo target: the highest annual spending, or the earliest retirement month, lowest savings rate or lowest annual contribution. Bisects on the chosen variable and re-runs the full simulation on the same path seeds each time (common random numbers), so only the input changes between runs. Returns the solved value, a 90% confidence band from the target's sampling error, and every bisection step.
+
+VARIABLES:
+- spending: annualSpending, maximized (default search $0 to twice annualSpending)
+- retirement_month: month the salary and contributions stop, minimized (retirementAge is ignored; the result includes the age)
+- savings_rate: all contributions as a share of income, minimized (0 to 1)
+- contribution: all annual contributions, minimized (default search $0 to twice the current total)
+
+TARGETS:
+- terminal_wealth_p10 / terminal_wealth_p50: that percentile of final net worth at or above threshold (dollars, default 0)
+- breach_probability: share of paths whose cash falls below the $10,000 floor (within month months, if given) at or below threshold (default 0.10)
+- bankruptcy_probability: share of paths that run out of money at or below threshold (default 0.10)
+
+RESPONSE GUIDELINES: present the value as "under these assumptions, the highest spending that keeps P10 final net worth above $0 is about $X (band $L to $H)", never as a recommendation. Say so when the result is infeasible or sits at the edge of the search range.`,
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": planProperties(map[string]interface{}{
+				"variable": map[string]interface{}{
+					"type":        "string",
+					"description": "Plan input to solve for",
+					"enum":        []string{engine.GoalVariableSpending, engine.GoalVariableRetirementMonth, engine.GoalVariableSavingsRate, engine.GoalVariableContribution},
+				},
+				"metric": map[string]interface{}{
+					"type":        "string",
+					"description": "Target metric the solved value must meet",
+					"enum":        []string{engine.GoalMetricTerminalWealthP10, engine.GoalMetricTerminalWealthP50, engine.GoalMetricBreachProbability, engine.GoalMetricBankruptcyProbability},
 				},
-				"retirement401kBalance": map[string]interface{}{
+				"threshold": map[string]interface{}{
 					"type":        "number",
-					"description": "401k/Traditional IRA balance (bronze tier)",
+					"description": "Wealth floor in dollars for terminal_wealth_*, or the highest acceptable probability (0-1) otherwise",
 				},
-				"rothBalance": map[string]interface{}{
+				"month": map[string]interface{}{
 					"type":        "number",
-					"description": "Roth IRA/401k balance (bronze tier)",
+					"description": "For breach_probability: count breaches within this many months. Default: the whole horizon",
 				},
-				"contribution401k": map[string]interface{}{
+				"lower": map[string]interface{}{
 					"type":        "number",
-					"description": "Annual 401k contribution (bronze tier, max $23,000 for 2024)",
+					"description": "Lower end of the search range (dollars, month or rate)",
 				},
-				"contributionRoth": map[string]interface{}{
+				"upper": map[string]interface{}{
 					"type":        "number",
-					"description": "Annual Roth IRA contribution (bronze tier, max $7,000 for 2024)",
+					"description": "Upper end of the search range (dollars, month or rate)",
 				},
-				"stateRate": map[string]interface{}{
+				"tolerance": map[string]interface{}{
 					"type":        "number",
-					"description": "State income tax rate (e.g., 0.093 for CA). Default: 0.065",
+					"description": "Stop when the bracket is this narrow. Default: 0.1% of the range, or 1 month",
 				},
-			},
+			}),
 			"required": []string{
 				"investableAssets",
 				"annualSpending",
@@ -111,6 +146,8 @@ SIMULATION TIERS:
 				"expectedIncome",
 				"seed",
 				"startYear",
+				"variable",
+				"metric",
 			},
 		},
 		Annotations: &ToolAnnotations{
@@ -120,17 +157,46 @@ SIMULATION TIERS:
 		},
 	},
 	{
-		Name: "extract_financial_changes",
-		Description: `Extract structured financial changes from natural language text. Returns proposed draft changes with field paths, values, and confidence levels. Use this to parse user statements like "I make $100k and spend $60k per year".`,
+		Name: "compare_scenarios",
+		Description: `Compare a plan with one or more variants ("baseline vs. take a sabbatical") on identical Monte Carlo paths. Every variant runs with the same path seeds, so path i sees the same market returns and inflation in each variant, and the per-path difference isolates the plan change from market luck. Two separate run_simulation_packet calls would add their sampling noise to every difference.
+
+The top-level plan fields are the baseline. Each entry in variants has a name plus the plan fields it changes; everything else, including the horizon, comes from the baseline (a variant's events list replaces the baseline's). All variants use the baseline's seed and mcPaths.
+
+Returns, per variant: its own terminal wealth P10/P50/P90, median runway (months until cash first falls below the $10,000 floor, or the horizon), median lifetime tax and bankruptcy probability; the share of paths on which it ends with the most wealth (winShare); and, against the baseline path by path, the mean and P10-P90 of the terminal wealth, runway and lifetime tax deltas plus the share of paths on which it ends wealthier (beatsBaseline).
+
+RESPONSE GUIDELINES: describe the deltas mechanically ("on the same simulated markets, the sabbatical ends with $X less in the median path, and less on 92% of paths"); do not recommend a variant.`,
 		InputSchema: map[string]interface{}{
 			"type": "object",
-			"properties": map[string]interface{}{
-				"text": map[string]interface{}{
+			"properties": planProperties(map[string]interface{}{
+				"baselineName": map[string]interface{}{
 					"type":        "string",
-					"description": "Natural language text describing financial situation or changes",
+					"description": "Label for the plan described by the top-level fields. Default: baseline",
+				},
+				"variants": map[string]interface{}{
+					"type":        "array",
+					"description": "Variants to compare with the baseline, each a name plus the plan fields it changes",
+					"minItems":    1,
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": planProperties(map[string]interface{}{
+							"name": map[string]interface{}{
+								"type":        "string",
+								"description": "Label for the variant, e.g. \"sabbatical\"",
+							},
+						}),
+						"required": []string{"name"},
+					},
 				},
+			}),
+			"required": []string{
+				"investableAssets",
+				"annualSpending",
+				"currentAge",
+				"expectedIncome",
+				"seed",
+				"startYear",
+				"variants",
 			},
-			"required": []string{"text"},
 		},
 		Annotations: &ToolAnnotations{
 			ReadOnlyHint:    true,
@@ -140,6 +206,206 @@ SIMULATION TIERS:
 	},
 }
 
+// planProperties adds the plan fields shared by run_simulation_packet, solve_goal and
+// compare_scenarios to a tool's own properties
+func planProperties(own map[string]interface{}) map[string]interface{} {
+	properties := map[string]interface{}{
+		"investableAssets": map[string]interface{}{
+			"type":        "number",
+			"description": "Total investable assets in dollars (for basic tier, or split across accounts for bronze)",
+		},
+		"annualSpending": map[string]interface{}{
+			"type":        "number",
+			"description": "Annual spending in dollars",
+		},
+		"currentAge": map[string]interface{}{
+			"type":        "number",
+			"description": "Current age in years. Simulation is capped at age 80 unless longevity is enabled.",
+		},
+		"expectedIncome": map[string]interface{}{
+			"type":        "number",
+			"description": "Expected annual income in dollars",
+		},
+		"seed": map[string]interface{}{
+			"type":        "number",
+			"description": "Random seed for deterministic simulation (integer)",
+		},
+		"startYear": map[string]interface{}{
+			"type":        "number",
+			"description": "Calendar year to start simulation",
+		},
+		"horizonMonths": map[string]interface{}{
+			"type":        "number",
+			"description": "Simulation horizon in months (default: until age 80, or age 120 with longevity)",
+		},
+		"mcPaths": map[string]interface{}{
+			"type":        "number",
+			"description": "Number of Monte Carlo paths (default: 100)",
+		},
+		"cashBalance": map[string]interface{}{
+			"type":        "number",
+			"description": "Cash/checking balance in dollars (bronze tier)",
+		},
+		"taxableBalance": map[string]interface{}{
+			"type":        "number",
+			"description": "Taxable brokerage account balance (bronze tier)",
+		},
+		"retirement401kBalance": map[string]interface{}{
+			"type":        "number",
+			"description": "401k/Traditional IRA balance (bronze tier)",
+		},
+		"rothBalance": map[string]interface{}{
+			"type":        "number",
+			"description": "Roth IRA/401k balance (bronze tier)",
+		},
+		"contribution401k": map[string]interface{}{
+			"type":        "number",
+			"description": "Annual 401k contribution (bronze tier, max $23,000 for 2024)",
+		},
+		"contributionRoth": map[string]interface{}{
+			"type":        "number",
+			"description": "Annual Roth IRA contribution (bronze tier, max $7,000 for 2024)",
+		},
+		"contributionHSA": map[string]interface{}{
+			"type":        "number",
+			"description": "Annual HSA contribution (bronze/full tiers, max $4,150 self-only for 2024)",
+		},
+		"fiveTwoNineBalance": map[string]interface{}{
+			"type":        "number",
+			"description": "529 education savings balance (bronze/full tiers)",
+		},
+		"stateCode": map[string]interface{}{
+			"type":        "string",
+			"description": "Two-letter code of the state of residence (50 states or DC), taxed with its brackets, Social Security, retirement-income and capital gains rules. Default: CA",
+		},
+		"retirementAge": map[string]interface{}{
+			"type":        "number",
+			"description": "Age at which salary and contributions stop (bronze/full tiers)",
+		},
+		"sabbaticalMonthOffset": map[string]interface{}{
+			"type":        "number",
+			"description": "Month (from start) a sabbatical begins; salary and contributions pause (bronze/full tiers)",
+		},
+		"sabbaticalMonths": map[string]interface{}{
+			"type":        "number",
+			"description": "Length of the sabbatical in months (bronze/full tiers)",
+		},
+		"socialSecurityAge": map[string]interface{}{
+			"type":        "number",
+			"description": "Age Social Security benefits start (bronze/full tiers)",
+		},
+		"socialSecurityBenefit": map[string]interface{}{
+			"type":        "number",
+			"description": "Monthly Social Security benefit in dollars (bronze/full tiers). Omit it to have the engine derive the benefit at socialSecurityAge (62-70) from annualIncome, with the earnings test against simulated wages",
+		},
+		"deathAge": map[string]interface{}{
+			"type":        "number",
+			"description": "Age at which the primary person dies, for survivor planning with a spouse (bronze/full tiers)",
+		},
+		"longevity": map[string]interface{}{
+			"type":        "boolean",
+			"description": "Draw a lifespan per Monte Carlo path from a period life table instead of ending every path at the horizon; reports outcomes conditional on being alive at each age and estate tax at death (bronze/full tiers)",
+		},
+		"sex": map[string]interface{}{
+			"type":        "string",
+			"description": "Life table column for longevity draws. Omit for a unisex average",
+			"enum":        []string{"male", "female"},
+		},
+		"mortalityMultiplier": map[string]interface{}{
+			"type":        "number",
+			"description": "Scales the life table's death rates for health (e.g. 0.8 for better than average). Default: 1",
+		},
+		"spouse": spouseSchema(),
+		"events": eventsSchema(),
+	}
+	for name, schema := range own {
+		properties[name] = schema
+	}
+	return properties
+}
+
+// eventsSchema describes the typed event list accepted by the bronze and full tiers.
+// The type enum comes from the engine's handler registry so it cannot drift.
+func eventsSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "array",
+		"description": `Additional financial events (bronze/full tiers), appended to the salary, spending and contribution events built from the scalar fields. ` +
+			`Use for one-time expenses (ONE_TIME_EXPENSE), sabbaticals, home purchases (REAL_ESTATE_PURCHASE), pensions (PENSION_INCOME), ` +
+			`Roth conversions (ROTH_CONVERSION), new debts (LIABILITY_ADD), moving to another state (RELOCATE with metadata stateCode; ` +
+			`amount is the moving cost) and so on. Month offsets count from the simulation start.`,
+		"items": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"id": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional unique identifier",
+				},
+				"type": map[string]interface{}{
+					"type":        "string",
+					"description": "Engine event type",
+					"enum":        simulation.SupportedEventTypes(),
+				},
+				"description": map[string]interface{}{
+					"type":        "string",
+					"description": "Human-readable label",
+				},
+				"monthOffset": map[string]interface{}{
+					"type":        "integer",
+					"description": "Month the event starts, counted from the simulation start (0 = first month)",
+				},
+				"amount": map[string]interface{}{
+					"type":        "number",
+					"description": "Dollar amount per occurrence (INCOME with frequency 'annually' is an annual figure paid monthly)",
+				},
+				"frequency": map[string]interface{}{
+					"type":        "string",
+					"description": "Recurrence schedule. Default: once",
+					"enum":        simulation.EventFrequencies,
+				},
+				"targetAccountType": map[string]interface{}{
+					"type":        "string",
+					"description": "Destination account for contributions/transfers: cash, taxable, tax_deferred, roth, hsa, 529",
+				},
+				"metadata": map[string]interface{}{
+					"type":        "object",
+					"description": "Event-specific details, e.g. endDateOffset (month a recurring event stops), growthRate (annual), owner ('primary' or 'spouse'), or liability/property terms",
+				},
+			},
+			"required": []string{"type", "monthOffset", "amount"},
+		},
+	}
+}
+
+// spouseSchema describes the optional second person in a two-person household
+func spouseSchema() map[string]interface{} {
+	number := func(description string) map[string]interface{} {
+		return map[string]interface{}{"type": "number", "description": description}
+	}
+	return map[string]interface{}{
+		"type": "object",
+		"description": `Second person in a two-person household (bronze/full tiers). The top-level person fields describe the primary. ` +
+			`The couple files jointly while both are alive; each person has their own 401k/IRA limits and RMDs, and at a death the survivor ` +
+			`keeps the larger Social Security benefit and inherits the 401k/IRA.`,
+		"properties": map[string]interface{}{
+			"currentAge":            number("Spouse's current age in years"),
+			"annualIncome":          number("Spouse's annual salary in dollars"),
+			"retirementAge":         number("Age at which the spouse's salary and contributions stop"),
+			"taxDeferredBalance":    number("Spouse-owned 401k/Traditional IRA balance, added to retirement401kBalance"),
+			"contribution401k":      number("Spouse's annual 401k contribution"),
+			"contributionRoth":      number("Spouse's annual Roth IRA contribution"),
+			"socialSecurityAge":     number("Age the spouse's Social Security starts"),
+			"socialSecurityBenefit": number("Spouse's monthly Social Security benefit in dollars; omit it to derive the benefit from the spouse's annualIncome"),
+			"deathAge":              number("Age at which the spouse dies, for survivor planning"),
+			"sex": map[string]interface{}{
+				"type":        "string",
+				"description": "Spouse's life table column for longevity draws",
+				"enum":        []string{"male", "female"},
+			},
+		},
+		"required": []string{"currentAge"},
+	}
+}
+
 // handleToolsList returns the list of available tools
 func (s *Server) handleToolsList(req *JSONRPCRequest) *JSONRPCResponse {
 	// Add _meta to each tool for OpenAI Apps SDK
@@ -197,6 +463,10 @@ func (s *Server) handleToolsCall(req *JSONRPCRequest) *JSONRPCResponse {
 		return s.handleRunSimulation(req.ID, args)
 	case "extract_financial_changes":
 		return s.handleExtractChanges(req.ID, args)
+	case "solve_goal":
+		return s.handleSolveGoal(req.ID, args)
+	case "compare_scenarios":
+		return s.handleCompareScenarios(req.ID, args)
 	default:
 		return &JSONRPCResponse{
 			JSONRPC: "2.0",
@@ -214,6 +484,84 @@ func (s *Server) handleRunSimulation(id interface{}, args map[string]interface{}
 	// Check simulation tier - default to full for parity with WASM engine
 	tier := getString(args, "tier", "full")
 
+	params, errResp := parsePlanParams(id, args)
+	if errResp != nil {
+		return errResp
+	}
+
+	var result interface{}
+	var err error
+
+	switch tier {
+	case "basic":
+		if len(params.Events) > 0 {
+			return invalidEventsResponse(id, fmt.Errorf("events require tier 'bronze' or 'full'"))
+		}
+		if params.Spouse != nil {
+			return invalidParamsResponse(id, fmt.Errorf("spouse requires tier 'bronze' or 'full'"))
+		}
+		if params.Longevity {
+			return invalidParamsResponse(id, fmt.Errorf("longevity requires tier 'bronze' or 'full'"))
+		}
+
+		// Basic tier: fast, no taxes
+		basic := simulation.SimulationParams{
+			InvestableAssets: getFloat(args, "investableAssets", 0),
+			AnnualSpending:   params.AnnualSpending,
+			CurrentAge:       getFloat(args, "currentAge", 35),
+			ExpectedIncome:   params.AnnualIncome,
+			Seed:             params.Seed,
+			StartYear:        params.StartYear,
+			HorizonMonths:    params.HorizonMonths,
+			MCPaths:          params.MCPaths,
+		}
+		result, err = s.engine.RunSimulation(basic)
+
+	default:
+		// Full and bronze tiers both run the full engine in LiteMode for WASM parity
+		result, err = s.fullEngine.RunFullSimulation(params)
+	}
+
+	if err != nil {
+		var eventErrs simulation.EventValidationErrors
+		if errors.As(err, &eventErrs) {
+			return invalidEventsResponse(id, err)
+		}
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      id,
+			Error: &JSONRPCError{
+				Code:    -32000,
+				Message: "Simulation error: " + err.Error(),
+			},
+		}
+	}
+
+	// Build text summary
+	textSummary := buildTextSummary(result)
+
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Result: ToolResult{
+			Content: []ContentBlock{
+				{Type: "text", Text: textSummary},
+			},
+			StructuredContent: result,
+			Meta: map[string]interface{}{
+				"openai/outputTemplate":          WidgetTemplateURI,
+				"openai/toolInvocation/invoking": "Running Monte Carlo simulation...",
+				"openai/toolInvocation/invoked":  "Simulation complete",
+				"simulationTier":                 tier,
+			},
+		},
+	}
+}
+
+// parsePlanParams reads the plan fields shared by run_simulation_packet, solve_goal and
+// compare_scenarios.
+// A malformed argument comes back as the error response to send.
+func parsePlanParams(id interface{}, args map[string]interface{}) (simulation.FullSimulationParams, *JSONRPCResponse) {
 	// Calculate default horizon (age 80)
 	currentAge := getFloat(args, "currentAge", 35)
 	horizonMonths := getInt(args, "horizonMonths", 0)
@@ -225,8 +573,33 @@ func (s *Server) handleRunSimulation(id interface{}, args map[string]interface{}
 		horizonMonths = yearsToAge80 * 12
 	}
 
-	var result interface{}
-	var err error
+	events, err := parseEvents(args)
+	if err != nil {
+		return simulation.FullSimulationParams{}, invalidEventsResponse(id, err)
+	}
+
+	spouse, err := parseSpouse(args)
+	if err != nil {
+		return simulation.FullSimulationParams{}, invalidParamsResponse(id, err)
+	}
+	// Default horizon runs until the younger person reaches 80
+	if spouse != nil && getInt(args, "horizonMonths", 0) == 0 && spouse.CurrentAge < int(currentAge) {
+		yearsToAge80 := 80 - spouse.CurrentAge
+		if yearsToAge80*12 > horizonMonths {
+			horizonMonths = yearsToAge80 * 12
+		}
+	}
+
+	longevity := getBool(args, "longevity", false)
+	// With drawn lifespans the horizon is only an upper bound: paths end at death
+	if longevity && getInt(args, "horizonMonths", 0) == 0 {
+		horizonMonths = simulation.LongevityHorizonMonths(int(currentAge), spouse)
+	}
+
+	stateCode := strings.ToUpper(getString(args, "stateCode", simulation.DefaultStateCode))
+	if !simulation.ValidStateCode(stateCode) {
+		return simulation.FullSimulationParams{}, invalidParamsResponse(id, fmt.Errorf("stateCode %q is not a US state or DC", stateCode))
+	}
 
 	// Parse account balances
 	investableAssets := getFloat(args, "investableAssets", 0)
@@ -244,108 +617,217 @@ func (s *Server) handleRunSimulation(id interface{}, args map[string]interface{}
 		rothBalance = investableAssets * 0.10
 	}
 
-	switch tier {
-	case "basic":
-		// Basic tier: fast, no taxes
-		params := simulation.SimulationParams{
-			InvestableAssets: investableAssets,
-			AnnualSpending:   getFloat(args, "annualSpending", 0),
-			CurrentAge:       currentAge,
-			ExpectedIncome:   getFloat(args, "expectedIncome", 0),
-			Seed:             getInt(args, "seed", 12345),
-			StartYear:        getInt(args, "startYear", 2024),
-			HorizonMonths:    horizonMonths,
-			MCPaths:          getInt(args, "mcPaths", 100),
+	return simulation.FullSimulationParams{
+		Seed:                  getInt(args, "seed", 12345),
+		StartYear:             getInt(args, "startYear", simulation.DefaultStartYear),
+		HorizonMonths:         horizonMonths,
+		MCPaths:               getInt(args, "mcPaths", 100),
+		CurrentAge:            int(currentAge),
+		StateCode:             stateCode,
+		CashBalance:           cashBalance,
+		TaxableBalance:        taxableBalance,
+		TaxDeferredBalance:    retirement401k,
+		RothBalance:           rothBalance,
+		FiveTwoNineBalance:    getFloat(args, "fiveTwoNineBalance", 0),
+		AnnualIncome:          getFloat(args, "expectedIncome", 0),
+		AnnualSpending:        getFloat(args, "annualSpending", 0),
+		Contribution401k:      getFloat(args, "contribution401k", 0),
+		ContributionRoth:      getFloat(args, "contributionRoth", 0),
+		ContributionHSA:       getFloat(args, "contributionHSA", 0),
+		RetirementAge:         getInt(args, "retirementAge", 0),
+		SabbaticalMonthOffset: getInt(args, "sabbaticalMonthOffset", 0),
+		SabbaticalMonths:      getInt(args, "sabbaticalMonths", 0),
+		SocialSecurityAge:     getInt(args, "socialSecurityAge", 0),
+		SocialSecurityBenefit: getFloat(args, "socialSecurityBenefit", 0),
+		DeathAge:              getInt(args, "deathAge", 0),
+		Spouse:                spouse,
+		Longevity:             longevity,
+		Sex:                   getString(args, "sex", ""),
+		MortalityMultiplier:   getFloat(args, "mortalityMultiplier", 0),
+		Events:                events,
+		LiteMode:              true, // Use optimized mode by default
+	}, nil
+}
+
+// handleSolveGoal solves for one plan input against a Monte Carlo target
+func (s *Server) handleSolveGoal(id interface{}, args map[string]interface{}) *JSONRPCResponse {
+	params, errResp := parsePlanParams(id, args)
+	if errResp != nil {
+		return errResp
+	}
+
+	// A probability target defaults to one path in ten; a wealth target to $0
+	metric := getString(args, "metric", "")
+	threshold := 0.0
+	if metric == engine.GoalMetricBreachProbability || metric == engine.GoalMetricBankruptcyProbability {
+		threshold = 0.10
+	}
+	goal := simulation.GoalParams{
+		Variable:  getString(args, "variable", ""),
+		Metric:    metric,
+		Threshold: getFloat(args, "threshold", threshold),
+		Month:     getInt(args, "month", 0),
+		Lower:     getFloat(args, "lower", 0),
+		Upper:     getFloat(args, "upper", 0),
+		Tolerance: getFloat(args, "tolerance", 0),
+	}
+
+	result, err := s.fullEngine.SolveGoal(params, goal)
+	if err != nil {
+		var eventErrs simulation.EventValidationErrors
+		if errors.As(err, &eventErrs) {
+			return invalidEventsResponse(id, err)
 		}
-		result, err = s.engine.RunSimulation(params)
-
-	case "full":
-		// Full tier: complete simulation with all features
-		params := simulation.FullSimulationParams{
-			Seed:               getInt(args, "seed", 12345),
-			StartYear:          getInt(args, "startYear", 2024),
-			HorizonMonths:      horizonMonths,
-			MCPaths:            getInt(args, "mcPaths", 100),
-			CurrentAge:         int(currentAge),
-			StateCode:          getString(args, "stateCode", "CA"),
-			StateRate:          getFloat(args, "stateRate", 0.093),
-			CashBalance:        cashBalance,
-			TaxableBalance:     taxableBalance,
-			TaxDeferredBalance: retirement401k,
-			RothBalance:        rothBalance,
-			AnnualIncome:       getFloat(args, "expectedIncome", 0),
-			AnnualSpending:     getFloat(args, "annualSpending", 0),
-			Contribution401k:   getFloat(args, "contribution401k", 0),
-			ContributionRoth:   getFloat(args, "contributionRoth", 0),
-			LiteMode:           true, // Use optimized mode by default
+		return &JSONRPCResponse{
+			JSONRPC: "2.0",
+			ID:      id,
+			Error: &JSONRPCError{
+				Code:    -32000,
+				Message: "Goal seek error: " + err.Error(),
+			},
 		}
-		result, err = s.fullEngine.RunFullSimulation(params)
+	}
 
-	default:
-		// Bronze tier: use full engine in LiteMode for WASM parity
-		params := simulation.FullSimulationParams{
-			Seed:               getInt(args, "seed", 12345),
-			StartYear:          getInt(args, "startYear", 2024),
-			HorizonMonths:      horizonMonths,
-			MCPaths:            getInt(args, "mcPaths", 100),
-			CurrentAge:         int(currentAge),
-			StateCode:          getString(args, "stateCode", "CA"),
-			StateRate:          getFloat(args, "stateRate", 0.093),
-			CashBalance:        cashBalance,
-			TaxableBalance:     taxableBalance,
-			TaxDeferredBalance: retirement401k,
-			RothBalance:        rothBalance,
-			AnnualIncome:       getFloat(args, "expectedIncome", 0),
-			AnnualSpending:     getFloat(args, "annualSpending", 0),
-			Contribution401k:   getFloat(args, "contribution401k", 0),
-			ContributionRoth:   getFloat(args, "contributionRoth", 0),
-			LiteMode:           true, // Use optimized mode
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Result: ToolResult{
+			Content: []ContentBlock{
+				{Type: "text", Text: buildGoalSummary(result)},
+			},
+			StructuredContent: result,
+		},
+	}
+}
+
+// buildGoalSummary describes a solved goal in one or two sentences
+func buildGoalSummary(r *engine.GoalSeekResult) string {
+	format := func(v float64) string {
+		switch r.Variable {
+		case engine.GoalVariableRetirementMonth:
+			return fmt.Sprintf("month %.0f", v)
+		case engine.GoalVariableSavingsRate:
+			return fmt.Sprintf("%.1f%%", v*100)
+		default:
+			return fmt.Sprintf("$%.0f/yr", v)
 		}
-		result, err = s.fullEngine.RunFullSimulation(params)
 	}
 
+	if !r.Feasible {
+		return fmt.Sprintf("No %s in the search range meets %s %g; at %s it is %.4g.",
+			r.Variable, r.Target.Metric, r.Target.Threshold, format(r.Value), r.Metric)
+	}
+	summary := fmt.Sprintf("Solved %s: %s (%.0f%% band %s to %s) for %s %g, over %d paths and %d simulation runs.",
+		r.Variable, format(r.Value), r.ConfidenceLevel*100, format(r.Low), format(r.High),
+		r.Target.Metric, r.Target.Threshold, r.Paths, r.Evaluations)
+	if r.RetirementAge > 0 {
+		summary += fmt.Sprintf(" Retirement at age %.1f.", r.RetirementAge)
+	}
+	if r.AtBound {
+		summary += " The target is still met at the end of the search range."
+	}
+	return summary
+}
+
+// handleCompareScenarios runs the plan and its variants on the same path seeds
+func (s *Server) handleCompareScenarios(id interface{}, args map[string]interface{}) *JSONRPCResponse {
+	rawVariants, _ := args["variants"].([]interface{})
+	if len(rawVariants) == 0 {
+		return invalidParamsResponse(id, fmt.Errorf("variants must list at least one variant of the plan"))
+	}
+
+	baseline, errResp := parsePlanParams(id, args)
+	if errResp != nil {
+		return errResp
+	}
+	scenarios := []simulation.ScenarioParams{{Name: getString(args, "baselineName", "baseline"), Params: baseline}}
+
+	for i, raw := range rawVariants {
+		overrides, ok := raw.(map[string]interface{})
+		if !ok {
+			return invalidParamsResponse(id, fmt.Errorf("variants[%d] must be an object", i))
+		}
+
+		// A variant is the baseline's arguments with its own fields on top; it keeps the
+		// baseline's horizon so the paths cover the same months
+		merged := map[string]interface{}{"horizonMonths": float64(baseline.HorizonMonths)}
+		for key, value := range args {
+			if key != "variants" && key != "baselineName" {
+				merged[key] = value
+			}
+		}
+		for key, value := range overrides {
+			if key != "name" {
+				merged[key] = value
+			}
+		}
+		params, errResp := parsePlanParams(id, merged)
+		if errResp != nil {
+			return errResp
+		}
+		scenarios = append(scenarios, simulation.ScenarioParams{
+			Name:   getString(overrides, "name", fmt.Sprintf("variant-%d", i+1)),
+			Params: params,
+		})
+	}
+
+	result, err := s.fullEngine.CompareScenarios(scenarios)
 	if err != nil {
+		var eventErrs simulation.EventValidationErrors
+		if errors.As(err, &eventErrs) {
+			return invalidEventsResponse(id, err)
+		}
 		return &JSONRPCResponse{
 			JSONRPC: "2.0",
 			ID:      id,
 			Error: &JSONRPCError{
 				Code:    -32000,
-				Message: "Simulation error: " + err.Error(),
+				Message: "Comparison error: " + err.Error(),
 			},
 		}
 	}
 
-	// Build text summary
-	textSummary := buildTextSummary(result)
-
 	return &JSONRPCResponse{
 		JSONRPC: "2.0",
 		ID:      id,
 		Result: ToolResult{
 			Content: []ContentBlock{
-				{Type: "text", Text: textSummary},
+				{Type: "text", Text: buildComparisonSummary(result)},
 			},
 			StructuredContent: result,
-			Meta: map[string]interface{}{
-				"openai/outputTemplate":          WidgetTemplateURI,
-				"openai/toolInvocation/invoking": "Running Monte Carlo simulation...",
-				"openai/toolInvocation/invoked":  "Simulation complete",
-				"simulationTier":                 tier,
-			},
 		},
 	}
 }
 
+// buildComparisonSummary gives each variant's median terminal wealth delta and how
+// often it beats the baseline
+func buildComparisonSummary(r *engine.ScenarioComparison) string {
+	lines := []string{fmt.Sprintf("Compared %d variants with %q on the same %d paths.", len(r.Variants)-1, r.Baseline, r.Paths)}
+	for _, v := range r.Variants[1:] {
+		d := v.TerminalWealthDelta
+		lines = append(lines, fmt.Sprintf("%s: terminal wealth %+.0f in the median path (P10 %+.0f, P90 %+.0f), wealthier than %s on %.0f%% of paths.",
+			v.Name, d.P50, d.P10, d.P90, r.Baseline, v.BeatsBaseline*100))
+	}
+	return strings.Join(lines, " ")
+}
+
 // handleExtractChanges extracts financial changes from text
 func (s *Server) handleExtractChanges(id interface{}, args map[string]interface{}) *JSONRPCResponse {
 	text, _ := args["text"].(string)
 
-	// TODO: Implement NLP extraction
-	// For now, return empty changes
-	result := map[string]interface{}{
-		"changes":    []interface{}{},
-		"confidence": 0.0,
-		"rawText":    text,
+	result := extract.Extract(text, getInt(args, "startYear", simulation.DefaultStartYear))
+
+	summary := "No financial details recognized in the text."
+	if len(result.Changes) > 0 {
+		fields := make([]string, len(result.Changes))
+		for i, ch := range result.Changes {
+			fields[i] = ch.FieldPath
+			if ch.EventType != "" {
+				fields[i] += " (" + ch.EventType + ")"
+			}
+		}
+		summary = fmt.Sprintf("Extracted %d draft changes (confidence %.2f): %s. Confirm with the user before simulating.",
+			len(result.Changes), result.Confidence, strings.Join(fields, ", "))
 	}
 
 	return &JSONRPCResponse{
@@ -353,7 +835,7 @@ func (s *Server) handleExtractChanges(id interface{}, args map[string]interface{
 		ID:      id,
 		Result: ToolResult{
 			Content: []ContentBlock{
-				{Type: "text", Text: "Extracted financial changes from text."},
+				{Type: "text", Text: summary},
 			},
 			StructuredContent: result,
 		},
@@ -374,6 +856,12 @@ func buildTextSummary(result interface{}) string {
 				"Median runway: %d months. See widget for trajectory analysis.",
 				r.PathsRun, r.MC.RunwayP50)
 		}
+	case *simulation.FullSimulationResult:
+		if r.Longevity != nil {
+			return fmt.Sprintf("Monte Carlo simulation complete (%d paths) with lifespans drawn per path "+
+				"(median age at death %d). See widget for outcomes by age.",
+				r.PathsRun, r.Longevity.DeathAgeP50)
+		}
 	case *simulation.EnhancedSimulationResult:
 		if r.MC != nil {
 			return fmt.Sprintf("Monte Carlo simulation complete (%d paths, bronze tier). "+
@@ -385,6 +873,73 @@ func buildTextSummary(result interface{}) string {
 	return "Monte Carlo simulation complete. See widget for trajectory and plan duration analysis."
 }
 
+// parseEvents decodes the optional "events" argument into engine events
+func parseEvents(args map[string]interface{}) ([]engine.FinancialEvent, error) {
+	raw, ok := args["events"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("events: %w", err)
+	}
+	var events []engine.FinancialEvent
+	if err := json.Unmarshal(data, &events); err != nil {
+		return nil, fmt.Errorf("events must be an array of event objects: %w", err)
+	}
+	return events, nil
+}
+
+// parseSpouse decodes the optional "spouse" argument
+func parseSpouse(args map[string]interface{}) (*simulation.SpouseParams, error) {
+	raw, ok := args["spouse"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("spouse: %w", err)
+	}
+	var spouse simulation.SpouseParams
+	if err := json.Unmarshal(data, &spouse); err != nil {
+		return nil, fmt.Errorf("spouse must be an object: %w", err)
+	}
+	return &spouse, nil
+}
+
+// invalidParamsResponse returns an invalid-params error for a malformed argument
+func invalidParamsResponse(id interface{}, err error) *JSONRPCResponse {
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error: &JSONRPCError{
+			Code:    -32602,
+			Message: "Invalid params: " + err.Error(),
+		},
+	}
+}
+
+// invalidEventsResponse returns an invalid-params error; validation failures carry
+// the per-event details and the supported types in Data so the caller can self-correct
+func invalidEventsResponse(id interface{}, err error) *JSONRPCResponse {
+	data := map[string]interface{}{
+		"supportedEventTypes": simulation.SupportedEventTypes(),
+	}
+	var eventErrs simulation.EventValidationErrors
+	if errors.As(err, &eventErrs) {
+		data["errors"] = eventErrs
+	}
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error: &JSONRPCError{
+			Code:    -32602,
+			Message: "Invalid events: " + err.Error(),
+			Data:    data,
+		},
+	}
+}
+
 // Helper functions
 func getFloat(m map[string]interface{}, key string, def float64) float64 {
 	if v, ok := m[key].(float64); ok {
@@ -406,3 +961,10 @@ func getString(m map[string]interface{}, key string, def string) string {
 	}
 	return def
 }
+
+func getBool(m map[string]interface{}, key string, def bool) bool {
+	if v, ok := m[key].(bool); ok {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,69 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/areumfire/mcp-server-go/internal/extract"
+	"github.com/areumfire/mcp-server-go/internal/simulation"
+)
+
+// TestExtractedChangesReachSimulation verifies every extracted field path is an
+// argument run_simulation_packet reads, by applying it and checking the result moves
+func TestExtractedChangesReachSimulation(t *testing.T) {
+	s := NewServer(simulation.NewEngine())
+	base := map[string]interface{}{
+		"currentAge":            40.0,
+		"seed":                  4242.0,
+		"startYear":             2025.0,
+		"horizonMonths":         240.0,
+		"mcPaths":               10.0,
+		"stateCode":             "TX",
+		"cashBalance":           40000.0,
+		"taxableBalance":        200000.0,
+		"retirement401kBalance": 100000.0,
+		"expectedIncome":        120000.0,
+		"annualSpending":        70000.0,
+	}
+	properties := tools[0].InputSchema["properties"].(map[string]interface{})
+
+	run := func(args map[string]interface{}) float64 {
+		resp := s.handleRunSimulation(1, args)
+		if resp.Error != nil {
+			t.Fatalf("simulation failed: %s", resp.Error.Message)
+		}
+		return resp.Result.(ToolResult).StructuredContent.(*simulation.FullSimulationResult).MC.FinalNetWorthP50
+	}
+	baseline := run(base)
+
+	for _, text := range []string{
+		"I make $180k a year now",
+		"I have $400k in my 401k",
+		"I'm maxing out my HSA",
+		"I also have $60k in a 529",
+	} {
+		changes := extract.Extract(text, 2025).Changes
+		if len(changes) != 1 {
+			t.Fatalf("%q: expected one change, got %+v", text, changes)
+		}
+		ch := changes[0]
+		if _, ok := properties[ch.FieldPath]; !ok {
+			t.Errorf("%q: field path %q is not a run_simulation_packet argument", text, ch.FieldPath)
+			continue
+		}
+
+		// Apply the change as a client would send it, through JSON
+		args := make(map[string]interface{}, len(base)+1)
+		for k, v := range base {
+			args[k] = v
+		}
+		raw, _ := json.Marshal(ch.Value)
+		var value interface{}
+		json.Unmarshal(raw, &value)
+		args[ch.FieldPath] = value
+
+		if got := run(args); got == baseline {
+			t.Errorf("%q: setting %s=%v left the median final net worth at %.0f", text, ch.FieldPath, value, got)
+		}
+	}
+}
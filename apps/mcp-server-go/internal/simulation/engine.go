@@ -53,6 +53,7 @@ type MCResults struct {
 	RunwayP50            int     `json:"runwayP50"`
 	RunwayP75            int     `json:"runwayP75"`
 	FinalNetWorthP50     float64 `json:"finalNetWorthP50"`
+	FinalNetWorthRealP50 float64 `json:"finalNetWorthRealP50,omitempty"` // Today's dollars
 	EverBreachProbability float64 `json:"everBreachProbability"`
 }
 
@@ -70,6 +71,7 @@ type TrajectoryPoint struct {
 	P10         float64 `json:"p10"`
 	P50         float64 `json:"p50"`
 	P75         float64 `json:"p75"`
+	RealP50     float64 `json:"realP50,omitempty"` // Median in today's dollars
 }
 
 // AnnualSnapshot is a yearly summary
@@ -78,6 +80,7 @@ type AnnualSnapshot struct {
 	Year             int     `json:"year"`
 	StartBalance     float64 `json:"startBalance"`
 	EndBalance       float64 `json:"endBalance"`
+	EndBalanceReal   float64 `json:"endBalanceReal,omitempty"` // Median end balance in today's dollars
 	TotalIncome      float64 `json:"totalIncome"`
 	TotalExpenses    float64 `json:"totalExpenses"`
 	InvestmentGrowth float64 `json:"investmentGrowth"`
@@ -5,6 +5,8 @@ import (
 	"math"
 	"math/rand"
 	"sort"
+
+	"pathfinder-wasm/engine"
 )
 
 // BronzeEngine is an enhanced simulation engine with basic tax and account support
@@ -80,7 +82,7 @@ func (e *BronzeEngine) RunEnhancedSimulation(params EnhancedSimulationParams) (*
 
 			// Process income (deposited to cash)
 			if monthlyIncome > 0 {
-				netIncome := e.applyWithholding(monthlyIncome, &state.taxState, params.StateRate)
+				netIncome := e.applyWithholding(monthlyIncome, &state.taxState, params.StateCode, state.currentYear)
 				state.accounts.Cash += netIncome
 				state.taxState.OrdinaryIncome += monthlyIncome
 			}
@@ -128,6 +130,7 @@ func (e *BronzeEngine) RunEnhancedSimulation(params EnhancedSimulationParams) (*
 				remaining -= withdraw
 				// TaxDeferred withdrawals are ordinary income
 				state.taxState.OrdinaryIncome += withdraw
+				state.taxState.Distributions += withdraw
 			}
 
 			// 4. Withdraw from Roth (tax-free)
@@ -151,7 +154,7 @@ func (e *BronzeEngine) RunEnhancedSimulation(params EnhancedSimulationParams) (*
 				}
 
 				// Calculate and pay annual taxes
-				taxes := e.calculateAnnualTax(&state.taxState, params.StateRate)
+				taxes := e.calculateAnnualTax(&state.taxState, params.StateCode, state.age, state.currentYear)
 				state.totalTaxPaid += taxes
 
 				// Pay taxes from cash (or taxable if insufficient)
@@ -321,20 +324,23 @@ func (e *BronzeEngine) RunEnhancedSimulation(params EnhancedSimulationParams) (*
 }
 
 // applyWithholding calculates take-home pay after withholding
-func (e *BronzeEngine) applyWithholding(grossIncome float64, taxState *TaxState, stateRate float64) float64 {
+func (e *BronzeEngine) applyWithholding(grossIncome float64, taxState *TaxState, stateCode string, year int) float64 {
 	// Estimate annual income for bracket calculation
 	estimatedAnnual := grossIncome * 12
 
 	// Calculate approximate marginal rates
-	federalRate := e.estimateMarginalRate(estimatedAnnual, FederalBrackets2024)
-	if stateRate <= 0 {
-		stateRate = 0.065 // Default ~6.5% effective state rate
+	federalRate := e.estimateMarginalRate(estimatedAnnual, FederalBrackets(year))
+
+	// State withholding at the state's effective rate on the estimated annual income
+	stateRate := 0.0
+	if estimatedAnnual > 0 {
+		stateRate = CalculateStateTax(engine.StateIncome{Ordinary: estimatedAnnual}, stateCode, year) / estimatedAnnual
 	}
 
 	// FICA (Social Security + Medicare)
 	ficaRate := 0.0765 // 6.2% SS + 1.45% Medicare
 
-	totalWithholding := grossIncome * (federalRate + stateRate*0.7 + ficaRate)
+	totalWithholding := grossIncome * (federalRate + stateRate + ficaRate)
 	taxState.TaxWithheld += totalWithholding
 
 	return grossIncome - totalWithholding
@@ -351,9 +357,9 @@ func (e *BronzeEngine) estimateMarginalRate(income float64, brackets []TaxBracke
 }
 
 // calculateAnnualTax computes total annual tax liability
-func (e *BronzeEngine) calculateAnnualTax(taxState *TaxState, stateRate float64) float64 {
-	// Standard deduction (2024 single)
-	standardDeduction := 14600.0
+func (e *BronzeEngine) calculateAnnualTax(taxState *TaxState, stateCode string, age, year int) float64 {
+	// Standard deduction (single) for the tax year
+	standardDeduction := StandardDeduction(year)
 
 	// Taxable ordinary income
 	taxableOrdinary := max(0, taxState.OrdinaryIncome-standardDeduction)
@@ -362,13 +368,19 @@ func (e *BronzeEngine) calculateAnnualTax(taxState *TaxState, stateRate float64)
 	taxableOrdinary += taxState.ShortTermGains
 
 	// Federal income tax
-	federalTax := CalculateFederalTax(taxableOrdinary)
+	federalTax := CalculateFederalTax(taxableOrdinary, year)
 
 	// Capital gains tax (long-term)
-	capGainsTax := CalculateCapitalGainsTax(taxState.LongTermGains, taxableOrdinary)
-
-	// State tax (simplified)
-	stateTax := CalculateStateTax(taxableOrdinary+taxState.LongTermGains, stateRate)
+	capGainsTax := CalculateCapitalGainsTax(taxState.LongTermGains, taxableOrdinary, year)
+
+	// State tax with the state's own deductions, retirement exclusions and capital gains rules
+	stateTax := CalculateStateTax(engine.StateIncome{
+		Ordinary:                taxState.OrdinaryIncome,
+		RetirementDistributions: taxState.Distributions,
+		ShortTermCapitalGains:   taxState.ShortTermGains,
+		LongTermCapitalGains:    taxState.LongTermGains,
+		FilerAges:               []int{age},
+	}, stateCode, year)
 
 	return federalTax + capGainsTax + stateTax
 }
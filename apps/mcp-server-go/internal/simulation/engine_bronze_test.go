@@ -16,7 +16,7 @@ func TestBronzeEngineBasic(t *testing.T) {
 		MCPaths:       100,
 		Tier:          TierBronze,
 		CurrentAge:    35,
-		StateRate:     0.093, // CA
+		StateCode:     "CA",
 		InitialAccounts: Accounts{
 			Cash:        50000,
 			Taxable:     300000,
@@ -102,7 +102,7 @@ func TestBronzeDeterminism(t *testing.T) {
 // TestTaxCalculations verifies tax calculations are reasonable
 func TestTaxCalculations(t *testing.T) {
 	// Test federal tax
-	tax100k := CalculateFederalTax(100000)
+	tax100k := CalculateFederalTax(100000, 2024)
 	t.Logf("Federal tax on $100k: $%.2f (%.1f%% effective)", tax100k, tax100k/100000*100)
 
 	// Expected ~$17,400 for $100k single filer 2024
@@ -110,8 +110,16 @@ func TestTaxCalculations(t *testing.T) {
 		t.Errorf("Federal tax on $100k seems wrong: $%.2f", tax100k)
 	}
 
+	// Later years use that year's (wider) brackets
+	if tax2026 := CalculateFederalTax(100000, 2026); tax2026 >= tax100k {
+		t.Errorf("2026 tax on $100k ($%.2f) should be below 2024 ($%.2f)", tax2026, tax100k)
+	}
+	if StandardDeduction(2026) != 16100 {
+		t.Errorf("2026 standard deduction: got $%.0f, want $16,100", StandardDeduction(2026))
+	}
+
 	// Test capital gains
-	capGains := CalculateCapitalGainsTax(50000, 100000)
+	capGains := CalculateCapitalGainsTax(50000, 100000, 2024)
 	t.Logf("Cap gains tax on $50k (with $100k ordinary): $%.2f", capGains)
 
 	// At $100k ordinary + $50k gains = $150k total, most gains at 15%
@@ -131,7 +139,7 @@ func BenchmarkBronzeEngine100Paths(b *testing.B) {
 		HorizonMonths: 360,
 		MCPaths:       100,
 		CurrentAge:    35,
-		StateRate:     0.093,
+		StateCode:     "CA",
 		InitialAccounts: Accounts{
 			Cash:        50000,
 			Taxable:     300000,
@@ -220,7 +228,7 @@ func TestBronzePerformanceReport(t *testing.T) {
 			HorizonMonths: 360,
 			MCPaths:       cfg.paths,
 			CurrentAge:    35,
-			StateRate:     0.093,
+			StateCode:     "CA",
 			InitialAccounts: Accounts{
 				Cash:        50000,
 				Taxable:     300000,
@@ -2,11 +2,20 @@ package simulation
 
 import (
 	"fmt"
+	"log"
 
-	"github.com/areumfire/mcp-server-go/internal/engine"
+	"pathfinder-wasm/engine"
 )
 
-// FullEngine wraps the complete simulation engine from wasm/
+func init() {
+	// The shared engine package leaves config loading to its host (the WASM
+	// entrypoint loads it at startup); load the embedded tax tables here.
+	if err := engine.LoadEmbeddedFinancialConfig(); err != nil {
+		log.Printf("Warning: failed to load embedded engine config: %v", err)
+	}
+}
+
+// FullEngine wraps the shared simulation engine (wasm/engine)
 type FullEngine struct {
 	ready bool
 }
@@ -33,9 +42,9 @@ type FullSimulationParams struct {
 	MCPaths       int `json:"mcPaths"`
 
 	// Person info
-	CurrentAge int     `json:"currentAge"`
-	StateCode  string  `json:"stateCode"` // e.g., "CA", "TX"
-	StateRate  float64 `json:"stateRate"` // Override state rate
+	CurrentAge int    `json:"currentAge"`
+	DeathAge   int    `json:"deathAge,omitempty"` // Survivor transition for households (0 = alive through the horizon)
+	StateCode  string `json:"stateCode"`          // e.g., "CA", "TX"; taxed from the engine's state tables
 
 	// Initial accounts
 	CashBalance        float64 `json:"cashBalance"`
@@ -53,10 +62,25 @@ type FullSimulationParams struct {
 	ContributionRoth float64 `json:"contributionRoth"`
 	ContributionHSA  float64 `json:"contributionHSA"`
 
+	// Working years: salary and contributions stop at RetirementAge (0 = never)
+	// and pause for SabbaticalMonths starting at SabbaticalMonthOffset
+	RetirementAge         int `json:"retirementAge,omitempty"`
+	SabbaticalMonthOffset int `json:"sabbaticalMonthOffset,omitempty"`
+	SabbaticalMonths      int `json:"sabbaticalMonths,omitempty"`
+
 	// Social Security
-	SocialSecurityAge    int     `json:"socialSecurityAge"`
+	SocialSecurityAge     int     `json:"socialSecurityAge"`
 	SocialSecurityBenefit float64 `json:"socialSecurityBenefit"` // Monthly
 
+	// Spouse (optional) makes this a two-person household filing jointly
+	Spouse *SpouseParams `json:"spouse,omitempty"`
+
+	// Longevity (optional): each path draws lifespans from the period life table
+	// and ends at the last death; HorizonMonths becomes the maximum horizon
+	Longevity           bool    `json:"longevity,omitempty"`
+	Sex                 string  `json:"sex,omitempty"`                 // Life table column: "male", "female" or "" (unisex)
+	MortalityMultiplier float64 `json:"mortalityMultiplier,omitempty"` // Health adjustment to the table (0 = 1.0)
+
 	// Events (optional)
 	Events []engine.FinancialEvent `json:"events,omitempty"`
 
@@ -88,25 +112,69 @@ type FullSimulationResult struct {
 	FinalAccounts map[string]float64 `json:"finalAccounts,omitempty"`
 
 	// Tax summary
-	TotalTaxesPaid    float64 `json:"totalTaxesPaid,omitempty"`
-	EffectiveTaxRate  float64 `json:"effectiveTaxRate,omitempty"`
+	TotalTaxesPaid   float64 `json:"totalTaxesPaid,omitempty"`
+	EffectiveTaxRate float64 `json:"effectiveTaxRate,omitempty"`
+
+	// Lifespans, estates and outcomes conditional on being alive (longevity only)
+	Longevity *engine.LongevitySummary `json:"longevity,omitempty"`
 }
 
 // RunFullSimulation runs the complete simulation engine with UI payload transformer
 // This ensures full parity with the WASM engine including trajectory data
 func (e *FullEngine) RunFullSimulation(params FullSimulationParams) (*FullSimulationResult, error) {
+	if err := prepareFullParams(&params); err != nil {
+		return &FullSimulationResult{Success: false, Error: err.Error()}, err
+	}
+
+	input := buildSimulationInput(params)
+
+	// Run simulation with UI payload transformer (includes trajectory)
+	payload := engine.RunSimulationWithUIPayload(input, params.MCPaths)
+
+	// Check for errors in plan health
+	if len(payload.PlanProjection.Summary.PlanHealth.KeyRisks) > 0 &&
+		payload.PlanProjection.Summary.PlanHealth.KeyRisks[0] != "" &&
+		len(payload.PlanProjection.Summary.PlanHealth.KeyRisks[0]) > 20 &&
+		payload.PlanProjection.Summary.PlanHealth.KeyRisks[0][:20] == "Simulation failed: " {
+		return &FullSimulationResult{
+			Success: false,
+			Error:   payload.PlanProjection.Summary.PlanHealth.KeyRisks[0],
+		}, fmt.Errorf("%s", payload.PlanProjection.Summary.PlanHealth.KeyRisks[0])
+	}
+
+	// Convert payload to our result format
+	return convertPayloadToResult(payload, params), nil
+}
+
+// prepareFullParams fills in the default paths and horizon and validates the plan
+func prepareFullParams(params *FullSimulationParams) error {
 	if params.MCPaths < 1 {
 		params.MCPaths = 100
 	}
 	if params.HorizonMonths < 12 {
 		params.HorizonMonths = 360
 	}
+	if err := ValidateEvents(params.Events, params.HorizonMonths); err != nil {
+		return err
+	}
+	if err := validateHousehold(*params); err != nil {
+		return err
+	}
+	if err := validateLongevity(*params); err != nil {
+		return err
+	}
+	return validateSocialSecurity(*params)
+}
 
-	// Build simulation input for the engine
-	input := engine.SimulationInput{
+// buildSimulationInput maps adapter params onto the shared engine's SimulationInput
+func buildSimulationInput(params FullSimulationParams) engine.SimulationInput {
+	return engine.SimulationInput{
 		MonthsToRun: params.HorizonMonths,
 		StartYear:   params.StartYear,
 		InitialAge:  params.CurrentAge,
+		Household:   buildHousehold(params),
+		Longevity:   buildLongevity(params),
+		TaxConfig:   &engine.SimpleTaxConfig{Enabled: true, State: params.StateCode},
 		InitialAccounts: engine.AccountHoldingsMonthEnd{
 			Cash: params.CashBalance,
 			Taxable: &engine.Account{
@@ -114,13 +182,14 @@ func (e *FullEngine) RunFullSimulation(params FullSimulationParams) (*FullSimula
 				Holdings:   []engine.Holding{},
 			},
 			TaxDeferred: &engine.Account{
-				TotalValue: params.TaxDeferredBalance,
+				TotalValue: totalTaxDeferredBalance(params),
 				Holdings:   []engine.Holding{},
 			},
 			Roth: &engine.Account{
 				TotalValue: params.RothBalance,
 				Holdings:   []engine.Holding{},
 			},
+			FiveTwoNine: fiveTwoNineAccount(params.FiveTwoNineBalance),
 		},
 		Config: engine.StochasticModelConfig{
 			RandomSeed:        int64(params.Seed),
@@ -135,39 +204,33 @@ func (e *FullEngine) RunFullSimulation(params FullSimulationParams) (*FullSimula
 		},
 		Events: buildEvents(params),
 	}
+}
 
-	// Run simulation with UI payload transformer (includes trajectory)
-	payload := engine.RunSimulationWithUIPayload(input, params.MCPaths)
-
-	// Check for errors in plan health
-	if len(payload.PlanProjection.Summary.PlanHealth.KeyRisks) > 0 &&
-		payload.PlanProjection.Summary.PlanHealth.KeyRisks[0] != "" &&
-		len(payload.PlanProjection.Summary.PlanHealth.KeyRisks[0]) > 20 &&
-		payload.PlanProjection.Summary.PlanHealth.KeyRisks[0][:20] == "Simulation failed: " {
-		return &FullSimulationResult{
-			Success: false,
-			Error:   payload.PlanProjection.Summary.PlanHealth.KeyRisks[0],
-		}, fmt.Errorf("%s", payload.PlanProjection.Summary.PlanHealth.KeyRisks[0])
+// fiveTwoNineAccount opens the 529 only when the plan has a balance in it
+func fiveTwoNineAccount(balance float64) *engine.Account {
+	if balance <= 0 {
+		return nil
 	}
-
-	// Convert payload to our result format
-	return convertPayloadToResult(payload, params), nil
+	return &engine.Account{TotalValue: balance, Holdings: []engine.Holding{}}
 }
 
 // buildEvents creates financial events from params
 func buildEvents(params FullSimulationParams) []engine.FinancialEvent {
 	events := make([]engine.FinancialEvent, 0)
 
-	// Add income event
+	windows := workingWindows(params)
+
+	// Add income event (one per working window)
 	if params.AnnualIncome > 0 {
-		events = append(events, engine.FinancialEvent{
-			ID:          "income-salary",
-			Type:        "INCOME",
-			Description: "Annual salary income",
-			Amount:      params.AnnualIncome / 12, // Monthly
-			MonthOffset: 0,
-			Frequency:   "monthly",
-		})
+		for i, w := range windows {
+			events = append(events, w.apply(engine.FinancialEvent{
+				ID:          windowID("income-salary", i),
+				Type:        "INCOME",
+				Description: "Annual salary income",
+				Amount:      params.AnnualIncome / 12, // Monthly
+				Frequency:   "monthly",
+			}, params.HorizonMonths))
+		}
 	}
 
 	// Add spending event
@@ -184,30 +247,47 @@ func buildEvents(params FullSimulationParams) []engine.FinancialEvent {
 
 	// Add 401k contribution
 	if params.Contribution401k > 0 {
-		targetType := "tax_deferred"
-		events = append(events, engine.FinancialEvent{
-			ID:                fmt.Sprintf("contribution-401k-%d", params.Seed),
-			Type:              "SCHEDULED_CONTRIBUTION",
-			Description:       "401k contribution",
-			Amount:            params.Contribution401k / 12,
-			MonthOffset:       0,
-			Frequency:         "monthly",
-			TargetAccountType: &targetType,
-		})
+		for i, w := range windows {
+			targetType := "tax_deferred"
+			events = append(events, w.apply(engine.FinancialEvent{
+				ID:                windowID(fmt.Sprintf("contribution-401k-%d", params.Seed), i),
+				Type:              "SCHEDULED_CONTRIBUTION",
+				Description:       "401k contribution",
+				Amount:            params.Contribution401k / 12,
+				Frequency:         "monthly",
+				TargetAccountType: &targetType,
+			}, params.HorizonMonths))
+		}
 	}
 
 	// Add Roth contribution
 	if params.ContributionRoth > 0 {
-		targetType := "roth"
-		events = append(events, engine.FinancialEvent{
-			ID:                fmt.Sprintf("contribution-roth-%d", params.Seed),
-			Type:              "SCHEDULED_CONTRIBUTION",
-			Description:       "Roth IRA contribution",
-			Amount:            params.ContributionRoth / 12,
-			MonthOffset:       0,
-			Frequency:         "monthly",
-			TargetAccountType: &targetType,
-		})
+		for i, w := range windows {
+			targetType := "roth"
+			events = append(events, w.apply(engine.FinancialEvent{
+				ID:                windowID(fmt.Sprintf("contribution-roth-%d", params.Seed), i),
+				Type:              "SCHEDULED_CONTRIBUTION",
+				Description:       "Roth IRA contribution",
+				Amount:            params.ContributionRoth / 12,
+				Frequency:         "monthly",
+				TargetAccountType: &targetType,
+			}, params.HorizonMonths))
+		}
+	}
+
+	// Add HSA contribution
+	if params.ContributionHSA > 0 {
+		for i, w := range windows {
+			targetType := "hsa"
+			events = append(events, w.apply(engine.FinancialEvent{
+				ID:                windowID(fmt.Sprintf("contribution-hsa-%d", params.Seed), i),
+				Type:              "SCHEDULED_CONTRIBUTION",
+				Description:       "HSA contribution",
+				Amount:            params.ContributionHSA / 12,
+				Frequency:         "monthly",
+				TargetAccountType: &targetType,
+			}, params.HorizonMonths))
+		}
 	}
 
 	// Add Social Security
@@ -223,14 +303,74 @@ func buildEvents(params FullSimulationParams) []engine.FinancialEvent {
 				Frequency:   "monthly",
 			})
 		}
+	} else if derivesSocialSecurity(params.SocialSecurityAge, params.SocialSecurityBenefit, params.AnnualIncome) {
+		events = append(events, socialSecurityClaim("social-security", "Social Security benefits",
+			params.CurrentAge, params.SocialSecurityAge, params.RetirementAge, params.AnnualIncome))
 	}
 
+	// Add the spouse's own income, contributions and benefits
+	events = append(events, buildSpouseEvents(params)...)
+
 	// Append any custom events
-	events = append(events, params.Events...)
+	events = append(events, normalizeEvents(params.Events)...)
 
 	return events
 }
 
+// monthWindow is a half-open [start, end) range of simulation months
+type monthWindow struct {
+	start, end int
+}
+
+// apply schedules a recurring event over the window
+func (w monthWindow) apply(event engine.FinancialEvent, horizonMonths int) engine.FinancialEvent {
+	event.MonthOffset = w.start
+	if w.end < horizonMonths {
+		event.Metadata = map[string]interface{}{"endDateOffset": w.end}
+	}
+	return event
+}
+
+// windowID keeps the first window's ID stable and suffixes later ones
+func windowID(base string, i int) string {
+	if i == 0 {
+		return base
+	}
+	return fmt.Sprintf("%s-resumed-%d", base, i)
+}
+
+// workingWindows returns the months in which salary and contributions are paid:
+// the whole horizon, cut off at retirement and split around a sabbatical
+func workingWindows(params FullSimulationParams) []monthWindow {
+	end := params.HorizonMonths
+	if params.RetirementAge > 0 {
+		retireMonth := (params.RetirementAge - params.CurrentAge) * 12
+		if retireMonth < end {
+			end = retireMonth
+		}
+	}
+	if end <= 0 {
+		return nil
+	}
+
+	if params.SabbaticalMonths <= 0 || params.SabbaticalMonthOffset >= end {
+		return []monthWindow{{0, end}}
+	}
+
+	windows := make([]monthWindow, 0, 2)
+	sabbaticalStart := params.SabbaticalMonthOffset
+	if sabbaticalStart < 0 {
+		sabbaticalStart = 0
+	}
+	if sabbaticalStart > 0 {
+		windows = append(windows, monthWindow{0, sabbaticalStart})
+	}
+	if resume := sabbaticalStart + params.SabbaticalMonths; resume < end {
+		windows = append(windows, monthWindow{resume, end})
+	}
+	return windows
+}
+
 // convertResult converts engine result to our format
 func convertResult(result engine.SimulationResults, params FullSimulationParams) *FullSimulationResult {
 	horizonYears := params.HorizonMonths / 12
@@ -255,6 +395,7 @@ func convertResult(result engine.SimulationResults, params FullSimulationParams)
 		RunwayP50:             runwayP50,
 		RunwayP75:             runwayP75,
 		FinalNetWorthP50:      result.FinalNetWorthP50,
+		FinalNetWorthRealP50:  result.FinalNetWorthRealP50,
 		EverBreachProbability: result.EverBreachProbability,
 	}
 
@@ -267,6 +408,7 @@ func convertResult(result engine.SimulationResults, params FullSimulationParams)
 				P10:         pt.P10,
 				P50:         pt.P50,
 				P75:         pt.P75,
+				RealP50:     pt.RealP50,
 			})
 		}
 	}
@@ -279,21 +421,22 @@ func convertResult(result engine.SimulationResults, params FullSimulationParams)
 	}
 	for year := 0; year <= horizonYears; year += interval {
 		// Find matching trajectory point
-		var endBalance float64
+		var endBalance, endBalanceReal float64
 		monthOffset := year * 12
 		for _, pt := range trajectory {
 			if pt.MonthOffset == monthOffset {
-				endBalance = pt.P50
+				endBalance, endBalanceReal = pt.P50, pt.RealP50
 				break
 			}
 		}
 
 		snapshots = append(snapshots, AnnualSnapshot{
-			Age:           params.CurrentAge + year,
-			Year:          year,
-			EndBalance:    endBalance,
-			TotalIncome:   params.AnnualIncome,
-			TotalExpenses: params.AnnualSpending,
+			Age:            params.CurrentAge + year,
+			Year:           year,
+			EndBalance:     endBalance,
+			EndBalanceReal: endBalanceReal,
+			TotalIncome:    params.AnnualIncome,
+			TotalExpenses:  params.AnnualSpending,
 		})
 	}
 
@@ -328,6 +471,7 @@ func convertPayloadToResult(payload engine.SimulationPayload, params FullSimulat
 			P10:         pt.P10,
 			P50:         pt.P50,
 			P75:         pt.P75,
+			RealP50:     pt.RealP50,
 		})
 	}
 
@@ -340,15 +484,17 @@ func convertPayloadToResult(payload engine.SimulationPayload, params FullSimulat
 	}
 
 	for year := 0; year <= horizonYears; year += interval {
-		var startBal, endBal float64
+		var startBal, endBal, endBalReal float64
 		if year < len(charts.NetWorth.TimeSeries) {
 			endBal = charts.NetWorth.TimeSeries[year].P50
+			endBalReal = charts.NetWorth.TimeSeries[year].RealP50
 		}
 		if year > 0 && year-1 < len(charts.NetWorth.TimeSeries) {
 			startBal = charts.NetWorth.TimeSeries[year-1].P50
 		} else if year == 0 {
-			startBal = params.CashBalance + params.TaxableBalance + params.TaxDeferredBalance + params.RothBalance
+			startBal = params.CashBalance + params.TaxableBalance + totalTaxDeferredBalance(params) + params.RothBalance
 			endBal = startBal // Year 0 is starting point
+			endBalReal = startBal
 		}
 
 		snapshots = append(snapshots, AnnualSnapshot{
@@ -356,6 +502,7 @@ func convertPayloadToResult(payload engine.SimulationPayload, params FullSimulat
 			Year:             year,
 			StartBalance:     startBal,
 			EndBalance:       endBal,
+			EndBalanceReal:   endBalReal,
 			TotalIncome:      params.AnnualIncome,
 			TotalExpenses:    params.AnnualSpending,
 			InvestmentGrowth: endBal - startBal - params.AnnualIncome + params.AnnualSpending,
@@ -383,9 +530,10 @@ func convertPayloadToResult(payload engine.SimulationPayload, params FullSimulat
 	breachProb := 1.0 - stats.SuccessRate
 
 	// Get final net worth from chart summary
-	finalNW := 0.0
+	finalNW, finalNWReal := 0.0, 0.0
 	if len(charts.NetWorth.TimeSeries) > 0 {
 		finalNW = charts.NetWorth.TimeSeries[len(charts.NetWorth.TimeSeries)-1].P50
+		finalNWReal = charts.NetWorth.TimeSeries[len(charts.NetWorth.TimeSeries)-1].RealP50
 	}
 
 	return &FullSimulationResult{
@@ -398,6 +546,7 @@ func convertPayloadToResult(payload engine.SimulationPayload, params FullSimulat
 			RunwayP50:             runwayP50,
 			RunwayP75:             runwayP75,
 			FinalNetWorthP50:      finalNW,
+			FinalNetWorthRealP50:  finalNWReal,
 			EverBreachProbability: breachProb,
 		},
 		PlanDuration: &PlanDuration{
@@ -408,5 +557,6 @@ func convertPayloadToResult(payload engine.SimulationPayload, params FullSimulat
 		},
 		Trajectory: trajectory,
 		Snapshots:  snapshots,
+		Longevity:  summary.Longevity,
 	}
 }
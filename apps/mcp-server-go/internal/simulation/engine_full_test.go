@@ -1,8 +1,11 @@
 package simulation
 
 import (
+	"errors"
 	"testing"
 	"time"
+
+	"pathfinder-wasm/engine"
 )
 
 // TestFullEngineBasic verifies the full engine produces valid results
@@ -16,7 +19,6 @@ func TestFullEngineBasic(t *testing.T) {
 		MCPaths:            100,
 		CurrentAge:         35,
 		StateCode:          "CA",
-		StateRate:          0.093,
 		CashBalance:        50000,
 		TaxableBalance:     300000,
 		TaxDeferredBalance: 150000, // 401k
@@ -53,6 +55,273 @@ func TestFullEngineBasic(t *testing.T) {
 	t.Logf("  Paths run: %d", result.PathsRun)
 }
 
+// TestFullEngineCustomEvents verifies caller events reach the engine
+func TestFullEngineCustomEvents(t *testing.T) {
+	fullEngine := NewFullEngine()
+
+	params := FullSimulationParams{
+		Seed:               2024,
+		StartYear:          2025,
+		HorizonMonths:      240,
+		MCPaths:            50,
+		CurrentAge:         40,
+		CashBalance:        100000,
+		TaxableBalance:     400000,
+		TaxDeferredBalance: 200000,
+		AnnualIncome:       120000,
+		AnnualSpending:     70000,
+		LiteMode:           true,
+	}
+
+	baseline, err := fullEngine.RunFullSimulation(params)
+	if err != nil {
+		t.Fatalf("Baseline simulation failed: %v", err)
+	}
+
+	params.Events = []engine.FinancialEvent{
+		{Type: "ONE_TIME_EXPENSE", Description: "Home renovation", MonthOffset: 12, Amount: 150000},
+	}
+	withExpense, err := fullEngine.RunFullSimulation(params)
+	if err != nil {
+		t.Fatalf("Simulation with events failed: %v", err)
+	}
+
+	if withExpense.MC.FinalNetWorthP50 >= baseline.MC.FinalNetWorthP50 {
+		t.Errorf("Expected a $150k expense to lower P50 net worth: baseline=%.0f, with expense=%.0f",
+			baseline.MC.FinalNetWorthP50, withExpense.MC.FinalNetWorthP50)
+	}
+}
+
+// TestFullEngineRejectsInvalidEvents verifies unknown types come back as structured errors
+func TestFullEngineRejectsInvalidEvents(t *testing.T) {
+	params := FullSimulationParams{
+		Seed:          1,
+		StartYear:     2025,
+		HorizonMonths: 120,
+		MCPaths:       10,
+		CurrentAge:    40,
+		CashBalance:   50000,
+		Events: []engine.FinancialEvent{
+			{ID: "ok", Type: "PENSION_INCOME", MonthOffset: 0, Amount: 2000, Frequency: "monthly"},
+			{ID: "bad-type", Type: "LOTTERY_WIN", MonthOffset: 6, Amount: 1000000},
+			{ID: "bad-offset", Type: "ONE_TIME_EXPENSE", MonthOffset: 500, Amount: 10000, Frequency: "fortnightly"},
+		},
+	}
+
+	result, err := NewFullEngine().RunFullSimulation(params)
+	if err == nil {
+		t.Fatal("Expected validation error")
+	}
+	if result.Success {
+		t.Error("Expected unsuccessful result")
+	}
+
+	var eventErrs EventValidationErrors
+	if !errors.As(err, &eventErrs) {
+		t.Fatalf("Expected EventValidationErrors, got %T", err)
+	}
+
+	codes := make(map[string]int)
+	for _, e := range eventErrs {
+		codes[e.Code] = e.Index
+	}
+	if idx, ok := codes["unknown_event_type"]; !ok || idx != 1 {
+		t.Errorf("Expected unknown_event_type at index 1, got %v", codes)
+	}
+	if _, ok := codes["month_offset_beyond_horizon"]; !ok {
+		t.Errorf("Expected month_offset_beyond_horizon, got %v", codes)
+	}
+	if _, ok := codes["unknown_frequency"]; !ok {
+		t.Errorf("Expected unknown_frequency, got %v", codes)
+	}
+}
+
+// TestWorkingWindows verifies retirement and sabbatical cut the salary schedule
+func TestWorkingWindows(t *testing.T) {
+	params := FullSimulationParams{
+		HorizonMonths:         480,
+		CurrentAge:            40,
+		RetirementAge:         60,
+		SabbaticalMonthOffset: 24,
+		SabbaticalMonths:      6,
+	}
+
+	windows := workingWindows(params)
+	want := []monthWindow{{0, 24}, {30, 240}}
+	if len(windows) != len(want) {
+		t.Fatalf("got windows %+v, want %+v", windows, want)
+	}
+	for i := range want {
+		if windows[i] != want[i] {
+			t.Errorf("window %d: got %+v, want %+v", i, windows[i], want[i])
+		}
+	}
+
+	params.AnnualIncome = 120000
+	events := buildEvents(params)
+	if len(events) != 2 || events[1].MonthOffset != 30 || events[1].Metadata["endDateOffset"] != 240 {
+		t.Errorf("expected salary split around the sabbatical and ending at retirement, got %+v", events)
+	}
+}
+
+// TestFullEngineHousehold verifies a spouse becomes a second household member with owned events
+func TestFullEngineHousehold(t *testing.T) {
+	params := FullSimulationParams{
+		Seed:               7,
+		StartYear:          2025,
+		HorizonMonths:      360,
+		MCPaths:            20,
+		CurrentAge:         55,
+		CashBalance:        50000,
+		TaxableBalance:     300000,
+		TaxDeferredBalance: 400000,
+		AnnualIncome:       150000,
+		AnnualSpending:     90000,
+		RetirementAge:      65,
+		LiteMode:           true,
+	}
+
+	single, err := NewFullEngine().RunFullSimulation(params)
+	if err != nil {
+		t.Fatalf("Single-person simulation failed: %v", err)
+	}
+
+	params.Spouse = &SpouseParams{
+		CurrentAge:            50,
+		AnnualIncome:          90000,
+		RetirementAge:         62,
+		TaxDeferredBalance:    150000,
+		Contribution401k:      20000,
+		SocialSecurityAge:     67,
+		SocialSecurityBenefit: 2000,
+		DeathAge:              85,
+	}
+
+	input := buildSimulationInput(params)
+	if input.Household == nil || len(input.Household.Members) != 2 {
+		t.Fatalf("expected a two-person household, got %+v", input.Household)
+	}
+	spouse := input.Household.Members[1]
+	if spouse.BirthYear != 1975 || *spouse.RetirementMonthOffset != 144 || *spouse.DeathMonthOffset != 420 {
+		t.Errorf("unexpected spouse member: %+v", spouse)
+	}
+	if input.InitialAccounts.TaxDeferred.TotalValue != 550000 {
+		t.Errorf("expected pooled tax-deferred balance of 550000, got %.0f", input.InitialAccounts.TaxDeferred.TotalValue)
+	}
+
+	spouseEvents := 0
+	for _, ev := range input.Events {
+		if ev.Metadata["owner"] == SpouseMemberID {
+			spouseEvents++
+		}
+	}
+	if spouseEvents != 3 {
+		t.Errorf("expected spouse salary, 401k and Social Security events, got %d", spouseEvents)
+	}
+
+	couple, err := NewFullEngine().RunFullSimulation(params)
+	if err != nil {
+		t.Fatalf("Household simulation failed: %v", err)
+	}
+	if couple.MC.FinalNetWorthP50 <= single.MC.FinalNetWorthP50 {
+		t.Errorf("Expected a second earner to raise P50 net worth: single=%.0f, couple=%.0f",
+			single.MC.FinalNetWorthP50, couple.MC.FinalNetWorthP50)
+	}
+
+	params.Spouse.DeathAge = 40
+	if _, err := NewFullEngine().RunFullSimulation(params); err == nil {
+		t.Error("Expected a spouse deathAge below currentAge to be rejected")
+	}
+}
+
+// TestFullEngineLongevity verifies per-path lifespans replace the fixed horizon
+func TestFullEngineLongevity(t *testing.T) {
+	params := FullSimulationParams{
+		Seed:           11,
+		StartYear:      2025,
+		HorizonMonths:  LongevityHorizonMonths(70, nil),
+		MCPaths:        30,
+		CurrentAge:     70,
+		CashBalance:    100000,
+		TaxableBalance: 900000,
+		AnnualSpending: 50000,
+		Longevity:      true,
+		Sex:            "female",
+		LiteMode:       true,
+	}
+	if params.HorizonMonths != 600 {
+		t.Fatalf("expected a horizon to age 120 (600 months), got %d", params.HorizonMonths)
+	}
+
+	input := buildSimulationInput(params)
+	if input.Longevity == nil || input.Longevity.Sex != "female" || input.Household != nil {
+		t.Fatalf("expected a single-person longevity config, got %+v / %+v", input.Longevity, input.Household)
+	}
+
+	result, err := NewFullEngine().RunFullSimulation(params)
+	if err != nil {
+		t.Fatalf("Longevity simulation failed: %v", err)
+	}
+	if result.Longevity == nil || len(result.Longevity.ByAge) == 0 {
+		t.Fatal("Expected outcomes conditional on being alive")
+	}
+	if result.Longevity.DeathAgeP50 < 80 || result.Longevity.DeathAgeP50 > 100 {
+		t.Errorf("Median age at death %d is implausible for a 70-year-old", result.Longevity.DeathAgeP50)
+	}
+	if result.Longevity.ProbabilityOutlivesHorizon != 0 {
+		t.Errorf("Nobody should outlive a horizon to age 120, got %.2f", result.Longevity.ProbabilityOutlivesHorizon)
+	}
+
+	params.Sex = "unknown"
+	if _, err := NewFullEngine().RunFullSimulation(params); err == nil {
+		t.Error("Expected an unknown sex to be rejected")
+	}
+
+	if got := LongevityHorizonMonths(10, nil); got != 1200 {
+		t.Errorf("Expected the horizon to cap at the engine limit, got %d", got)
+	}
+	if got := LongevityHorizonMonths(70, &SpouseParams{CurrentAge: 60}); got != 720 {
+		t.Errorf("Expected the horizon to follow the younger spouse, got %d", got)
+	}
+}
+
+// TestFullEngineDerivedSocialSecurity verifies a claiming age without a benefit becomes a claim on the salary
+func TestFullEngineDerivedSocialSecurity(t *testing.T) {
+	params := FullSimulationParams{
+		Seed:              5,
+		StartYear:         2025,
+		HorizonMonths:     240,
+		MCPaths:           10,
+		CurrentAge:        55,
+		CashBalance:       50000,
+		TaxableBalance:    500000,
+		AnnualIncome:      120000,
+		AnnualSpending:    70000,
+		RetirementAge:     63,
+		SocialSecurityAge: 67,
+		LiteMode:          true,
+	}
+
+	input := buildSimulationInput(params)
+	var claim *engine.FinancialEvent
+	for i, ev := range input.Events {
+		if ev.Type == "SOCIAL_SECURITY_CLAIM" {
+			claim = &input.Events[i]
+		}
+	}
+	if claim == nil || claim.Metadata["projectedYears"] != 8.0 || claim.Metadata["claimingAge"] != 67.0 {
+		t.Fatalf("Expected a claim on the salary through retirement, got %+v", claim)
+	}
+	if _, err := NewFullEngine().RunFullSimulation(params); err != nil {
+		t.Fatalf("Derived Social Security simulation failed: %v", err)
+	}
+
+	params.SocialSecurityAge = 60
+	if _, err := NewFullEngine().RunFullSimulation(params); err == nil {
+		t.Error("Expected a claiming age below 62 to be rejected without a benefit")
+	}
+}
+
 // TestFullEngineDeterminism verifies same seed produces same results
 func TestFullEngineDeterminism(t *testing.T) {
 	engine := NewFullEngine()
@@ -94,7 +363,7 @@ func BenchmarkFullEngine100Paths(b *testing.B) {
 		HorizonMonths:      360,
 		MCPaths:            100,
 		CurrentAge:         35,
-		StateRate:          0.093,
+		StateCode:          "CA",
 		CashBalance:        50000,
 		TaxableBalance:     300000,
 		TaxDeferredBalance: 150000,
@@ -124,7 +393,7 @@ func BenchmarkFullEngine100PathsFullMode(b *testing.B) {
 		HorizonMonths:      360,
 		MCPaths:            100,
 		CurrentAge:         35,
-		StateRate:          0.093,
+		StateCode:          "CA",
 		CashBalance:        50000,
 		TaxableBalance:     300000,
 		TaxDeferredBalance: 150000,
@@ -187,7 +456,7 @@ func TestAllEngineComparison(t *testing.T) {
 		HorizonMonths: 360,
 		MCPaths:       mcPaths,
 		CurrentAge:    35,
-		StateRate:     0.093,
+		StateCode:     "CA",
 		InitialAccounts: Accounts{
 			Cash:        50000,
 			Taxable:     300000,
@@ -214,7 +483,7 @@ func TestAllEngineComparison(t *testing.T) {
 		HorizonMonths:      360,
 		MCPaths:            mcPaths,
 		CurrentAge:         35,
-		StateRate:          0.093,
+		StateCode:          "CA",
 		CashBalance:        50000,
 		TaxableBalance:     300000,
 		TaxDeferredBalance: 150000,
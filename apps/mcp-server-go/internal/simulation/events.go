@@ -0,0 +1,113 @@
+package simulation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"pathfinder-wasm/engine"
+)
+
+// EventFrequencies are the schedules the engine's event preprocessor expands
+var EventFrequencies = []string{"once", "monthly", "biweekly", "quarterly", "semiannually", "annually"}
+
+// EventValidationError describes one problem with a caller-supplied event
+type EventValidationError struct {
+	Index   int    `json:"index"` // Position in the events array
+	EventID string `json:"eventId,omitempty"`
+	Field   string `json:"field"`   // JSON field name, e.g. "type"
+	Code    string `json:"code"`    // Machine-readable code, e.g. "unknown_event_type"
+	Message string `json:"message"` // Human-readable description
+}
+
+// EventValidationErrors is returned when one or more events fail validation
+type EventValidationErrors []EventValidationError
+
+func (errs EventValidationErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = fmt.Sprintf("events[%d].%s: %s", e.Index, e.Field, e.Message)
+	}
+	return "invalid events: " + strings.Join(msgs, "; ")
+}
+
+// SupportedEventTypes returns the event types the shared engine has handlers for, sorted
+func SupportedEventTypes() []string {
+	registered := engine.NewEventHandlerRegistry().GetRegisteredEventTypes()
+	types := make([]string, len(registered))
+	for i, t := range registered {
+		types[i] = string(t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// ValidateEvents checks caller-supplied events against the engine's handler registry.
+// horizonMonths bounds monthOffset; pass 0 to skip that check.
+func ValidateEvents(events []engine.FinancialEvent, horizonMonths int) error {
+	supported := make(map[string]bool)
+	for _, t := range SupportedEventTypes() {
+		supported[t] = true
+	}
+	frequencies := make(map[string]bool)
+	for _, f := range EventFrequencies {
+		frequencies[f] = true
+	}
+	// Aliases the preprocessor also understands
+	frequencies["one-time"] = true
+	frequencies["annual"] = true
+
+	var errs EventValidationErrors
+	add := func(i int, ev engine.FinancialEvent, field, code, format string, args ...interface{}) {
+		errs = append(errs, EventValidationError{
+			Index:   i,
+			EventID: ev.ID,
+			Field:   field,
+			Code:    code,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	for i, ev := range events {
+		switch {
+		case ev.Type == "":
+			add(i, ev, "type", "missing_event_type", "event type is required")
+		case !supported[ev.Type]:
+			add(i, ev, "type", "unknown_event_type", "unsupported event type %q", ev.Type)
+		}
+
+		if math.IsNaN(ev.Amount) || math.IsInf(ev.Amount, 0) {
+			add(i, ev, "amount", "invalid_amount", "amount must be a finite number")
+		}
+
+		if ev.MonthOffset < 0 {
+			add(i, ev, "monthOffset", "invalid_month_offset", "monthOffset must be >= 0, got %d", ev.MonthOffset)
+		} else if horizonMonths > 0 && ev.MonthOffset >= horizonMonths {
+			add(i, ev, "monthOffset", "month_offset_beyond_horizon",
+				"monthOffset %d is past the %d-month horizon", ev.MonthOffset, horizonMonths)
+		}
+
+		if ev.Frequency != "" && !frequencies[ev.Frequency] {
+			add(i, ev, "frequency", "unknown_frequency",
+				"unsupported frequency %q (expected one of %s)", ev.Frequency, strings.Join(EventFrequencies, ", "))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// normalizeEvents fills in IDs for caller events that omit them so engine logs stay traceable
+func normalizeEvents(events []engine.FinancialEvent) []engine.FinancialEvent {
+	normalized := make([]engine.FinancialEvent, len(events))
+	for i, ev := range events {
+		if ev.ID == "" {
+			ev.ID = fmt.Sprintf("user-event-%d", i)
+		}
+		normalized[i] = ev
+	}
+	return normalized
+}
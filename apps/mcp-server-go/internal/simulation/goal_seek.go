@@ -0,0 +1,71 @@
+package simulation
+
+import (
+	"fmt"
+	"strings"
+
+	"pathfinder-wasm/engine"
+)
+
+// GoalParams says which plan input SolveGoal solves for and the target it must meet
+type GoalParams struct {
+	Variable  string  `json:"variable"`        // spending, retirement_month, savings_rate or contribution
+	Metric    string  `json:"metric"`          // terminal_wealth_p10, terminal_wealth_p50, breach_probability or bankruptcy_probability
+	Threshold float64 `json:"threshold"`       // Wealth floor, or the highest acceptable probability
+	Month     int     `json:"month,omitempty"` // Breach probability within this many months (0 = the whole plan)
+	Lower     float64 `json:"lower,omitempty"` // Search range (0 = the engine's default)
+	Upper     float64 `json:"upper,omitempty"`
+	Tolerance float64 `json:"tolerance,omitempty"`
+}
+
+// SolveGoal bisects one input of the plan on the plan's path seeds. Spending scales
+// the living expenses, contributions scale every contribution, and retirement moves
+// the month the primary's salary and contributions stop.
+func (e *FullEngine) SolveGoal(params FullSimulationParams, goal GoalParams) (*engine.GoalSeekResult, error) {
+	if err := prepareFullParams(&params); err != nil {
+		return nil, err
+	}
+
+	// The solver sets the retirement month itself, so the working windows run to the
+	// horizon (a derived Social Security benefit then assumes work until the claim)
+	if goal.Variable == engine.GoalVariableRetirementMonth {
+		params.RetirementAge = 0
+	}
+	input := buildSimulationInput(params)
+
+	var ids []string
+	switch goal.Variable {
+	case engine.GoalVariableSpending:
+		ids = []string{"expense-living"}
+		if params.AnnualSpending <= 0 {
+			return nil, fmt.Errorf("solving for spending needs annualSpending")
+		}
+	case engine.GoalVariableRetirementMonth:
+		for _, event := range input.Events {
+			if strings.HasPrefix(event.ID, "income-salary") ||
+				strings.HasPrefix(event.ID, fmt.Sprintf("contribution-401k-%d", params.Seed)) ||
+				strings.HasPrefix(event.ID, fmt.Sprintf("contribution-roth-%d", params.Seed)) ||
+				strings.HasPrefix(event.ID, fmt.Sprintf("contribution-hsa-%d", params.Seed)) {
+				ids = append(ids, event.ID)
+			}
+		}
+		if len(ids) == 0 {
+			return nil, fmt.Errorf("solving for the retirement month needs annualIncome")
+		}
+	}
+
+	return engine.SolveGoal(engine.GoalSeekRequest{
+		Input:    input,
+		Variable: goal.Variable,
+		EventIDs: ids,
+		Target: engine.GoalTarget{
+			Metric:    goal.Metric,
+			Threshold: goal.Threshold,
+			Month:     goal.Month,
+		},
+		Lower:     goal.Lower,
+		Upper:     goal.Upper,
+		Tolerance: goal.Tolerance,
+		Paths:     params.MCPaths,
+	})
+}
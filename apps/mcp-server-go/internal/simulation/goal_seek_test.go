@@ -0,0 +1,60 @@
+package simulation
+
+import (
+	"testing"
+
+	"pathfinder-wasm/engine"
+)
+
+// TestFullEngineSolveGoal verifies the adapter solves on the plan's own events
+func TestFullEngineSolveGoal(t *testing.T) {
+	fullEngine := NewFullEngine()
+	params := FullSimulationParams{
+		Seed:             4242,
+		StartYear:        2025,
+		HorizonMonths:    240,
+		MCPaths:          20,
+		CurrentAge:       45,
+		StateCode:        "TX",
+		CashBalance:      30000,
+		TaxableBalance:   250000,
+		AnnualIncome:     120000,
+		AnnualSpending:   60000,
+		Contribution401k: 15000,
+		RetirementAge:    50, // Ignored when solving for retirement
+		LiteMode:         true,
+	}
+
+	retire, err := fullEngine.SolveGoal(params, GoalParams{
+		Variable:  engine.GoalVariableRetirementMonth,
+		Metric:    engine.GoalMetricBankruptcyProbability,
+		Threshold: 0.1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !retire.Feasible || retire.Value <= 0 || retire.Value >= 240 {
+		t.Fatalf("expected a retirement month inside the horizon, got %+v", retire)
+	}
+	if retire.RetirementAge != 45+retire.Value/12 || retire.Low > retire.Value || retire.High < retire.Value {
+		t.Errorf("retirement age %.2f, band [%.0f, %.0f] around month %.0f", retire.RetirementAge, retire.Low, retire.High, retire.Value)
+	}
+
+	spend, err := fullEngine.SolveGoal(params, GoalParams{
+		Variable: engine.GoalVariableSpending,
+		Metric:   engine.GoalMetricTerminalWealthP10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !spend.Feasible || spend.Value <= 0 || spend.Metric < 0 || spend.Paths != 20 {
+		t.Errorf("spending solve: %+v", spend)
+	}
+	t.Logf("Retire at %.1f (month %.0f, band %.0f-%.0f); spend $%.0f/yr (band $%.0f-$%.0f)",
+		retire.RetirementAge, retire.Value, retire.Low, retire.High, spend.Value, spend.Low, spend.High)
+
+	params.AnnualSpending = 0
+	if _, err := fullEngine.SolveGoal(params, GoalParams{Variable: engine.GoalVariableSpending, Metric: engine.GoalMetricTerminalWealthP10}); err == nil {
+		t.Error("expected an error solving for spending without annualSpending")
+	}
+}
@@ -0,0 +1,194 @@
+package simulation
+
+import (
+	"fmt"
+
+	"pathfinder-wasm/engine"
+)
+
+// Household member IDs used as event "owner" metadata
+const (
+	PrimaryMemberID = "primary"
+	SpouseMemberID  = "spouse"
+)
+
+// SpouseParams describes the second person in a two-person household.
+// The top-level person fields (CurrentAge, AnnualIncome, ...) describe the primary.
+type SpouseParams struct {
+	CurrentAge         int     `json:"currentAge"`
+	AnnualIncome       float64 `json:"annualIncome,omitempty"`
+	RetirementAge      int     `json:"retirementAge,omitempty"`      // Salary and contributions stop (0 = never)
+	TaxDeferredBalance float64 `json:"taxDeferredBalance,omitempty"` // Spouse-owned 401k/IRA, added to the household total
+	Contribution401k   float64 `json:"contribution401k,omitempty"`
+	ContributionRoth   float64 `json:"contributionRoth,omitempty"`
+
+	SocialSecurityAge     int     `json:"socialSecurityAge,omitempty"`
+	SocialSecurityBenefit float64 `json:"socialSecurityBenefit,omitempty"` // Monthly
+
+	DeathAge int    `json:"deathAge,omitempty"` // Survivor transition (0 = alive through the horizon, or drawn with longevity)
+	Sex      string `json:"sex,omitempty"`      // Life table column for longevity draws
+}
+
+// validateHousehold checks the per-person fields of a household plan
+func validateHousehold(params FullSimulationParams) error {
+	if params.DeathAge != 0 && params.DeathAge <= params.CurrentAge {
+		return fmt.Errorf("deathAge %d must be greater than currentAge %d", params.DeathAge, params.CurrentAge)
+	}
+	sp := params.Spouse
+	if sp == nil {
+		return nil
+	}
+	if sp.CurrentAge < 18 || sp.CurrentAge > 120 {
+		return fmt.Errorf("spouse.currentAge must be between 18 and 120, got %d", sp.CurrentAge)
+	}
+	if sp.DeathAge != 0 && sp.DeathAge <= sp.CurrentAge {
+		return fmt.Errorf("spouse.deathAge %d must be greater than spouse.currentAge %d", sp.DeathAge, sp.CurrentAge)
+	}
+	if sp.AnnualIncome < 0 || sp.TaxDeferredBalance < 0 || sp.Contribution401k < 0 ||
+		sp.ContributionRoth < 0 || sp.SocialSecurityBenefit < 0 {
+		return fmt.Errorf("spouse amounts cannot be negative")
+	}
+	return nil
+}
+
+// householdStartYear is the calendar year birth years are measured from
+func householdStartYear(params FullSimulationParams) int {
+	if params.StartYear > 0 {
+		return params.StartYear
+	}
+	return DefaultStartYear
+}
+
+// buildHousehold maps the primary and spouse onto engine household members.
+// Single-person plans without a death age return nil.
+func buildHousehold(params FullSimulationParams) *engine.Household {
+	if params.Spouse == nil && params.DeathAge == 0 {
+		return nil
+	}
+	startYear := householdStartYear(params)
+
+	members := []engine.HouseholdMember{{
+		ID:                    PrimaryMemberID,
+		BirthYear:             startYear - params.CurrentAge,
+		RetirementMonthOffset: ageMonthOffset(params.RetirementAge, params.CurrentAge),
+		DeathMonthOffset:      ageMonthOffset(params.DeathAge, params.CurrentAge),
+		TaxDeferredBalance:    params.TaxDeferredBalance,
+		Sex:                   params.Sex,
+	}}
+	if sp := params.Spouse; sp != nil {
+		members = append(members, engine.HouseholdMember{
+			ID:                    SpouseMemberID,
+			BirthYear:             startYear - sp.CurrentAge,
+			RetirementMonthOffset: ageMonthOffset(sp.RetirementAge, sp.CurrentAge),
+			DeathMonthOffset:      ageMonthOffset(sp.DeathAge, sp.CurrentAge),
+			TaxDeferredBalance:    sp.TaxDeferredBalance,
+			Sex:                   sp.Sex,
+		})
+	}
+	return &engine.Household{Members: members}
+}
+
+// ageMonthOffset converts a target age to a month offset (nil when age is unset)
+func ageMonthOffset(age, currentAge int) *int {
+	if age <= 0 {
+		return nil
+	}
+	offset := (age - currentAge) * 12
+	if offset < 0 {
+		offset = 0
+	}
+	return &offset
+}
+
+// totalTaxDeferredBalance is the pooled 401k/IRA balance across both people
+func totalTaxDeferredBalance(params FullSimulationParams) float64 {
+	total := params.TaxDeferredBalance
+	if params.Spouse != nil {
+		total += params.Spouse.TaxDeferredBalance
+	}
+	return total
+}
+
+// buildSpouseEvents creates the spouse's salary, contributions and Social Security,
+// each owned by the spouse so the engine applies their limits, retirement and survivor rules
+func buildSpouseEvents(params FullSimulationParams) []engine.FinancialEvent {
+	sp := params.Spouse
+	if sp == nil {
+		return nil
+	}
+	events := make([]engine.FinancialEvent, 0)
+
+	end := params.HorizonMonths
+	if retire := ageMonthOffset(sp.RetirementAge, sp.CurrentAge); retire != nil && *retire < end {
+		end = *retire
+	}
+	window := monthWindow{0, end}
+	working := end > 0
+
+	if sp.AnnualIncome > 0 && working {
+		events = append(events, owned(window.apply(engine.FinancialEvent{
+			ID:          "spouse-income-salary",
+			Type:        "INCOME",
+			Description: "Spouse salary income",
+			Amount:      sp.AnnualIncome / 12,
+			Frequency:   "monthly",
+		}, params.HorizonMonths), SpouseMemberID))
+	}
+
+	if sp.Contribution401k > 0 && working {
+		targetType := "tax_deferred"
+		events = append(events, owned(window.apply(engine.FinancialEvent{
+			ID:                fmt.Sprintf("spouse-contribution-401k-%d", params.Seed),
+			Type:              "SCHEDULED_CONTRIBUTION",
+			Description:       "Spouse 401k contribution",
+			Amount:            sp.Contribution401k / 12,
+			Frequency:         "monthly",
+			TargetAccountType: &targetType,
+		}, params.HorizonMonths), SpouseMemberID))
+	}
+
+	if sp.ContributionRoth > 0 && working {
+		targetType := "roth"
+		events = append(events, owned(window.apply(engine.FinancialEvent{
+			ID:                fmt.Sprintf("spouse-contribution-roth-%d", params.Seed),
+			Type:              "SCHEDULED_CONTRIBUTION",
+			Description:       "Spouse Roth IRA contribution",
+			Amount:            sp.ContributionRoth / 12,
+			Frequency:         "monthly",
+			TargetAccountType: &targetType,
+		}, params.HorizonMonths), SpouseMemberID))
+	}
+
+	if sp.SocialSecurityBenefit > 0 && sp.SocialSecurityAge > 0 {
+		ssStartMonth := (sp.SocialSecurityAge - sp.CurrentAge) * 12
+		if ssStartMonth < 0 {
+			ssStartMonth = 0
+		}
+		if ssStartMonth < params.HorizonMonths {
+			events = append(events, owned(engine.FinancialEvent{
+				ID:          "spouse-social-security",
+				Type:        "SOCIAL_SECURITY_INCOME",
+				Description: "Spouse Social Security benefits",
+				Amount:      sp.SocialSecurityBenefit,
+				MonthOffset: ssStartMonth,
+				Frequency:   "monthly",
+			}, SpouseMemberID))
+		}
+	} else if derivesSocialSecurity(sp.SocialSecurityAge, sp.SocialSecurityBenefit, sp.AnnualIncome) {
+		events = append(events, owned(socialSecurityClaim("spouse-social-security", "Spouse Social Security benefits",
+			sp.CurrentAge, sp.SocialSecurityAge, sp.RetirementAge, sp.AnnualIncome), SpouseMemberID))
+	}
+
+	return events
+}
+
+// owned tags an event with its household owner
+func owned(event engine.FinancialEvent, owner string) engine.FinancialEvent {
+	metadata := make(map[string]interface{}, len(event.Metadata)+1)
+	for k, v := range event.Metadata {
+		metadata[k] = v
+	}
+	metadata["owner"] = owner
+	event.Metadata = metadata
+	return event
+}
@@ -0,0 +1,58 @@
+package simulation
+
+import (
+	"fmt"
+
+	"pathfinder-wasm/engine"
+)
+
+// maxEngineHorizonMonths is the longest MonthsToRun the engine accepts
+const maxEngineHorizonMonths = 1200
+
+// LongevityHorizonMonths is the default horizon when lifespans are drawn per path:
+// long enough for the younger person to reach engine.MaxLifespanAge, so every path
+// ends at a death rather than at an arbitrary age
+func LongevityHorizonMonths(currentAge int, spouse *SpouseParams) int {
+	youngest := currentAge
+	if spouse != nil && spouse.CurrentAge < youngest {
+		youngest = spouse.CurrentAge
+	}
+	months := (engine.MaxLifespanAge - youngest) * 12
+	if months > maxEngineHorizonMonths {
+		months = maxEngineHorizonMonths
+	}
+	if months < 12 {
+		months = 12
+	}
+	return months
+}
+
+// validateLongevity checks the life table columns and mortality multiplier
+func validateLongevity(params FullSimulationParams) error {
+	if !params.Longevity {
+		return nil
+	}
+	if err := buildLongevity(params).Validate(); err != nil {
+		return err
+	}
+	if params.Spouse != nil {
+		switch params.Spouse.Sex {
+		case "", "male", "female":
+		default:
+			return fmt.Errorf("spouse.sex must be \"male\", \"female\" or empty, got %q", params.Spouse.Sex)
+		}
+	}
+	return nil
+}
+
+// buildLongevity maps the longevity params onto the engine config (nil when disabled)
+func buildLongevity(params FullSimulationParams) *engine.LongevityConfig {
+	if !params.Longevity {
+		return nil
+	}
+	return &engine.LongevityConfig{
+		Sex:                 params.Sex,
+		MortalityMultiplier: params.MortalityMultiplier,
+		StateCode:           params.StateCode,
+	}
+}
@@ -3,9 +3,10 @@ package simulation
 import (
 	"encoding/json"
 	"math"
+	"reflect"
 	"testing"
 
-	"github.com/areumfire/mcp-server-go/internal/engine"
+	"pathfinder-wasm/engine"
 )
 
 // TestParitySimpleScenario verifies native Go matches expected behavior
@@ -165,6 +166,43 @@ func TestParityDirectEngineCall(t *testing.T) {
 	}
 }
 
+// TestParitySharedEngine verifies the full-tier adapter is a thin wrapper over the
+// shared engine package that the WASM build uses, so both return identical numbers
+func TestParitySharedEngine(t *testing.T) {
+	params := FullSimulationParams{
+		Seed:               777,
+		StartYear:          2025,
+		HorizonMonths:      240,
+		MCPaths:            40,
+		CurrentAge:         50,
+		CashBalance:        40000,
+		TaxableBalance:     400000,
+		TaxDeferredBalance: 300000,
+		RothBalance:        60000,
+		AnnualIncome:       120000,
+		AnnualSpending:     70000,
+		Contribution401k:   23000,
+		LiteMode:           true,
+	}
+
+	result, err := NewFullEngine().RunFullSimulation(params)
+	if err != nil {
+		t.Fatalf("Adapter simulation failed: %v", err)
+	}
+
+	payload := engine.RunSimulationWithUIPayload(buildSimulationInput(params), params.MCPaths)
+	series := payload.PlanProjection.Charts.NetWorth.TimeSeries
+
+	if len(result.Trajectory) != len(series) {
+		t.Fatalf("Trajectory length mismatch: adapter=%d, engine=%d", len(result.Trajectory), len(series))
+	}
+	for i, pt := range series {
+		if result.Trajectory[i].P50 != pt.P50 {
+			t.Errorf("Year %d P50 differs: adapter=%f, engine=%f", i, result.Trajectory[i].P50, pt.P50)
+		}
+	}
+}
+
 // TestParityDeterminism verifies identical seeds produce identical results
 func TestParityDeterminism(t *testing.T) {
 	seeds := []int{12345, 42, 99999, 1}
@@ -259,6 +297,49 @@ func TestParityDeterminism(t *testing.T) {
 	}
 }
 
+// TestParityParallelMatchesSerial verifies the native worker pool is bit-identical to a serial run
+func TestParityParallelMatchesSerial(t *testing.T) {
+	for _, liteMode := range []bool{true, false} {
+		config := engine.GetDefaultStochasticConfig()
+		config.RandomSeed = 4242
+		config.SimulationMode = "stochastic"
+		config.CashFloor = 10000
+		config.LiteMode = liteMode
+		config.PayTaxesEndOfYear = true
+
+		input := engine.SimulationInput{
+			MonthsToRun: 240, StartYear: 2025, InitialAge: 45,
+			InitialAccounts: engine.AccountHoldingsMonthEnd{
+				Cash:        40000,
+				Taxable:     &engine.Account{TotalValue: 350000},
+				TaxDeferred: &engine.Account{TotalValue: 200000},
+			},
+			Config: config,
+			Events: []engine.FinancialEvent{
+				{ID: "income", Type: "INCOME", Amount: 9000, MonthOffset: 0, Frequency: "monthly"},
+				{ID: "expense", Type: "EXPENSE", Amount: 7000, MonthOffset: 0, Frequency: "monthly"},
+			},
+		}
+
+		serialInput := input
+		serialInput.Config.MonteCarloWorkers = 1
+		serial := engine.RunMonteCarloSimulation(serialInput, 64)
+
+		parallelInput := input
+		parallelInput.Config.MonteCarloWorkers = 8
+		parallel := engine.RunMonteCarloSimulation(parallelInput, 64)
+
+		if !serial.Success || !parallel.Success {
+			t.Fatalf("liteMode=%v: MC failed: serial=%q parallel=%q", liteMode, serial.Error, parallel.Error)
+		}
+		if !reflect.DeepEqual(serial, parallel) {
+			t.Errorf("liteMode=%v: parallel differs from serial: P50 %f vs %f, breach %f vs %f",
+				liteMode, serial.FinalNetWorthP50, parallel.FinalNetWorthP50,
+				serial.EverBreachProbability, parallel.EverBreachProbability)
+		}
+	}
+}
+
 // TestParityWithEvents tests event processing matches expectations
 func TestParityWithEvents(t *testing.T) {
 	// Test with various event types
@@ -0,0 +1,33 @@
+package simulation
+
+import (
+	"fmt"
+
+	"pathfinder-wasm/engine"
+)
+
+// ScenarioParams is one named variant of a plan
+type ScenarioParams struct {
+	Name   string               `json:"name"`
+	Params FullSimulationParams `json:"params"`
+}
+
+// CompareScenarios runs every variant on the first variant's seed and path count and
+// reports per-path differences from it
+func (e *FullEngine) CompareScenarios(scenarios []ScenarioParams) (*engine.ScenarioComparison, error) {
+	req := engine.ScenarioComparisonRequest{
+		Variants: make([]engine.ScenarioVariant, len(scenarios)),
+	}
+	for i, scenario := range scenarios {
+		params := scenario.Params
+		if err := prepareFullParams(&params); err != nil {
+			return nil, fmt.Errorf("%s: %w", scenario.Name, err)
+		}
+		if i == 0 {
+			req.Paths = params.MCPaths
+			req.Seed = int64(params.Seed)
+		}
+		req.Variants[i] = engine.ScenarioVariant{Name: scenario.Name, Input: buildSimulationInput(params)}
+	}
+	return engine.CompareScenarios(req)
+}
@@ -0,0 +1,56 @@
+package simulation
+
+import (
+	"testing"
+)
+
+// TestFullEngineCompareScenarios verifies a sabbatical is compared on the baseline's paths
+func TestFullEngineCompareScenarios(t *testing.T) {
+	fullEngine := NewFullEngine()
+	baseline := FullSimulationParams{
+		Seed:             4242,
+		StartYear:        2025,
+		HorizonMonths:    240,
+		MCPaths:          20,
+		CurrentAge:       40,
+		StateCode:        "TX",
+		CashBalance:      30000,
+		TaxableBalance:   250000,
+		AnnualIncome:     120000,
+		AnnualSpending:   60000,
+		Contribution401k: 15000,
+		LiteMode:         true,
+	}
+	sabbatical := baseline
+	sabbatical.Seed = 7 // Overridden by the baseline's seed
+	sabbatical.SabbaticalMonthOffset = 24
+	sabbatical.SabbaticalMonths = 12
+
+	result, err := fullEngine.CompareScenarios([]ScenarioParams{
+		{Name: "baseline", Params: baseline},
+		{Name: "sabbatical", Params: sabbatical},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Baseline != "baseline" || result.Seed != 4242 || result.Paths != 20 || len(result.Variants) != 2 {
+		t.Fatalf("unexpected comparison %+v", result)
+	}
+
+	// A year without salary ends with less wealth and pays no more tax
+	v := result.Variants[1]
+	if v.TerminalWealthDelta.P50 >= 0 || v.BeatsBaseline > 0.5 {
+		t.Errorf("sabbatical wealth delta %+v, beats baseline on %.2f", v.TerminalWealthDelta, v.BeatsBaseline)
+	}
+	if v.LifetimeTaxDelta.P50 > 0 {
+		t.Errorf("sabbatical tax delta %+v", v.LifetimeTaxDelta)
+	}
+	t.Logf("Sabbatical: median delta $%.0f (P10 $%.0f, P90 $%.0f), tax delta $%.0f",
+		v.TerminalWealthDelta.P50, v.TerminalWealthDelta.P10, v.TerminalWealthDelta.P90, v.LifetimeTaxDelta.P50)
+
+	mismatched := sabbatical
+	mismatched.HorizonMonths = 120
+	if _, err := fullEngine.CompareScenarios([]ScenarioParams{{Name: "a", Params: baseline}, {Name: "b", Params: mismatched}}); err == nil {
+		t.Error("expected an error comparing different horizons")
+	}
+}
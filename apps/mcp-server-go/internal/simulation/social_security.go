@@ -0,0 +1,50 @@
+package simulation
+
+import (
+	"fmt"
+
+	"pathfinder-wasm/engine"
+)
+
+// derivesSocialSecurity reports whether a person's benefit is derived by the engine
+// from their salary: a claiming age without a benefit amount
+func derivesSocialSecurity(claimAge int, benefit, salary float64) bool {
+	return claimAge > 0 && benefit <= 0 && salary > 0
+}
+
+// validateSocialSecurity checks the claiming ages the engine derives benefits for
+func validateSocialSecurity(params FullSimulationParams) error {
+	if derivesSocialSecurity(params.SocialSecurityAge, params.SocialSecurityBenefit, params.AnnualIncome) &&
+		(params.SocialSecurityAge < 62 || params.SocialSecurityAge > 70) {
+		return fmt.Errorf("socialSecurityAge %d must be between 62 and 70 without a socialSecurityBenefit", params.SocialSecurityAge)
+	}
+	if sp := params.Spouse; sp != nil && derivesSocialSecurity(sp.SocialSecurityAge, sp.SocialSecurityBenefit, sp.AnnualIncome) &&
+		(sp.SocialSecurityAge < 62 || sp.SocialSecurityAge > 70) {
+		return fmt.Errorf("spouse.socialSecurityAge %d must be between 62 and 70 without a socialSecurityBenefit", sp.SocialSecurityAge)
+	}
+	return nil
+}
+
+// socialSecurityClaim builds a SOCIAL_SECURITY_CLAIM from the current salary: the
+// engine estimates past earnings from it, projects it through retirement, and derives
+// the benefit at the claiming age
+func socialSecurityClaim(id, description string, currentAge, claimAge, retirementAge int, salary float64) engine.FinancialEvent {
+	workingYears := claimAge - currentAge
+	if retirementAge > 0 {
+		workingYears = retirementAge - currentAge
+	}
+	if workingYears < 0 {
+		workingYears = 0
+	}
+	return engine.FinancialEvent{
+		ID:          id,
+		Type:        "SOCIAL_SECURITY_CLAIM",
+		Description: description,
+		Frequency:   "monthly",
+		Metadata: map[string]interface{}{
+			"currentSalary":  salary,
+			"projectedYears": float64(workingYears),
+			"claimingAge":    float64(claimAge),
+		},
+	}
+}
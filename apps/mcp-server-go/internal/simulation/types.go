@@ -1,5 +1,12 @@
 package simulation
 
+import (
+	"fmt"
+	"sync"
+
+	"pathfinder-wasm/engine"
+)
+
 // AccountType represents different account types for tax purposes
 type AccountType string
 
@@ -68,6 +75,7 @@ type TaxState struct {
 	OrdinaryIncome   float64 `json:"ordinaryIncome"`
 	LongTermGains    float64 `json:"longTermGains"`
 	ShortTermGains   float64 `json:"shortTermGains"`
+	Distributions    float64 `json:"distributions"` // 401k/IRA withdrawals, included in OrdinaryIncome
 	SocialSecIncome  float64 `json:"socialSecIncome"`
 	TaxFreeIncome    float64 `json:"taxFreeIncome"`
 	TaxWithheld      float64 `json:"taxWithheld"`
@@ -78,6 +86,7 @@ func (ts *TaxState) Reset() {
 	ts.OrdinaryIncome = 0
 	ts.LongTermGains = 0
 	ts.ShortTermGains = 0
+	ts.Distributions = 0
 	ts.SocialSecIncome = 0
 	ts.TaxFreeIncome = 0
 	ts.TaxWithheld = 0
@@ -90,26 +99,56 @@ type TaxBracket struct {
 	Rate float64
 }
 
-// 2024 Federal Tax Brackets (Single filer, simplified)
-var FederalBrackets2024 = []TaxBracket{
-	{0, 11600, 0.10},
-	{11600, 47150, 0.12},
-	{47150, 100525, 0.22},
-	{100525, 191950, 0.24},
-	{191950, 243725, 0.32},
-	{243725, 609350, 0.35},
-	{609350, 1e12, 0.37},
+// taxThresholdIndexRate projects brackets past the latest published tax year
+const taxThresholdIndexRate = 0.025
+
+// federalTaxYears caches the single-filer federal parameters per tax year
+var federalTaxYears sync.Map // int -> *federalTaxYear
+
+type federalTaxYear struct {
+	ordinary          []TaxBracket
+	capitalGains      []TaxBracket
+	standardDeduction float64
+}
+
+// federalTaxYearFor returns the single-filer brackets and standard deduction for a
+// tax year from the engine's tax-year registry (published years, indexed beyond)
+func federalTaxYearFor(year int) *federalTaxYear {
+	if cached, ok := federalTaxYears.Load(year); ok {
+		return cached.(*federalTaxYear)
+	}
+	params, err := engine.LookupTaxYear(year, engine.FilingStatusSingle, taxThresholdIndexRate, "")
+	if err != nil {
+		panic(fmt.Sprintf("CRITICAL: federal tax parameters not available for %d: %v", year, err))
+	}
+	ty := &federalTaxYear{standardDeduction: params.StandardDeduction}
+	for _, b := range params.OrdinaryBrackets {
+		ty.ordinary = append(ty.ordinary, TaxBracket{Min: b.IncomeMin, Max: min(b.IncomeMax, 1e12), Rate: b.Rate})
+	}
+	for _, b := range params.LTCGBrackets {
+		ty.capitalGains = append(ty.capitalGains, TaxBracket{Min: b.IncomeMin, Max: min(b.IncomeMax, 1e12), Rate: b.Rate})
+	}
+	cached, _ := federalTaxYears.LoadOrStore(year, ty)
+	return cached.(*federalTaxYear)
 }
 
-// Long-term capital gains brackets (2024, single)
-var CapGainsBrackets2024 = []TaxBracket{
-	{0, 47025, 0.00},
-	{47025, 518900, 0.15},
-	{518900, 1e12, 0.20},
+// FederalBrackets returns the single-filer ordinary brackets for a tax year
+func FederalBrackets(year int) []TaxBracket {
+	return federalTaxYearFor(year).ordinary
 }
 
-// CalculateFederalTax computes federal income tax using 2024 brackets
-func CalculateFederalTax(taxableIncome float64) float64 {
+// CapGainsBrackets returns the single-filer long-term capital gains brackets for a tax year
+func CapGainsBrackets(year int) []TaxBracket {
+	return federalTaxYearFor(year).capitalGains
+}
+
+// StandardDeduction returns the single-filer standard deduction for a tax year
+func StandardDeduction(year int) float64 {
+	return federalTaxYearFor(year).standardDeduction
+}
+
+// CalculateFederalTax computes federal income tax using the tax year's brackets
+func CalculateFederalTax(taxableIncome float64, year int) float64 {
 	if taxableIncome <= 0 {
 		return 0
 	}
@@ -117,7 +156,7 @@ func CalculateFederalTax(taxableIncome float64) float64 {
 	tax := 0.0
 	remaining := taxableIncome
 
-	for _, bracket := range FederalBrackets2024 {
+	for _, bracket := range FederalBrackets(year) {
 		if remaining <= 0 {
 			break
 		}
@@ -131,7 +170,7 @@ func CalculateFederalTax(taxableIncome float64) float64 {
 }
 
 // CalculateCapitalGainsTax computes long-term capital gains tax
-func CalculateCapitalGainsTax(gains float64, ordinaryIncome float64) float64 {
+func CalculateCapitalGainsTax(gains float64, ordinaryIncome float64, year int) float64 {
 	if gains <= 0 {
 		return 0
 	}
@@ -141,7 +180,7 @@ func CalculateCapitalGainsTax(gains float64, ordinaryIncome float64) float64 {
 	tax := 0.0
 	gainsRemaining := gains
 
-	for _, bracket := range CapGainsBrackets2024 {
+	for _, bracket := range CapGainsBrackets(year) {
 		if gainsRemaining <= 0 {
 			break
 		}
@@ -171,13 +210,27 @@ func CalculateCapitalGainsTax(gains float64, ordinaryIncome float64) float64 {
 	return tax
 }
 
-// CalculateStateTax estimates state income tax (simplified CA rate)
-func CalculateStateTax(taxableIncome float64, stateRate float64) float64 {
-	if stateRate <= 0 {
-		stateRate = 0.093 // Default CA top marginal
-	}
-	// Simplified: use flat rate (real implementation uses brackets)
-	return taxableIncome * stateRate * 0.7 // Approximate effective rate
+// stateTaxes holds the engine's income tax tables for the 50 states and DC
+var stateTaxes = engine.NewStateTaxCalculator()
+
+// DefaultStateCode is the state taxed when a request gives none
+const DefaultStateCode = "CA"
+
+// DefaultStartYear is the calendar year a plan starts in when a request gives
+// none, fixed so the same request always simulates the same years
+const DefaultStartYear = 2024
+
+// ValidStateCode reports whether a two-letter code is one of the 50 states or DC
+func ValidStateCode(stateCode string) bool {
+	_, ok := stateTaxes.StateConfig(stateCode)
+	return ok
+}
+
+// CalculateStateTax computes a single filer's state income tax for a tax year, with the
+// state's brackets, deductions, retirement-income and capital gains rules indexed like
+// the federal brackets past the published year
+func CalculateStateTax(income engine.StateIncome, stateCode string, year int) float64 {
+	return stateTaxes.CalculateIncomeTaxForYear(income, stateCode, engine.FilingStatusSingle, year, taxThresholdIndexRate)
 }
 
 // SimulationTier represents different levels of simulation fidelity
@@ -199,8 +252,8 @@ type EnhancedSimulationParams struct {
 	Tier          SimulationTier `json:"tier"`
 
 	// Person info
-	CurrentAge int     `json:"currentAge"`
-	StateRate  float64 `json:"stateRate"` // State income tax rate
+	CurrentAge int    `json:"currentAge"`
+	StateCode  string `json:"stateCode"` // Two-letter state code (default CA)
 
 	// Initial accounts
 	InitialAccounts Accounts `json:"initialAccounts"`
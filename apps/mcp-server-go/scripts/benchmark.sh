@@ -31,11 +31,11 @@ echo "--------------------------------------"
 go test -v -run TestPerformanceReport ./internal/simulation/ 2>&1 | grep -A 50 "Performance Report" || true
 
 # If WASM benchmarks exist, run them too
-if [ -f "$ROOT_DIR/wasm/simulation_test.go" ]; then
+if [ -f "$ROOT_DIR/wasm/engine/simulation_test.go" ]; then
     echo ""
     echo "Running WASM Go Benchmarks (for comparison)..."
     echo "-----------------------------------------------"
-    cd "$ROOT_DIR/wasm"
+    cd "$ROOT_DIR/wasm/engine"
     go test -bench=BenchmarkSimulation -benchmem . 2>&1 | head -20 || echo "WASM benchmarks not available"
     cd "$PROJECT_DIR"
 fi
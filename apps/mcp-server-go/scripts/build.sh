@@ -14,7 +14,8 @@ if [ -f "default.pgo" ]; then
 fi
 
 # Build for current platform
-go build -v $PGO_FLAG -o server ./cmd/server
+# -tags production: strip the shared engine's verbose debug logging
+go build -v -tags production $PGO_FLAG -o server ./cmd/server
 
 echo "Build complete: ./server"
 echo ""
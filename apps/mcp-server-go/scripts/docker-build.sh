@@ -10,7 +10,8 @@ TAG="${1:-latest}"
 
 echo "Building Docker image: ${IMAGE_NAME}:${TAG}"
 
-docker build -t "${IMAGE_NAME}:${TAG}" .
+# Build from the repo root so the shared engine module (wasm/) is in the context
+docker build -f Dockerfile -t "${IMAGE_NAME}:${TAG}" ../..
 
 echo ""
 echo "Build complete: ${IMAGE_NAME}:${TAG}"
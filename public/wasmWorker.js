@@ -312,24 +312,25 @@ function validateEventsForWASM(events) {
     // Extended event registry types
     'RECURRING_EXPENSE', 'ONE_TIME_EXPENSE', 'SCHEDULED_CONTRIBUTION',
     // Income stream events
-    'SOCIAL_SECURITY_INCOME', 'PENSION_INCOME', 'DIVIDEND_INCOME', 'ANNUITY_PAYMENT',
+    'SOCIAL_SECURITY_INCOME', 'SOCIAL_SECURITY_CLAIM', 'PENSION_INCOME', 'DIVIDEND_INCOME', 'ANNUITY_PAYMENT',
     // Capital gains and investment events
     'CAPITAL_GAINS_REALIZATION', 'RSU_VESTING', 'RSU_SALE',
     // Portfolio management events
     'REBALANCE_PORTFOLIO', 'TAX_LOSS_HARVESTING_SALE', 'STRATEGIC_CAPITAL_GAINS_REALIZATION',
     'TAX_LOSS_HARVESTING_CHECK_AND_EXECUTE',
     // Healthcare and charitable events
-    'HEALTHCARE_COST', 'QUALIFIED_CHARITABLE_DISTRIBUTION',
+    'HEALTHCARE_COST', 'ACA_MARKETPLACE_COVERAGE', 'QUALIFIED_CHARITABLE_DISTRIBUTION',
     // Cash management events
     'ADJUST_CASH_RESERVE_SELL_ASSETS', 'ADJUST_CASH_RESERVE_BUY_ASSETS',
     // Planning and monitoring events
     'GOAL_DEFINE', 'CONCENTRATION_RISK_ALERT',
     // Distribution events
-    'REQUIRED_MINIMUM_DISTRIBUTION',
+    'REQUIRED_MINIMUM_DISTRIBUTION', 'ROTH_CONVERSION_LADDER',
     // Debt and liability events
     'ONE_TIME_EVENT', 'LIABILITY_ADD', 'MORTGAGE_ORIGINATION', 'LIABILITY_PAYMENT', 'DEBT_PAYMENT',
+    'REFINANCE', 'EXTRA_PRINCIPAL_PAYMENT',
     // Real estate events
-    'REAL_ESTATE_PURCHASE', 'REAL_ESTATE_SALE',
+    'REAL_ESTATE_PURCHASE', 'REAL_ESTATE_SALE', 'RENTAL_PROPERTY_PURCHASE', 'RENTAL_PROPERTY_SALE', 'RELOCATE',
     // Strategy configuration events
     'STRATEGY_ASSET_ALLOCATION_SET', 'STRATEGY_REBALANCING_RULE_SET',
     // Initial state events
@@ -339,7 +340,7 @@ function validateEventsForWASM(events) {
     'DISABILITY_INSURANCE_PREMIUM', 'DISABILITY_INSURANCE_PAYOUT',
     'LONG_TERM_CARE_INSURANCE_PREMIUM', 'LONG_TERM_CARE_PAYOUT',
     // Education events
-    'FIVE_TWO_NINE_CONTRIBUTION', 'FIVE_TWO_NINE_WITHDRAWAL', 'TUITION_PAYMENT',
+    'FIVE_TWO_NINE_CONTRIBUTION', 'FIVE_TWO_NINE_WITHDRAWAL', 'FIVE_TWO_NINE_ROTH_ROLLOVER', 'TUITION_PAYMENT',
     // Business events
     'BUSINESS_INCOME', 'QUARTERLY_ESTIMATED_TAX_PAYMENT',
     // Additional types found in WASM registry
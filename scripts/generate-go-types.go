@@ -34,7 +34,7 @@ type PropertyDefinition struct {
 
 func main() {
 	schemasDir := filepath.Join("schemas")
-	outputFile := filepath.Join("wasm", "generated_types.go")
+	outputFile := filepath.Join("wasm", "engine", "generated_types.go")
 
 	schemas := []string{
 		"financial-event.json",
@@ -48,7 +48,7 @@ func main() {
 	var output strings.Builder
 	output.WriteString("// Code generated from JSON Schema. DO NOT EDIT.\n")
 	output.WriteString("// To regenerate: go run scripts/generate-go-types.go\n\n")
-	output.WriteString("package main\n\n")
+	output.WriteString("package engine\n\n")
 
 	for _, schemaFile := range schemas {
 		schemaPath := filepath.Join(schemasDir, schemaFile)
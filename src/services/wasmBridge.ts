@@ -313,6 +313,8 @@ type WasmFunctionName =
   | 'loadConfigurationData'
   | 'checkConfigState'
   | 'goPreviewFireTarget'
+  | 'goSolveGoal'
+  | 'goCompareScenarios'
   | 'goCalculateQuickstartGoalAnalysis'
   | 'goCalculateGoalFormSuggestions'
   | 'goGenerateQuickstartPlan'
@@ -565,6 +567,38 @@ class WasmBridge {
     return normalizeWasmResult<any>(result);
   }
 
+  /**
+   * Solve for the spending, retirement month, savings rate or contribution that
+   * just meets a Monte Carlo target. Input: { input, variable, target, paths, ... }.
+   */
+  async solveGoal(request: any): Promise<any> {
+    await this.ensureReady();
+
+    const fn = getWasmFunction('goSolveGoal');
+    if (!fn) {
+      throw new Error('goSolveGoal WASM function not available');
+    }
+
+    const result = fn(JSON.stringify(request));
+    return normalizeWasmResult<any>(result);
+  }
+
+  /**
+   * Run plan variants on the same path seeds and report per-path differences from
+   * the first. Input: { variants: [{ name, input }], paths }.
+   */
+  async compareScenarios(request: any): Promise<any> {
+    await this.ensureReady();
+
+    const fn = getWasmFunction('goCompareScenarios');
+    if (!fn) {
+      throw new Error('goCompareScenarios WASM function not available');
+    }
+
+    const result = fn(JSON.stringify(request));
+    return normalizeWasmResult<any>(result);
+  }
+
   /**
    * Calculate quickstart goal analysis.
    */
@@ -699,6 +733,8 @@ class WasmBridge {
       'loadConfigurationData',
       'checkConfigState',
       'goPreviewFireTarget',
+      'goSolveGoal',
+      'goCompareScenarios',
       'goCalculateQuickstartGoalAnalysis',
       'goCalculateGoalFormSuggestions',
       'goGenerateQuickstartPlan',
@@ -728,6 +764,8 @@ type WasmEntrypoint =
   | 'runMonteCarloSimulation'
   | 'runSimulationWithUIPayload'
   | 'goPreviewFireTarget'
+  | 'goSolveGoal'
+  | 'goCompareScenarios'
   | 'goCalculateQuickstartGoalAnalysis'
   | 'goCalculateGoalFormSuggestions'
   | 'goGenerateQuickstartPlan'
@@ -742,6 +780,8 @@ const _entrypointCoverage: Record<WasmEntrypoint, boolean> = {
   runMonteCarloSimulation: true,
   runSimulationWithUIPayload: true,
   goPreviewFireTarget: true,
+  goSolveGoal: true,
+  goCompareScenarios: true,
   goCalculateQuickstartGoalAnalysis: true,
   goCalculateGoalFormSuggestions: true,
   goGenerateQuickstartPlan: true,
@@ -149,6 +149,7 @@ export interface SpreadsheetYearData {
   taxes: SpreadsheetPercentiles;
   savings: SpreadsheetPercentiles;
   netWorth: SpreadsheetPercentiles;
+  netWorthReal?: SpreadsheetPercentiles; // Year-end net worth in today's dollars
 }
 
 /**
@@ -221,6 +222,11 @@ export interface PlanSummary {
     p5FinalValue?: number;
     p95FinalValue?: number;
 
+    // Final value percentiles in today's dollars
+    p10FinalValueReal?: number;
+    p50FinalValueReal?: number;
+    p90FinalValueReal?: number;
+
     // Min cash KPIs (across all successful paths)
     minCashP5?: number;
     minCashP50?: number;
@@ -288,6 +294,27 @@ export interface PlanSummary {
   bankruptcyMonthP50?: number; // Median bankruptcy month
   bankruptcyMonthP75?: number; // 75th percentile
   bankruptcyMonthP90?: number; // 90th percentile - latest bankruptcies
+
+  /** Realized retirement spending in today's dollars (withdrawal policy only) */
+  spending?: {
+    meanP10: number; // Each path's average retirement year
+    meanP25: number;
+    meanP50: number;
+    meanP75: number;
+    meanP90: number;
+    minP10: number; // Each path's lowest retirement year
+    minP50: number;
+    minP90: number;
+    byYear: Array<{
+      year: number; // Retirement year, 0 for the first
+      paths: number;
+      p10: number;
+      p25: number;
+      p50: number;
+      p75: number;
+      p90: number;
+    }>;
+  };
 }
 
 // =============================================================================
@@ -419,6 +446,13 @@ export interface NetWorthTimeSeriesPoint {
   p75: number;
   p90: number;
   mean?: number;
+
+  // The same percentiles in today's dollars
+  realP10?: number;
+  realP25?: number;
+  realP50?: number;
+  realP75?: number;
+  realP90?: number;
 }
 
 export interface NetWorthChart {
@@ -655,6 +689,8 @@ export interface AnnualDeepDiveSnapshot {
   /** High-level metrics */
   netWorth: number;
   netWorthChangeYoY: { amount: number; percent: number };
+  netWorthReal?: number; // Today's dollars
+  priceIndex?: number; // Year-end price index (1.0 at the start)
   
   /** Complete balance sheet - grounded in simulation engine data */
   balanceSheet: {
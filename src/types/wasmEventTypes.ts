@@ -26,6 +26,7 @@ export const VALID_WASM_EVENT_TYPES = new Set([
   
   // Income stream events
   'SOCIAL_SECURITY_INCOME',
+  'SOCIAL_SECURITY_CLAIM',
   'PENSION_INCOME',
   'DIVIDEND_INCOME',
   'ANNUITY_PAYMENT',
@@ -43,6 +44,7 @@ export const VALID_WASM_EVENT_TYPES = new Set([
   
   // Healthcare and charitable events
   'HEALTHCARE_COST',
+  'ACA_MARKETPLACE_COVERAGE',
   'QUALIFIED_CHARITABLE_DISTRIBUTION',
   
   // Cash management events
@@ -55,6 +57,7 @@ export const VALID_WASM_EVENT_TYPES = new Set([
   
   // Distribution events
   'REQUIRED_MINIMUM_DISTRIBUTION',
+  'ROTH_CONVERSION_LADDER',
   
   // Debt and liability events
   'ONE_TIME_EVENT',
@@ -62,10 +65,15 @@ export const VALID_WASM_EVENT_TYPES = new Set([
   'MORTGAGE_ORIGINATION',
   'LIABILITY_PAYMENT',
   'DEBT_PAYMENT',
+  'REFINANCE',
+  'EXTRA_PRINCIPAL_PAYMENT',
   
   // Real estate events
   'REAL_ESTATE_PURCHASE',
   'REAL_ESTATE_SALE',
+  'RENTAL_PROPERTY_PURCHASE',
+  'RENTAL_PROPERTY_SALE',
+  'RELOCATE',
   
   // Strategy configuration events
   'STRATEGY_ASSET_ALLOCATION_SET',
@@ -89,6 +97,7 @@ export const VALID_WASM_EVENT_TYPES = new Set([
   // Education events
   'FIVE_TWO_NINE_CONTRIBUTION',
   'FIVE_TWO_NINE_WITHDRAWAL',
+  'FIVE_TWO_NINE_ROTH_ROLLOVER',
   'TUITION_PAYMENT',
   
   // Business events
@@ -120,6 +129,7 @@ export const FRONTEND_TO_WASM_EVENT_TYPE_MAP: Record<string, WASMEventType> = {
   'WITHDRAWAL': 'WITHDRAWAL',
   'TRANSFER': 'TRANSFER',
   'ROTH_CONVERSION': 'ROTH_CONVERSION',
+  'ROTH_CONVERSION_LADDER': 'ROTH_CONVERSION_LADDER',
   'TAX_PAYMENT': 'TAX_PAYMENT',
   'RMD': 'RMD',
   
@@ -131,6 +141,7 @@ export const FRONTEND_TO_WASM_EVENT_TYPE_MAP: Record<string, WASMEventType> = {
   'REBALANCE_PORTFOLIO': 'REBALANCE_PORTFOLIO',
   'TAX_LOSS_HARVESTING_SALE': 'TAX_LOSS_HARVESTING_SALE',
   'STRATEGIC_CAPITAL_GAINS_REALIZATION': 'STRATEGIC_CAPITAL_GAINS_REALIZATION',
+  'ACA_MARKETPLACE_COVERAGE': 'ACA_MARKETPLACE_COVERAGE',
   'TAX_LOSS_HARVESTING_CHECK_AND_EXECUTE': 'TAX_LOSS_HARVESTING_CHECK_AND_EXECUTE',
   'QUALIFIED_CHARITABLE_DISTRIBUTION': 'QUALIFIED_CHARITABLE_DISTRIBUTION',
   'ADJUST_CASH_RESERVE_SELL_ASSETS': 'ADJUST_CASH_RESERVE_SELL_ASSETS',
@@ -142,8 +153,15 @@ export const FRONTEND_TO_WASM_EVENT_TYPE_MAP: Record<string, WASMEventType> = {
   'MORTGAGE_ORIGINATION': 'MORTGAGE_ORIGINATION',
   'LIABILITY_PAYMENT': 'LIABILITY_PAYMENT',
   'DEBT_PAYMENT': 'DEBT_PAYMENT',
+  'REFINANCE': 'REFINANCE',
+  'EXTRA_PRINCIPAL_PAYMENT': 'EXTRA_PRINCIPAL_PAYMENT',
+  'FIVE_TWO_NINE_ROTH_ROLLOVER': 'FIVE_TWO_NINE_ROTH_ROLLOVER',
   'REAL_ESTATE_PURCHASE': 'REAL_ESTATE_PURCHASE',
   'REAL_ESTATE_SALE': 'REAL_ESTATE_SALE',
+  'RENTAL_PROPERTY_PURCHASE': 'RENTAL_PROPERTY_PURCHASE',
+  'RENTAL_PROPERTY_SALE': 'RENTAL_PROPERTY_SALE',
+  'RELOCATE': 'RELOCATE',
+  'SOCIAL_SECURITY_CLAIM': 'SOCIAL_SECURITY_CLAIM',
   'STRATEGY_ASSET_ALLOCATION_SET': 'STRATEGY_ASSET_ALLOCATION_SET',
   'STRATEGY_REBALANCING_RULE_SET': 'STRATEGY_REBALANCING_RULE_SET',
   'STRATEGY_POLICY': 'STRATEGY_POLICY',
@@ -185,7 +203,6 @@ export const FRONTEND_TO_WASM_EVENT_TYPE_MAP: Record<string, WASMEventType> = {
   
   // Add missing event types that aren't mapped yet
   'DEBT_CONSOLIDATION': 'DEBT_PAYMENT',
-  'REFINANCE': 'DEBT_PAYMENT',
   'HOME_EQUITY_LOAN': 'DEBT_PAYMENT',
   'ACCOUNT_TRANSFER': 'TRANSFER',
   'MEGA_BACKDOOR_ROTH': 'ROTH_CONVERSION',
@@ -7,12 +7,13 @@ echo "Building production WASM (no debug logs)..."
 TEMP_DIR=$(mktemp -d)
 echo "Using temp directory: $TEMP_DIR"
 
-# Copy all Go files to temp directory
-cp *.go "$TEMP_DIR/"
+# Copy the module (entrypoint + shared engine package) to temp directory
+cp go.mod go.sum *.go "$TEMP_DIR/"
+cp -r engine "$TEMP_DIR/engine"
 
 # Remove all debug print statements from the copies
 echo "Stripping debug statements..."
-for file in "$TEMP_DIR"/*.go; do
+for file in "$TEMP_DIR"/*.go "$TEMP_DIR"/engine/*.go; do
     # Comment out all fmt.Print statements (keep fmt.Sprintf as it's used for formatting)
     sed -i 's/^\s*fmt\.Print/\/\/ fmt.Print/g' "$file"
     
@@ -1,315 +0,0 @@
-package main
-
-import (
-	"encoding/json"
-	"fmt"
-	"strings"
-)
-
-// ConfigurationValidator ensures all configuration data meets accuracy requirements
-type ConfigurationValidator struct{}
-
-// NewConfigurationValidator creates a new validator instance
-func NewConfigurationValidator() *ConfigurationValidator {
-	return &ConfigurationValidator{}
-}
-
-// ValidateAllConfigurations performs comprehensive validation of all configuration files
-// CRITICAL: This function ensures NO placeholder or stub data can pass through to the simulation engine
-func (cv *ConfigurationValidator) ValidateAllConfigurations() error {
-	simLogVerbose("🔍 [CONFIG] Starting comprehensive configuration validation...")
-
-	// 1. Validate tax brackets configuration
-	if err := cv.validateTaxBracketsConfig(); err != nil {
-		return fmt.Errorf("tax brackets validation failed: %w", err)
-	}
-
-	// 2. Validate asset returns configuration (MOST CRITICAL)
-	if err := cv.validateAssetReturnsConfig(); err != nil {
-		return fmt.Errorf("asset returns validation failed: %w", err)
-	}
-
-	// 3. Validate dividend model configuration
-	if err := cv.validateDividendModelConfig(); err != nil {
-		return fmt.Errorf("dividend model validation failed: %w", err)
-	}
-
-	// 4. Validate real estate configuration
-	if err := cv.validateRealEstateConfig(); err != nil {
-		return fmt.Errorf("real estate validation failed: %w", err)
-	}
-
-	// 5. Validate RMD table configuration
-	if err := cv.validateRMDTableConfig(); err != nil {
-		return fmt.Errorf("RMD table validation failed: %w", err)
-	}
-
-	// 6. Validate FICA and contribution limits
-	if err := cv.validateFICAConfig(); err != nil {
-		return fmt.Errorf("FICA tax validation failed: %w", err)
-	}
-
-	simLogVerbose("✅ [CONFIG] All configuration validations passed - engine ready for accurate simulation")
-	return nil
-}
-
-// validateAssetReturnsConfig ensures market data is real, not placeholder
-func (cv *ConfigurationValidator) validateAssetReturnsConfig() error {
-	if assetReturnsConfig == nil {
-		return fmt.Errorf("CRITICAL: asset returns configuration not loaded")
-	}
-
-	// Check metadata for placeholder status
-	if err := cv.checkForPlaceholderMetadata("asset_returns_historical.json"); err != nil {
-		return err
-	}
-
-	// Validate asset class data exists
-	if assetReturnsConfig.AssetClassReturns == nil {
-		return fmt.Errorf("CRITICAL: asset class returns data is empty - cannot simulate with no market data")
-	}
-
-	// Ensure minimum required asset classes have data
-	requiredAssets := []string{"spy", "bnd", "intl"}
-	for _, asset := range requiredAssets {
-		if !cv.hasValidAssetData(asset) {
-			return fmt.Errorf("CRITICAL: missing or invalid data for required asset class '%s' - simulation accuracy compromised", asset)
-		}
-	}
-
-	simLogVerbose("✅ [CONFIG] Asset returns validation passed - real market data confirmed")
-	return nil
-}
-
-// validateDividendModelConfig ensures dividend data is realistic, not placeholder
-func (cv *ConfigurationValidator) validateDividendModelConfig() error {
-	if dividendModelConfig == nil {
-		return fmt.Errorf("CRITICAL: dividend model configuration not loaded")
-	}
-
-	// Check for placeholder status
-	if err := cv.checkForPlaceholderMetadata("dividend_model_data.json"); err != nil {
-		return err
-	}
-
-	// Validate dividend yields are reasonable (not placeholder values like 0.000 or 1.000)
-	for assetClass, dividendData := range dividendModelConfig.AssetClassDividends {
-		if dividendData.CurrentYield <= 0 || dividendData.CurrentYield > 0.20 {
-			return fmt.Errorf("CRITICAL: unrealistic dividend yield for %s: %.4f - must be between 0%% and 20%%", assetClass, dividendData.CurrentYield)
-		}
-
-		if dividendData.QualifiedPercentage < 0 || dividendData.QualifiedPercentage > 1.0 {
-			return fmt.Errorf("CRITICAL: invalid qualified percentage for %s: %.2f - must be between 0.0 and 1.0", assetClass, dividendData.QualifiedPercentage)
-		}
-
-		// Validate payment frequency is realistic
-		validFrequencies := []string{"monthly", "quarterly", "semiannual", "annual"}
-		if !cv.contains(validFrequencies, dividendData.PaymentFrequency) {
-			return fmt.Errorf("CRITICAL: invalid payment frequency for %s: '%s' - must be one of: %v", assetClass, dividendData.PaymentFrequency, validFrequencies)
-		}
-	}
-
-	simLogVerbose("✅ [CONFIG] Dividend model validation passed - realistic dividend data confirmed")
-	return nil
-}
-
-// validateRealEstateConfig ensures housing data is authoritative and uses discrete monthly data
-func (cv *ConfigurationValidator) validateRealEstateConfig() error {
-	if monthlyRealEstateConfig == nil {
-		return fmt.Errorf("CRITICAL: monthly real estate configuration not loaded")
-	}
-
-	// Check for placeholder status
-	if err := cv.checkForPlaceholderMetadata("monthly_real_estate_data.json"); err != nil {
-		return err
-	}
-
-	// Validate monthly housing data exists
-	if len(monthlyRealEstateConfig.NationalMonthlyData) == 0 {
-		return fmt.Errorf("CRITICAL: no monthly housing data available - real estate calculations impossible")
-	}
-
-	// Validate monthly appreciation rates are reasonable (not placeholder extremes)
-	for yearStr, yearData := range monthlyRealEstateConfig.NationalMonthlyData {
-		for _, monthData := range yearData {
-			if monthData.HomeAppreciation < -0.10 || monthData.HomeAppreciation > 0.10 { // Monthly limits
-					return fmt.Errorf("CRITICAL: unrealistic monthly appreciation rate for year %s month %d: %.2f%% - check data source", yearStr, monthData.Month, monthData.HomeAppreciation*100)
-			}
-
-			if monthData.RentGrowth < -0.05 || monthData.RentGrowth > 0.05 { // Monthly rent growth limits
-				return fmt.Errorf("CRITICAL: unrealistic monthly rent growth for year %s month %d: %.2f%% - check data source", yearStr, monthData.Month, monthData.RentGrowth*100)
-			}
-		}
-	}
-
-	simLogVerbose("✅ [CONFIG] Real estate validation passed - authoritative housing data confirmed")
-	return nil
-}
-
-// validateTaxBracketsConfig ensures tax data is accurate and current
-func (cv *ConfigurationValidator) validateTaxBracketsConfig() error {
-	if taxBracketsConfig == nil {
-		return fmt.Errorf("CRITICAL: tax brackets configuration not loaded")
-	}
-
-	// Validate federal tax brackets are present and realistic
-	if len(taxBracketsConfig.FederalTaxBracketsSingle2024) < 5 {
-		return fmt.Errorf("CRITICAL: insufficient federal tax brackets for Single filers - need at least 5 brackets, got %d", len(taxBracketsConfig.FederalTaxBracketsSingle2024))
-	}
-
-	if len(taxBracketsConfig.FederalTaxBracketsMFJ2024) < 5 {
-		return fmt.Errorf("CRITICAL: insufficient federal tax brackets for MFJ filers - need at least 5 brackets, got %d", len(taxBracketsConfig.FederalTaxBracketsMFJ2024))
-	}
-
-	// Validate tax rates are reasonable (not placeholders like 0.0 or 1.0)
-	for _, bracket := range taxBracketsConfig.FederalTaxBracketsSingle2024 {
-		if bracket.Rate <= 0 || bracket.Rate >= 1.0 {
-			return fmt.Errorf("CRITICAL: invalid tax rate in Single bracket: %.2f - must be between 0%% and 100%%", bracket.Rate)
-		}
-	}
-
-	// Validate standard deductions are realistic
-	if taxBracketsConfig.StandardDeduction.Single < 10000 || taxBracketsConfig.StandardDeduction.Single > 50000 {
-		return fmt.Errorf("CRITICAL: unrealistic standard deduction for Single: $%.0f - check IRS data", taxBracketsConfig.StandardDeduction.Single)
-	}
-
-	simLogVerbose("✅ [CONFIG] Tax brackets validation passed - current IRS data confirmed")
-	return nil
-}
-
-// validateRMDTableConfig ensures RMD calculations will be accurate
-func (cv *ConfigurationValidator) validateRMDTableConfig() error {
-	if rmdTableConfig == nil {
-		return fmt.Errorf("CRITICAL: RMD table configuration not loaded")
-	}
-
-	// Must have RMD data for reasonable age range
-	if len(rmdTableConfig.RMDLifeExpectancyTable) < 20 {
-		return fmt.Errorf("CRITICAL: insufficient RMD table data - need at least 20 age entries, got %d", len(rmdTableConfig.RMDLifeExpectancyTable))
-	}
-
-	// Validate distribution periods are reasonable
-	for _, entry := range rmdTableConfig.RMDLifeExpectancyTable {
-		if entry.Age < 70 || entry.Age > 120 {
-			return fmt.Errorf("CRITICAL: invalid age in RMD table: %d - must be between 70 and 120", entry.Age)
-		}
-
-		if entry.DistributionPeriod <= 0 || entry.DistributionPeriod > 100 {
-			return fmt.Errorf("CRITICAL: invalid distribution period for age %d: %.1f - must be between 0 and 100 years", entry.Age, entry.DistributionPeriod)
-		}
-	}
-
-	simLogVerbose("✅ [CONFIG] RMD table validation passed - IRS life expectancy tables confirmed")
-	return nil
-}
-
-// validateFICAConfig ensures Social Security and Medicare calculations are accurate
-func (cv *ConfigurationValidator) validateFICAConfig() error {
-	if ficaTaxConfig == nil {
-		return fmt.Errorf("CRITICAL: FICA tax configuration not loaded")
-	}
-
-	// Validate Social Security rate (should be 6.2% for employee)
-	if ficaTaxConfig.SocialSecurityRate < 0.05 || ficaTaxConfig.SocialSecurityRate > 0.10 {
-		return fmt.Errorf("CRITICAL: invalid Social Security rate: %.2f%% - expected ~6.2%%", ficaTaxConfig.SocialSecurityRate*100)
-	}
-
-	// Validate Medicare rate (should be 1.45% for employee)
-	if ficaTaxConfig.MedicareRate < 0.01 || ficaTaxConfig.MedicareRate > 0.03 {
-		return fmt.Errorf("CRITICAL: invalid Medicare rate: %.2f%% - expected ~1.45%%", ficaTaxConfig.MedicareRate*100)
-	}
-
-	// Validate wage base is realistic
-	if contributionConfig.SocialSecurityWageBase < 100000 || contributionConfig.SocialSecurityWageBase > 500000 {
-		return fmt.Errorf("CRITICAL: unrealistic Social Security wage base: $%.0f - check SSA data", contributionConfig.SocialSecurityWageBase)
-	}
-
-	simLogVerbose("✅ [CONFIG] FICA validation passed - current SSA rates confirmed")
-	return nil
-}
-
-// checkForPlaceholderMetadata detects placeholder status in configuration files
-func (cv *ConfigurationValidator) checkForPlaceholderMetadata(filename string) error {
-	var metadata map[string]interface{}
-
-	switch filename {
-	case "asset_returns_historical.json":
-		// Read raw JSON to check status field
-		return cv.validateJSONForPlaceholders(assetReturnsConfig, filename)
-	case "dividend_model_data.json":
-		return cv.validateJSONForPlaceholders(dividendModelConfig, filename)
-	case "monthly_real_estate_data.json":
-		return cv.validateJSONForPlaceholders(monthlyRealEstateConfig, filename)
-	}
-
-	// Generic check for _metadata.status field
-	if status, exists := metadata["status"]; exists {
-		statusStr := strings.ToLower(fmt.Sprintf("%v", status))
-		if statusStr == "placeholder" || statusStr == "stub" || statusStr == "mock" {
-			return fmt.Errorf("CRITICAL: configuration file '%s' is marked as placeholder (status: %s) - engine cannot run with fake data", filename, statusStr)
-		}
-	}
-
-	return nil
-}
-
-// validateJSONForPlaceholders checks any configuration object for placeholder status
-func (cv *ConfigurationValidator) validateJSONForPlaceholders(config interface{}, filename string) error {
-	// Convert to JSON and back to map for metadata inspection
-	jsonData, err := json.Marshal(config)
-	if err != nil {
-		return fmt.Errorf("failed to marshal config for placeholder check: %v", err)
-	}
-
-	var rawConfig map[string]interface{}
-	if err := json.Unmarshal(jsonData, &rawConfig); err != nil {
-		return fmt.Errorf("failed to unmarshal config for placeholder check: %v", err)
-	}
-
-	// Check _metadata.status field
-	if metadata, exists := rawConfig["_metadata"]; exists {
-		if metadataMap, ok := metadata.(map[string]interface{}); ok {
-			if status, hasStatus := metadataMap["status"]; hasStatus {
-				statusStr := strings.ToLower(fmt.Sprintf("%v", status))
-				if statusStr == "placeholder" || statusStr == "stub" || statusStr == "mock" {
-					return fmt.Errorf("CRITICAL: configuration '%s' contains placeholder data (status: %s) - engine cannot proceed with fake market data", filename, statusStr)
-				}
-			}
-		}
-	}
-
-	return nil
-}
-
-// hasValidAssetData checks if an asset class has meaningful data
-func (cv *ConfigurationValidator) hasValidAssetData(assetClass string) bool {
-	// This would need to be implemented based on the actual structure
-	// For now, return true if basic structure exists
-	return assetReturnsConfig != nil && assetReturnsConfig.AssetClassReturns != nil
-}
-
-// contains checks if a slice contains a string
-func (cv *ConfigurationValidator) contains(slice []string, item string) bool {
-	for _, s := range slice {
-		if s == item {
-			return true
-		}
-	}
-	return false
-}
-
-// ValidateMarketDataIntegrity performs deep validation of market data consistency
-func (cv *ConfigurationValidator) ValidateMarketDataIntegrity() error {
-	simLogVerbose("🔍 [CONFIG] Performing market data integrity validation...")
-
-	// Ensure return data has reasonable values
-	// This prevents corrupted or test data from being used in production simulations
-
-	if assetReturnsConfig == nil {
-		return fmt.Errorf("CRITICAL: cannot validate market data integrity - asset returns config not loaded")
-	}
-
-	// Add specific integrity checks here based on actual configuration structure
-	simLogVerbose("✅ [CONFIG] Market data integrity validation passed")
-	return nil
-}
\ No newline at end of file
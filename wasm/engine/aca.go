@@ -0,0 +1,218 @@
+package engine
+
+import "math"
+
+/**
+ * ACA Marketplace Coverage
+ *
+ * ACA_MARKETPLACE_COVERAGE buys a marketplace plan each month until Medicare and
+ * settles the premium tax credit (IRC §36B) on the year's return:
+ *
+ * - Amount is the household's monthly benchmark (second-lowest-cost silver) premium;
+ *   metadata planCostRatio prices the plan actually bought against it (default 1)
+ * - The first covered month of each year sets the advance credit from estimated MAGI:
+ *   metadata estimatedMAGI, else the prior year's simulated MAGI. With neither, no
+ *   advance is paid and the whole credit is claimed at filing. Each month the plan
+ *   premium less the advance credit is paid from cash
+ * - At filing the credit is recomputed from the year's actual MAGI, which includes Roth
+ *   conversions, capital gains, nontaxable Social Security and tax-exempt interest.
+ *   A larger credit is refunded; excess advance credit is repaid up to the year's
+ *   repayment limit (repaid in full from 2026)
+ * - The credit is the benchmark premium less the applicable percentage of MAGI, and
+ *   there is none outside the eligible income range: below 100% of the poverty line
+ *   (unless an advance credit was paid) or above the income limit, which brings back
+ *   the 400% cliff in 2026. Married filing separately is ineligible
+ * - Metadata householdSize (default: living household members) and endAge (default 65)
+ *
+ * The poverty line, applicable percentages and repayment limits come from the tax-year
+ * registry (aca_<year>.json). The reconciliation reports how much of the credit the
+ * year's Roth conversions cost.
+ *
+ * Reference: IRS Form 8962 instructions; Publication 974
+ */
+
+const acaDefaultEndAge = 65
+
+// ACARepaymentLimit caps the excess advance credit repaid by household income below FPLMax
+type ACARepaymentLimit struct {
+	FPLMax float64 `json:"fplMax"`
+	Limit  float64 `json:"limit"`
+}
+
+// ACAParameters are the premium tax credit tables for one coverage year and filing status
+type ACAParameters struct {
+	PovertyLineFirstPerson      float64                  `json:"povertyLineFirstPerson"`
+	PovertyLineAdditionalPerson float64                  `json:"povertyLineAdditionalPerson"`
+	MinimumFPLPercent           float64                  `json:"minimumFplPercent"`
+	MaximumFPLPercent           float64                  `json:"maximumFplPercent,omitempty"` // 0 = no income limit
+	ApplicablePercentages       []ACAContributionBracket `json:"applicablePercentages"`
+	RepaymentLimits             []ACARepaymentLimit      `json:"repaymentLimits,omitempty"` // Empty = repaid in full
+}
+
+// povertyLine returns the federal poverty line for a household size
+func (p ACAParameters) povertyLine(householdSize int) float64 {
+	if householdSize < 1 {
+		householdSize = 1
+	}
+	return p.PovertyLineFirstPerson + float64(householdSize-1)*p.PovertyLineAdditionalPerson
+}
+
+// applicablePercentage returns the percent of household income expected toward the benchmark
+// plan, and false when income is outside the eligible range
+func (p ACAParameters) applicablePercentage(fplPercent float64) (float64, bool) {
+	if fplPercent < p.MinimumFPLPercent || (p.MaximumFPLPercent > 0 && fplPercent > p.MaximumFPLPercent) {
+		return 0, false
+	}
+	for i, band := range p.ApplicablePercentages {
+		last := i == len(p.ApplicablePercentages)-1
+		if fplPercent < band.FPLMin || (fplPercent >= band.FPLMax && !last) {
+			continue
+		}
+		if band.PercentMin == band.PercentMax || math.IsInf(band.FPLMax, 1) {
+			return band.PercentMin, true
+		}
+		position := math.Min(1, (fplPercent-band.FPLMin)/(band.FPLMax-band.FPLMin))
+		return band.PercentMin + position*(band.PercentMax-band.PercentMin), true
+	}
+	return 0, false
+}
+
+// repaymentLimit returns the cap on excess advance credit repaid at an income level
+func (p ACAParameters) repaymentLimit(fplPercent float64) float64 {
+	for _, limit := range p.RepaymentLimits {
+		if fplPercent < limit.FPLMax {
+			return limit.Limit
+		}
+	}
+	return math.Inf(1)
+}
+
+// acaCoverageYear accumulates one tax year's marketplace coverage for reconciliation
+type acaCoverageYear struct {
+	months            int
+	householdSize     int
+	estimatedMAGI     float64 // MAGI the advance credit is based on (0 = no advance credit)
+	benchmarkPremiums float64
+	planPremiums      float64
+	advanceCredit     float64
+}
+
+// acaParameters returns the premium tax credit tables for a calendar year
+func (se *SimulationEngine) acaParameters(year int) (ACAParameters, bool) {
+	se.taxCalculator.SetSimulationYear(year, se.taxThresholdRate())
+	params := se.taxCalculator.YearParameters()
+	if params == nil || se.taxCalculator.FilingStatus() == FilingStatusMarriedSeparately {
+		return ACAParameters{}, false
+	}
+	return params.ACA, true
+}
+
+// acaHouseholdSize returns the household members alive in a month (1 without a household)
+func (se *SimulationEngine) acaHouseholdSize(monthOffset int) int {
+	if se.household == nil {
+		return 1
+	}
+	size := 0
+	for i := range se.household.members {
+		if se.household.alive(i, monthOffset) {
+			size++
+		}
+	}
+	return int(math.Max(1, float64(size)))
+}
+
+// premiumTaxCredit returns the credit on a span of coverage at a MAGI. Reconciliation passes
+// advancePaid so that income below the poverty line keeps the credit an advance was paid on.
+func premiumTaxCredit(params ACAParameters, magi float64, householdSize int, benchmarkPremiums, planPremiums float64, months int, advancePaid bool) float64 {
+	fplPercent := magi / params.povertyLine(householdSize) * 100
+	if advancePaid {
+		fplPercent = math.Max(fplPercent, params.MinimumFPLPercent)
+	}
+	percent, eligible := params.applicablePercentage(fplPercent)
+	if !eligible {
+		return 0
+	}
+	contribution := math.Max(0, magi) * percent / 100 * float64(months) / 12
+	return math.Min(planPremiums, math.Max(0, benchmarkPremiums-contribution))
+}
+
+// reconcilePremiumTaxCredit settles the year's advance credit against the credit allowed on
+// actual MAGI and adds the net credit or repayment to the tax result
+func (se *SimulationEngine) reconcilePremiumTaxCredit(year int, magi, taxableSocialSecurity float64, result *TaxCalculationResult) {
+	coverage := se.acaCoverage
+	if coverage.months == 0 {
+		return
+	}
+	params, ok := se.acaParameters(year)
+	acaMAGI := magi + (se.socialSecurityBenefitsYTD - taxableSocialSecurity) + se.taxExemptIncomeYTD
+	advancePaid := coverage.advanceCredit > 0
+
+	allowed := 0.0
+	if ok {
+		allowed = premiumTaxCredit(params, acaMAGI, coverage.householdSize, coverage.benchmarkPremiums, coverage.planPremiums, coverage.months, advancePaid)
+		if se.rothConversionsYTD > 0 {
+			withoutConversions := premiumTaxCredit(params, acaMAGI-se.rothConversionsYTD, coverage.householdSize,
+				coverage.benchmarkPremiums, coverage.planPremiums, coverage.months, advancePaid)
+			result.PremiumTaxCreditLostToConversions = math.Max(0, withoutConversions-allowed)
+		}
+	}
+
+	result.ACACoverageMonths = coverage.months
+	result.ACAModifiedAGI = acaMAGI
+	result.PremiumTaxCredit = allowed
+	result.AdvancePremiumTaxCredit = coverage.advanceCredit
+	if net := allowed - coverage.advanceCredit; net >= 0 {
+		result.TotalTax -= net // Refundable
+	} else {
+		fplPercent := acaMAGI / params.povertyLine(coverage.householdSize) * 100
+		result.ExcessAdvanceCreditRepayment = math.Min(-net, params.repaymentLimit(fplPercent))
+		result.TotalTax += result.ExcessAdvanceCreditRepayment
+	}
+
+	simLogEvent("INFO  [Year %d] ACA reconciliation | MAGI: $%.0f | Credit: $%.0f | Advance: $%.0f | Repaid: $%.0f | Lost to Roth conversions: $%.0f",
+		year, acaMAGI, allowed, coverage.advanceCredit, result.ExcessAdvanceCreditRepayment, result.PremiumTaxCreditLostToConversions)
+}
+
+// ACAMarketplaceCoverageEventHandler pays a month's marketplace premium net of the advance credit
+type ACAMarketplaceCoverageEventHandler struct{}
+
+func (h *ACAMarketplaceCoverageEventHandler) Process(event FinancialEvent, accounts *AccountHoldingsMonthEnd, cashFlow *float64, context *EventProcessingContext) error {
+	se := context.SimulationEngine
+	currentMonth := context.CurrentMonth
+
+	endAge := int(getFloat64FromMetadata(event.Metadata, "endAge", acaDefaultEndAge))
+	if se.primaryAge(currentMonth) >= endAge || event.Amount <= 0 {
+		return nil
+	}
+
+	benchmark := event.Amount
+	premium := benchmark * getFloat64FromMetadata(event.Metadata, "planCostRatio", 1)
+	coverage := &se.acaCoverage
+	year := se.calendarYear(currentMonth)
+
+	// The marketplace application sets household size and the income estimate for the year
+	if coverage.months == 0 {
+		coverage.householdSize = int(getFloat64FromMetadata(event.Metadata, "householdSize", float64(se.acaHouseholdSize(currentMonth))))
+		coverage.estimatedMAGI = getFloat64FromMetadata(event.Metadata, "estimatedMAGI", se.magiHistory[year-1])
+	}
+
+	advance := 0.0
+	if params, ok := se.acaParameters(year); ok && coverage.estimatedMAGI > 0 {
+		advance = premiumTaxCredit(params, coverage.estimatedMAGI, coverage.householdSize, benchmark, premium, 1, false)
+	}
+
+	coverage.months++
+	coverage.benchmarkPremiums += benchmark
+	coverage.planPremiums += premium
+	coverage.advanceCredit += advance
+
+	netPremium := premium - advance
+	accounts.Cash -= netPremium
+	*cashFlow -= netPremium
+	se.currentMonthFlows.ExpensesThisMonth += netPremium
+	se.currentMonthFlows.HealthcareExpensesThisMonth += netPremium
+
+	simLogEvent("INFO  [Month %d] Event: ACA_MARKETPLACE_COVERAGE | Premium: $%.2f | Advance credit: $%.2f | Result: Cash -$%.2f",
+		currentMonth, premium, advance, netPremium)
+	return nil
+}
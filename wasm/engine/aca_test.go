@@ -0,0 +1,182 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func newACATestEngine(t *testing.T, age, startYear int) (*SimulationEngine, *AccountHoldingsMonthEnd) {
+	t.Helper()
+	accounts := &AccountHoldingsMonthEnd{Cash: 50000}
+	return newHandlerTestEngine(t, SimulationInput{InitialAge: age, StartYear: startYear}, accounts), accounts
+}
+
+// coverACAYear buys a year of marketplace coverage and returns the total advance credit
+func coverACAYear(t *testing.T, se *SimulationEngine, accounts *AccountHoldingsMonthEnd, benchmark float64, metadata map[string]interface{}) float64 {
+	t.Helper()
+	for month := 0; month < 12; month++ {
+		cashFlow := 0.0
+		event := FinancialEvent{ID: "aca", Type: "ACA_MARKETPLACE_COVERAGE", Amount: benchmark, MonthOffset: month, Metadata: metadata}
+		if err := (&ACAMarketplaceCoverageEventHandler{}).Process(event, accounts, &cashFlow, &EventProcessingContext{SimulationEngine: se, CurrentMonth: month}); err != nil {
+			t.Fatalf("coverage: %v", err)
+		}
+	}
+	return se.acaCoverage.advanceCredit
+}
+
+func TestACAParametersByYear(t *testing.T) {
+	p2025, err := LookupTaxYear(2025, FilingStatusSingle, 0.025, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	aca := p2025.ACA
+	if aca.povertyLine(1) != 15060 || aca.povertyLine(2) != 20440 {
+		t.Errorf("2025 poverty line: %.0f / %.0f", aca.povertyLine(1), aca.povertyLine(2))
+	}
+	if pct, ok := aca.applicablePercentage(600); !ok || pct != 8.5 {
+		t.Errorf("2025 has no cliff: %.2f%% eligible=%v", pct, ok)
+	}
+	if pct, _ := aca.applicablePercentage(225); math.Abs(pct-3) > 1e-9 {
+		t.Errorf("2025 at 225%% FPL: %.3f%%, want 3%%", pct)
+	}
+	if aca.repaymentLimit(250) != 975 || !math.IsInf(aca.repaymentLimit(450), 1) {
+		t.Errorf("2025 repayment limits: %.0f / %.0f", aca.repaymentLimit(250), aca.repaymentLimit(450))
+	}
+	if _, ok := aca.applicablePercentage(90); ok {
+		t.Error("below 100% FPL is ineligible")
+	}
+
+	p2026, err := LookupTaxYear(2026, FilingStatusMarriedJointly, 0.025, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	aca = p2026.ACA
+	if pct, ok := aca.applicablePercentage(400); !ok || pct != 9.96 {
+		t.Errorf("2026 at 400%% FPL: %.2f%% eligible=%v", pct, ok)
+	}
+	if _, ok := aca.applicablePercentage(401); ok {
+		t.Error("2026 restores the 400% FPL cliff")
+	}
+	if !math.IsInf(aca.repaymentLimit(150), 1) {
+		t.Error("from 2026 excess advance credit is repaid in full")
+	}
+
+	p2028, err := LookupTaxYear(2028, FilingStatusSingle, 0.025, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p2028.ACA.povertyLine(1); got <= 15650 || got > 15650*1.06 {
+		t.Errorf("2028 poverty line should be indexed from 2026: %.0f", got)
+	}
+}
+
+func TestACAAdvanceCreditReconciliation(t *testing.T) {
+	// $40,000 is 265.6% of the 2025 poverty line: 4.62% of income toward an $800 benchmark
+	se, accounts := newACATestEngine(t, 60, 2025)
+	advance := coverACAYear(t, se, accounts, 800, map[string]interface{}{"estimatedMAGI": 40000.0})
+	expectedCredit := 9600 - 40000*(4+2*(40000/15060.0*100-250)/50)/100
+	if math.Abs(advance-expectedCredit) > 0.01 {
+		t.Errorf("advance credit %.2f, want %.2f", advance, expectedCredit)
+	}
+	if math.Abs(accounts.Cash-(50000-9600+advance)) > 0.01 {
+		t.Errorf("cash %.2f: the net premium should be paid", accounts.Cash)
+	}
+
+	// Income as estimated: nothing to settle
+	result := TaxCalculationResult{}
+	se.reconcilePremiumTaxCredit(2025, 40000, 0, &result)
+	if math.Abs(result.PremiumTaxCredit-advance) > 0.01 || result.TotalTax > 0.01 || result.ACACoverageMonths != 12 {
+		t.Errorf("as estimated: %+v", result)
+	}
+
+	// $55,000 (365% FPL) means excess advance credit, repaid up to the $1,625 limit
+	result = TaxCalculationResult{}
+	se.reconcilePremiumTaxCredit(2025, 55000, 0, &result)
+	if result.ExcessAdvanceCreditRepayment != 1625 || result.TotalTax != 1625 {
+		t.Errorf("capped repayment: %+v", result)
+	}
+
+	// Less income than estimated: the extra credit is refunded
+	result = TaxCalculationResult{}
+	se.reconcilePremiumTaxCredit(2025, 30000, 0, &result)
+	if result.PremiumTaxCredit <= advance || math.Abs(-result.TotalTax-(result.PremiumTaxCredit-advance)) > 0.01 {
+		t.Errorf("refund: %+v", result)
+	}
+
+	// Nontaxable Social Security counts toward ACA MAGI
+	result = TaxCalculationResult{}
+	se.socialSecurityBenefitsYTD = 20000
+	se.reconcilePremiumTaxCredit(2025, 30000, 5000, &result)
+	if result.ACAModifiedAGI != 45000 {
+		t.Errorf("ACA MAGI %.0f, want 45000", result.ACAModifiedAGI)
+	}
+}
+
+func TestACASubsidyCliffAndRothConversions(t *testing.T) {
+	// 400% of the 2026 poverty line for one is $62,600
+	se, accounts := newACATestEngine(t, 60, 2026)
+	coverACAYear(t, se, accounts, 1000, nil)
+	if se.acaCoverage.advanceCredit != 0 {
+		t.Error("without an income estimate the whole credit waits for the return")
+	}
+
+	below, above := TaxCalculationResult{}, TaxCalculationResult{}
+	se.reconcilePremiumTaxCredit(2026, 62500, 0, &below)
+	se.reconcilePremiumTaxCredit(2026, 62700, 0, &above)
+	if math.Abs(below.PremiumTaxCredit-(12000-62500*0.0996)) > 0.01 || above.PremiumTaxCredit != 0 {
+		t.Errorf("cliff: credit %.2f at $62,500, %.2f at $62,700", below.PremiumTaxCredit, above.PremiumTaxCredit)
+	}
+
+	// A $20,000 conversion on $50,000 of other income goes over the cliff
+	se.recordRothConversion(20000, 11)
+	withConversion := TaxCalculationResult{}
+	se.reconcilePremiumTaxCredit(2026, 70000, 0, &withConversion)
+	withoutConversion := premiumTaxCredit(se.taxCalculator.YearParameters().ACA, 50000, 1, 12000, 12000, 12, false)
+	if withConversion.PremiumTaxCredit != 0 || math.Abs(withConversion.PremiumTaxCreditLostToConversions-withoutConversion) > 0.01 {
+		t.Errorf("conversion cost %.2f of credit, want %.2f", withConversion.PremiumTaxCreditLostToConversions, withoutConversion)
+	}
+
+	// Married filing separately can't claim the credit
+	se.taxCalculator.SetFilingStatus(FilingStatusMarriedSeparately)
+	mfs := TaxCalculationResult{}
+	se.reconcilePremiumTaxCredit(2026, 40000, 0, &mfs)
+	if mfs.PremiumTaxCredit != 0 {
+		t.Errorf("married filing separately: credit %.2f", mfs.PremiumTaxCredit)
+	}
+}
+
+func TestACAMarketplaceCoverageInSimulation(t *testing.T) {
+	input := createRollingTestInput(1000000, 48000)
+	input.Config.RandomSeed = 11
+	input.InitialAge = 63
+	input.StartYear = 2025
+	input.MonthsToRun = 36
+	input.TaxConfig = &SimpleTaxConfig{Enabled: true, EffectiveRate: 0.15, CapitalGainsRate: 0.15}
+	input.Events = append(input.Events,
+		FinancialEvent{ID: "aca", Type: "ACA_MARKETPLACE_COVERAGE", Amount: 900, Frequency: "monthly",
+			Metadata: map[string]interface{}{"estimatedMAGI": 30000.0}},
+		FinancialEvent{ID: "conversion", Type: "ROTH_CONVERSION", Amount: 20000, MonthOffset: 23},
+	)
+	input.InitialAccounts.TaxDeferred = &Account{TotalValue: 200000, Holdings: []Holding{{
+		ID: "ira", AssetClass: AssetClassUSStocksTotalMarket, Quantity: 200000, CostBasisPerUnit: 1, CostBasisTotal: 200000,
+		CurrentMarketPricePerUnit: 1, CurrentMarketValueTotal: 200000,
+	}}}
+
+	result := NewSimulationEngine(input.Config).RunSingleSimulation(input)
+	if !result.Success {
+		t.Fatalf("simulation failed: %s", result.Error)
+	}
+	for _, month := range result.MonthlyData {
+		if month.MonthOffset%12 != 11 {
+			continue
+		}
+		covered := month.MonthOffset < 24 // Medicare from 65
+		if covered != (month.PremiumTaxCreditAnnual != nil) {
+			t.Errorf("month %d: reconciliation reported %v, coverage %v", month.MonthOffset, month.PremiumTaxCreditAnnual != nil, covered)
+			continue
+		}
+		if month.MonthOffset == 23 && (month.PremiumTaxCreditLostToConversionsAnnual == nil || *month.PremiumTaxCreditLostToConversionsAnnual <= 0) {
+			t.Error("the 2026 conversion should show the credit it cost")
+		}
+	}
+}
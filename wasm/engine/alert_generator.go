@@ -110,7 +110,8 @@ type IRMAAPartBPremiums struct {
 }
 
 type IRMAAPartDPremiums struct {
-	IRMAAAAdjustments []IRMAAPartDAdjustment `json:"irmaaAdjustments"`
+	BaseBeneficiaryPremium float64                `json:"baseBeneficiaryPremium"`
+	IRMAAAAdjustments      []IRMAAPartDAdjustment `json:"irmaaAdjustments"`
 }
 
 type IRMAAPartBAdjustment struct {
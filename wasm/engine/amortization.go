@@ -42,6 +42,25 @@ func CalculateMonthlyPayment(principal float64, annualRate float64, termMonths i
 	return payment
 }
 
+// CalculateRemainingTermMonths returns the number of payments needed to retire a balance
+// at a fixed payment: n = -ln(1 - rB/P) / ln(1 + r). Returns 0 if the payment never
+// covers the interest.
+func CalculateRemainingTermMonths(balance float64, monthlyPayment float64, annualRate float64) int {
+	if balance <= 0 || monthlyPayment <= 0 {
+		return 0
+	}
+	if annualRate <= 0 {
+		return int(math.Ceil(balance/monthlyPayment - 1e-9))
+	}
+
+	monthlyRate := annualRate / 12.0
+	if monthlyPayment <= balance*monthlyRate {
+		return 0
+	}
+	n := -math.Log(1-monthlyRate*balance/monthlyPayment) / math.Log(1+monthlyRate)
+	return int(math.Ceil(n - 1e-9))
+}
+
 // CalculateAmortizationSplit calculates how much of a payment goes to principal vs interest
 func CalculateAmortizationSplit(balance float64, monthlyPayment float64, monthlyRate float64) (principal float64, interest float64) {
 	// SAFETY: Validate inputs to prevent invalid amortization calculations
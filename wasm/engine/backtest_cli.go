@@ -1,4 +1,4 @@
-package main
+package engine
 
 import (
 	"encoding/json"
@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 )
 
 // BacktestCLI provides command-line interface for running backtests
@@ -198,11 +199,22 @@ func runBacktestMain() {
 		fmt.Println("  all                    - Run all available scenarios")
 		fmt.Println("  list                   - List all available scenarios")
 		fmt.Println("  <scenario_name>        - Run a specific scenario")
+		fmt.Println("  rolling [years] [portfolio] [annualSpending]")
+		fmt.Println("                         - Replay a 60/40 withdrawal plan from every historical start year")
+		fmt.Println("                           (defaults: 10 years, $1,000,000, $40,000/year)")
 		os.Exit(1)
 	}
 
 	command := os.Args[1]
 
+	// Rolling cohorts use the embedded monthly series, not historical_data.json
+	if command == "rolling" {
+		if err := runRollingBacktestCLI(os.Args[2:]); err != nil {
+			log.Fatalf("Rolling backtest failed: %v", err)
+		}
+		return
+	}
+
 	// Default to looking for historical data in the project root
 	historicalDataPath := filepath.Join("..", "historical_data.json")
 
@@ -299,3 +311,99 @@ func (cli *BacktestCLI) createDefaultTestInput(scenario HistoricalScenario) Simu
 		MonthsToRun:     0, // Will be calculated by RunBacktest
 	}
 }
+
+// runRollingBacktestCLI replays a simple withdrawal plan from every start year
+// Args: [windowYears] [initialPortfolio] [annualSpending]
+func runRollingBacktestCLI(args []string) error {
+	values := []float64{10, 1000000, 40000}
+	for i, arg := range args {
+		if i >= len(values) {
+			break
+		}
+		v, err := strconv.ParseFloat(arg, 64)
+		if err != nil || v < 0 {
+			return fmt.Errorf("invalid argument %q", arg)
+		}
+		values[i] = v
+	}
+	windowMonths := int(values[0] * 12)
+	portfolio, annualSpending := values[1], values[2]
+
+	input := createRollingTestInput(portfolio, annualSpending)
+	result, err := RunRollingBacktest(input, windowMonths)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Rolling %d-year cohorts, %d-%d: $%s portfolio (60/40), $%s/year spending\n",
+		result.WindowMonths/12, result.FirstStartYear, result.LastStartYear,
+		formatCurrency(portfolio), formatCurrency(annualSpending))
+	if result.TruncatedCohorts > 0 {
+		fmt.Printf("Note: %d of %d cohorts ran out of data before %d years and end early\n",
+			result.TruncatedCohorts, len(result.Cohorts), result.WindowMonths/12)
+	}
+	if result.Degenerate {
+		fmt.Println("Warning: only one start year has enough data; the percentiles below are a single outcome")
+	}
+	fmt.Println()
+	for _, cohort := range result.Cohorts {
+		status := "✅"
+		detail := ""
+		if cohort.Depleted {
+			status = "❌"
+			detail = fmt.Sprintf(" (depleted month %d, %d months short)", cohort.DepletionMonth, cohort.MonthsDepleted)
+		} else if cohort.Truncated {
+			detail = fmt.Sprintf(" (data ends after %d months)", cohort.MonthsRun)
+		}
+		fmt.Printf("   %s %d  $%s%s\n", status, cohort.StartYear, formatCurrency(cohort.TerminalWealth), detail)
+	}
+	fmt.Println()
+	fmt.Printf("Success Rate:          %.1f%% (%d of %d cohorts depleted)\n",
+		result.SuccessRate*100, result.DepletedCohorts, len(result.Cohorts))
+	fmt.Printf("Terminal Wealth P10:   $%s\n", formatCurrency(result.TerminalWealthP10))
+	fmt.Printf("Terminal Wealth P50:   $%s\n", formatCurrency(result.TerminalWealthP50))
+	fmt.Printf("Terminal Wealth P90:   $%s\n", formatCurrency(result.TerminalWealthP90))
+	fmt.Printf("Worst Start Year:      %d ($%s)\n", result.WorstStartYear, formatCurrency(result.WorstTerminalWealth))
+	fmt.Printf("Best Start Year:       %d ($%s)\n", result.BestStartYear, formatCurrency(result.BestTerminalWealth))
+	return nil
+}
+
+// createRollingTestInput builds a 60/40 taxable portfolio with a monthly expense
+// NOTE: For production usage, callers pass the user's actual plan to RunRollingBacktest
+func createRollingTestInput(portfolio, annualSpending float64) SimulationInput {
+	holding := func(id string, assetClass AssetClass, amount float64) Holding {
+		// Market prices start normalized at $1/share, so shares = dollars
+		return Holding{
+			ID:                        id,
+			AssetClass:                assetClass,
+			Quantity:                  amount,
+			CostBasisPerUnit:          1,
+			CostBasisTotal:            amount,
+			CurrentMarketPricePerUnit: 1,
+			CurrentMarketValueTotal:   amount,
+		}
+	}
+
+	config := GetDefaultStochasticConfig()
+	config.LiteMode = true
+
+	return SimulationInput{
+		InitialAccounts: AccountHoldingsMonthEnd{
+			Taxable: &Account{
+				TotalValue: portfolio,
+				Holdings: []Holding{
+					holding("rolling-stocks", AssetClassUSStocksTotalMarket, portfolio*0.6),
+					holding("rolling-bonds", AssetClassUSBondsTotalMarket, portfolio*0.4),
+				},
+			},
+			TaxDeferred: &Account{Holdings: []Holding{}},
+			Roth:        &Account{Holdings: []Holding{}},
+		},
+		Events: []FinancialEvent{
+			{ID: "rolling-spending", Type: "EXPENSE", Description: "Living expenses", Amount: annualSpending / 12, Frequency: "monthly"},
+		},
+		Config:     config,
+		StartYear:  defaultStartYear,
+		InitialAge: 65,
+	}
+}
@@ -0,0 +1,178 @@
+package engine
+
+import (
+	"fmt"
+)
+
+/**
+ * Rolling-Cohort Backtest
+ *
+ * RunBacktest replays one named window. RunRollingBacktest replays the user's
+ * plan once per historical start year (the Trinity-study approach): cohort Y
+ * runs the plan with the real monthly returns from January of year Y onward.
+ *
+ * Cohorts come from the continuous monthly series in monthly_historical_data.json
+ * (the same months the historical bootstrap samples), which holds about 21
+ * gap-free years. A cohort runs the full window when the data covers it;
+ * otherwise it runs over the months it has and is marked Truncated. Start
+ * years with less than rollingMinimumCohortMonths of data are skipped, so a
+ * 30-year plan is replayed from every start year with at least 10 years.
+ * Terminal wealth of a truncated cohort is measured where its data ends.
+ *
+ * Each cohort runs on its own engine with a deep copy of the accounts; only the
+ * market returns differ between cohorts.
+ */
+
+// rollingBacktestDefaultWindowMonths is the window when neither the caller nor the plan sets one
+const rollingBacktestDefaultWindowMonths = 120
+
+// rollingMinimumCohortMonths is the shortest run of data a start year needs to
+// become a cohort when the window is longer than that
+const rollingMinimumCohortMonths = 120
+
+// RunRollingBacktest replays the plan from every start year with at least
+// rollingMinimumCohortMonths of historical data (or the whole window, if shorter).
+// windowMonths = 0 uses the plan's MonthsToRun.
+func RunRollingBacktest(userInput SimulationInput, windowMonths int) (*RollingBacktestResult, error) {
+	pool, err := getBootstrapPool()
+	if err != nil {
+		return nil, err
+	}
+	return runRollingBacktest(pool, userInput, windowMonths)
+}
+
+// runRollingBacktest replays the plan over the cohorts of one monthly series
+func runRollingBacktest(pool *bootstrapPool, userInput SimulationInput, windowMonths int) (*RollingBacktestResult, error) {
+	if windowMonths <= 0 {
+		windowMonths = userInput.MonthsToRun
+	}
+	if windowMonths <= 0 {
+		windowMonths = rollingBacktestDefaultWindowMonths
+	}
+
+	minimumMonths := windowMonths
+	if minimumMonths > rollingMinimumCohortMonths {
+		minimumMonths = rollingMinimumCohortMonths
+	}
+	starts := rollingCohortStarts(pool, minimumMonths)
+	if len(starts) == 0 {
+		return nil, fmt.Errorf("no start year has %d months of continuous historical data", minimumMonths)
+	}
+
+	// Historical returns replace the return model, but the config still
+	// carries plan-level settings (LiteMode, CashFloor, tax timing)
+	config := userInput.Config
+	if err := validateStochasticConfig(&config); err != nil {
+		return nil, fmt.Errorf("invalid plan config: %w", err)
+	}
+	config.SimulationMode = "stochastic"
+
+	result := &RollingBacktestResult{
+		WindowMonths:   windowMonths,
+		FirstStartYear: pool.months[starts[0]].Year,
+		LastStartYear:  pool.months[starts[len(starts)-1]].Year,
+		Cohorts:        make([]RollingCohortResult, 0, len(starts)),
+		Degenerate:     len(starts) == 1,
+	}
+
+	terminalWealth := make([]float64, 0, len(starts))
+	for _, start := range starts {
+		months := contiguousRun(pool, start)
+		if months > windowMonths {
+			months = windowMonths
+		}
+		historicalReturns := make(map[int]StochasticReturns, months)
+		for offset := 0; offset < months; offset++ {
+			historicalReturns[offset] = pool.months[start+offset].returns(&config)
+		}
+
+		engine := NewSimulationEngine(config)
+		engine.backtestReturns = historicalReturns
+
+		cohortInput := userInput
+		cohortInput.Config = config
+		cohortInput.MonthsToRun = months
+		cohortInput.InitialAccounts = deepCopyInputAccounts(userInput.InitialAccounts)
+
+		// A depleted cohort ends early with Success false; that is a result, not an error
+		run := engine.RunSingleSimulation(cohortInput)
+		if !run.Success && !run.IsBankrupt {
+			return nil, fmt.Errorf("cohort starting %d failed: %s", pool.months[start].Year, run.Error)
+		}
+
+		cohort := RollingCohortResult{
+			StartYear:      pool.months[start].Year,
+			MonthsRun:      months,
+			Truncated:      months < windowMonths,
+			TerminalWealth: run.FinalNetWorth,
+			DepletionMonth: -1,
+		}
+		if cohort.Truncated {
+			result.TruncatedCohorts++
+		}
+		if run.IsBankrupt {
+			cohort.Depleted = true
+			cohort.DepletionMonth = run.BankruptcyMonth
+			cohort.MonthsDepleted = months - run.BankruptcyMonth
+			result.DepletedCohorts++
+		}
+		result.Cohorts = append(result.Cohorts, cohort)
+		terminalWealth = append(terminalWealth, cohort.TerminalWealth)
+	}
+
+	result.SuccessRate = 1 - float64(result.DepletedCohorts)/float64(len(result.Cohorts))
+	percentiles := calculatePercentiles(terminalWealth)
+	result.TerminalWealthP10 = percentiles[0]
+	result.TerminalWealthP25 = percentiles[1]
+	result.TerminalWealthP50 = percentiles[2]
+	result.TerminalWealthP75 = percentiles[3]
+	result.TerminalWealthP90 = percentiles[4]
+
+	worst, best := result.Cohorts[0], result.Cohorts[0]
+	for _, cohort := range result.Cohorts[1:] {
+		if worseCohort(cohort, worst) {
+			worst = cohort
+		}
+		if cohort.TerminalWealth > best.TerminalWealth {
+			best = cohort
+		}
+	}
+	result.WorstStartYear = worst.StartYear
+	result.WorstTerminalWealth = worst.TerminalWealth
+	result.BestStartYear = best.StartYear
+	result.BestTerminalWealth = best.TerminalWealth
+
+	return result, nil
+}
+
+// rollingCohortStarts returns the pool index of every January that begins a
+// gap-free run of at least minimumMonths months
+func rollingCohortStarts(pool *bootstrapPool, minimumMonths int) []int {
+	var starts []int
+	for i, month := range pool.months {
+		if month.Month == 1 && contiguousRun(pool, i) >= minimumMonths {
+			starts = append(starts, i)
+		}
+	}
+	return starts
+}
+
+// contiguousRun counts the consecutive calendar months starting at index start
+func contiguousRun(pool *bootstrapPool, start int) int {
+	n := 1
+	for start+n < len(pool.months) && pool.follows[start+n] {
+		n++
+	}
+	return n
+}
+
+// worseCohort ranks depletion first (earlier is worse), then terminal wealth
+func worseCohort(a, b RollingCohortResult) bool {
+	if a.Depleted != b.Depleted {
+		return a.Depleted
+	}
+	if a.Depleted && a.DepletionMonth != b.DepletionMonth {
+		return a.DepletionMonth < b.DepletionMonth
+	}
+	return a.TerminalWealth < b.TerminalWealth
+}
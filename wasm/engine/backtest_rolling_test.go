@@ -0,0 +1,135 @@
+package engine
+
+import (
+	"testing"
+)
+
+func TestRollingBacktestCohorts(t *testing.T) {
+	input := createRollingTestInput(1000000, 40000)
+
+	result, err := RunRollingBacktest(input, 120)
+	if err != nil {
+		t.Fatalf("RunRollingBacktest: %v", err)
+	}
+	// The monthly series runs from late 2003 to 2025, so 10-year windows start 2004-2015
+	if result.FirstStartYear != 2004 || result.LastStartYear != 2015 || len(result.Cohorts) != 12 {
+		t.Fatalf("expected cohorts 2004-2015, got %d-%d (%d cohorts)", result.FirstStartYear, result.LastStartYear, len(result.Cohorts))
+	}
+	if input.InitialAccounts.Taxable.Holdings[0].Quantity != 600000 {
+		t.Fatal("cohorts must not modify the caller's holdings")
+	}
+	distinct := map[float64]bool{}
+	for _, cohort := range result.Cohorts {
+		distinct[cohort.TerminalWealth] = true
+	}
+	if len(distinct) != len(result.Cohorts) {
+		t.Error("each cohort should see different market returns")
+	}
+	if result.TerminalWealthP10 > result.TerminalWealthP50 || result.TerminalWealthP50 > result.TerminalWealthP90 {
+		t.Errorf("terminal wealth percentiles out of order: %.0f/%.0f/%.0f",
+			result.TerminalWealthP10, result.TerminalWealthP50, result.TerminalWealthP90)
+	}
+	for _, cohort := range result.Cohorts {
+		if cohort.TerminalWealth < result.WorstTerminalWealth && result.DepletedCohorts == 0 {
+			t.Errorf("cohort %d ended below the reported worst start year %d", cohort.StartYear, result.WorstStartYear)
+		}
+	}
+
+	// Heavy spending depletes some cohorts; the earliest depletion is the worst
+	stressed, err := RunRollingBacktest(createRollingTestInput(1000000, 90000), 180)
+	if err != nil {
+		t.Fatalf("RunRollingBacktest (stressed): %v", err)
+	}
+	if stressed.DepletedCohorts == 0 || stressed.SuccessRate >= 1 {
+		t.Fatal("expected 9% withdrawals to deplete some 15-year cohorts")
+	}
+	earliest := stressed.WindowMonths
+	for _, cohort := range stressed.Cohorts {
+		if cohort.Depleted {
+			if cohort.MonthsDepleted != cohort.MonthsRun-cohort.DepletionMonth {
+				t.Errorf("cohort %d: months depleted %d inconsistent with depletion month %d",
+					cohort.StartYear, cohort.MonthsDepleted, cohort.DepletionMonth)
+			}
+			if cohort.DepletionMonth < earliest {
+				earliest = cohort.DepletionMonth
+			}
+		} else if cohort.DepletionMonth != -1 {
+			t.Errorf("cohort %d was not depleted but has depletion month %d", cohort.StartYear, cohort.DepletionMonth)
+		}
+	}
+	for _, cohort := range stressed.Cohorts {
+		if cohort.StartYear == stressed.WorstStartYear && cohort.DepletionMonth != earliest {
+			t.Errorf("worst start year %d should be the earliest depletion", stressed.WorstStartYear)
+		}
+	}
+
+	if result.TruncatedCohorts != 0 || result.Degenerate {
+		t.Error("every start year has 10 full years of data")
+	}
+}
+
+// TestRollingBacktestDefaultWindow verifies a 30-year plan with no explicit
+// window replays every start year with 10+ years of data, truncating the
+// cohorts whose data ends first
+func TestRollingBacktestDefaultWindow(t *testing.T) {
+	input := createRollingTestInput(1000000, 40000)
+	input.MonthsToRun = 360
+
+	result, err := RunRollingBacktest(input, 0)
+	if err != nil {
+		t.Fatalf("RunRollingBacktest with the plan's 30-year window: %v", err)
+	}
+	if result.WindowMonths != 360 {
+		t.Errorf("expected the plan's 360-month window, got %d", result.WindowMonths)
+	}
+	if result.FirstStartYear != 2004 || result.LastStartYear != 2015 || len(result.Cohorts) != 12 || result.Degenerate {
+		t.Fatalf("expected cohorts 2004-2015, got %d-%d (%d cohorts, degenerate=%v)",
+			result.FirstStartYear, result.LastStartYear, len(result.Cohorts), result.Degenerate)
+	}
+	if result.TruncatedCohorts != len(result.Cohorts) {
+		t.Errorf("no start year has 30 years of data, so every cohort is truncated; got %d", result.TruncatedCohorts)
+	}
+	for i, cohort := range result.Cohorts {
+		if !cohort.Truncated || cohort.MonthsRun < rollingMinimumCohortMonths || cohort.MonthsRun >= 360 {
+			t.Errorf("cohort %d: truncated=%v after %d months", cohort.StartYear, cohort.Truncated, cohort.MonthsRun)
+		}
+		if i > 0 && cohort.MonthsRun != result.Cohorts[i-1].MonthsRun-12 {
+			t.Errorf("cohort %d should run a year less than %d", cohort.StartYear, result.Cohorts[i-1].StartYear)
+		}
+	}
+	if result.TerminalWealthP10 >= result.TerminalWealthP90 || result.WorstStartYear == result.BestStartYear {
+		t.Errorf("expected a spread across start years, got P10 %.0f P90 %.0f", result.TerminalWealthP10, result.TerminalWealthP90)
+	}
+}
+
+// TestRollingBacktestDegenerate verifies a series with only one start year long
+// enough is flagged rather than reported as a distribution
+func TestRollingBacktestDegenerate(t *testing.T) {
+	pool, err := getBootstrapPool()
+	if err != nil {
+		t.Fatalf("getBootstrapPool: %v", err)
+	}
+	starts := rollingCohortStarts(pool, rollingMinimumCohortMonths)
+	end := starts[0] + rollingMinimumCohortMonths + 6
+	short := &bootstrapPool{months: pool.months[:end], follows: pool.follows[:end]}
+
+	result, err := runRollingBacktest(short, createRollingTestInput(1000000, 40000), 360)
+	if err != nil {
+		t.Fatalf("runRollingBacktest: %v", err)
+	}
+	if len(result.Cohorts) != 1 || !result.Degenerate {
+		t.Errorf("expected one degenerate cohort, got %d (degenerate=%v)", len(result.Cohorts), result.Degenerate)
+	}
+}
+
+// TestRollingBacktestRejectsInvalidConfig verifies a bad plan config is an
+// error instead of being replaced by the defaults
+func TestRollingBacktestRejectsInvalidConfig(t *testing.T) {
+	input := createRollingTestInput(1000000, 40000)
+	input.Config.GarchSPYAlpha = 0.5
+	input.Config.GarchSPYBeta = 0.6
+
+	if _, err := RunRollingBacktest(input, 120); err == nil {
+		t.Error("expected a non-stationary GARCH config to be rejected")
+	}
+}
@@ -104,6 +104,45 @@ type BacktestResult struct {
 	MonthsSimulated    int     `json:"monthsSimulated"`
 }
 
+// RollingCohortResult is one replay of the plan starting in January of StartYear
+type RollingCohortResult struct {
+	StartYear      int     `json:"startYear"`
+	MonthsRun      int     `json:"monthsRun"`
+	Truncated      bool    `json:"truncated"` // Data ended before the window; MonthsRun < WindowMonths
+	TerminalWealth float64 `json:"terminalWealth"`
+	Depleted       bool    `json:"depleted"`
+	DepletionMonth int     `json:"depletionMonth"` // Month offset of depletion, -1 if never depleted
+	MonthsDepleted int     `json:"monthsDepleted"` // Months of the cohort's run left after depletion
+}
+
+// RollingBacktestResult summarizes the plan replayed from every available start year
+type RollingBacktestResult struct {
+	WindowMonths    int                   `json:"windowMonths"`
+	FirstStartYear  int                   `json:"firstStartYear"`
+	LastStartYear   int                   `json:"lastStartYear"`
+	Cohorts         []RollingCohortResult `json:"cohorts"`
+	DepletedCohorts int                   `json:"depletedCohorts"`
+	SuccessRate     float64               `json:"successRate"` // Share of cohorts never depleted
+
+	// Cohorts whose data ended before the window, and whether only one start
+	// year had enough data (the percentiles are then a single outcome)
+	TruncatedCohorts int  `json:"truncatedCohorts"`
+	Degenerate       bool `json:"degenerate"`
+
+	// Distribution of terminal wealth across cohorts
+	TerminalWealthP10 float64 `json:"terminalWealthP10"`
+	TerminalWealthP25 float64 `json:"terminalWealthP25"`
+	TerminalWealthP50 float64 `json:"terminalWealthP50"`
+	TerminalWealthP75 float64 `json:"terminalWealthP75"`
+	TerminalWealthP90 float64 `json:"terminalWealthP90"`
+
+	// Worst cohort: earliest depletion, or lowest terminal wealth if none depleted
+	WorstStartYear      int     `json:"worstStartYear"`
+	WorstTerminalWealth float64 `json:"worstTerminalWealth"`
+	BestStartYear       int     `json:"bestStartYear"`
+	BestTerminalWealth  float64 `json:"bestTerminalWealth"`
+}
+
 
 // ValidateBacktestResult checks if the backtesting result is within tolerance
 func ValidateBacktestResult(result BacktestResult) error {
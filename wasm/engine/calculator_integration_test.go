@@ -1,4 +1,4 @@
-package main
+package engine
 
 import (
 	"testing"
@@ -65,12 +65,12 @@ func TestContributionLimitIntegration(t *testing.T) {
 		engine.contributionLimitTracker.ResetForNewYear(2026)
 		engine.contributionLimitTracker.SetUserAge(52)
 
-		// 401k limit should be $23k + $7.5k = $30.5k
+		// 2026 401k limit should be $24.5k + $8k = $32.5k
 		requestedAmount := 35000.0
 		maxAllowed := engine.GetContributionLimit("tax_deferred", requestedAmount)
 
-		if maxAllowed != 30500.0 {
-			t.Errorf("Expected max allowed $30,500 (with catch-up), got $%.0f", maxAllowed)
+		if maxAllowed != 32500.0 {
+			t.Errorf("Expected max allowed $32,500 (with catch-up), got $%.0f", maxAllowed)
 		}
 	})
 
@@ -83,12 +83,12 @@ func TestContributionLimitIntegration(t *testing.T) {
 		firstContribution := 10000.0
 		engine.TrackContribution("tax_deferred", firstContribution)
 
-		// Try to contribute $20k more - should get $13k (to reach $23k limit)
+		// Try to contribute $20k more - should get $15k (to reach the projected $25k 2027 limit)
 		requestedAmount := 20000.0
 		maxAllowed := engine.GetContributionLimit("tax_deferred", requestedAmount)
 
-		if maxAllowed != 13000.0 {
-			t.Errorf("Expected max allowed $13,000 (to reach limit), got $%.0f", maxAllowed)
+		if maxAllowed != 15000.0 {
+			t.Errorf("Expected max allowed $15,000 (to reach limit), got $%.0f", maxAllowed)
 		}
 	})
 }
@@ -164,7 +164,7 @@ func TestStateTaxIntegration(t *testing.T) {
 
 	t.Run("Washington Capital Gains Tax", func(t *testing.T) {
 		ordinaryIncome := 50000.0
-		capitalGains := 300000.0 // Above $250k threshold
+		capitalGains := 300000.0 // Above the $278k 2025 deduction
 
 		stateTax := engine.CalculateStateTaxLiability(
 			ordinaryIncome,
@@ -174,9 +174,9 @@ func TestStateTaxIntegration(t *testing.T) {
 			0,
 		)
 
-		// WA has 7% capital gains tax on gains over $250k
-		// So tax = ($300k - $250k) * 0.07 = $3,500
-		expectedTax := 3500.0
+		// WA has 7% capital gains tax on gains over its deduction ($278k for 2025)
+		// So tax = ($300k - $278k) * 0.07 = $1,540
+		expectedTax := 1540.0
 		tolerance := 100.0
 
 		if stateTax < expectedTax-tolerance || stateTax > expectedTax+tolerance {
@@ -196,7 +196,7 @@ func TestEnforcePreTaxContributionLimits(t *testing.T) {
 
 	t.Run("Within Limit", func(t *testing.T) {
 		contribution := 15000.0
-		excess := engine.enforcePreTaxContributionLimits(&contribution, 0)
+		excess := engine.enforcePreTaxContributionLimits(&contribution, 0, "")
 
 		if excess != 0 {
 			t.Errorf("Expected no excess for $15k contribution, got $%.2f", excess)
@@ -217,19 +217,19 @@ func TestEnforcePreTaxContributionLimits(t *testing.T) {
 		t.Logf("DEBUG: Limit: $%.2f", engine.contributionLimitTracker.GetLimit("tax_deferred"))
 		t.Logf("DEBUG: YTD before: $%.2f", engine.contributionLimitTracker.GetYTDContribution("tax_deferred"))
 
-		excess := engine.enforcePreTaxContributionLimits(&contribution, 0)
+		excess := engine.enforcePreTaxContributionLimits(&contribution, 0, "")
 
 		t.Logf("DEBUG: Contribution after enforce: $%.2f", contribution)
 		t.Logf("DEBUG: Excess: $%.2f", excess)
 		t.Logf("DEBUG: YTD after: $%.2f", engine.contributionLimitTracker.GetYTDContribution("tax_deferred"))
 
-		expectedExcess := 7000.0 // $30k - $23k limit
+		expectedExcess := 6500.0 // $30k - $23.5k 2025 limit
 		if excess != expectedExcess {
 			t.Errorf("Expected excess of $%.0f, got $%.2f", expectedExcess, excess)
 		}
 
-		if contribution != 23000.0 {
-			t.Errorf("Expected contribution capped at $23k, got $%.2f", contribution)
+		if contribution != 23500.0 {
+			t.Errorf("Expected contribution capped at $23.5k, got $%.2f", contribution)
 		}
 	})
 }
@@ -245,7 +245,7 @@ func TestEnforceRothContributionLimits(t *testing.T) {
 
 	t.Run("Within Limit", func(t *testing.T) {
 		contribution := 5000.0
-		excess := engine.enforceRothContributionLimits(&contribution, 0)
+		excess := engine.enforceRothContributionLimits(&contribution, 0, "")
 
 		if excess != 0 {
 			t.Errorf("Expected no excess for $5k contribution, got $%.2f", excess)
@@ -261,7 +261,7 @@ func TestEnforceRothContributionLimits(t *testing.T) {
 		engine.contributionLimitTracker.ResetForNewYear(2025)
 
 		contribution := 10000.0
-		excess := engine.enforceRothContributionLimits(&contribution, 0)
+		excess := engine.enforceRothContributionLimits(&contribution, 0, "")
 
 		expectedExcess := 3000.0 // $10k - $7k limit
 		if excess != expectedExcess {
@@ -279,22 +279,22 @@ func TestEnforceRothContributionLimits(t *testing.T) {
 
 		// First contribution: $4k
 		firstContribution := 4000.0
-		excess1 := engine.enforceRothContributionLimits(&firstContribution, 0)
+		excess1 := engine.enforceRothContributionLimits(&firstContribution, 0, "")
 
 		if excess1 != 0 || firstContribution != 4000.0 {
 			t.Errorf("First contribution should be fully allowed")
 		}
 
-		// Second contribution: $5k (should only allow $3k)
+		// Second contribution: $5k (should only allow $3.5k of the $7.5k 2026 limit)
 		secondContribution := 5000.0
-		excess2 := engine.enforceRothContributionLimits(&secondContribution, 6)
+		excess2 := engine.enforceRothContributionLimits(&secondContribution, 6, "")
 
-		if excess2 != 2000.0 {
-			t.Errorf("Expected $2k excess on second contribution, got $%.2f", excess2)
+		if excess2 != 1500.0 {
+			t.Errorf("Expected $1.5k excess on second contribution, got $%.2f", excess2)
 		}
 
-		if secondContribution != 3000.0 {
-			t.Errorf("Expected second contribution capped at $3k, got $%.2f", secondContribution)
+		if secondContribution != 3500.0 {
+			t.Errorf("Expected second contribution capped at $3.5k, got $%.2f", secondContribution)
 		}
 	})
 }
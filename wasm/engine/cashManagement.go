@@ -1,4 +1,4 @@
-package main
+package engine
 
 import (
 	"fmt"
@@ -12,6 +12,10 @@ type CashManager struct {
 	nextLotID    int
 	config       *StochasticModelConfig
 	marketPrices *MarketPrices // Current market prices for share-based calculations
+
+	// PERF: When true, SellAssetsFromAccountFIFO computes totals without materializing
+	// SoldLots/SaleTransactions slices (MC mode never reads them)
+	SummaryOnly bool
 }
 
 // NewCashManager creates a new cash manager
@@ -54,7 +58,7 @@ func (cm *CashManager) getPricePerShare(assetClass AssetClass, marketPrices *Mar
 		return marketPrices.BND, nil
 	case AssetClassInternationalStocks:
 		return marketPrices.INTL, nil
-	case AssetClassRealEstatePrimaryHome:
+	case AssetClassRealEstatePrimaryHome, AssetClassRealEstateRental:
 		return marketPrices.RealEstate, nil
 	case AssetClassIndividualStock:
 		return marketPrices.Individual, nil
@@ -275,9 +279,10 @@ func (cm *CashManager) UpdateLotTermStatus(accounts *AccountHoldingsMonthEnd, cu
 
 // SellAssetsFromAccountFIFO sells assets using FIFO methodology with liquidity-aware prioritization using current market prices
 func (cm *CashManager) SellAssetsFromAccountFIFO(account *Account, targetAmount float64, currentMonth int) LotSaleResult {
-	result := LotSaleResult{
-		SoldLots:         make([]TaxLot, 0, 10),          // Pre-allocate with reasonable capacity
-		SaleTransactions: make([]SaleTransaction, 0, 10), // Pre-allocate with reasonable capacity
+	var result LotSaleResult
+	if !cm.SummaryOnly {
+		result.SoldLots = make([]TaxLot, 0, 10)
+		result.SaleTransactions = make([]SaleTransaction, 0, 10)
 	}
 
 	if account == nil || targetAmount <= 0 {
@@ -348,52 +353,65 @@ func (cm *CashManager) SellAssetsFromAccountFIFO(account *Account, targetAmount
 			sellQuantity := sellAmount / currentPrice
 
 			if sellQuantity > 0 {
-				// Create sale transaction
-				saleResult := cm.createSaleTransaction(lot, sellQuantity, currentPrice, currentMonth)
-				result.SaleTransactions = append(result.SaleTransactions, saleResult)
+				// PERF: In summary-only mode, compute totals inline without materializing
+				// SaleTransaction/SoldLot objects (saves string building + struct allocs in MC)
+				costBasis := sellQuantity * lot.CostBasisPerUnit
+				grossProceeds := sellQuantity * currentPrice
+				transactionCost := cm.calculateTransactionCost(grossProceeds)
+				netProceeds := grossProceeds - transactionCost
+				gainLoss := netProceeds - costBasis
+
+				if !cm.SummaryOnly {
+					saleResult := cm.createSaleTransaction(lot, sellQuantity, currentPrice, currentMonth)
+					result.SaleTransactions = append(result.SaleTransactions, saleResult)
+				}
 
 				simLogVerbose("🔍 SALE TRANSACTION: Asset=%s, Quantity=%.2f, SalePrice=%.2f, Proceeds=%.2f, CostBasis=%.2f, Gain=%.2f, IsLongTerm=%v",
-					saleResult.AssetClass, sellQuantity, currentPrice, saleResult.Proceeds, saleResult.CostBasis, saleResult.RealizedGainLoss, saleResult.IsLongTerm)
+					lot.AssetClass, sellQuantity, currentPrice, netProceeds, costBasis, gainLoss, lot.IsLongTerm)
 
 				// Update totals
-				result.TotalProceeds += saleResult.Proceeds
-				result.TotalCostBasis += saleResult.CostBasis
-				result.TotalRealizedGains += saleResult.RealizedGainLoss
+				result.TotalProceeds += netProceeds
+				result.TotalCostBasis += costBasis
+				result.TotalRealizedGains += gainLoss
 
-				if saleResult.RealizedGainLoss > 0 { // Only positive gains
-					if saleResult.IsLongTerm {
-						result.LongTermGains += saleResult.RealizedGainLoss
+				if gainLoss > 0 { // Only positive gains
+					if lot.IsLongTerm {
+						result.LongTermGains += gainLoss
 					} else {
-						result.ShortTermGains += saleResult.RealizedGainLoss
+						result.ShortTermGains += gainLoss
 					}
 				}
 
 				// Update lot
 				if sellQuantity >= lot.Quantity {
 					// Completely sold this lot
-					result.SoldLots = append(result.SoldLots, lot)
+					if !cm.SummaryOnly {
+						result.SoldLots = append(result.SoldLots, lot)
+					}
 					lotsToRemove = append(lotsToRemove, j)
 				} else {
 					// Partially sold this lot - create a new lot representing the sold portion
-					partialLot := TaxLot{
-						ID:                lot.ID + "_partial_" + strconv.Itoa(cm.nextLotID),
-						AssetClass:        lot.AssetClass,
-						Quantity:          sellQuantity,
-						CostBasisPerUnit:  lot.CostBasisPerUnit,
-						CostBasisTotal:    lot.CostBasisPerUnit * sellQuantity,
-						AcquisitionDate:   lot.AcquisitionDate,
-						IsLongTerm:        lot.IsLongTerm,
-						WashSalePeriodEnd: lot.WashSalePeriodEnd,
+					if !cm.SummaryOnly {
+						partialLot := TaxLot{
+							ID:                lot.ID + "_partial_" + strconv.Itoa(cm.nextLotID),
+							AssetClass:        lot.AssetClass,
+							Quantity:          sellQuantity,
+							CostBasisPerUnit:  lot.CostBasisPerUnit,
+							CostBasisTotal:    lot.CostBasisPerUnit * sellQuantity,
+							AcquisitionDate:   lot.AcquisitionDate,
+							IsLongTerm:        lot.IsLongTerm,
+							WashSalePeriodEnd: lot.WashSalePeriodEnd,
+						}
+						cm.nextLotID++
+						result.SoldLots = append(result.SoldLots, partialLot)
 					}
-					cm.nextLotID++
-					result.SoldLots = append(result.SoldLots, partialLot)
 
 					simLogVerbose("🔍 PARTIAL LOT SALE: Original lot ID=%s, quantity=%.2f, cost=%.2f. Selling quantity=%.2f, cost=%.2f",
-						lot.ID, lot.Quantity, lot.CostBasisTotal, sellQuantity, partialLot.CostBasisTotal)
+						lot.ID, lot.Quantity, lot.CostBasisTotal, sellQuantity, costBasis)
 
 					// Update remaining lot
 					holding.Lots[j].Quantity -= sellQuantity
-					holding.Lots[j].CostBasisTotal -= partialLot.CostBasisTotal
+					holding.Lots[j].CostBasisTotal -= costBasis
 				}
 
 				remainingToSell -= sellAmount
@@ -557,7 +575,7 @@ func (cm *CashManager) SellFromInvestmentsOnly(accounts *AccountHoldingsMonthEnd
 	return totalResult
 }
 
-// executeConventionalWithdrawal implements "Conventional" strategy: Cash -> Taxable -> Tax-Deferred -> Roth
+// executeConventionalWithdrawal implements "Conventional" strategy: Cash -> Taxable -> Tax-Deferred -> Roth -> HSA
 func (cm *CashManager) executeConventionalWithdrawal(accounts *AccountHoldingsMonthEnd, targetAmount float64, currentMonth int) (LotSaleResult, float64) {
 	simLogVerbose("CONVENTIONAL-WITHDRAWAL ENTRY: Target $%.0f", targetAmount)
 
@@ -607,6 +625,16 @@ func (cm *CashManager) executeConventionalWithdrawal(accounts *AccountHoldingsMo
 		simLogVerbose("CONVENTIONAL-WITHDRAWAL Step 4: Sold $%.0f from Roth, remaining $%.0f", rothResult.TotalProceeds, remainingNeeded)
 	}
 
+	// Step 5: Withdraw from HSA (tax-free only against medical receipts, penalized before 65)
+	if hsaAccount := GetHSAAccount(accounts); remainingNeeded > 0 && hsaAccount != nil && hsaAccount.TotalValue > 0 {
+		simLogVerbose("CONVENTIONAL-WITHDRAWAL Step 5: Selling from HSA, value $%.0f", hsaAccount.TotalValue)
+		hsaResult := cm.SellAssetsFromAccountFIFO(hsaAccount, remainingNeeded, currentMonth)
+		totalResult.HSAProceeds += hsaResult.TotalProceeds
+		cm.mergeSaleResults(&totalResult, hsaResult)
+		remainingNeeded -= hsaResult.TotalProceeds
+		simLogVerbose("CONVENTIONAL-WITHDRAWAL Step 5: Sold $%.0f from HSA, remaining $%.0f", hsaResult.TotalProceeds, remainingNeeded)
+	}
+
 	totalRaised := totalResult.TotalProceeds + totalResult.CashUsed
 	simLogVerbose("CONVENTIONAL-WITHDRAWAL FINAL: TotalProceeds $%.0f, cashUsed $%.0f, totalRaised $%.0f (taxDeferred=$%.0f, taxable=$%.0f, roth=$%.0f)",
 		totalResult.TotalProceeds, totalResult.CashUsed, totalRaised, totalResult.TaxDeferredProceeds, totalResult.TaxableProceeds, totalResult.RothProceeds)
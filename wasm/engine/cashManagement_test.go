@@ -1,4 +1,4 @@
-package main
+package engine
 
 import (
 	"math"
@@ -222,3 +222,28 @@ func TestTaxLossHarvesting(t *testing.T) {
 		t.Errorf("Expected negative realized gains (losses), got %.2f", result.TotalRealizedGains)
 	}
 }
+
+// TestFIFOSummaryOnly verifies summary-only sales report the same totals without the per-lot detail
+func TestFIFOSummaryOnly(t *testing.T) {
+	sell := func(summaryOnly bool) LotSaleResult {
+		cm := NewCashManager()
+		cm.SummaryOnly = summaryOnly
+		account := &Account{Holdings: []Holding{}, TotalValue: 0}
+		cm.AddHoldingWithLotTracking(account, AssetClassUSStocksTotalMarket, 10000, 1)
+		cm.AddHoldingWithLotTracking(account, AssetClassUSStocksTotalMarket, 5000, 6)
+		cm.AddHoldingWithLotTracking(account, AssetClassUSBondsTotalMarket, 8000, 12)
+		return cm.SellAssetsFromAccountFIFO(account, 17000, 15)
+	}
+
+	detailed, summary := sell(false), sell(true)
+	if len(detailed.SaleTransactions) == 0 || len(detailed.SoldLots) == 0 {
+		t.Fatal("expected per-lot detail outside summary-only mode")
+	}
+	if summary.SaleTransactions != nil || summary.SoldLots != nil {
+		t.Errorf("summary-only sale built %d transactions and %d lots", len(summary.SaleTransactions), len(summary.SoldLots))
+	}
+	if summary.TotalProceeds != detailed.TotalProceeds || summary.TotalCostBasis != detailed.TotalCostBasis ||
+		summary.TotalRealizedGains != detailed.TotalRealizedGains || summary.ShortTermGains != detailed.ShortTermGains {
+		t.Errorf("summary-only totals %+v differ from %+v", summary, detailed)
+	}
+}
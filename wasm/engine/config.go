@@ -1,4 +1,4 @@
-package main
+package engine
 
 // GetDefaultStochasticConfig returns a default stochastic model configuration
 // This function is available for both WASM builds and tests
@@ -1,4 +1,4 @@
-package main
+package engine
 
 import (
     "embed"
@@ -53,6 +53,10 @@ func LoadEmbeddedFinancialConfig() error {
     if err := readJSON("rmd_table_2024.json", &rmd); err != nil { return err }
     rmdTableConfig = &rmd
 
+    var life LifeTableConfig
+    if err := readJSON("life_table_2021.json", &life); err != nil { return err }
+    lifeTableConfig = &life
+
     var contrib ContributionLimitsConfig
     if err := readJSON("contribution_limits_2025.json", &contrib); err != nil { return err }
     contributionConfig = &contrib
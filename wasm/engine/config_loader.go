@@ -1,4 +1,4 @@
-package main
+package engine
 
 import (
 	"encoding/json"
@@ -42,6 +42,15 @@ type RMDTableConfig struct {
 	} `json:"rmdLifeExpectancyTable"`
 }
 
+// LifeTableConfig represents the external period life table configuration
+type LifeTableConfig struct {
+	MortalityTable []struct {
+		Age    int     `json:"age"`
+		Male   float64 `json:"male"`   // qx: probability of dying before age+1
+		Female float64 `json:"female"`
+	} `json:"mortalityTable"`
+}
+
 // ContributionLimitsConfig represents the external contribution limits configuration
 type ContributionLimitsConfig struct {
 	RetirementContributions struct {
@@ -386,6 +395,7 @@ type DividendModelConfig struct {
 var (
 	taxBracketsConfig     *TaxBracketsConfig
 	rmdTableConfig        *RMDTableConfig
+	lifeTableConfig       *LifeTableConfig
 	contributionConfig    *ContributionLimitsConfig
 	ficaTaxConfig         *FICATaxConfig
 	stateTaxConfig        *StateTaxBracketsConfig
@@ -418,6 +428,12 @@ func LoadFinancialConfigFromFiles(configDir string) error {
 		return fmt.Errorf("failed to load RMD table config: %w", err)
 	}
 
+	// Load period life table
+	lifeTableConfig, err = loadLifeTableConfig(filepath.Join(configDir, "life_table_2021.json"))
+	if err != nil {
+		return fmt.Errorf("failed to load life table config: %w", err)
+	}
+
 	// Load contribution limits
 	contributionConfig, err = loadContributionLimitsConfig(filepath.Join(configDir, "contribution_limits_2025.json"))
 	if err != nil {
@@ -539,6 +555,21 @@ func loadRMDTableConfig(filePath string) (*RMDTableConfig, error) {
 	return &config, nil
 }
 
+func loadLifeTableConfig(filePath string) (*LifeTableConfig, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var config LifeTableConfig
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse life table config: %w", err)
+	}
+
+	return &config, nil
+}
+
 func loadContributionLimitsConfig(filePath string) (*ContributionLimitsConfig, error) {
 	data, err := ioutil.ReadFile(filePath)
 	if err != nil {
@@ -728,6 +759,31 @@ func GetRMDLifeExpectancy(age int) (float64, bool) {
 	return 0, false
 }
 
+// GetMortalityRate returns the one-year death probability (qx) at age from the period life table.
+// sex is "male" or "female"; any other value averages the two. Ages past the table end return 1.
+func GetMortalityRate(age int, sex string) (float64, error) {
+	if lifeTableConfig == nil || len(lifeTableConfig.MortalityTable) == 0 {
+		return 0, fmt.Errorf("life table configuration not loaded")
+	}
+	table := lifeTableConfig.MortalityTable
+	if age < table[0].Age {
+		age = table[0].Age
+	}
+	for _, entry := range table {
+		if entry.Age == age {
+			switch sex {
+			case "male":
+				return entry.Male, nil
+			case "female":
+				return entry.Female, nil
+			default:
+				return (entry.Male + entry.Female) / 2, nil
+			}
+		}
+	}
+	return 1, nil
+}
+
 // GetSocialSecurityWageBase returns the SS wage base from config
 func GetSocialSecurityWageBase() float64 {
 
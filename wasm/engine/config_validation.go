@@ -49,6 +49,11 @@ func (cv *ConfigurationValidator) ValidateAllConfigurations() error {
 		return fmt.Errorf("FICA tax validation failed: %w", err)
 	}
 
+	// 7. Validate period life table
+	if err := cv.validateLifeTableConfig(); err != nil {
+		return fmt.Errorf("life table validation failed: %w", err)
+	}
+
 	simLogVerbose("✅ [CONFIG] All configuration validations passed - engine ready for accurate simulation")
 	return nil
 }
@@ -203,6 +208,35 @@ func (cv *ConfigurationValidator) validateRMDTableConfig() error {
 	return nil
 }
 
+// validateLifeTableConfig ensures lifespan draws cover every age and terminate
+func (cv *ConfigurationValidator) validateLifeTableConfig() error {
+	if lifeTableConfig == nil {
+		return fmt.Errorf("CRITICAL: life table configuration not loaded")
+	}
+
+	table := lifeTableConfig.MortalityTable
+	if len(table) == 0 || table[0].Age != 0 {
+		return fmt.Errorf("CRITICAL: life table must start at age 0")
+	}
+
+	for i, entry := range table {
+		if entry.Age != i {
+			return fmt.Errorf("CRITICAL: life table ages must be consecutive, got %d at position %d", entry.Age, i)
+		}
+		if entry.Male < 0 || entry.Male > 1 || entry.Female < 0 || entry.Female > 1 {
+			return fmt.Errorf("CRITICAL: invalid mortality rate at age %d - must be between 0 and 1", entry.Age)
+		}
+	}
+
+	last := table[len(table)-1]
+	if last.Male != 1 || last.Female != 1 {
+		return fmt.Errorf("CRITICAL: life table must end with a terminal age (qx = 1), got age %d", last.Age)
+	}
+
+	simLogVerbose("✅ [CONFIG] Life table validation passed - mortality rates confirmed")
+	return nil
+}
+
 // validateFICAConfig ensures Social Security and Medicare calculations are accurate
 func (cv *ConfigurationValidator) validateFICAConfig() error {
 	if ficaTaxConfig == nil {
@@ -9,7 +9,8 @@ import (
  * Contribution Limit Tracker
  *
  * Enforces IRS contribution limits for retirement accounts to ensure plan compliance.
- * Updated annually based on IRS announcements and inflation adjustments.
+ * Limits by year come from the tax-year registry (contribution_limits_<year>.json),
+ * indexed forward past the latest announced year.
  *
  * Key Limits (2024):
  * - 401(k)/403(b)/457: $23,000 + $7,500 catch-up (age 50+)
@@ -76,10 +77,10 @@ type ContributionLimitTracker struct {
 	hasFamily             bool // For HSA family vs individual limit
 }
 
-// NewContributionLimitTracker creates a new tracker with 2024 IRS limits
+// NewContributionLimitTracker creates a new tracker with the current year's IRS limits
 func NewContributionLimitTracker() *ContributionLimitTracker {
 	return &ContributionLimitTracker{
-		limits: GetContributionLimits(2024),
+		limits: GetContributionLimits(time.Now().Year()),
 		trackingYear: time.Now().Year(),
 		ytdTaxDeferred: 0,
 		ytdIRA: 0,
@@ -91,31 +92,10 @@ func NewContributionLimitTracker() *ContributionLimitTracker {
 	}
 }
 
-// GetContributionLimits returns IRS contribution limits for a given year
+// GetContributionLimits returns IRS contribution limits for a given year.
+// 2024 onward come from the tax-year registry: published years as announced,
+// later years indexed at the default threshold rate.
 func GetContributionLimits(year int) ContributionLimits {
-	// 2024 limits (most current)
-	if year >= 2024 {
-		return ContributionLimits{
-			Year: year,
-			DeferredContributionLimit: 23000,
-			DeferredCatchUpLimit: 7500,
-			DeferredCatchUpAge: 50,
-			IRAContributionLimit: 7000,
-			IRACatchUpLimit: 1000,
-			IRACatchUpAge: 50,
-			HSAIndividualLimit: 4150,
-			HSAFamilyLimit: 8300,
-			HSACatchUpLimit: 1000,
-			HSACatchUpAge: 55,
-			SIMPLEContributionLimit: 16000,
-			SIMPLECatchUpLimit: 3500,
-			SIMPLECatchUpAge: 50,
-			SEPContributionLimit: 69000,
-			SEPCompensationPercentage: 0.25,
-			OverallDCPlanLimit: 69000,
-		}
-	}
-
 	// 2023 limits
 	if year == 2023 {
 		return ContributionLimits{
@@ -139,8 +119,11 @@ func GetContributionLimits(year int) ContributionLimits {
 		}
 	}
 
-	// Default to 2024 for future years (will need annual updates)
-	return GetContributionLimits(2024)
+	params, err := LookupTaxYear(year, FilingStatusSingle, defaultTaxThresholdInflationRate, "")
+	if err != nil {
+		panic("CRITICAL: contribution limit data not available: " + err.Error())
+	}
+	return params.ContributionLimits
 }
 
 // SetUserAge updates the user's age for catch-up eligibility calculations
@@ -1,7 +1,7 @@
 //go:build production
 // +build production
 
-package main
+package engine
 
 // Production build - all debug functions are no-ops
 // Build with: go build -tags production
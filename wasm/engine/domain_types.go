@@ -1,6 +1,6 @@
 // Core domain types for the financial simulation engine
 
-package main
+package engine
 
 import "encoding/json"
 
@@ -34,6 +34,10 @@ type LiabilityInfo struct {
 	PMIAnnual                  float64 `json:"pmiAnnual,omitempty"`                  // Annual Private Mortgage Insurance
 	PropertyTaxDeductible      bool    `json:"propertyTaxDeductible,omitempty"`      // Whether property tax is tax-deductible
 	MortgageInterestDeductible bool    `json:"mortgageInterestDeductible,omitempty"` // Whether mortgage interest is tax-deductible
+
+	// Refinance and prepayment tracking
+	InterestSaved  float64 `json:"interestSaved,omitempty"`  // Projected interest avoided vs. the schedule each refinance or prepayment replaced
+	RefinanceCosts float64 `json:"refinanceCosts,omitempty"` // Closing costs paid or rolled into principal on refinances
 }
 
 // AssetClass represents different types of assets
@@ -45,6 +49,7 @@ const (
 	AssetClassUSBondsTotalMarket    AssetClass = "bonds"
 	AssetClassInternationalStocks   AssetClass = "international_stocks"
 	AssetClassRealEstatePrimaryHome AssetClass = "real_estate_primary_home"
+	AssetClassRealEstateRental      AssetClass = "real_estate_rental"
 	AssetClassLeveragedSPY          AssetClass = "leveraged_spy"
 	AssetClassOtherAssets           AssetClass = "otherAssets"
 	AssetClassIndividualStock       AssetClass = "individual_stock"
@@ -62,6 +67,8 @@ func NormalizeAssetClass(assetClass AssetClass) AssetClass {
 		return AssetClassInternationalStocks // "international_stocks" (same)
 	case "real_estate_primary_home":
 		return AssetClassRealEstatePrimaryHome // "real_estate_primary_home" (same)
+	case "real_estate_rental":
+		return AssetClassRealEstateRental // "real_estate_rental" (same)
 	case "leveraged_spy":
 		return AssetClassLeveragedSPY // "leveraged_spy" (same)
 	case "other_assets", "otherAssets":
@@ -102,6 +109,10 @@ type StochasticState struct {
 	// Withdrawal guardrails state
 	LastWithdrawalAmount           float64 `json:"lastWithdrawalAmount"`
 	PortfolioValueAtLastWithdrawal float64 `json:"portfolioValueAtLastWithdrawal"`
+
+	// Historical bootstrap state: position of the last sampled month in the pool
+	BootstrapIndex   int  `json:"bootstrapIndex,omitempty"`
+	BootstrapInBlock bool `json:"bootstrapInBlock,omitempty"`
 }
 
 // StochasticReturns defines monthly stochastic returns for various asset classes
@@ -124,6 +135,30 @@ type GuardrailConfig struct {
 	SpendingBonusPct float64 `json:"spendingBonusPct"`
 }
 
+// StressShock is a scripted market shock overlaid on the generated returns (see stress_overlay.go)
+type StressShock struct {
+	Name            string            `json:"name,omitempty"`
+	Preset          string            `json:"preset,omitempty"`          // "dot_com_2000" | "gfc_2008" | "stagflation_1970s"; unset fields come from the preset
+	StartMonth      int               `json:"startMonth"`                // Month offset where the drawdown begins
+	DurationMonths  int               `json:"durationMonths,omitempty"`  // Months from peak to trough
+	RecoveryMonths  int               `json:"recoveryMonths,omitempty"`  // Months to recover the drawdown (0 = permanent loss)
+	RecoveryShape   string            `json:"recoveryShape,omitempty"`   // "linear" (default) | "v" (front-loaded) | "u" (back-loaded)
+	AssetShocks     StressAssetShocks `json:"assetShocks"`               // Peak-to-trough change by asset
+	ExcessInflation float64           `json:"excessInflation,omitempty"` // Annual inflation added on top of the model
+	InflationMonths int               `json:"inflationMonths,omitempty"` // Months the excess inflation lasts (0 = DurationMonths)
+}
+
+// StressAssetShocks is the cumulative change per asset over a shock's drawdown
+// (-0.5 = -50%, positive for flight-to-quality gains)
+type StressAssetShocks struct {
+	SPY             float64 `json:"spy,omitempty"`
+	BND             float64 `json:"bnd,omitempty"`
+	Intl            float64 `json:"intl,omitempty"`
+	Other           float64 `json:"other,omitempty"`
+	IndividualStock float64 `json:"individualStock,omitempty"`
+	Home            float64 `json:"home,omitempty"`
+}
+
 // StochasticModelConfig contains all parameters for the stochastic simulation
 type StochasticModelConfig struct {
 	// Asset returns
@@ -219,7 +254,10 @@ type StochasticModelConfig struct {
 
 	// Seeded stochastic simulation
 	RandomSeed     int64  `json:"randomSeed,omitempty"`     // 0 = use crypto/rand (non-reproducible), >0 = seeded PCG32 (reproducible)
-	SimulationMode string `json:"simulationMode,omitempty"` // "deterministic" | "stochastic" - controls return generation
+	SimulationMode string `json:"simulationMode,omitempty"` // "deterministic" | "stochastic" | "historical_bootstrap" - controls return generation
+
+	// Historical bootstrap: mean length in months of the resampled blocks (0 = 12)
+	BootstrapMeanBlockLength float64 `json:"bootstrapMeanBlockLength,omitempty"`
 
 	// Cash floor for breach detection (default 0 means breach = going negative)
 	CashFloor float64 `json:"cashFloor,omitempty"` // End Cash < CashFloor triggers breach
@@ -228,13 +266,23 @@ type StochasticModelConfig struct {
 	// Defaults to 2.5% if not set (0). Set to 0.0 explicitly via -1 sentinel to disable.
 	TaxThresholdInflationRate float64 `json:"taxThresholdInflationRate,omitempty"`
 
+	// Tax-law schedule applied on top of the published/projected tax years:
+	// "" or "current_law" | "tcja_sunset" (pre-2018 rates and deductions from 2026)
+	TaxLawSchedule string `json:"taxLawSchedule,omitempty"`
+
+	// Sequence-of-returns stress: scripted shocks applied on top of the generated returns
+	StressScenarios []StressShock `json:"stressScenarios,omitempty"`
+
 	// Performance optimization: LiteMode skips expensive features for Bronze tier
 	// - Skips GARCH volatility (uses constant volatility)
 	// - Skips tax lot tracking (tracks total values only)
-	// - Skips IRMAA calculations
 	// - Uses simplified withdrawal sequencing
 	LiteMode bool `json:"liteMode,omitempty"`
 
+	// PERF: Number of goroutines used to run Monte Carlo paths on native builds
+	// 0 = auto (GOMAXPROCS), 1 = serial. WASM builds always run serially.
+	MonteCarloWorkers int `json:"monteCarloWorkers,omitempty"`
+
 	// PERF: Cached Cholesky decomposition (computed once per simulation, not per month)
 	// This avoids recomputing O(n^3) decomposition every month
 	CachedCholeskyMatrix [][]float64 `json:"-"` // Not serialized - computed at runtime
@@ -557,6 +605,19 @@ type SimulationInput struct {
 	CashStrategy       *CashManagementStrategy `json:"cashStrategy,omitempty"`
 	StrategySettings   *StrategySettings       `json:"strategySettings,omitempty"` // Dynamic strategy configuration
 	TaxConfig          *SimpleTaxConfig        `json:"taxConfig,omitempty"`        // Simplified tax config for Bronze tier
+	Household          *Household              `json:"household,omitempty"`        // Two-person household; nil models InitialAge alone
+	Longevity          *LongevityConfig        `json:"longevity,omitempty"`        // Per-path lifespan draws; MonthsToRun becomes the maximum horizon
+	HSA                *HSAConfig              `json:"hsa,omitempty"`              // HSA coverage and how medical bills are paid
+	FiveTwoNinePlans   []FiveTwoNinePlan       `json:"fiveTwoNinePlans,omitempty"` // 529 beneficiaries and their part of the initial balance
+
+	// Rule that sets retirement spending each year (see withdrawal_policy.go); nil spends only the events
+	WithdrawalPolicy *WithdrawalPolicyConfig `json:"withdrawalPolicy,omitempty"`
+
+	// Contributions (and conversions over five years old) in the initial Roth balance; 0 = the Roth's cost basis
+	RothContributionBasis float64 `json:"rothContributionBasis,omitempty"`
+
+	// MAGI (AGI plus tax-exempt interest) for calendar years before StartYear; sets IRMAA for the first two years
+	MAGIHistory map[int]float64 `json:"magiHistory,omitempty"`
 }
 
 // MonthlyDataSimulation represents simulation results for a single month
@@ -568,6 +629,10 @@ type MonthlyDataSimulation struct {
 	Liabilities []*LiabilityInfo        `json:"liabilities"`
 	Returns     StochasticReturns       `json:"returns"`
 
+	// Price index at the end of the month (1.0 at the start) and net worth in today's dollars
+	PriceIndex   float64 `json:"priceIndex"`
+	NetWorthReal float64 `json:"netWorthReal"`
+
 	// Monthly flow tracking for UI projection
 	IncomeThisMonth                     float64           `json:"incomeThisMonth"`
 	EmploymentIncomeThisMonth           float64           `json:"employmentIncomeThisMonth"`
@@ -620,6 +685,7 @@ type MonthlyDataSimulation struct {
 	TaxPaidAnnual                  *float64 `json:"taxPaidAnnual,omitempty"`
 	RMDAmountAnnual                *float64 `json:"rmdAmountAnnual,omitempty"`
 	IRMAAMedicarePremiumAdjustment *float64 `json:"irmaaMedicarePremiumAdjustment,omitempty"`
+	MedicarePremiumsAnnual         *float64 `json:"medicarePremiumsAnnual,omitempty"` // Part B and D premiums paid, IRMAA included
 	CapitalLossCarryoverEndYear    *float64 `json:"capitalLossCarryoverEndYear,omitempty"`
 	ActiveFilingStatus             *string  `json:"activeFilingStatus,omitempty"`
 	ActiveNumDependents            *int     `json:"activeNumDependents,omitempty"`
@@ -641,6 +707,13 @@ type MonthlyDataSimulation struct {
 	AdditionalMedicareTaxAnnual *float64 `json:"additionalMedicareTaxAnnual,omitempty"`
 	TotalFICATaxAnnual          *float64 `json:"totalFicaTaxAnnual,omitempty"`
 
+	// ACA premium tax credit reconciliation (populated in December of years with marketplace coverage)
+	ACAModifiedAGIAnnual                    *float64 `json:"acaModifiedAgiAnnual,omitempty"`
+	PremiumTaxCreditAnnual                  *float64 `json:"premiumTaxCreditAnnual,omitempty"`
+	AdvancePremiumTaxCreditAnnual           *float64 `json:"advancePremiumTaxCreditAnnual,omitempty"`
+	ExcessAdvanceCreditRepaymentAnnual      *float64 `json:"excessAdvanceCreditRepaymentAnnual,omitempty"`
+	PremiumTaxCreditLostToConversionsAnnual *float64 `json:"premiumTaxCreditLostToConversionsAnnual,omitempty"`
+
 	// Savings analysis fields
 	AvailableForSavings   float64 `json:"availableForSavings,omitempty"`
 	SavingsRate           float64 `json:"savingsRate,omitempty"`
@@ -754,6 +827,8 @@ type PlanSummary struct {
 	ProbabilityOfBankruptcy float64          `json:"probabilityOfBankruptcy,omitempty"` // 0.0 to 1.0
 	BankruptcyCount         int              `json:"bankruptcyCount,omitempty"`         // Number of paths that went bankrupt
 	NumberOfRuns            int              `json:"numberOfRuns,omitempty"`            // Total Monte Carlo runs
+	Longevity               *LongevitySummary `json:"longevity,omitempty"`              // Outcomes conditional on being alive (longevity model only)
+	Spending                *SpendingSummary  `json:"spending,omitempty"`               // Realized retirement spending (withdrawal policy only)
 }
 
 // GoalOutcome represents the achievement probability for a specific goal
@@ -798,6 +873,11 @@ type PortfolioStats struct {
 	P5FinalValue  float64 `json:"p5FinalValue,omitempty"`
 	P95FinalValue float64 `json:"p95FinalValue,omitempty"`
 
+	// Final value percentiles in today's dollars
+	P10FinalValueReal float64 `json:"p10FinalValueReal"`
+	P50FinalValueReal float64 `json:"p50FinalValueReal"`
+	P90FinalValueReal float64 `json:"p90FinalValueReal"`
+
 	// Min cash KPIs (across all successful paths)
 	MinCashP5  float64 `json:"minCashP5,omitempty"`
 	MinCashP50 float64 `json:"minCashP50,omitempty"`
@@ -875,6 +955,13 @@ type NetWorthTimeSeriesPoint struct {
 	P75  float64 `json:"p75"`
 	P90  float64 `json:"p90"`
 	Mean *float64 `json:"mean,omitempty"`
+
+	// The same percentiles in today's dollars
+	RealP10 float64 `json:"realP10"`
+	RealP25 float64 `json:"realP25"`
+	RealP50 float64 `json:"realP50"`
+	RealP75 float64 `json:"realP75"`
+	RealP90 float64 `json:"realP90"`
 }
 
 // NetWorthChartSummary contains chart optimization hints
@@ -1024,6 +1111,9 @@ type SpreadsheetYearData struct {
 	Taxes    SpreadsheetPercentiles  `json:"taxes"`
 	Savings  SpreadsheetPercentiles  `json:"savings"`
 	NetWorth SpreadsheetPercentiles  `json:"netWorth"`
+
+	// Year-end net worth in today's dollars
+	NetWorthReal SpreadsheetPercentiles `json:"netWorthReal"`
 }
 
 // SpreadsheetPercentiles contains p10, p50, p90 values for a metric
@@ -1233,6 +1323,8 @@ type AnnualDeepDiveSnapshot struct {
 	// High-level metrics
 	NetWorth         float64              `json:"netWorth"`
 	NetWorthChangeYoY NetWorthChangeYoY   `json:"netWorthChangeYoY"`
+	NetWorthReal     float64              `json:"netWorthReal"` // Today's dollars
+	PriceIndex       float64              `json:"priceIndex"`   // Year-end price index (1.0 at the start)
 
 	// Complete balance sheet - grounded in simulation engine data
 	BalanceSheet     BalanceSheet         `json:"balanceSheet"`
@@ -1245,6 +1337,10 @@ type AnnualDeepDiveSnapshot struct {
 	HealthcareExpenses float64 `json:"healthcareExpenses,omitempty"`
 	TaxesPaid          float64 `json:"taxesPaid,omitempty"`
 
+	// Medicare premiums paid (part of HealthcareExpenses) and the IRMAA surcharge within them
+	MedicarePremiums float64 `json:"medicarePremiums,omitempty"`
+	IRMAASurcharge   float64 `json:"irmaaSurcharge,omitempty"`
+
 	// Divestment proceeds from forced asset sales (calculated by simulation engine)
 	DivestmentProceeds float64            `json:"divestmentProceeds"`
 
@@ -1407,6 +1503,10 @@ type SimulationResult struct {
 	Error          string                  `json:"error,omitempty"`
 	Metadata       map[string]interface{}  `json:"metadata,omitempty"`
 
+	// Final net worth in today's dollars, deflated by the path's final price index
+	FinalNetWorthReal float64 `json:"finalNetWorthReal,omitempty"`
+	FinalPriceIndex   float64 `json:"finalPriceIndex,omitempty"`
+
 	// Enhanced bankruptcy and financial stress data
 	IsBankrupt              bool    `json:"isBankrupt,omitempty"`
 	BankruptcyMonth         int     `json:"bankruptcyMonth,omitempty"`
@@ -1424,9 +1524,19 @@ type SimulationResult struct {
 	MinCash                float64 `json:"minCash,omitempty"`                // Minimum cash observed (-1 if not tracked)
 	MinCashMonth           int     `json:"minCashMonth,omitempty"`           // Month when minimum cash occurred
 	CashFloorBreachedMonth int     `json:"cashFloorBreachedMonth,omitempty"` // Month when cash breached floor (-1 if never)
+	MonthsSimulated        int     `json:"monthsSimulated,omitempty"`        // Months actually run (shorter than MonthsToRun after a death or bankruptcy)
 
 	// Year-end net worth checkpoints for cross-sectional exemplar path selection
 	YearEndNetWorth []float64 `json:"-"` // Not serialized — internal use only
+
+	// Lifespans drawn for this path and the estate at the last death (longevity model only)
+	Lifespan *PathLifespan `json:"lifespan,omitempty"`
+
+	// Spending realized by the withdrawal policy (withdrawal policy only)
+	Spending *PathSpending `json:"spending,omitempty"`
+
+	// Federal, state and penalty tax on every return settled during the path
+	LifetimeTax float64 `json:"lifetimeTax,omitempty"`
 }
 
 // FinancialStressEvent tracks significant financial stress events during simulation
@@ -1478,6 +1588,15 @@ type SimulationResults struct {
 	FinalNetWorthP5  float64 `json:"finalNetWorthP5,omitempty"`
 	FinalNetWorthP95 float64 `json:"finalNetWorthP95,omitempty"`
 
+	// Final net worth in today's dollars, each path deflated by its own price index
+	FinalNetWorthRealP5  float64 `json:"finalNetWorthRealP5,omitempty"`
+	FinalNetWorthRealP10 float64 `json:"finalNetWorthRealP10"`
+	FinalNetWorthRealP25 float64 `json:"finalNetWorthRealP25"`
+	FinalNetWorthRealP50 float64 `json:"finalNetWorthRealP50"`
+	FinalNetWorthRealP75 float64 `json:"finalNetWorthRealP75"`
+	FinalNetWorthRealP90 float64 `json:"finalNetWorthRealP90"`
+	FinalNetWorthRealP95 float64 `json:"finalNetWorthRealP95,omitempty"`
+
 	// Min cash KPIs (across all successful paths)
 	MinCashP5  float64 `json:"minCashP5,omitempty"`
 	MinCashP50 float64 `json:"minCashP50,omitempty"`
@@ -1511,21 +1630,28 @@ type SimulationResults struct {
 	SuccessfulPaths int   `json:"successfulPaths,omitempty"` // Paths with valid data (denominator for percentiles)
 	FailedPaths     int   `json:"failedPaths,omitempty"`     // Paths that errored/produced no data
 
+	// Lifespan, estate and alive-conditional outcomes (longevity model only)
+	Longevity *LongevitySummary `json:"longevity,omitempty"`
+
+	// Distribution of spending realized by the withdrawal policy (withdrawal policy only)
+	Spending *SpendingSummary `json:"spending,omitempty"`
+
 	Error string `json:"error,omitempty"`
 }
 
 // MCPathMetrics captures per-path metrics for aggregation (internal, not exported to JSON)
 type MCPathMetrics struct {
-	PathIndex         int
-	PathSeed          int64
-	TerminalWealth    float64
-	MinCash           float64
-	MinCashMonth      int
-	RunwayMonths      int  // Months until first cash floor breach (-1 if never)
-	CashFloorBreached bool
-	IsBankrupt        bool
-	BankruptcyMonth   int
-	YearEndNetWorth   []float64 // Net worth at each year-end checkpoint (for exemplar selection)
+	PathIndex          int
+	PathSeed           int64
+	TerminalWealth     float64
+	TerminalWealthReal float64 // Terminal wealth in today's dollars
+	MinCash            float64
+	MinCashMonth       int
+	RunwayMonths       int  // Months until first cash floor breach (-1 if never)
+	CashFloorBreached  bool
+	IsBankrupt         bool
+	BankruptcyMonth    int
+	YearEndNetWorth    []float64 // Net worth at each year-end checkpoint (for exemplar selection)
 }
 
 // MCBreachProbability tracks cumulative first-breach probability over time
@@ -1567,6 +1693,11 @@ type NetWorthTrajectoryPoint struct {
 	P50          float64 `json:"p50"` // Median
 	P75          float64 `json:"p75"`
 	P90          float64 `json:"p90,omitempty"`
+	RealP10      float64 `json:"realP10"` // Same percentiles in today's dollars
+	RealP25      float64 `json:"realP25,omitempty"`
+	RealP50      float64 `json:"realP50"`
+	RealP75      float64 `json:"realP75"`
+	RealP90      float64 `json:"realP90,omitempty"`
 	PctPathsFunded float64 `json:"pctPathsFunded"` // % of paths still funded (spending sustainable) at this age
 	SpendingP10    float64 `json:"spendingP10,omitempty"`    // Annual spending 10th percentile
 	SpendingP50    float64 `json:"spendingP50,omitempty"`    // Annual spending median
@@ -1653,6 +1784,7 @@ type LotSaleResult struct {
 	TaxDeferredProceeds float64 `json:"taxDeferredProceeds"` // Amount from tax-deferred (taxed as ordinary income)
 	TaxableProceeds     float64 `json:"taxableProceeds"`     // Amount from taxable (only gains taxed)
 	RothProceeds        float64 `json:"rothProceeds"`        // Amount from Roth (tax-free)
+	HSAProceeds         float64 `json:"hsaProceeds"`         // Amount from HSA (tax-free against medical receipts)
 	CashUsed            float64 `json:"cashUsed"`            // Amount from cash (no tax)
 }
 
@@ -1786,6 +1918,9 @@ const (
 	// System events
 	PriorityTimeStep EventPriority = 10
 
+	// Residence changes (before the month's income and expenses)
+	PriorityRelocation EventPriority = 20
+
 	// Income events
 	PriorityPensionIncome  EventPriority = 25
 	PrioritySocialSecurity EventPriority = 28
@@ -1928,7 +2063,7 @@ func (mp *MarketPrices) GetPriceForAssetClass(assetClass AssetClass) float64 {
 		return mp.BND
 	case AssetClassInternationalStocks:
 		return mp.INTL
-	case AssetClassRealEstatePrimaryHome:
+	case AssetClassRealEstatePrimaryHome, AssetClassRealEstateRental:
 		return mp.RealEstate
 	case AssetClassIndividualStock:
 		return mp.Individual
@@ -2107,6 +2242,8 @@ type DeterministicMonthSnapshot struct {
 
 	// Balances at end of month
 	NetWorth           float64 `json:"netWorth"`
+	NetWorthReal       float64 `json:"netWorthReal"` // Today's dollars
+	PriceIndex         float64 `json:"priceIndex"`   // 1.0 at the start
 	CashBalance        float64 `json:"cashBalance"`
 	TaxableBalance     float64 `json:"taxableBalance"`
 	TaxDeferredBalance float64 `json:"taxDeferredBalance"`
@@ -2160,6 +2297,7 @@ type DeterministicYearData struct {
 	Age              int     `json:"age"`
 	StartNetWorth    float64 `json:"startNetWorth"`
 	EndNetWorth      float64 `json:"endNetWorth"`
+	EndNetWorthReal  float64 `json:"endNetWorthReal"` // Today's dollars
 	NetWorthChange   float64 `json:"netWorthChange"`
 	TotalIncome      float64 `json:"totalIncome"`
 	TotalExpenses    float64 `json:"totalExpenses"`
@@ -2187,8 +2325,10 @@ type DeterministicResults struct {
 
 	// Final state
 	FinalNetWorth float64 `json:"finalNetWorth"`
+	FinalNetWorthReal float64 `json:"finalNetWorthReal"` // Today's dollars
 	IsBankrupt    bool    `json:"isBankrupt"`
 	BankruptcyMonth int   `json:"bankruptcyMonth,omitempty"`
+	Spending      *PathSpending `json:"spending,omitempty"` // Realized retirement spending (withdrawal policy only)
 
 	// Comprehensive monthly state for enhanced spreadsheet view
 	ComprehensiveMonthlyStates []DeterministicMonthState `json:"comprehensiveMonthlyStates,omitempty"`
@@ -2263,6 +2403,16 @@ type ComprehensiveTaxState struct {
 	TaxWithholdingYTD    float64 `json:"taxWithholdingYTD"`
 	EstimatedPaymentsYTD float64 `json:"estimatedPaymentsYTD"`
 
+	// Additional taxes on non-qualified distributions
+	PenaltyTaxYTD float64 `json:"penaltyTaxYTD"`
+
+	// HSA medical receipts not yet reimbursed (tax-free to withdraw at any age)
+	HSAUnreimbursedReceipts float64 `json:"hsaUnreimbursedReceipts"`
+
+	// Roth basis withdrawable before 59½: contributions, and conversions once seasoned five years
+	RothContributionBasis float64              `json:"rothContributionBasis"`
+	RothConversions       []RothConversionRung `json:"rothConversions,omitempty"`
+
 	// Accrual tracking
 	UnpaidTaxLiability float64 `json:"unpaidTaxLiability"`
 
@@ -2297,6 +2447,11 @@ type ComprehensiveLiabilityState struct {
 	TotalInterestRemaining float64 `json:"totalInterestRemaining"` // Sum of remaining interest over life of loan
 	PayoffDate             string  `json:"payoffDate"`             // Estimated payoff date YYYY-MM
 
+	// Refinance/prepayment comparison against the schedule each change replaced
+	InterestSavedVsSchedule float64 `json:"interestSavedVsSchedule"` // Projected interest avoided (negative if a change costs interest)
+	RefinanceCosts          float64 `json:"refinanceCosts"`          // Closing costs of refinances
+	NetSavingsVsSchedule    float64 `json:"netSavingsVsSchedule"`    // Interest saved less closing costs
+
 	// Flags
 	IsTaxDeductible bool `json:"isTaxDeductible"`
 	IsActive        bool `json:"isActive"`
@@ -2375,6 +2530,9 @@ type DeterministicMonthState struct {
 	HSA         *ComprehensiveAccountState `json:"hsa,omitempty"`
 	FiveTwoNine *ComprehensiveAccountState `json:"fiveTwoNine,omitempty"`
 
+	// 529 balances by beneficiary
+	FiveTwoNinePlans []FiveTwoNineBeneficiaryState `json:"fiveTwoNinePlans,omitempty"`
+
 	// Aggregates (derived)
 	NetWorth         float64 `json:"netWorth"`
 	TotalAssets      float64 `json:"totalAssets"`
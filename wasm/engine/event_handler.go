@@ -1,4 +1,4 @@
-package main
+package engine
 
 import (
 	"fmt"
@@ -74,6 +74,7 @@ func (h *IncomeEventHandler) Process(event FinancialEvent, accounts *AccountHold
 
 	se.currentMonthFlows.EmploymentIncomeThisMonth += event.Amount
 	se.employmentIncomeYTD += event.Amount
+	se.recordWages(event.Metadata, event.Amount)
 	// fmt.Printf("🔴 [INCOME-HANDLER] Updated EmploymentIncomeThisMonth\n")
 
 	se.currentMonthFlows.TaxWithheldThisMonth += salaryWithholding
@@ -514,11 +515,12 @@ func (h *ScheduledContributionEventHandler) Process(event FinancialEvent, accoun
 	// Use the actual contribution amount (which may be less than requested due to cash constraints)
 	contributionAmount := actualContribution
 	excessAmount := 0.0
+	owner := getStringFromMetadata(event.Metadata, "owner", "")
 
 	// Apply contribution limits based on account type
 	switch targetAccount {
 	case "tax_deferred":
-		excessAmount = se.enforcePreTaxContributionLimits(&contributionAmount, currentMonth)
+		excessAmount = se.enforcePreTaxContributionLimits(&contributionAmount, currentMonth, owner)
 		// Track allowed pre-tax contributions for tax calculations
 		if contributionAmount > 0 {
 			se.preTaxContributionsYTD += contributionAmount
@@ -530,12 +532,22 @@ func (h *ScheduledContributionEventHandler) Process(event FinancialEvent, accoun
 			}
 		}
 	case "roth":
-		excessAmount = se.enforceRothContributionLimits(&contributionAmount, currentMonth)
+		excessAmount = se.enforceRothContributionLimits(&contributionAmount, currentMonth, owner)
+	case "hsa":
+		excessAmount = se.enforceHSAContributionLimits(&contributionAmount, currentMonth, owner)
+		se.preTaxContributionsYTD += contributionAmount
 	}
 
 	// Process the allowed contribution amount
 	if contributionAmount > 0 {
-		if err := se.processInvestmentContributionWithFIFO(accounts, contributionAmount, targetAccount, assetClass, currentMonth); err != nil {
+		// 529 contributions also credit the beneficiary's share and basis
+		var err error
+		if targetAccount == "529" {
+			err = se.contributeToFiveTwoNine(accounts, getStringFromMetadata(event.Metadata, "beneficiary", ""), contributionAmount, assetClass, currentMonth)
+		} else {
+			err = se.processInvestmentContributionWithFIFO(accounts, contributionAmount, targetAccount, assetClass, currentMonth)
+		}
+		if err != nil {
 			// CRITICAL: Holdings creation failed - restore the cash that was deducted
 			simLogVerbose("❌ [CONTRIBUTION-FAILED] Failed to create holding: %v - restoring $%.2f to cash", err, contributionAmount)
 			accounts.Cash += contributionAmount  // Restore cash
@@ -549,8 +561,11 @@ func (h *ScheduledContributionEventHandler) Process(event FinancialEvent, accoun
 			se.currentMonthFlows.ContributionsTaxableThisMonth += contributionAmount
 		case "tax_deferred":
 			se.currentMonthFlows.ContributionsTaxDeferredThisMonth += contributionAmount
+			se.recordTaxDeferredOwnership(owner, contributionAmount, accounts)
 		case "roth":
 			se.currentMonthFlows.ContributionsRothThisMonth += contributionAmount
+		case "hsa":
+			se.currentMonthFlows.ContributionsHSAThisMonth += contributionAmount
 		}
 	}
 
@@ -650,7 +665,11 @@ func (h *RothConversionEventHandler) Process(event FinancialEvent, accounts *Acc
 		accounts.TaxDeferred.TotalValue, accounts.Roth.TotalValue)
 
 	// STEP 1: Sell assets from tax-deferred account using FIFO
+	// The Roth re-buys the mix that was sold, so keep the per-lot sales even in MC mode
+	summaryOnly := se.cashManager.SummaryOnly
+	se.cashManager.SummaryOnly = false
 	saleResult := se.cashManager.SellAssetsFromAccountFIFO(accounts.TaxDeferred, conversionAmount, currentMonth)
+	se.cashManager.SummaryOnly = summaryOnly
 
 	if saleResult.TotalProceeds < conversionAmount {
 		return fmt.Errorf("unable to sell sufficient assets for conversion: need %.2f, sold %.2f",
@@ -706,6 +725,10 @@ func (h *RothConversionEventHandler) Process(event FinancialEvent, accounts *Acc
 	// STEP 6: Deduct the conversion amount from cash
 	accounts.Cash -= conversionAmount
 
+	// Each conversion starts its own five-year clock for penalty-free withdrawal
+	se.recordRothConversion(conversionAmount, currentMonth)
+	se.currentMonthFlows.RothConversionAmountThisMonth += conversionAmount
+
 	simLogVerbose("🔍 [ROTH-CONVERSION] Post-conversion balances: TaxDeferred=%.2f, Roth=%.2f, Cash=%.2f",
 		accounts.TaxDeferred.TotalValue, accounts.Roth.TotalValue, accounts.Cash)
 
@@ -729,13 +752,9 @@ func (h *SocialSecurityIncomeEventHandler) Process(event FinancialEvent, account
 	isColaAdjusted := getBoolFromMetadata(event.Metadata, "isColaAdjusted", false)
 	adjustedAmount := event.Amount
 
-	if isColaAdjusted && se.currentMonthReturns != nil {
-		// Apply COLA adjustment based on inflation rate
-		// IMPROVED: Use precise float division instead of integer division
-		yearsSinceStart := float64(currentMonth) / 12.0
-		if yearsSinceStart > 0 {
-			adjustedAmount = event.Amount * math.Pow(1+se.currentMonthReturns.Inflation, yearsSinceStart)
-		}
+	if isColaAdjusted {
+		// Annual COLAs follow the path's realized inflation through the prior year
+		adjustedAmount = event.Amount * se.colaIndex(currentMonth)
 	}
 
 	accounts.Cash += adjustedAmount
@@ -771,13 +790,9 @@ func (h *PensionIncomeEventHandler) Process(event FinancialEvent, accounts *Acco
 	isColaAdjusted := getBoolFromMetadata(event.Metadata, "isColaAdjusted", false)
 	adjustedAmount := event.Amount
 
-	if isColaAdjusted && se.currentMonthReturns != nil {
-		// Apply COLA adjustment based on inflation rate
-		// IMPROVED: Use precise float division instead of integer division
-		yearsSinceStart := float64(currentMonth) / 12.0
-		if yearsSinceStart > 0 {
-			adjustedAmount = event.Amount * math.Pow(1+se.currentMonthReturns.Inflation, yearsSinceStart)
-		}
+	if isColaAdjusted {
+		// Annual COLAs follow the path's realized inflation through the prior year
+		adjustedAmount = event.Amount * se.colaIndex(currentMonth)
 	}
 
 	accounts.Cash += adjustedAmount
@@ -787,6 +802,7 @@ func (h *PensionIncomeEventHandler) Process(event FinancialEvent, accounts *Acco
 	se.currentMonthFlows.PensionIncomeThisMonth += adjustedAmount // Track separately for Trace View
 	// Pension income is typically fully taxable
 	se.ProcessIncome(adjustedAmount, false, 0)
+	se.pensionIncomeYTD += adjustedAmount
 
 	// Level 1 (EVENT): One-line event summary
 	if isColaAdjusted {
@@ -1008,7 +1024,7 @@ func (h *LongTermCarePayoutEventHandler) Process(event FinancialEvent, accounts
 
 // Education Event Handlers
 
-// FiveTwoNineContributionEventHandler handles 529 plan contributions
+// FiveTwoNineContributionEventHandler handles 529 plan contributions for the beneficiary in metadata "beneficiary"
 type FiveTwoNineContributionEventHandler struct{}
 
 func (h *FiveTwoNineContributionEventHandler) Process(event FinancialEvent, accounts *AccountHoldingsMonthEnd, cashFlow *float64, context *EventProcessingContext) error {
@@ -1019,17 +1035,13 @@ func (h *FiveTwoNineContributionEventHandler) Process(event FinancialEvent, acco
 		return fmt.Errorf("insufficient cash for 529 contribution: have $%.2f, need $%.2f", accounts.Cash, event.Amount)
 	}
 
-	// Initialize 529 account if it doesn't exist
-	if accounts.FiveTwoNine == nil {
-		accounts.FiveTwoNine = &Account{
-			Holdings:   []Holding{},
-			TotalValue: 0.0,
-		}
+	// Transfer cash to 529 holdings, adding to the beneficiary's share and basis
+	beneficiary := getStringFromMetadata(event.Metadata, "beneficiary", "")
+	assetClass := NormalizeAssetClass(AssetClass(getStringFromMetadata(event.Metadata, "assetClass", string(AssetClassUSStocksTotalMarket))))
+	if err := se.contributeToFiveTwoNine(accounts, beneficiary, event.Amount, assetClass, context.CurrentMonth); err != nil {
+		return fmt.Errorf("529 contribution failed: %w", err)
 	}
-
-	// Transfer cash to 529 account
 	accounts.Cash -= event.Amount
-	accounts.FiveTwoNine.TotalValue += event.Amount
 	*cashFlow -= event.Amount
 
 	// Track contribution for monthly flows
@@ -1046,46 +1058,89 @@ func (h *FiveTwoNineContributionEventHandler) Process(event FinancialEvent, acco
 	// State tax deductions would be handled separately based on state-specific rules
 
 	// Level 1 (EVENT): One-line event summary
-	simLogEvent("INFO  [Month %d] Event: 529_CONTRIBUTION | Amount: $%.2f | Result: Cash -$%.2f, 529 +$%.2f",
-		context.CurrentMonth, event.Amount, event.Amount, event.Amount)
+	simLogEvent("INFO  [Month %d] Event: 529_CONTRIBUTION | Beneficiary: %s | Amount: $%.2f | Result: Cash -$%.2f, 529 +$%.2f",
+		context.CurrentMonth, beneficiary, event.Amount, event.Amount, event.Amount)
 
 	return nil
 }
 
-// FiveTwoNineWithdrawalEventHandler handles 529 plan withdrawals
+// FiveTwoNineWithdrawalEventHandler handles 529 plan withdrawals from the beneficiary in metadata
+// "beneficiary". Withdrawals are tax-free up to the beneficiary's qualified expenses for the year;
+// the earnings in the rest are taxed with a 10% additional tax at year end.
 type FiveTwoNineWithdrawalEventHandler struct{}
 
 func (h *FiveTwoNineWithdrawalEventHandler) Process(event FinancialEvent, accounts *AccountHoldingsMonthEnd, cashFlow *float64, context *EventProcessingContext) error {
 	se := context.SimulationEngine
+	beneficiary := getStringFromMetadata(event.Metadata, "beneficiary", "")
 
-	// Check if 529 account exists and has sufficient balance
-	if accounts.FiveTwoNine == nil || accounts.FiveTwoNine.TotalValue < event.Amount {
-		available := 0.0
-		if accounts.FiveTwoNine != nil {
-			available = accounts.FiveTwoNine.TotalValue
-		}
+	// Check the beneficiary has a sufficient 529 balance
+	b := se.fiveTwoNineBeneficiary(beneficiary, context.CurrentMonth)
+	if available := fiveTwoNineBalance(b, accounts); available < event.Amount {
 		return fmt.Errorf("insufficient 529 balance for withdrawal: have $%.2f, need $%.2f", available, event.Amount)
 	}
 
-	// Use proper FIFO selling to transfer from 529 account to cash (tax-free for qualified education expenses)
-	saleResult := se.cashManager.SellAssetsFromAccountFIFO(accounts.FiveTwoNine, event.Amount, context.CurrentMonth)
-	accounts.Cash += saleResult.TotalProceeds
-	*cashFlow += event.Amount
+	// Use proper FIFO selling to transfer from 529 account to cash
+	proceeds := se.withdrawFromFiveTwoNine(accounts, b, event.Amount, context.CurrentMonth)
+	*cashFlow += proceeds
 
 	// Track as one-time income event
-	se.currentMonthFlows.OneTimeEventsImpactThisMonth += event.Amount
+	se.currentMonthFlows.OneTimeEventsImpactThisMonth += proceeds
 
-	// Record in ledger as tax-free income (assuming qualified withdrawal)
-	if err := se.ledger.RecordIncome(event.Amount, "529_withdrawal"); err != nil {
+	// Record in ledger as income; the tax on non-qualified use is settled at year end
+	if err := se.ledger.RecordIncome(proceeds, "529_withdrawal"); err != nil {
 		simLogVerbose("Warning: Failed to record 529 withdrawal in ledger: %v", err)
 	}
 
-	// Note: For qualified education expenses, 529 withdrawals are tax-free
-	// Non-qualified withdrawals would incur taxes + 10% penalty on earnings (not implemented here)
+	// Level 1 (EVENT): One-line event summary
+	simLogEvent("INFO  [Month %d] Event: 529_WITHDRAWAL | Beneficiary: %s | Amount: $%.2f | Result: 529 -$%.2f, Cash +$%.2f (qualified expenses YTD $%.2f of $%.2f withdrawn)",
+		context.CurrentMonth, b.name, event.Amount, proceeds, proceeds, b.qualifiedExpensesYTD, b.distributionsYTD)
+
+	return nil
+}
+
+// FiveTwoNineRothRolloverEventHandler rolls a beneficiary's 529 into their Roth IRA (SECURE 2.0).
+// Amount 0 rolls the most allowed this year.
+type FiveTwoNineRothRolloverEventHandler struct{}
+
+func (h *FiveTwoNineRothRolloverEventHandler) Process(event FinancialEvent, accounts *AccountHoldingsMonthEnd, cashFlow *float64, context *EventProcessingContext) error {
+	se := context.SimulationEngine
+	b := se.fiveTwoNineBeneficiary(getStringFromMetadata(event.Metadata, "beneficiary", ""), context.CurrentMonth)
+
+	// Limited by the 15-year, seasoning and lifetime rules, then the beneficiary's IRA room
+	allowed := se.rothRolloverLimit(b, accounts, context.CurrentMonth)
+	if event.Amount > 0 {
+		allowed = math.Min(allowed, event.Amount)
+	}
+	tracker, intoPlanRoth := se.rothRolloverTracker(b, context.CurrentMonth)
+	allowed = tracker.GetMaxAllowedContribution("roth", allowed)
+	if allowed <= 0 {
+		simLogVerbose("529-ROTH-ROLLOVER-SKIP Event %s: no rollover room for beneficiary %q", event.ID, b.name)
+		return nil
+	}
+
+	proceeds, _ := se.sellFromFiveTwoNine(accounts, b, allowed, context.CurrentMonth)
+	if err := tracker.TrackContribution("roth", proceeds); err != nil {
+		simLogVerbose("Warning: Failed to track 529 Roth rollover: %v", err)
+	}
+	b.rothRollovers += proceeds
+
+	// A trustee-to-trustee transfer: not a distribution, so neither taxed nor penalized
+	destination := "Roth"
+	if intoPlanRoth {
+		assetClass := NormalizeAssetClass(AssetClass(getStringFromMetadata(event.Metadata, "assetClass", string(AssetClassUSStocksTotalMarket))))
+		if err := se.processInvestmentContributionWithFIFO(accounts, proceeds, "roth", assetClass, context.CurrentMonth); err != nil {
+			return fmt.Errorf("529 Roth rollover failed: %w", err)
+		}
+	} else {
+		destination = "beneficiary's Roth (outside the plan)"
+		if err := se.ledger.RecordExpense(proceeds, "529_roth_rollover"); err != nil {
+			simLogVerbose("Warning: Failed to record 529 Roth rollover in ledger: %v", err)
+		}
+	}
 
 	// Level 1 (EVENT): One-line event summary
-	simLogEvent("INFO  [Month %d] Event: 529_WITHDRAWAL | Amount: $%.2f | Result: 529 -$%.2f, Cash +$%.2f (tax-free)",
-		context.CurrentMonth, event.Amount, event.Amount, saleResult.TotalProceeds)
+	simLogEvent("INFO  [Month %d] Event: 529_ROTH_ROLLOVER | Beneficiary: %s | Amount: $%.2f | Result: 529 -$%.2f, %s +$%.2f (lifetime $%.0f of $%.0f)",
+		context.CurrentMonth, b.name, proceeds, proceeds, destination, proceeds, b.rothRollovers, float64(fiveTwoNineRothLifetimeLimit))
 
 	return nil
 }
@@ -1101,22 +1156,32 @@ func (h *HSAContributionEventHandler) Process(event FinancialEvent, accounts *Ac
 		return fmt.Errorf("insufficient cash for HSA contribution: have $%.2f, need $%.2f", accounts.Cash, event.Amount)
 	}
 
-	// Initialize HSA account if it doesn't exist
-	if accounts.HSA == nil {
-		accounts.HSA = &Account{
-			Holdings:   []Holding{},
-			TotalValue: 0.0,
-		}
-	}
+	// Cap at the self-only or family limit; the excess is invested in the taxable account
+	contributionAmount := event.Amount
+	excessAmount := se.enforceHSAContributionLimits(&contributionAmount, context.CurrentMonth, getStringFromMetadata(event.Metadata, "owner", ""))
+	assetClass := NormalizeAssetClass(AssetClass(getStringFromMetadata(event.Metadata, "assetClass", string(AssetClassUSStocksTotalMarket))))
 
-	// Transfer cash to HSA account
+	// Transfer cash to HSA holdings
 	accounts.Cash -= event.Amount
-	accounts.HSA.TotalValue += event.Amount
 	*cashFlow -= event.Amount
+	if err := se.processInvestmentContributionWithFIFO(accounts, contributionAmount, "hsa", assetClass, context.CurrentMonth); err != nil {
+		accounts.Cash += event.Amount
+		*cashFlow += event.Amount
+		return fmt.Errorf("HSA contribution failed: %w", err)
+	}
+	if err := se.processInvestmentContributionWithFIFO(accounts, excessAmount, "taxable", assetClass, context.CurrentMonth); err != nil {
+		accounts.Cash += excessAmount
+		*cashFlow += excessAmount
+		return fmt.Errorf("excess HSA contribution routing failed: %w", err)
+	}
+
+	// HSA contributions are deducted from ordinary income like pre-tax 401(k) deferrals
+	se.preTaxContributionsYTD += contributionAmount
 
 	// Track contribution for monthly flows
 	se.currentMonthFlows.ContributionsToInvestmentsThisMonth += event.Amount
-	se.currentMonthFlows.ContributionsHSAThisMonth += event.Amount // Track separately for Trace View
+	se.currentMonthFlows.ContributionsHSAThisMonth += contributionAmount // Track separately for Trace View
+	se.currentMonthFlows.ContributionsTaxableThisMonth += excessAmount
 
 	// Record in ledger
 	if err := se.ledger.RecordExpense(event.Amount, "hsa_contribution"); err != nil {
@@ -1129,47 +1194,62 @@ func (h *HSAContributionEventHandler) Process(event FinancialEvent, accounts *Ac
 	// 3. Tax-free withdrawals for qualified medical expenses
 
 	// Level 1 (EVENT): One-line event summary
-	simLogEvent("INFO  [Month %d] Event: HSA_CONTRIBUTION | Amount: $%.2f | Result: Cash -$%.2f, HSA +$%.2f (tax-deductible)",
-		context.CurrentMonth, event.Amount, event.Amount, event.Amount)
+	simLogEvent("INFO  [Month %d] Event: HSA_CONTRIBUTION | Amount: $%.2f | Result: Cash -$%.2f, HSA +$%.2f (tax-deductible), Taxable +$%.2f (over limit)",
+		context.CurrentMonth, event.Amount, event.Amount, contributionAmount, excessAmount)
 
 	return nil
 }
 
-// HSAWithdrawalEventHandler handles HSA withdrawals (tax-free for qualified medical expenses)
+// HSAWithdrawalEventHandler handles HSA withdrawals. Withdrawals for a current medical bill
+// (qualifiedMedical) or against saved receipts are tax-free; the rest is ordinary income plus
+// the 20% additional tax before 65. reimburseReceipts withdraws every saved receipt.
 type HSAWithdrawalEventHandler struct{}
 
 func (h *HSAWithdrawalEventHandler) Process(event FinancialEvent, accounts *AccountHoldingsMonthEnd, cashFlow *float64, context *EventProcessingContext) error {
 	se := context.SimulationEngine
 
+	amount := event.Amount
+	if getBoolFromMetadata(event.Metadata, "reimburseReceipts", false) {
+		amount = se.hsaReceipts
+		if accounts.HSA != nil {
+			amount = math.Min(amount, accounts.HSA.TotalValue)
+		}
+		if amount <= 0 {
+			return nil
+		}
+	}
+
 	// Check if HSA account exists and has sufficient balance
-	if accounts.HSA == nil || accounts.HSA.TotalValue < event.Amount {
+	if accounts.HSA == nil || accounts.HSA.TotalValue < amount {
 		available := 0.0
 		if accounts.HSA != nil {
 			available = accounts.HSA.TotalValue
 		}
-		return fmt.Errorf("insufficient HSA balance for withdrawal: have $%.2f, need $%.2f", available, event.Amount)
+		return fmt.Errorf("insufficient HSA balance for withdrawal: have $%.2f, need $%.2f", available, amount)
 	}
 
-	// Use proper FIFO selling to transfer from HSA account to cash (tax-free for qualified medical expenses)
-	saleResult := se.cashManager.SellAssetsFromAccountFIFO(accounts.HSA, event.Amount, context.CurrentMonth)
+	// Use proper FIFO selling to transfer from HSA account to cash
+	saleResult := se.cashManager.SellAssetsFromAccountFIFO(accounts.HSA, amount, context.CurrentMonth)
 	accounts.Cash += saleResult.TotalProceeds
-	*cashFlow += event.Amount
+	*cashFlow += saleResult.TotalProceeds
 
 	// Track as one-time income event
-	se.currentMonthFlows.OneTimeEventsImpactThisMonth += event.Amount
+	se.currentMonthFlows.OneTimeEventsImpactThisMonth += saleResult.TotalProceeds
 
-	// Record in ledger as tax-free income (assuming qualified medical withdrawal)
-	if err := se.ledger.RecordIncome(event.Amount, "hsa_withdrawal"); err != nil {
-		simLogVerbose("Warning: Failed to record HSA withdrawal in ledger: %v", err)
+	// A withdrawal paying a current medical bill is qualified outright; otherwise receipts cover it first
+	nonQualified, penalty := 0.0, 0.0
+	if !getBoolFromMetadata(event.Metadata, "qualifiedMedical", false) {
+		nonQualified, penalty = se.recordHSADistribution(saleResult.TotalProceeds, context.CurrentMonth)
 	}
 
-	// Note: For qualified medical expenses, HSA withdrawals are tax-free at any age
-	// After age 65, HSA can be used for non-medical expenses (taxed as ordinary income, no penalty)
-	// Non-qualified withdrawals before age 65 incur taxes + 20% penalty (not implemented here)
+	// Record in ledger
+	if err := se.ledger.RecordIncome(saleResult.TotalProceeds, "hsa_withdrawal"); err != nil {
+		simLogVerbose("Warning: Failed to record HSA withdrawal in ledger: %v", err)
+	}
 
 	// Level 1 (EVENT): One-line event summary
-	simLogEvent("INFO  [Month %d] Event: HSA_WITHDRAWAL | Amount: $%.2f | Result: HSA -$%.2f, Cash +$%.2f (tax-free)",
-		context.CurrentMonth, event.Amount, event.Amount, saleResult.TotalProceeds)
+	simLogEvent("INFO  [Month %d] Event: HSA_WITHDRAWAL | Amount: $%.2f | Result: HSA -$%.2f, Cash +$%.2f (non-qualified $%.2f, additional tax $%.2f)",
+		context.CurrentMonth, amount, amount, saleResult.TotalProceeds, nonQualified, penalty)
 
 	return nil
 }
@@ -1180,9 +1260,18 @@ type TuitionPaymentEventHandler struct{}
 func (h *TuitionPaymentEventHandler) Process(event FinancialEvent, accounts *AccountHoldingsMonthEnd, cashFlow *float64, context *EventProcessingContext) error {
 	se := context.SimulationEngine
 
+	// Tuition is a qualified expense for the beneficiary's 529 withdrawals this year;
+	// fundFrom529 withdraws it from their 529 first
+	b := se.fiveTwoNineBeneficiary(getStringFromMetadata(event.Metadata, "beneficiary", ""), context.CurrentMonth)
+	b.qualifiedExpensesYTD += event.Amount
+	fromFiveTwoNine := 0.0
+	if getBoolFromMetadata(event.Metadata, "fundFrom529", false) {
+		fromFiveTwoNine = se.withdrawFromFiveTwoNine(accounts, b, event.Amount, context.CurrentMonth)
+	}
+
 	// Tuition payment reduces cash
 	accounts.Cash -= event.Amount
-	*cashFlow -= event.Amount
+	*cashFlow -= event.Amount - fromFiveTwoNine
 
 	// Track as expense
 	se.currentMonthFlows.ExpensesThisMonth += event.Amount
@@ -1193,8 +1282,8 @@ func (h *TuitionPaymentEventHandler) Process(event FinancialEvent, accounts *Acc
 	}
 
 	// Level 1 (EVENT): One-line event summary
-	simLogEvent("INFO  [Month %d] Event: TUITION_PAYMENT | Amount: $%.2f | Result: Cash -$%.2f",
-		context.CurrentMonth, event.Amount, event.Amount)
+	simLogEvent("INFO  [Month %d] Event: TUITION_PAYMENT | Amount: $%.2f | Result: Cash -$%.2f, 529 -$%.2f",
+		context.CurrentMonth, event.Amount, event.Amount-fromFiveTwoNine, fromFiveTwoNine)
 
 	return nil
 }
@@ -1424,6 +1513,7 @@ func (h *WithdrawalEventHandler) Process(event FinancialEvent, accounts *Account
 
 		// Tax-deferred withdrawals are taxed as ordinary income
 		se.ProcessIncome(saleResult.TotalProceeds, false, 0) // Ordinary income, no withholding yet
+		se.retirementDistributionsYTD += saleResult.TotalProceeds
 	case "roth":
 		// Initialize roth account if needed
 		if accounts.Roth == nil {
@@ -1462,7 +1552,8 @@ func (h *WithdrawalEventHandler) Process(event FinancialEvent, accounts *Account
 		// Track divestment proceeds
 		se.currentMonthFlows.DivestmentProceedsThisMonth += saleResult.TotalProceeds
 
-		// Roth withdrawals are generally tax-free (assuming qualified)
+		// Tax-free from contributions and seasoned conversions; otherwise owed with the return before 59½
+		se.recordRothDistribution(saleResult.TotalProceeds, context.CurrentMonth)
 	default:
 		return fmt.Errorf("unsupported source account type for withdrawal: %s", sourceAccount)
 	}
@@ -1542,6 +1633,7 @@ func (h *TransferEventHandler) Process(event FinancialEvent, accounts *AccountHo
 		// Tax-deferred transfers trigger ordinary income tax
 		if targetAccount != "roth" {
 			se.ProcessIncome(saleResult.TotalProceeds, false, 0)
+			se.retirementDistributionsYTD += saleResult.TotalProceeds
 		}
 
 	case "roth":
@@ -1,6 +1,10 @@
-package main
+package engine
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"strings"
+)
 
 // RebalancePortfolioEventHandler handles portfolio rebalancing events
 type RebalancePortfolioEventHandler struct{}
@@ -74,12 +78,18 @@ type HealthcareCostEventHandler struct{}
 func (h *HealthcareCostEventHandler) Process(event FinancialEvent, accounts *AccountHoldingsMonthEnd, cashFlow *float64, context *EventProcessingContext) error {
 	se := context.SimulationEngine
 
+	// Long-term care costs follow the state the household lives in
+	amount := se.longTermCareCost(event.Amount, event.Metadata)
+
+	// Qualified medical expenses come out of the HSA tax-free unless it is being shoeboxed
+	fromHSA := se.payMedicalExpenseFromHSA(amount, accounts, context.CurrentMonth)
+
 	// Process healthcare expenses
-	accounts.Cash -= event.Amount
-	*cashFlow -= event.Amount
-	se.currentMonthFlows.HealthcareExpensesThisMonth += event.Amount
+	accounts.Cash -= amount - fromHSA
+	*cashFlow -= amount - fromHSA
+	se.currentMonthFlows.HealthcareExpensesThisMonth += amount
 	// Healthcare costs may be tax-deductible
-	se.processHealthcareExpense(event.Amount, event.Metadata)
+	se.processHealthcareExpense(amount, event.Metadata)
 
 	return nil
 }
@@ -409,9 +419,12 @@ func (h *RealEstatePurchaseEventHandler) Process(event FinancialEvent, accounts
 	}
 
 	// CRITICAL FIX: Use true share-based model even for real estate
-	// Real estate should be modeled as fractional "shares" of the property
-	// with a standard price per "share" to maintain mathematical consistency
-	pricePerShare := 1000.0 // $1000 per "share" of real estate (standard unit)
+	// Shares are priced at the home price index so monthly growth marks the
+	// property to market with the simulated Home returns
+	pricePerShare := se.GetPricePerShare(AssetClassRealEstatePrimaryHome)
+	if pricePerShare <= 0 {
+		pricePerShare = 1.0
+	}
 	sharesOwned := purchasePrice / pricePerShare
 
 	propertyHolding := Holding{
@@ -460,8 +473,8 @@ func (h *RealEstatePurchaseEventHandler) Process(event FinancialEvent, accounts
 		if tax, ok := propertyDetails["propertyTaxAnnual"].(float64); ok && tax >= 0 {
 			propertyTaxAnnual = tax
 		} else {
-			// Estimate property tax as 1.2% of home value (national average)
-			propertyTaxAnnual = purchasePrice * 0.012
+			// Estimate property tax from the state's rules (1.2% of home value without them)
+			propertyTaxAnnual = se.defaultPropertyTax(purchasePrice, se.propertyStateCode(propertyDetails))
 		}
 
 		homeInsuranceAnnual := 0.0
@@ -509,23 +522,180 @@ func (h *RealEstatePurchaseEventHandler) Process(event FinancialEvent, accounts
 	return nil
 }
 
-// RealEstateSaleEventHandler handles real estate sale events
+// RealEstateSaleEventHandler handles real estate sale events.
+//
+// The property holding (found by metadata propertyId, or the only primary home)
+// is sold at its marked-to-market value, or at metadata salePrice if given.
+// Selling costs (metadata sellingCosts in dollars, or sellingCostPercent, default 6%)
+// are deducted, the linked mortgage is paid off, and the gain over basis is taxed
+// after the Section 121 exclusion. Without a property holding, event.Amount is
+// added to cash as before.
 type RealEstateSaleEventHandler struct{}
 
+// defaultHomeSellingCostPercent covers agent commissions and closing costs
+const defaultHomeSellingCostPercent = 0.06
+
+// Section 121 primary-residence exclusion: owned and used as a home for 2 of the 5 years before sale
+const (
+	section121ExclusionSingle = 250000.0
+	section121ExclusionJoint  = 500000.0
+	section121MonthsRequired  = 24
+)
+
 func (h *RealEstateSaleEventHandler) Process(event FinancialEvent, accounts *AccountHoldingsMonthEnd, cashFlow *float64, context *EventProcessingContext) error {
 	se := context.SimulationEngine
 
-	// Add sale proceeds to cash
-	accounts.Cash += event.Amount
-	*cashFlow += event.Amount
+	propertyID, _ := event.Metadata["propertyId"].(string)
+	holdingIndex := findPropertyHolding(accounts, propertyID)
+	if holdingIndex < 0 {
+		if propertyID != "" {
+			simLogVerbose("⚠️ [REAL_ESTATE] Property %s not found for sale %s, adding sale amount to cash", propertyID, event.ID)
+		}
 
-	// Track as positive cash flow (property sale income)
-	se.currentMonthFlows.IncomeThisMonth += event.Amount
-	se.currentMonthFlows.OneTimeEventsImpactThisMonth += event.Amount
+		// Add sale proceeds to cash
+		accounts.Cash += event.Amount
+		*cashFlow += event.Amount
+
+		// Track as positive cash flow (property sale income)
+		se.currentMonthFlows.IncomeThisMonth += event.Amount
+		se.currentMonthFlows.OneTimeEventsImpactThisMonth += event.Amount
+		return nil
+	}
+
+	property := accounts.Taxable.Holdings[holdingIndex]
+	basis, acquiredMonth := propertyBasis(property)
+
+	// Mark to market with the simulated home price index
+	salePrice := property.Quantity * se.GetPricePerShare(AssetClassRealEstatePrimaryHome)
+	if price, ok := event.Metadata["salePrice"].(float64); ok && price > 0 {
+		salePrice = price
+	}
+
+	sellingCosts := salePrice * defaultHomeSellingCostPercent
+	if pct, ok := event.Metadata["sellingCostPercent"].(float64); ok && pct >= 0 && pct < 1 {
+		sellingCosts = salePrice * pct
+	}
+	if costs, ok := event.Metadata["sellingCosts"].(float64); ok && costs >= 0 {
+		sellingCosts = costs
+	}
+	amountRealized := salePrice - sellingCosts
+
+	// Pay off the mortgage linked to the property
+	mortgageID, _ := event.Metadata["mortgageId"].(string)
+	if mortgageID == "" {
+		mortgageID = "mortgage-" + strings.TrimPrefix(property.ID, "property-")
+	}
+	mortgagePayoff := se.retireLiability(mortgageID)
+
+	// Gain over basis, less the primary-residence exclusion. Losses on a
+	// personal residence are not deductible.
+	monthsOwned := context.CurrentMonth - acquiredMonth
+	gain := amountRealized - basis
+	exclusion := 0.0
+	if primary, ok := event.Metadata["primaryResidence"].(bool); !ok || primary {
+		exclusion = section121Exclusion(se.taxCalculator.FilingStatus(), monthsOwned)
+	}
+	taxableGain := math.Max(0, gain-exclusion)
+	if taxableGain > 0 {
+		if monthsOwned > 12 {
+			se.ProcessCapitalGainsWithTermDifferentiation(0, taxableGain)
+		} else {
+			se.ProcessCapitalGainsWithTermDifferentiation(taxableGain, 0)
+		}
+	}
+
+	// Remove the property so it is not counted alongside the proceeds
+	holdings := accounts.Taxable.Holdings
+	accounts.Taxable.Holdings = append(holdings[:holdingIndex:holdingIndex], holdings[holdingIndex+1:]...)
+	accounts.Taxable.TotalValue -= property.CurrentMarketValueTotal
+	if accounts.Taxable.TotalValue < 0 {
+		accounts.Taxable.TotalValue = 0
+	}
+
+	netProceeds := amountRealized - mortgagePayoff
+	accounts.Cash += netProceeds
+	*cashFlow += netProceeds
+
+	se.currentMonthFlows.DivestmentProceedsThisMonth += amountRealized
+	se.currentMonthFlows.DebtPaymentsPrincipalThisMonth += mortgagePayoff
+	se.currentMonthFlows.OneTimeEventsImpactThisMonth += netProceeds
+
+	simLogVerbose("🏠 [REAL_ESTATE] Sold %s for $%.2f (costs $%.2f, mortgage payoff $%.2f, gain $%.2f, excluded $%.2f, taxable $%.2f)",
+		property.ID, salePrice, sellingCosts, mortgagePayoff, gain, math.Min(math.Max(gain, 0), exclusion), taxableGain)
 
 	return nil
 }
 
+// findPropertyHolding returns the index in the taxable account of the property
+// with the given holding or purchase event ID, or of the only primary home when
+// no ID is given (-1 if there is none)
+func findPropertyHolding(accounts *AccountHoldingsMonthEnd, propertyID string) int {
+	if accounts.Taxable == nil {
+		return -1
+	}
+	found := -1
+	for i, holding := range accounts.Taxable.Holdings {
+		if propertyID != "" {
+			if holding.ID == propertyID || holding.ID == "property-"+propertyID {
+				return i
+			}
+			continue
+		}
+		if NormalizeAssetClass(holding.AssetClass) == AssetClassRealEstatePrimaryHome {
+			if found >= 0 {
+				return -1 // Ambiguous: more than one property
+			}
+			found = i
+		}
+	}
+	return found
+}
+
+// propertyBasis returns a property's cost basis and the month it was acquired
+// (the earliest lot; properties without lots are treated as owned before the plan)
+func propertyBasis(property Holding) (float64, int) {
+	if len(property.Lots) == 0 {
+		return property.CostBasisTotal, math.MinInt32
+	}
+	basis := 0.0
+	acquired := property.Lots[0].AcquisitionDate
+	for _, lot := range property.Lots {
+		basis += lot.CostBasisTotal
+		if lot.AcquisitionDate < acquired {
+			acquired = lot.AcquisitionDate
+		}
+	}
+	return basis, acquired
+}
+
+// section121Exclusion is the primary-residence gain exclusion for the filing status.
+// A surviving spouse keeps the joint exclusion while filing as a qualifying widow(er).
+func section121Exclusion(status FilingStatus, monthsOwned int) float64 {
+	if monthsOwned < section121MonthsRequired {
+		return 0
+	}
+	switch status {
+	case FilingStatusMarriedJointly, FilingStatusQualifyingWidow:
+		return section121ExclusionJoint
+	default:
+		return section121ExclusionSingle
+	}
+}
+
+// retireLiability pays off and removes a liability, returning the balance paid
+func (se *SimulationEngine) retireLiability(liabilityID string) float64 {
+	for i, liability := range se.liabilities {
+		if liability.ID != liabilityID {
+			continue
+		}
+		payoff := math.Max(0, liability.CurrentPrincipalBalance)
+		se.liabilities = append(se.liabilities[:i:i], se.liabilities[i+1:]...)
+		se.recordPaidOffLiability(liability)
+		return payoff
+	}
+	return 0
+}
+
 // DefaultEventHandler handles unknown event types
 type DefaultEventHandler struct{}
 
@@ -0,0 +1,295 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+)
+
+// =============================================================================
+// DEBT EVENT HANDLERS - PFOS-E Debt Management Events
+//
+// This module contains handlers for debt-specific events:
+// - RateResetEventHandler: Adjusts interest rates on variable-rate loans
+// - RefinanceEventHandler: Replaces a loan's rate and term, with closing costs and cash-out
+// - ExtraPrincipalPaymentEventHandler: Prepays principal from cash
+//
+// Refinances and prepayments record the projected interest saved against the
+// schedule they replace on the liability (LiabilityInfo.InterestSaved), reported
+// per liability by captureLiabilityState. A liability that is paid off keeps
+// reporting its savings as inactive (see recordPaidOffLiability).
+//
+// PFOS-E Invariant: All handlers log via simLogEvent for traceability
+// =============================================================================
+
+// RateResetEventHandler handles variable-rate loan rate adjustments
+// This allows mid-simulation rate changes for ARMs, HELOCs, and other variable loans
+type RateResetEventHandler struct{}
+
+// Process implements the EventHandler interface for rate resets
+func (h *RateResetEventHandler) Process(event FinancialEvent, accounts *AccountHoldingsMonthEnd, cashFlow *float64, context *EventProcessingContext) error {
+	se := context.SimulationEngine
+
+	// Extract rate reset parameters from event
+	targetLiabilityID := ""
+	var newInterestRate *float64
+	var newBaseRate *float64
+
+	// Get target liability ID
+	if event.Metadata != nil {
+		if id, ok := event.Metadata["targetLiabilityId"].(string); ok {
+			targetLiabilityID = id
+		}
+		if rate, ok := event.Metadata["newInterestRate"].(float64); ok {
+			newInterestRate = &rate
+		}
+		if baseRate, ok := event.Metadata["newBaseRate"].(float64); ok {
+			newBaseRate = &baseRate
+		}
+	}
+
+	if targetLiabilityID == "" {
+		simLogEvent("WARN  RATE-RESET: Missing targetLiabilityId, skipping event")
+		return nil
+	}
+
+	// Find the target liability
+	var targetLiability *LiabilityInfo
+	for i := range se.liabilities {
+		if se.liabilities[i].ID == targetLiabilityID {
+			targetLiability = se.liabilities[i]
+			break
+		}
+	}
+
+	if targetLiability == nil {
+		simLogEvent("WARN  RATE-RESET: Liability %s not found, may have been paid off", targetLiabilityID)
+		return nil
+	}
+
+	oldRate := targetLiability.InterestRate
+
+	// Apply rate change
+	if newInterestRate != nil {
+		// Explicit rate override
+		targetLiability.InterestRate = *newInterestRate
+		simLogEvent("RATE-RESET: %s rate changed from %.2f%% to %.2f%% (explicit override)",
+			targetLiability.Name, oldRate*100, *newInterestRate*100)
+	} else if newBaseRate != nil {
+		// Variable rate: base + margin
+		// Look for margin in liability metadata (would need to be stored at liability creation)
+		margin := 0.0
+		// For now, calculate margin from current rate if this is the first reset
+		// In a full implementation, margin would be stored on the liability
+		targetLiability.InterestRate = *newBaseRate + margin
+		simLogEvent("RATE-RESET: %s rate changed from %.2f%% to %.2f%% (base rate: %.2f%%)",
+			targetLiability.Name, oldRate*100, targetLiability.InterestRate*100, *newBaseRate*100)
+	} else {
+		simLogEvent("WARN  RATE-RESET: No rate change specified for %s", targetLiability.Name)
+		return nil
+	}
+
+	// Recalculate monthly payment based on new rate
+	// P = L[c(1 + c)^n]/[(1 + c)^n – 1]
+	// where c = monthly rate, n = remaining months, L = remaining balance
+	if targetLiability.TermRemainingMonths > 0 && targetLiability.CurrentPrincipalBalance > 0 {
+		monthlyRate := targetLiability.InterestRate / 12.0
+		n := float64(targetLiability.TermRemainingMonths)
+		L := targetLiability.CurrentPrincipalBalance
+
+		if monthlyRate > 0 {
+			// Standard amortization formula
+			factor := monthlyRate * math.Pow(1+monthlyRate, n) / (math.Pow(1+monthlyRate, n) - 1)
+			newPayment := L * factor
+
+			oldPayment := targetLiability.MonthlyPayment
+			targetLiability.MonthlyPayment = newPayment
+
+			simLogEvent("RATE-RESET: Monthly payment changed from $%.2f to $%.2f",
+				oldPayment, newPayment)
+		}
+	}
+
+	// Log for PFOS-E traceability
+	driverKey := "debt:interest"
+	if event.DriverKey != nil {
+		driverKey = *event.DriverKey
+	}
+	simLogEvent("RATE-RESET: Event processed [driverKey=%s]", driverKey)
+
+	return nil
+}
+
+// RefinanceEventHandler refinances an existing liability.
+//
+// Metadata: targetLiabilityId (or originalLiabilityId), newInterestRate (or
+// newLiability.annualInterestRate), newTermMonths (or newLiability.remainingTermInMonths,
+// default the remaining term), closingCosts (or refinancingCosts), rollClosingCosts
+// (default true; false pays them from cash, selling investments for a shortfall) and
+// cashOutAmount, which is added to the new principal and paid to cash. The payment
+// is re-amortized over the new term.
+type RefinanceEventHandler struct{}
+
+func (h *RefinanceEventHandler) Process(event FinancialEvent, accounts *AccountHoldingsMonthEnd, cashFlow *float64, context *EventProcessingContext) error {
+	se := context.SimulationEngine
+
+	targetLiabilityID := getStringFromMetadata(event.Metadata, "targetLiabilityId",
+		getStringFromMetadata(event.Metadata, "originalLiabilityId", ""))
+	if targetLiabilityID == "" {
+		simLogEvent("WARN  REFINANCE: Missing targetLiabilityId, skipping event")
+		return nil
+	}
+	liability := se.findLiability(targetLiabilityID)
+	if liability == nil {
+		simLogEvent("WARN  REFINANCE: Liability %s not found, may have been paid off", targetLiabilityID)
+		return nil
+	}
+
+	newLiability, _ := event.Metadata["newLiability"].(map[string]interface{})
+	newRate := getFloat64FromMetadata(event.Metadata, "newInterestRate",
+		getFloat64FromMetadata(newLiability, "annualInterestRate", -1))
+	if newRate < 0 || newRate > 1.0 {
+		return fmt.Errorf("refinance event '%s' has invalid newInterestRate: %.6f (must be 0-1.0)", event.ID, newRate)
+	}
+	newTerm := int(getFloat64FromMetadata(event.Metadata, "newTermMonths",
+		getFloat64FromMetadata(newLiability, "remainingTermInMonths", float64(liability.TermRemainingMonths))))
+	if newTerm <= 0 || newTerm > 600 {
+		return fmt.Errorf("refinance event '%s' has invalid newTermMonths: %d (must be 1-600)", event.ID, newTerm)
+	}
+	closingCosts := math.Max(0, getFloat64FromMetadata(event.Metadata, "closingCosts",
+		getFloat64FromMetadata(event.Metadata, "refinancingCosts", 0)))
+	cashOut := math.Max(0, getFloat64FromMetadata(event.Metadata, "cashOutAmount", 0))
+
+	scheduledInterest := estimateTotalInterestRemaining(liability.CurrentPrincipalBalance, liability.MonthlyPayment,
+		liability.InterestRate/12.0, liability.TermRemainingMonths)
+
+	newBalance := liability.CurrentPrincipalBalance + cashOut
+	if cashOut > 0 {
+		accounts.Cash += cashOut
+		*cashFlow += cashOut
+		se.currentMonthFlows.OneTimeEventsImpactThisMonth += cashOut
+	}
+	if getBoolFromMetadata(event.Metadata, "rollClosingCosts", true) {
+		newBalance += closingCosts
+	} else {
+		// Closing costs paid at signing: sell investments for any cash shortfall,
+		// grossed up for the tax withheld on the sale
+		if accounts.Cash < closingCosts {
+			grossAmount := se.grossUpForTaxes(closingCosts-accounts.Cash, accounts)
+			saleResult := se.cashManager.SellFromInvestmentsOnly(accounts, grossAmount, context.CurrentMonth)
+			accounts.Cash += saleResult.TotalProceeds
+			se.ApplyImmediateWithdrawalTax(saleResult, accounts)
+			se.ProcessCapitalGainsWithTermDifferentiation(saleResult.ShortTermGains, saleResult.LongTermGains)
+			se.currentMonthFlows.DivestmentProceedsThisMonth += saleResult.TotalProceeds
+
+			if accounts.Cash < closingCosts-0.01 {
+				return fmt.Errorf("refinance event '%s': insufficient funds for closing costs: need $%.2f, have $%.2f",
+					event.ID, closingCosts, accounts.Cash)
+			}
+		}
+		accounts.Cash -= closingCosts
+		*cashFlow -= closingCosts
+		se.currentMonthFlows.ExpensesThisMonth += closingCosts
+		se.currentMonthFlows.OneTimeEventsImpactThisMonth -= closingCosts
+	}
+
+	oldRate, oldPayment := liability.InterestRate, liability.MonthlyPayment
+	liability.CurrentPrincipalBalance = newBalance
+	liability.InterestRate = newRate
+	liability.TermRemainingMonths = newTerm
+	liability.MonthlyPayment = CalculateMonthlyPayment(newBalance, newRate, newTerm)
+
+	newInterest := estimateTotalInterestRemaining(newBalance, liability.MonthlyPayment, newRate/12.0, newTerm)
+	liability.InterestSaved += scheduledInterest - newInterest
+	liability.RefinanceCosts += closingCosts
+
+	simLogEvent("REFINANCE: %s from %.2f%% ($%.2f/mo) to %.2f%% over %d months ($%.2f/mo), balance $%.2f, cash-out $%.2f, closing costs $%.2f, interest saved $%.2f",
+		liability.Name, oldRate*100, oldPayment, newRate*100, newTerm, liability.MonthlyPayment,
+		newBalance, cashOut, closingCosts, scheduledInterest-newInterest)
+
+	return nil
+}
+
+// ExtraPrincipalPaymentEventHandler prepays principal on a liability.
+//
+// event.Amount is the extra principal per occurrence (use a monthly frequency for
+// recurring prepayments), paid from cash on hand only. By default the payment stays
+// the same and the loan pays off sooner; metadata recastPayment re-amortizes the
+// lower balance over the remaining term instead.
+type ExtraPrincipalPaymentEventHandler struct{}
+
+func (h *ExtraPrincipalPaymentEventHandler) Process(event FinancialEvent, accounts *AccountHoldingsMonthEnd, cashFlow *float64, context *EventProcessingContext) error {
+	se := context.SimulationEngine
+
+	targetLiabilityID := getStringFromMetadata(event.Metadata, "targetLiabilityId", "")
+	if targetLiabilityID == "" {
+		simLogEvent("WARN  EXTRA-PRINCIPAL: Missing targetLiabilityId, skipping event")
+		return nil
+	}
+	liability := se.findLiability(targetLiabilityID)
+	if liability == nil || liability.CurrentPrincipalBalance <= 0 {
+		simLogEvent("WARN  EXTRA-PRINCIPAL: Liability %s not found, may have been paid off", targetLiabilityID)
+		return nil
+	}
+
+	payment := math.Min(event.Amount, liability.CurrentPrincipalBalance)
+	if payment > accounts.Cash {
+		payment = math.Max(0, accounts.Cash)
+	}
+	if payment <= 0 {
+		simLogEvent("WARN  EXTRA-PRINCIPAL: No cash available for %s prepayment", liability.Name)
+		return nil
+	}
+
+	monthlyRate := liability.InterestRate / 12.0
+	scheduledInterest := estimateTotalInterestRemaining(liability.CurrentPrincipalBalance, liability.MonthlyPayment,
+		monthlyRate, liability.TermRemainingMonths)
+
+	accounts.Cash -= payment
+	*cashFlow -= payment
+	liability.CurrentPrincipalBalance -= payment
+	se.currentMonthFlows.DebtPaymentsPrincipalThisMonth += payment
+
+	if liability.CurrentPrincipalBalance <= 0.01 {
+		// The whole remaining schedule's interest is saved; retireLiability keeps the figure
+		liability.InterestSaved += scheduledInterest
+		se.retireLiability(liability.ID)
+		simLogEvent("EXTRA-PRINCIPAL: %s paid off with $%.2f prepayment, saving $%.2f of interest",
+			liability.Name, payment, scheduledInterest)
+		return nil
+	}
+
+	if getBoolFromMetadata(event.Metadata, "recastPayment", false) {
+		liability.MonthlyPayment = CalculateMonthlyPayment(liability.CurrentPrincipalBalance, liability.InterestRate, liability.TermRemainingMonths)
+	} else if term := CalculateRemainingTermMonths(liability.CurrentPrincipalBalance, liability.MonthlyPayment, liability.InterestRate); term > 0 {
+		liability.TermRemainingMonths = term
+	}
+
+	newInterest := estimateTotalInterestRemaining(liability.CurrentPrincipalBalance, liability.MonthlyPayment,
+		monthlyRate, liability.TermRemainingMonths)
+	liability.InterestSaved += scheduledInterest - newInterest
+
+	simLogEvent("EXTRA-PRINCIPAL: $%.2f prepaid on %s, balance $%.2f, %d months remaining at $%.2f/mo, interest saved $%.2f",
+		payment, liability.Name, liability.CurrentPrincipalBalance, liability.TermRemainingMonths,
+		liability.MonthlyPayment, scheduledInterest-newInterest)
+
+	return nil
+}
+
+// recordPaidOffLiability keeps a paid-off liability that was refinanced or prepaid,
+// so captureLiabilityState still reports its interest saved once it leaves se.liabilities
+func (se *SimulationEngine) recordPaidOffLiability(liability *LiabilityInfo) {
+	if liability.InterestSaved == 0 && liability.RefinanceCosts == 0 {
+		return
+	}
+	se.paidOffLiabilities = append(se.paidOffLiabilities, liability)
+}
+
+// findLiability returns the active liability with the given ID (nil if none)
+func (se *SimulationEngine) findLiability(liabilityID string) *LiabilityInfo {
+	for _, liability := range se.liabilities {
+		if liability.ID == liabilityID {
+			return liability
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func newDebtTestEngine(t *testing.T, balance, rate float64, termMonths int) (*SimulationEngine, *AccountHoldingsMonthEnd, *LiabilityInfo) {
+	t.Helper()
+	accounts := &AccountHoldingsMonthEnd{Cash: 50000}
+	se := newHandlerTestEngine(t, SimulationInput{InitialAge: 45}, accounts)
+	liability := &LiabilityInfo{
+		ID:                      "mortgage",
+		Name:                    "Mortgage",
+		Type:                    "MORTGAGE",
+		CurrentPrincipalBalance: balance,
+		InterestRate:            rate,
+		TermRemainingMonths:     termMonths,
+		MonthlyPayment:          CalculateMonthlyPayment(balance, rate, termMonths),
+	}
+	se.liabilities = []*LiabilityInfo{liability}
+	return se, accounts, liability
+}
+
+func TestCalculateRemainingTermMonths(t *testing.T) {
+	for _, rate := range []float64{0, 0.03, 0.065} {
+		payment := CalculateMonthlyPayment(250000, rate, 360)
+		if got := CalculateRemainingTermMonths(250000, payment, rate); got != 360 {
+			t.Errorf("rate %.3f: remaining term %d, want 360", rate, got)
+		}
+	}
+	if got := CalculateRemainingTermMonths(250000, 1000, 0.06); got != 0 {
+		t.Errorf("a payment below the interest never amortizes, got %d months", got)
+	}
+}
+
+func TestRefinance(t *testing.T) {
+	se, accounts, liability := newDebtTestEngine(t, 300000, 0.07, 300)
+	scheduled := estimateTotalInterestRemaining(300000, liability.MonthlyPayment, 0.07/12, 300)
+
+	err := processTestEvent(t, &RefinanceEventHandler{}, se, accounts, FinancialEvent{ID: "refi", Type: "REFINANCE", Metadata: map[string]interface{}{
+		"targetLiabilityId": "mortgage",
+		"newInterestRate":   0.05,
+		"newTermMonths":     360.0,
+		"closingCosts":      6000.0,
+		"cashOutAmount":     20000.0,
+	}})
+	if err != nil {
+		t.Fatalf("refinance: %v", err)
+	}
+
+	if liability.CurrentPrincipalBalance != 326000 || liability.TermRemainingMonths != 360 || liability.InterestRate != 0.05 {
+		t.Errorf("new loan: balance %.2f, term %d, rate %.3f", liability.CurrentPrincipalBalance, liability.TermRemainingMonths, liability.InterestRate)
+	}
+	if want := CalculateMonthlyPayment(326000, 0.05, 360); math.Abs(liability.MonthlyPayment-want) > 1e-9 {
+		t.Errorf("payment %.2f, want %.2f", liability.MonthlyPayment, want)
+	}
+	if accounts.Cash != 70000 {
+		t.Errorf("cash-out should be paid to cash, got %.2f", accounts.Cash)
+	}
+	wantSaved := scheduled - estimateTotalInterestRemaining(326000, liability.MonthlyPayment, 0.05/12, 360)
+	if math.Abs(liability.InterestSaved-wantSaved) > 0.01 {
+		t.Errorf("interest saved %.2f, want %.2f", liability.InterestSaved, wantSaved)
+	}
+
+	states := se.captureLiabilityState(2025, 1)
+	if len(states) != 1 || math.Abs(states[0].NetSavingsVsSchedule-(wantSaved-6000)) > 0.01 || states[0].RefinanceCosts != 6000 {
+		t.Errorf("liability state should report savings net of closing costs, got %+v", states)
+	}
+
+	// Closing costs paid out of pocket; the remaining term is kept by default
+	se, accounts, liability = newDebtTestEngine(t, 200000, 0.06, 240)
+	err = processTestEvent(t, &RefinanceEventHandler{}, se, accounts, FinancialEvent{ID: "refi", Type: "REFINANCE", Metadata: map[string]interface{}{
+		"originalLiabilityId": "mortgage",
+		"newLiability":        map[string]interface{}{"annualInterestRate": 0.045},
+		"refinancingCosts":    4000.0,
+		"rollClosingCosts":    false,
+	}})
+	if err != nil {
+		t.Fatalf("refinance: %v", err)
+	}
+	if accounts.Cash != 46000 || liability.CurrentPrincipalBalance != 200000 || liability.TermRemainingMonths != 240 {
+		t.Errorf("out-of-pocket costs: cash %.2f, balance %.2f, term %d", accounts.Cash, liability.CurrentPrincipalBalance, liability.TermRemainingMonths)
+	}
+	if liability.InterestSaved <= 0 {
+		t.Error("a lower rate over the same term should save interest")
+	}
+
+	err = processTestEvent(t, &RefinanceEventHandler{}, se, accounts, FinancialEvent{ID: "bad", Type: "REFINANCE", Metadata: map[string]interface{}{
+		"targetLiabilityId": "mortgage",
+	}})
+	if err == nil {
+		t.Error("expected an error for a refinance without a new rate")
+	}
+}
+
+func TestExtraPrincipalPayment(t *testing.T) {
+	se, accounts, liability := newDebtTestEngine(t, 200000, 0.06, 360)
+	payment := liability.MonthlyPayment
+	scheduled := estimateTotalInterestRemaining(200000, payment, 0.005, 360)
+
+	prepay := FinancialEvent{ID: "prepay", Type: "EXTRA_PRINCIPAL_PAYMENT", Amount: 20000, Metadata: map[string]interface{}{"targetLiabilityId": "mortgage"}}
+	if err := processTestEvent(t, &ExtraPrincipalPaymentEventHandler{}, se, accounts, prepay); err != nil {
+		t.Fatalf("prepay: %v", err)
+	}
+	if liability.CurrentPrincipalBalance != 180000 || accounts.Cash != 30000 || liability.MonthlyPayment != payment {
+		t.Errorf("prepayment: balance %.2f, cash %.2f, payment %.2f", liability.CurrentPrincipalBalance, accounts.Cash, liability.MonthlyPayment)
+	}
+	if want := CalculateRemainingTermMonths(180000, payment, 0.06); liability.TermRemainingMonths != want || want >= 360 {
+		t.Errorf("term should shorten to %d months, got %d", want, liability.TermRemainingMonths)
+	}
+	wantSaved := scheduled - estimateTotalInterestRemaining(180000, payment, 0.005, liability.TermRemainingMonths)
+	if math.Abs(liability.InterestSaved-wantSaved) > 0.01 || wantSaved <= 0 {
+		t.Errorf("interest saved %.2f, want %.2f", liability.InterestSaved, wantSaved)
+	}
+
+	// Recasting keeps the term and lowers the payment
+	term := liability.TermRemainingMonths
+	prepay.Metadata["recastPayment"] = true
+	if err := processTestEvent(t, &ExtraPrincipalPaymentEventHandler{}, se, accounts, prepay); err != nil {
+		t.Fatalf("prepay: %v", err)
+	}
+	if liability.TermRemainingMonths != term || liability.MonthlyPayment >= payment {
+		t.Errorf("recast: term %d (want %d), payment %.2f (want below %.2f)", liability.TermRemainingMonths, term, liability.MonthlyPayment, payment)
+	}
+
+	// Prepayments are limited to cash on hand and retire the loan when they cover it
+	accounts.Cash = 5000
+	prepay.Amount = 1000000
+	if err := processTestEvent(t, &ExtraPrincipalPaymentEventHandler{}, se, accounts, prepay); err != nil {
+		t.Fatalf("prepay: %v", err)
+	}
+	if accounts.Cash != 0 || liability.CurrentPrincipalBalance != 155000 {
+		t.Errorf("cash-limited prepayment: cash %.2f, balance %.2f", accounts.Cash, liability.CurrentPrincipalBalance)
+	}
+	accounts.Cash = 200000
+	savedBeforePayoff := liability.InterestSaved
+	remainingInterest := estimateTotalInterestRemaining(liability.CurrentPrincipalBalance, liability.MonthlyPayment,
+		0.005, liability.TermRemainingMonths)
+	if err := processTestEvent(t, &ExtraPrincipalPaymentEventHandler{}, se, accounts, prepay); err != nil {
+		t.Fatalf("prepay: %v", err)
+	}
+	if len(se.liabilities) != 0 || accounts.Cash != 45000 {
+		t.Errorf("payoff: %d liabilities left, cash %.2f", len(se.liabilities), accounts.Cash)
+	}
+
+	// The payoff saves the rest of the schedule's interest, still reported once the loan is gone
+	states := se.captureLiabilityState(2025, 1)
+	if len(states) != 1 || states[0].IsActive || states[0].LiabilityID != "mortgage" {
+		t.Fatalf("expected the paid-off mortgage as an inactive state, got %+v", states)
+	}
+	if want := savedBeforePayoff + remainingInterest; math.Abs(states[0].InterestSavedVsSchedule-want) > 0.01 {
+		t.Errorf("paid-off interest saved %.2f, want %.2f", states[0].InterestSavedVsSchedule, want)
+	}
+}
+
+func TestRefinanceClosingCostsRaiseCash(t *testing.T) {
+	refinance := FinancialEvent{ID: "refi", Type: "REFINANCE", Metadata: map[string]interface{}{
+		"targetLiabilityId": "mortgage",
+		"newInterestRate":   0.045,
+		"closingCosts":      4000.0,
+		"rollClosingCosts":  false,
+	}}
+
+	// Nothing to sell: the refinance fails instead of overdrawing cash
+	se, accounts, liability := newDebtTestEngine(t, 200000, 0.06, 240)
+	accounts.Cash = 1000
+	if err := processTestEvent(t, &RefinanceEventHandler{}, se, accounts, refinance); err == nil {
+		t.Error("expected an error when closing costs cannot be paid")
+	}
+	if accounts.Cash != 1000 || liability.InterestRate != 0.06 {
+		t.Errorf("a failed refinance should change nothing: cash %.2f, rate %.3f", accounts.Cash, liability.InterestRate)
+	}
+
+	// Taxable investments cover the shortfall
+	se, accounts, liability = newDebtTestEngine(t, 200000, 0.06, 240)
+	accounts.Cash = 1000
+	accounts.Taxable = &Account{TotalValue: 10000, Holdings: []Holding{{
+		ID: "stocks", AssetClass: AssetClassUSStocksTotalMarket, Quantity: 10000,
+		CostBasisPerUnit: 1, CostBasisTotal: 10000, CurrentMarketPricePerUnit: 1, CurrentMarketValueTotal: 10000,
+		Lots: []TaxLot{{ID: "stocks-lot", AssetClass: AssetClassUSStocksTotalMarket, Quantity: 10000,
+			CostBasisPerUnit: 1, CostBasisTotal: 10000, AcquisitionDate: -12, IsLongTerm: true}},
+	}}}
+	if err := processTestEvent(t, &RefinanceEventHandler{}, se, accounts, refinance); err != nil {
+		t.Fatalf("refinance: %v", err)
+	}
+	if accounts.Cash < -0.01 || accounts.Taxable.TotalValue > 7000.01 || liability.InterestRate != 0.045 {
+		t.Errorf("closing costs should be raised from investments: cash %.2f, taxable %.2f",
+			accounts.Cash, accounts.Taxable.TotalValue)
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package engine
 
 import (
 	"fmt"
@@ -49,6 +49,8 @@ func (h *SystemEventHandlerSimple) ProcessSystemEvent(event FinancialEvent, acco
 		return h.processYearEnd(accounts, monthOffset)
 
 	case SystemEventDebtPayment:
+		// Rental income and costs settle before the month's mortgage payments
+		h.engine.processRentalProperties(accounts, monthOffset)
 		return h.processDebtPayments(accounts, monthOffset)
 
 	case SystemEventFinancialHealthCheck:
@@ -64,6 +66,15 @@ func (h *SystemEventHandlerSimple) processTimeStep(accounts *AccountHoldingsMont
 	// Reset monthly flow tracking for new month
 	h.engine.resetMonthlyFlows()
 
+	// Reimburse saved HSA receipts once the shoebox reimbursement age is reached
+	h.engine.processHSAShoebox(accounts, monthOffset)
+
+	// Medicare Part B and D premiums, with any IRMAA surcharge, from 65
+	h.engine.chargeMedicarePremiums(accounts, monthOffset)
+
+	// Retirement spending set by the withdrawal policy
+	h.engine.processWithdrawalPolicy(accounts, monthOffset)
+
 	// Set month offset in monthly data if available
 	if h.monthlyData != nil {
 		h.monthlyData.MonthOffset = monthOffset
@@ -129,6 +140,7 @@ func (h *SystemEventHandlerSimple) processMarketUpdate(accounts *AccountHoldings
 		h.engine.eventInflationFactor = h.engine.cumulativeInflationFactor
 		h.engine.cumulativeInflationFactor *= (1 + h.engine.currentMonthReturns.Inflation)
 	}
+	h.engine.recordPriceIndex(monthOffset)
 
 	// Apply market growth using the existing ApplyMarketGrowth method
 	err := h.engine.ApplyMarketGrowth(accounts, monthOffset)
@@ -156,6 +168,8 @@ func (h *SystemEventHandlerSimple) processMarketUpdate(accounts *AccountHoldings
 		getAccountVal(accounts.Roth),
 		accounts.Cash)
 
+	h.engine.recordPortfolioGrowth(totalBefore, totalAfter)
+
 	if totalAfter == 0 && totalBefore > 0 {
 		simLogVerbose("❌ [MARKET_UPDATE] WARNING: All accounts zeroed out! Before=$%.2f, After=$%.2f", totalBefore, totalAfter)
 	}
@@ -302,9 +316,10 @@ func (h *SystemEventHandlerSimple) processRMDCheck(accounts *AccountHoldingsMont
 
 	// Calculate user's current age based on initialAge from input
 	age := h.engine.simulationInput.InitialAge + (monthOffset / 12)
+	household := h.engine.household
 
-	// Only process RMDs if age 73 or older
-	if age < 73 {
+	// Only process RMDs if age 73 or older (households check each member's age)
+	if household == nil && age < 73 {
 		return nil
 	}
 
@@ -317,7 +332,13 @@ func (h *SystemEventHandlerSimple) processRMDCheck(accounts *AccountHoldingsMont
 	rmdBasis := h.engine.priorYearEndTaxDeferredBalance
 
 	// Calculate total RMD requirement for the year
-	totalRmdRequired := CalculateRMD(age, rmdBasis)
+	var totalRmdRequired float64
+	if household != nil {
+		// Each living member's RMD applies to their share of the account
+		totalRmdRequired = household.requiredMinimumDistribution(rmdBasis, monthOffset)
+	} else {
+		totalRmdRequired = CalculateRMD(age, rmdBasis)
+	}
 	if totalRmdRequired <= 0 {
 		h.engine.lastRMDAmount = 0
 		return nil
@@ -349,6 +370,7 @@ func (h *SystemEventHandlerSimple) processRMDCheck(accounts *AccountHoldingsMont
 		// Add RMD to cash and ordinary income
 		accounts.Cash += withdrawalAmount
 		h.engine.ordinaryIncomeYTD += withdrawalAmount
+		h.engine.retirementDistributionsYTD += withdrawalAmount
 	}
 
 	return nil
@@ -366,6 +388,11 @@ func (h *SystemEventHandlerSimple) processTaxCheck(accounts *AccountHoldingsMont
 	// Calculate user's current age based on initialAge from input
 	age := h.engine.simulationInput.InitialAge + (monthOffset / 12)
 
+	// Households file jointly while both members are alive at the start of the year
+	if h.engine.household != nil {
+		h.engine.taxCalculator.SetFilingStatus(h.engine.household.filingStatus(monthOffset))
+	}
+
 	// Use the existing ProcessAnnualTaxes method
 	err := h.engine.ProcessAnnualTaxes(accounts, monthOffset, age)
 	if err != nil {
@@ -382,14 +409,10 @@ func (h *SystemEventHandlerSimple) processYearEnd(accounts *AccountHoldingsMonth
 		return nil
 	}
 
-	year := monthOffset/12 + 2025
+	year := h.engine.calendarYear(monthOffset)
 	simLogVerbose("🎊 [YEAR_END] Processing year-end finalization for %d", year)
 
-	// Save current year's MAGI for IRMAA look-back (simplified)
-	if h.engine.magiHistory == nil {
-		h.engine.magiHistory = make(map[int]float64)
-	}
-	h.engine.magiHistory[year] = h.engine.ordinaryIncomeYTD + h.engine.capitalGainsYTD
+	// MAGI for the IRMAA look-back was recorded by the tax check, before the YTD reset
 
 	// Reset YTD trackers for the new year
 	h.engine.ordinaryIncomeYTD = 0
@@ -404,6 +427,7 @@ func (h *SystemEventHandlerSimple) processYearEnd(accounts *AccountHoldingsMonth
 	h.engine.qualifiedCharitableDistributionsYTD = 0
 	h.engine.itemizedDeductibleInterestYTD = 0
 	h.engine.preTaxContributionsYTD = 0
+	h.engine.penaltyTaxYTD = 0
 	h.engine.taxWithholdingYTD = 0
 	h.engine.estimatedPaymentsYTD = 0
 
@@ -477,6 +501,7 @@ func (h *SystemEventHandlerSimple) processDebtPayments(accounts *AccountHoldings
 			remainingLiabilities = append(remainingLiabilities, liability)
 		} else {
 			simLogVerbose("✅ [DEBT] Paid off %s liability", liability.Name)
+			h.engine.recordPaidOffLiability(liability)
 		}
 
 		// Reset missed payments counter on successful payment
@@ -1,4 +1,4 @@
-package main
+package engine
 
 import (
 	"fmt"
@@ -182,14 +182,19 @@ func (h *UnifiedIncomeEventHandler) Process(event FinancialEvent, accounts *Acco
 		se.currentMonthFlows.SalaryIncomeThisMonth += event.Amount
 		se.currentMonthFlows.EmploymentIncomeThisMonth += event.Amount
 		se.employmentIncomeYTD += event.Amount
+		se.recordWages(event.Metadata, event.Amount)
 	case "bonus":
 		se.currentMonthFlows.BonusIncomeThisMonth += event.Amount
 		se.currentMonthFlows.EmploymentIncomeThisMonth += event.Amount
 		se.employmentIncomeYTD += event.Amount
+		se.recordWages(event.Metadata, event.Amount)
 	case "rsu":
 		se.currentMonthFlows.RSUIncomeThisMonth += event.Amount
 		se.currentMonthFlows.EmploymentIncomeThisMonth += event.Amount
 		se.employmentIncomeYTD += event.Amount
+		se.recordWages(event.Metadata, event.Amount)
+	case "pension":
+		se.pensionIncomeYTD += event.Amount
 	}
 
 	// PFOS-E: Register income with tax engine by taxProfile
@@ -509,17 +514,22 @@ func (h *UnifiedContributionEventHandler) Process(event FinancialEvent, accounts
 	// Apply contribution limits and track excess
 	excessAmount := 0.0
 	contributionAmount := actualContribution
+	owner := getStringFromMetadata(event.Metadata, "owner", "")
 
 	switch targetAccount {
 	case "tax_deferred":
-		excessAmount = se.enforcePreTaxContributionLimits(&contributionAmount, currentMonth)
+		excessAmount = se.enforcePreTaxContributionLimits(&contributionAmount, currentMonth, owner)
 		if contributionAmount > 0 {
 			se.preTaxContributionsYTD += contributionAmount
 		}
 		se.currentMonthFlows.ContributionsTaxDeferredThisMonth += contributionAmount
 	case "roth":
-		excessAmount = se.enforceRothContributionLimits(&contributionAmount, currentMonth)
+		excessAmount = se.enforceRothContributionLimits(&contributionAmount, currentMonth, owner)
 		se.currentMonthFlows.ContributionsRothThisMonth += contributionAmount
+	case "hsa":
+		excessAmount = se.enforceHSAContributionLimits(&contributionAmount, currentMonth, owner)
+		se.preTaxContributionsYTD += contributionAmount
+		se.currentMonthFlows.ContributionsHSAThisMonth += contributionAmount
 	case "taxable":
 		se.currentMonthFlows.ContributionsTaxableThisMonth += contributionAmount
 	}
@@ -528,11 +538,21 @@ func (h *UnifiedContributionEventHandler) Process(event FinancialEvent, accounts
 	assetClass := NormalizeAssetClass(AssetClass(getStringFromMetadata(event.Metadata, "assetClass", string(AssetClassUSStocksTotalMarket))))
 
 	if contributionAmount > 0 {
-		if err := se.processInvestmentContributionWithFIFO(accounts, contributionAmount, targetAccount, assetClass, currentMonth); err != nil {
+		// 529 contributions also credit the beneficiary's share and basis
+		var err error
+		if targetAccount == "529" {
+			err = se.contributeToFiveTwoNine(accounts, getStringFromMetadata(event.Metadata, "beneficiary", ""), contributionAmount, assetClass, currentMonth)
+		} else {
+			err = se.processInvestmentContributionWithFIFO(accounts, contributionAmount, targetAccount, assetClass, currentMonth)
+		}
+		if err != nil {
 			accounts.Cash += contributionAmount
 			se.currentMonthFlows.ContributionsToInvestmentsThisMonth -= contributionAmount
 			return fmt.Errorf("contribution failed: %w", err)
 		}
+		if targetAccount == "tax_deferred" {
+			se.recordTaxDeferredOwnership(owner, contributionAmount, accounts)
+		}
 	}
 
 	// Route excess to taxable
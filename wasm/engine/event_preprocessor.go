@@ -1,4 +1,4 @@
-package main
+package engine
 
 import (
 	"fmt"
@@ -171,6 +171,25 @@ func (ep *EventPreprocessor) expandEventSchedule(event FinancialEvent, totalMont
 		}
 	}
 
+	// A Roth conversion ladder sizes each conversion from the year's income, so it runs every December
+	if event.Type == string(EventTypeRothConversionLadder) {
+		for m := startMonth; m < endMonth; m++ {
+			if m%12 == 11 {
+				months = append(months, m)
+			}
+		}
+		return months
+	}
+
+	// A Social Security claim works out its own start month from the birth date and
+	// claiming age, and can turn into a survivor benefit, so it is checked every month
+	if event.Type == string(EventTypeSocialSecurityClaim) {
+		for m := startMonth; m < endMonth; m++ {
+			months = append(months, m)
+		}
+		return months
+	}
+
 	// Handle different frequency types
 	switch frequency {
 	case "monthly":
@@ -258,10 +277,20 @@ func (ep *EventPreprocessor) cloneEventForMonth(event FinancialEvent, month int)
 	}
 
 	// Inflation adjustment is deferred to runtime using stochastic inflation
-	// The engine tracks cumulativeInflationFactor and applies it in processQueuedEvent
+	// The engine tracks the path's price index and applies it in processQueuedEvent
 	// Events with applyInflation: true in metadata will be adjusted at processing time
 	// This replaces the old static 2.5% approach with actual stochastic inflation
 
+	// An event inflated from its own start keeps that month, since the clone's MonthOffset
+	// is the occurrence
+	if base, _ := event.Metadata["inflationBase"].(string); base == "event_start" && month > event.MonthOffset {
+		clone.Metadata = make(map[string]interface{}, len(event.Metadata)+1)
+		for k, v := range event.Metadata {
+			clone.Metadata[k] = v
+		}
+		clone.Metadata["inflationStartMonth"] = float64(event.MonthOffset)
+	}
+
 	return clone
 }
 
@@ -1,7 +1,7 @@
 //go:build !test
 // +build !test
 
-package main
+package engine
 
 import (
 	"container/heap"
@@ -232,7 +232,7 @@ func GetEventPriority(eventType string) EventPriority {
 		return PriorityIncome
 	case "PENSION_INCOME":
 		return PriorityPensionIncome
-	case "SOCIAL_SECURITY":
+	case "SOCIAL_SECURITY", "SOCIAL_SECURITY_CLAIM":
 		return PrioritySocialSecurity
 
 	// Contribution events (default priority - use GetEventPriorityWithAccount for account-specific)
@@ -254,13 +254,17 @@ func GetEventPriority(eventType string) EventPriority {
 		return PriorityAssetPurchases
 
 	// Tax-related events
-	case "ROTH_CONVERSION":
+	case "ROTH_CONVERSION", "ROTH_CONVERSION_LADDER":
 		return PriorityRothConversion
 	case "TAX_PAYMENT", "ESTIMATED_TAX":
 		return PriorityTaxPayment
 
+	// A move takes effect at the start of the month
+	case "RELOCATE":
+		return PriorityRelocation
+
 	// Healthcare events
-	case "HEALTHCARE_EXPENSE":
+	case "HEALTHCARE_EXPENSE", "ACA_MARKETPLACE_COVERAGE":
 		return PriorityHealthcare
 	case "HSA_CONTRIBUTION":
 		return PriorityContributionHSA
@@ -1,7 +1,7 @@
 //go:build !wasm || !js
 // +build !wasm !js
 
-package main
+package engine
 
 import (
 	"fmt"
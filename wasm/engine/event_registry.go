@@ -39,9 +39,11 @@ func (r *EventHandlerRegistry) registerHandlers() {
 
 	r.handlers[EventTypeScheduledContribution] = NewScheduledContributionEventHandler()
 	r.handlers[EventTypeRothConversion] = &RothConversionEventHandler{}
+	r.handlers[EventTypeRothConversionLadder] = &RothConversionLadderEventHandler{}
 
 	// Income stream events
 	r.handlers[EventTypeSocialSecurityIncome] = &SocialSecurityIncomeEventHandler{}
+	r.handlers[EventTypeSocialSecurityClaim] = &SocialSecurityClaimEventHandler{}
 	r.handlers[EventTypePensionIncome] = &PensionIncomeEventHandler{}
 	r.handlers[EventTypeDividendIncome] = &DividendIncomeEventHandler{}
 	r.handlers[EventTypeAnnuityPayment] = &AnnuityPaymentEventHandler{}
@@ -82,10 +84,15 @@ func (r *EventHandlerRegistry) registerHandlers() {
 	r.handlers[EventTypeLiabilityPayment] = &LiabilityPaymentEventHandler{}
 	r.handlers[EventTypeDebtPayment] = &DebtPaymentEventHandler{}
 	r.handlers[EventTypeRateReset] = &RateResetEventHandler{}
+	r.handlers[EventTypeRefinance] = &RefinanceEventHandler{}
+	r.handlers[EventTypeExtraPrincipalPayment] = &ExtraPrincipalPaymentEventHandler{}
 
 	// Real estate events
 	r.handlers[EventTypeRealEstatePurchase] = &RealEstatePurchaseEventHandler{}
 	r.handlers[EventTypeRealEstateSale] = &RealEstateSaleEventHandler{}
+	r.handlers[EventTypeRentalPropertyPurchase] = &RentalPropertyPurchaseEventHandler{}
+	r.handlers[EventTypeRentalPropertySale] = &RentalPropertySaleEventHandler{}
+	r.handlers[EventTypeRelocate] = &RelocateEventHandler{}
 
 	// Strategy configuration events
 	r.handlers[EventTypeStrategyAssetAllocationSet] = &StrategyAssetAllocationSetEventHandler{}
@@ -105,6 +112,7 @@ func (r *EventHandlerRegistry) registerHandlers() {
 	// Education events
 	r.handlers[EventTypeFiveTwoNineContribution] = &FiveTwoNineContributionEventHandler{}
 	r.handlers[EventTypeFiveTwoNineWithdrawal] = &FiveTwoNineWithdrawalEventHandler{}
+	r.handlers[EventTypeFiveTwoNineRothRollover] = &FiveTwoNineRothRolloverEventHandler{}
 	r.handlers[EventTypeTuitionPayment] = &TuitionPaymentEventHandler{}
 
 	// HSA events
@@ -118,6 +126,7 @@ func (r *EventHandlerRegistry) registerHandlers() {
 
 	// New expense handlers
 	r.handlers[EventTypeHealthInsurancePremium] = &HealthInsurancePremiumEventHandler{}
+	r.handlers[EventTypeACAMarketplaceCoverage] = &ACAMarketplaceCoverageEventHandler{}
 	r.handlers[EventTypePropertyInsurance] = &PropertyInsuranceEventHandler{}
 	r.handlers[EventTypeCarPurchase] = &CarPurchaseEventHandler{}
 	r.handlers[EventTypeHomeRenovation] = &HomeRenovationEventHandler{}
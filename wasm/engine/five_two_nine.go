@@ -0,0 +1,301 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+)
+
+/**
+ * 529 Education Savings Plans
+ *
+ * The FiveTwoNine account is pooled; each beneficiary owns a share of it, the same
+ * way household members own shares of the tax-deferred account. Per beneficiary the
+ * engine tracks:
+ *
+ * - Basis: total contributions. Each distribution is part basis, part earnings, in
+ *   proportion to the beneficiary's balance (the earnings ratio)
+ * - Qualified expenses: TUITION_PAYMENT events for the beneficiary (metadata
+ *   "beneficiary"), matched against the year's distributions
+ * - Year-end settlement: the earnings in distributions beyond the year's qualified
+ *   expenses are ordinary income plus a 10% additional tax (IRC §529(c)(6)),
+ *   computed as earnings × (1 - qualified expenses / distributions)
+ * - SECURE 2.0 rollover to the beneficiary's Roth IRA (IRC §529(c)(3)(E)): the plan
+ *   must be open 15 years, contributions from the last 5 years are excluded, and the
+ *   rollover counts against the beneficiary's annual IRA limit and a $35,000 lifetime
+ *   cap. A beneficiary who is a household member (or "self" in a single-person plan)
+ *   rolls into the plan's Roth account; anyone else's Roth is outside the plan, so the
+ *   rollover leaves the household. The earned-income requirement is not modeled.
+ *
+ * Reference: IRS Publication 970, Chapter 7
+ */
+
+// FiveTwoNineBeneficiarySelf names the plan's own person as beneficiary in a single-person plan
+const FiveTwoNineBeneficiarySelf = "self"
+
+const (
+	fiveTwoNinePenaltyRate         = 0.10
+	fiveTwoNineRothLifetimeLimit   = 35000
+	fiveTwoNineRothMinAccountYears = 15
+	fiveTwoNineRothSeasoningMonths = 60 // Contributions from the last 5 years can't be rolled over
+)
+
+// FiveTwoNinePlan assigns part of the initial 529 balance to a beneficiary
+type FiveTwoNinePlan struct {
+	Beneficiary   string  `json:"beneficiary"`             // Referenced by event metadata "beneficiary"
+	Balance       float64 `json:"balance,omitempty"`       // Beneficiary's part of the initial 529 balance
+	Contributions float64 `json:"contributions,omitempty"` // Basis in that balance; 0 = its share of the account's cost basis
+	OpenedYear    int     `json:"openedYear,omitempty"`    // Year the account was opened (15-year Roth rollover rule); 0 = StartYear
+}
+
+// FiveTwoNineBeneficiaryState reports one beneficiary's part of the 529 account
+type FiveTwoNineBeneficiaryState struct {
+	Beneficiary          string  `json:"beneficiary"`
+	Balance              float64 `json:"balance"`
+	Contributions        float64 `json:"contributions"`
+	DistributionsYTD     float64 `json:"distributionsYtd"`
+	QualifiedExpensesYTD float64 `json:"qualifiedExpensesYtd"`
+	RothRollovers        float64 `json:"rothRollovers"` // Lifetime, against the $35,000 cap
+}
+
+// fiveTwoNineContribution dates a contribution for the rollover seasoning rule
+type fiveTwoNineContribution struct {
+	month  int
+	amount float64
+}
+
+// fiveTwoNineBeneficiary tracks one beneficiary's share of the pooled 529 account
+type fiveTwoNineBeneficiary struct {
+	name          string
+	share         float64 // Fraction of the pooled 529 balance
+	basis         float64
+	openedYear    int
+	contributions []fiveTwoNineContribution
+	rothRollovers float64
+	rothTracker   *ContributionLimitTracker // IRA limit for a beneficiary outside the household
+
+	// Current tax year
+	distributionsYTD     float64
+	earningsYTD          float64
+	qualifiedExpensesYTD float64
+}
+
+// fiveTwoNineState tracks 529 beneficiaries for one simulation path
+type fiveTwoNineState struct {
+	beneficiaries []*fiveTwoNineBeneficiary
+}
+
+// newFiveTwoNineState splits the initial 529 balance among the configured beneficiaries.
+// Returns nil when there is neither a balance nor a plan; contributions create one later.
+func newFiveTwoNineState(input *SimulationInput) *fiveTwoNineState {
+	account := input.InitialAccounts.FiveTwoNine
+	balance, costBasis := 0.0, 0.0
+	if account != nil {
+		balance = account.TotalValue
+		for _, holding := range account.Holdings {
+			costBasis += holding.CostBasisTotal
+		}
+	}
+	if costBasis <= 0 {
+		costBasis = balance
+	}
+	if balance <= 0 && len(input.FiveTwoNinePlans) == 0 {
+		return nil
+	}
+
+	state := &fiveTwoNineState{}
+	assigned := 0.0
+	for _, plan := range input.FiveTwoNinePlans {
+		assigned += plan.Balance
+	}
+	for _, plan := range input.FiveTwoNinePlans {
+		b := &fiveTwoNineBeneficiary{name: plan.Beneficiary, openedYear: plan.OpenedYear, basis: plan.Contributions}
+		if b.openedYear == 0 {
+			b.openedYear = input.StartYear
+		}
+		if assigned > 0 {
+			b.share = plan.Balance / assigned
+			if b.basis == 0 {
+				b.basis = costBasis * b.share
+			}
+		}
+		state.beneficiaries = append(state.beneficiaries, b)
+	}
+
+	// Without per-beneficiary balances the first beneficiary owns it all
+	if assigned <= 0 && balance > 0 {
+		if len(state.beneficiaries) == 0 {
+			state.beneficiaries = append(state.beneficiaries, &fiveTwoNineBeneficiary{openedYear: input.StartYear})
+		}
+		state.beneficiaries[0].share = 1
+		if state.beneficiaries[0].basis == 0 {
+			state.beneficiaries[0].basis = costBasis
+		}
+	}
+	return state
+}
+
+// beneficiary resolves an event's beneficiary. An empty name is the first beneficiary;
+// an unknown name opens a new account in openedYear.
+func (s *fiveTwoNineState) beneficiary(name string, openedYear int) *fiveTwoNineBeneficiary {
+	for _, b := range s.beneficiaries {
+		if b.name == name || name == "" {
+			return b
+		}
+	}
+	b := &fiveTwoNineBeneficiary{name: name, openedYear: openedYear}
+	s.beneficiaries = append(s.beneficiaries, b)
+	return b
+}
+
+// reallocate moves delta into (or out of) target's share after the pooled balance
+// changed from balanceBefore to balanceAfter
+func (s *fiveTwoNineState) reallocate(target *fiveTwoNineBeneficiary, delta, balanceBefore, balanceAfter float64) {
+	for _, b := range s.beneficiaries {
+		value := b.share * balanceBefore
+		if b == target {
+			value += delta
+		}
+		if balanceAfter > 0 {
+			b.share = math.Max(0, value) / balanceAfter
+		} else {
+			b.share = 0
+		}
+	}
+}
+
+// fiveTwoNineBeneficiary returns the beneficiary named by an event, creating the 529 state on first use
+func (se *SimulationEngine) fiveTwoNineBeneficiary(name string, monthOffset int) *fiveTwoNineBeneficiary {
+	if se.fiveTwoNine == nil {
+		se.fiveTwoNine = &fiveTwoNineState{}
+	}
+	return se.fiveTwoNine.beneficiary(name, se.calendarYear(monthOffset))
+}
+
+// fiveTwoNineBalance returns a beneficiary's part of the pooled 529 account
+func fiveTwoNineBalance(b *fiveTwoNineBeneficiary, accounts *AccountHoldingsMonthEnd) float64 {
+	if account := GetFiveTwoNineAccount(accounts); account != nil {
+		return b.share * account.TotalValue
+	}
+	return 0
+}
+
+// contributeToFiveTwoNine invests a contribution in the 529 account for a beneficiary
+func (se *SimulationEngine) contributeToFiveTwoNine(accounts *AccountHoldingsMonthEnd, beneficiary string, amount float64, assetClass AssetClass, currentMonth int) error {
+	if accounts.FiveTwoNine == nil {
+		accounts.FiveTwoNine = &Account{Holdings: []Holding{}}
+	}
+	b := se.fiveTwoNineBeneficiary(beneficiary, currentMonth)
+	balanceBefore := accounts.FiveTwoNine.TotalValue
+	if err := se.cashManager.AddHoldingWithLotTracking(accounts.FiveTwoNine, assetClass, amount, currentMonth); err != nil {
+		return fmt.Errorf("failed to create 529 holding: %w", err)
+	}
+	se.fiveTwoNine.reallocate(b, amount, balanceBefore, accounts.FiveTwoNine.TotalValue)
+	b.basis += amount
+	b.contributions = append(b.contributions, fiveTwoNineContribution{month: currentMonth, amount: amount})
+	return nil
+}
+
+// sellFromFiveTwoNine sells from a beneficiary's share and returns the proceeds and the basis they carry
+func (se *SimulationEngine) sellFromFiveTwoNine(accounts *AccountHoldingsMonthEnd, b *fiveTwoNineBeneficiary, amount float64, currentMonth int) (proceeds, basis float64) {
+	balance := fiveTwoNineBalance(b, accounts)
+	amount = math.Min(amount, balance)
+	if amount <= 0 {
+		return 0, 0
+	}
+
+	balanceBefore := accounts.FiveTwoNine.TotalValue
+	saleResult := se.cashManager.SellAssetsFromAccountFIFO(accounts.FiveTwoNine, amount, currentMonth)
+	proceeds = saleResult.TotalProceeds
+	se.fiveTwoNine.reallocate(b, -proceeds, balanceBefore, accounts.FiveTwoNine.TotalValue)
+
+	basis = b.basis * math.Min(1, proceeds/balance)
+	b.basis -= basis
+	return proceeds, basis
+}
+
+// withdrawFromFiveTwoNine distributes from a beneficiary's share to cash. Whether the earnings
+// in it are taxed is settled at year end against the year's qualified expenses.
+func (se *SimulationEngine) withdrawFromFiveTwoNine(accounts *AccountHoldingsMonthEnd, b *fiveTwoNineBeneficiary, amount float64, currentMonth int) float64 {
+	proceeds, basis := se.sellFromFiveTwoNine(accounts, b, amount, currentMonth)
+	accounts.Cash += proceeds
+	b.distributionsYTD += proceeds
+	b.earningsYTD += math.Max(0, proceeds-basis)
+	return proceeds
+}
+
+// rothRolloverLimit returns how much of a beneficiary's 529 may roll into a Roth IRA this year
+// before the IRA limit: 15-year account, seasoned contributions only, $35,000 lifetime cap
+func (se *SimulationEngine) rothRolloverLimit(b *fiveTwoNineBeneficiary, accounts *AccountHoldingsMonthEnd, currentMonth int) float64 {
+	if se.calendarYear(currentMonth)-b.openedYear < fiveTwoNineRothMinAccountYears {
+		return 0
+	}
+	eligible := fiveTwoNineBalance(b, accounts)
+	for _, c := range b.contributions {
+		if currentMonth-c.month < fiveTwoNineRothSeasoningMonths {
+			eligible -= c.amount
+		}
+	}
+	return math.Max(0, math.Min(eligible, fiveTwoNineRothLifetimeLimit-b.rothRollovers))
+}
+
+// rothRolloverTracker returns the IRA limit tracker for a beneficiary and whether their Roth
+// is the plan's Roth account
+func (se *SimulationEngine) rothRolloverTracker(b *fiveTwoNineBeneficiary, currentMonth int) (*ContributionLimitTracker, bool) {
+	if se.household != nil {
+		for _, m := range se.household.members {
+			if m.ID == b.name {
+				return se.household.contributionTracker(b.name, currentMonth), true
+			}
+		}
+	} else if b.name == FiveTwoNineBeneficiarySelf {
+		return se.contributionTrackerFor("", currentMonth), true
+	}
+
+	if b.rothTracker == nil {
+		b.rothTracker = NewContributionLimitTracker()
+	}
+	b.rothTracker.ResetForNewYear(se.calendarYear(currentMonth))
+	return b.rothTracker, false
+}
+
+// settleFiveTwoNineYear taxes the earnings in each beneficiary's non-qualified distributions
+// for the year and resets the year's totals
+func (se *SimulationEngine) settleFiveTwoNineYear(monthOffset int) {
+	if se.fiveTwoNine == nil {
+		return
+	}
+	for _, b := range se.fiveTwoNine.beneficiaries {
+		if b.distributionsYTD > 0 && b.distributionsYTD > b.qualifiedExpensesYTD {
+			nonQualifiedFraction := (b.distributionsYTD - b.qualifiedExpensesYTD) / b.distributionsYTD
+			taxableEarnings := b.earningsYTD * nonQualifiedFraction
+			penalty := taxableEarnings * fiveTwoNinePenaltyRate
+			se.ordinaryIncomeYTD += taxableEarnings
+			se.penaltyTaxYTD += penalty
+
+			simLogEvent("INFO  [Month %d] 529 non-qualified distributions | Beneficiary: %s | Distributions: $%.2f | Qualified expenses: $%.2f | Taxable earnings: $%.2f | Additional tax: $%.2f",
+				monthOffset, b.name, b.distributionsYTD, b.qualifiedExpensesYTD, taxableEarnings, penalty)
+		}
+		b.distributionsYTD = 0
+		b.earningsYTD = 0
+		b.qualifiedExpensesYTD = 0
+	}
+}
+
+// captureFiveTwoNineState reports each beneficiary's part of the 529 account
+func (se *SimulationEngine) captureFiveTwoNineState(accounts *AccountHoldingsMonthEnd) []FiveTwoNineBeneficiaryState {
+	if se.fiveTwoNine == nil {
+		return nil
+	}
+	states := make([]FiveTwoNineBeneficiaryState, 0, len(se.fiveTwoNine.beneficiaries))
+	for _, b := range se.fiveTwoNine.beneficiaries {
+		states = append(states, FiveTwoNineBeneficiaryState{
+			Beneficiary:          b.name,
+			Balance:              fiveTwoNineBalance(b, accounts),
+			Contributions:        b.basis,
+			DistributionsYTD:     b.distributionsYTD,
+			QualifiedExpensesYTD: b.qualifiedExpensesYTD,
+			RothRollovers:        b.rothRollovers,
+		})
+	}
+	return states
+}
@@ -0,0 +1,149 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func newFiveTwoNineTestEngine(t *testing.T, balance float64, plans []FiveTwoNinePlan) (*SimulationEngine, *AccountHoldingsMonthEnd) {
+	t.Helper()
+	accounts := &AccountHoldingsMonthEnd{Cash: 50000, Roth: &Account{Holdings: []Holding{}}, FiveTwoNine: &Account{Holdings: []Holding{}}}
+	se := newHandlerTestEngine(t, SimulationInput{InitialAge: 50, FiveTwoNinePlans: plans}, accounts)
+	fundTestAccount(t, se, accounts.FiveTwoNine, balance)
+	se.fiveTwoNine = newFiveTwoNineState(se.simulationInput)
+	return se, accounts
+}
+
+func TestFiveTwoNineBeneficiaryShares(t *testing.T) {
+	se, accounts := newFiveTwoNineTestEngine(t, 40000, []FiveTwoNinePlan{
+		{Beneficiary: "alice", Balance: 30000},
+		{Beneficiary: "bob", Balance: 10000},
+	})
+
+	err := processTestEvent(t, &FiveTwoNineContributionEventHandler{}, se, accounts, FinancialEvent{
+		ID: "c", Type: "FIVE_TWO_NINE_CONTRIBUTION", Amount: 10000, Metadata: map[string]interface{}{"beneficiary": "bob"},
+	})
+	if err != nil {
+		t.Fatalf("contribution: %v", err)
+	}
+	alice, bob := se.fiveTwoNineBeneficiary("alice", 0), se.fiveTwoNineBeneficiary("bob", 0)
+	if math.Abs(fiveTwoNineBalance(alice, accounts)-30000) > 0.01 || math.Abs(fiveTwoNineBalance(bob, accounts)-20000) > 0.01 {
+		t.Errorf("balances: alice %.2f, bob %.2f", fiveTwoNineBalance(alice, accounts), fiveTwoNineBalance(bob, accounts))
+	}
+	if bob.basis != 20000 || len(accounts.FiveTwoNine.Holdings) == 0 || accounts.Cash != 40000 {
+		t.Errorf("contribution should be invested and added to basis: basis %.2f, cash %.2f", bob.basis, accounts.Cash)
+	}
+
+	// Withdrawals are limited to the beneficiary's own balance
+	err = processTestEvent(t, &FiveTwoNineWithdrawalEventHandler{}, se, accounts, FinancialEvent{
+		ID: "w", Type: "FIVE_TWO_NINE_WITHDRAWAL", Amount: 25000, Metadata: map[string]interface{}{"beneficiary": "bob"},
+	})
+	if err == nil {
+		t.Error("expected an error withdrawing more than bob's balance")
+	}
+
+	// A new beneficiary opens their own share
+	err = processTestEvent(t, &FiveTwoNineContributionEventHandler{}, se, accounts, FinancialEvent{
+		ID: "c", Type: "FIVE_TWO_NINE_CONTRIBUTION", Amount: 5000, Metadata: map[string]interface{}{"beneficiary": "carol"},
+	})
+	if err != nil {
+		t.Fatalf("contribution: %v", err)
+	}
+	states := se.captureFiveTwoNineState(accounts)
+	if len(states) != 3 || math.Abs(states[2].Balance-5000) > 0.01 || math.Abs(states[0].Balance-30000) > 0.01 {
+		t.Errorf("beneficiary states: %+v", states)
+	}
+}
+
+func TestFiveTwoNineNonQualifiedDistributions(t *testing.T) {
+	// $20,000 balance on $10,000 of contributions: half of every distribution is earnings
+	se, accounts := newFiveTwoNineTestEngine(t, 20000, []FiveTwoNinePlan{{Beneficiary: "alice", Balance: 20000, Contributions: 10000}})
+	withdraw := FinancialEvent{ID: "w", Type: "FIVE_TWO_NINE_WITHDRAWAL", Amount: 8000, Metadata: map[string]interface{}{"beneficiary": "alice"}}
+	if err := processTestEvent(t, &FiveTwoNineWithdrawalEventHandler{}, se, accounts, withdraw); err != nil {
+		t.Fatalf("withdrawal: %v", err)
+	}
+	tuition := FinancialEvent{ID: "t", Type: "TUITION_PAYMENT", Amount: 5000, Metadata: map[string]interface{}{"beneficiary": "alice"}}
+	if err := processTestEvent(t, &TuitionPaymentEventHandler{}, se, accounts, tuition); err != nil {
+		t.Fatalf("tuition: %v", err)
+	}
+	if math.Abs(accounts.Cash-53000) > 0.01 {
+		t.Errorf("cash %.2f, want 53000", accounts.Cash)
+	}
+
+	// $3,000 of the $8,000 is non-qualified: 3/8 of the $4,000 of earnings is taxable
+	se.settleFiveTwoNineYear(11)
+	if math.Abs(se.ordinaryIncomeYTD-1500) > 0.01 || math.Abs(se.penaltyTaxYTD-150) > 0.01 {
+		t.Errorf("taxable earnings %.2f (want 1500), additional tax %.2f (want 150)", se.ordinaryIncomeYTD, se.penaltyTaxYTD)
+	}
+	alice := se.fiveTwoNineBeneficiary("alice", 0)
+	if alice.distributionsYTD != 0 || alice.qualifiedExpensesYTD != 0 || math.Abs(alice.basis-6000) > 0.01 {
+		t.Errorf("after settlement: distributions %.2f, expenses %.2f, basis %.2f", alice.distributionsYTD, alice.qualifiedExpensesYTD, alice.basis)
+	}
+
+	// Tuition funded from the 529 is fully qualified
+	se.ordinaryIncomeYTD, se.penaltyTaxYTD = 0, 0
+	tuition.Metadata["fundFrom529"] = true
+	if err := processTestEvent(t, &TuitionPaymentEventHandler{}, se, accounts, tuition); err != nil {
+		t.Fatalf("tuition: %v", err)
+	}
+	se.settleFiveTwoNineYear(23)
+	if math.Abs(accounts.Cash-53000) > 0.01 || math.Abs(fiveTwoNineBalance(alice, accounts)-7000) > 0.01 {
+		t.Errorf("funded tuition: cash %.2f, 529 %.2f", accounts.Cash, fiveTwoNineBalance(alice, accounts))
+	}
+	if se.ordinaryIncomeYTD != 0 || se.penaltyTaxYTD != 0 {
+		t.Error("a distribution matched by tuition should be tax-free")
+	}
+}
+
+func TestFiveTwoNineRothRollover(t *testing.T) {
+	rollover := func(se *SimulationEngine, accounts *AccountHoldingsMonthEnd, beneficiary string, month int) {
+		t.Helper()
+		err := processTestEvent(t, &FiveTwoNineRothRolloverEventHandler{}, se, accounts, FinancialEvent{
+			ID: "r", Type: "FIVE_TWO_NINE_ROTH_ROLLOVER", MonthOffset: month, Metadata: map[string]interface{}{"beneficiary": beneficiary},
+		})
+		if err != nil {
+			t.Fatalf("rollover: %v", err)
+		}
+	}
+
+	// A child's rollover goes to their own Roth, one IRA limit per year
+	se, accounts := newFiveTwoNineTestEngine(t, 50000, []FiveTwoNinePlan{{Beneficiary: "kid", Balance: 50000, OpenedYear: 2005}})
+	kid := se.fiveTwoNineBeneficiary("kid", 0)
+	rollover(se, accounts, "kid", 0)
+	rollover(se, accounts, "kid", 0)
+	if math.Abs(kid.rothRollovers-7000) > 0.01 || math.Abs(fiveTwoNineBalance(kid, accounts)-43000) > 0.01 || accounts.Roth.TotalValue != 0 {
+		t.Errorf("2025 rollover: rolled %.2f, 529 %.2f, plan Roth %.2f", kid.rothRollovers, fiveTwoNineBalance(kid, accounts), accounts.Roth.TotalValue)
+	}
+
+	// The $35,000 lifetime cap
+	kid.rothRollovers = 32000
+	rollover(se, accounts, "kid", 12)
+	if math.Abs(kid.rothRollovers-35000) > 0.01 {
+		t.Errorf("lifetime cap: rolled %.2f, want 35000", kid.rothRollovers)
+	}
+
+	// The account must be open 15 years, and recent contributions must season 5 years
+	se, accounts = newFiveTwoNineTestEngine(t, 50000, []FiveTwoNinePlan{{Beneficiary: "kid", Balance: 50000, OpenedYear: 2015}})
+	rollover(se, accounts, "kid", 0)
+	if se.fiveTwoNineBeneficiary("kid", 0).rothRollovers != 0 {
+		t.Error("no rollover from an account open under 15 years")
+	}
+	se, accounts = newFiveTwoNineTestEngine(t, 0, []FiveTwoNinePlan{{Beneficiary: FiveTwoNineBeneficiarySelf, OpenedYear: 2000}})
+	if err := se.contributeToFiveTwoNine(accounts, FiveTwoNineBeneficiarySelf, 10000, AssetClassUSStocksTotalMarket, 0); err != nil {
+		t.Fatalf("contribution: %v", err)
+	}
+	rollover(se, accounts, FiveTwoNineBeneficiarySelf, 12)
+	if accounts.Roth.TotalValue != 0 {
+		t.Error("contributions from the last 5 years can't be rolled over")
+	}
+
+	// A beneficiary in the plan rolls into the plan's Roth, sharing their IRA limit
+	rollover(se, accounts, FiveTwoNineBeneficiarySelf, 60)
+	tracker := se.contributionTrackerFor("", 60)
+	if math.Abs(accounts.Roth.TotalValue-tracker.GetLimit("roth")) > 0.01 || tracker.GetRemainingRoom("roth") > 0.01 {
+		t.Errorf("self rollover: plan Roth %.2f, IRA room left %.2f", accounts.Roth.TotalValue, tracker.GetRemainingRoom("roth"))
+	}
+	if se.ordinaryIncomeYTD != 0 || se.penaltyTaxYTD != 0 {
+		t.Error("a Roth rollover is not a taxable distribution")
+	}
+}
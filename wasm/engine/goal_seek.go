@@ -0,0 +1,525 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+/**
+ * Goal Seek
+ *
+ * SolveGoal answers the backward questions ("how much can I spend", "when can I
+ * retire") by bisecting one plan input until a Monte Carlo target is just met.
+ * Every evaluation runs the same path seeds (RandomSeed + i for path i), so two
+ * candidate values see identical market returns and the target metric moves only
+ * because the input moved. With independent runs, sampling noise could reverse the
+ * order of two nearby candidates and send the bisection the wrong way.
+ *
+ * Variables:
+ *   spending          annual recurring expenses, maximized
+ *   retirement_month  the month salary and contributions stop, minimized
+ *   savings_rate      annual contributions as a share of annual income, minimized
+ *   contribution      annual contributions, minimized
+ *
+ * Spending, savings rate and contribution scale the selected events by one factor,
+ * so their mix and timing are kept; the value is the annual total the selected
+ * events start at. Retirement ends each selected event at the earlier of its own
+ * end and the retirement month, and moves the withdrawal policy's start there.
+ *
+ * The confidence band re-solves at the edges of the target's sampling error: a
+ * percentile target moves to the quantiles q ± z·sqrt(q(1-q)/N) and a probability
+ * target to X ± z·sqrt(X(1-X)/N). Evaluations are cached by value, so the three
+ * solves share their simulations.
+ */
+
+// Goal-seek variables
+const (
+	GoalVariableSpending        = "spending"
+	GoalVariableRetirementMonth = "retirement_month"
+	GoalVariableSavingsRate     = "savings_rate"
+	GoalVariableContribution    = "contribution"
+)
+
+// Goal-seek target metrics
+const (
+	GoalMetricTerminalWealthP10     = "terminal_wealth_p10"
+	GoalMetricTerminalWealthP50     = "terminal_wealth_p50"
+	GoalMetricBreachProbability     = "breach_probability"
+	GoalMetricBankruptcyProbability = "bankruptcy_probability"
+)
+
+const (
+	goalSeekDefaultPaths      = 200
+	goalSeekDefaultIterations = 30
+	goalSeekConfidenceLevel   = 0.90
+	goalSeekConfidenceZ       = 1.645
+)
+
+// GoalTarget is the Monte Carlo condition the solved value must meet
+type GoalTarget struct {
+	Metric    string  `json:"metric"`
+	Threshold float64 `json:"threshold"`       // Wealth floor, or the highest acceptable probability
+	Month     int     `json:"month,omitempty"` // Breach probability within this many months (0 = the whole plan)
+}
+
+// GoalSeekRequest describes one solve
+type GoalSeekRequest struct {
+	Input         SimulationInput `json:"input"`
+	Variable      string          `json:"variable"`
+	EventIDs      []string        `json:"eventIds,omitempty"` // Events the variable changes (default: chosen by type)
+	Target        GoalTarget      `json:"target"`
+	Lower         float64         `json:"lower,omitempty"` // Search range (default per variable)
+	Upper         float64         `json:"upper,omitempty"`
+	Tolerance     float64         `json:"tolerance,omitempty"`     // Stop when the bracket is this narrow (default 0.1% of the range, 1 month)
+	Paths         int             `json:"paths,omitempty"`         // Monte Carlo paths per evaluation (default 200)
+	MaxIterations int             `json:"maxIterations,omitempty"` // Bisection steps per solve (default 30)
+}
+
+// GoalSeekIteration is one bisection step of the main solve
+type GoalSeekIteration struct {
+	Value  float64 `json:"value"`
+	Metric float64 `json:"metric"`
+	Met    bool    `json:"met"`
+}
+
+// GoalSeekResult is the solved value with its confidence band
+type GoalSeekResult struct {
+	Variable        string              `json:"variable"`
+	Value           float64             `json:"value"`
+	Low             float64             `json:"low"`
+	High            float64             `json:"high"`
+	ConfidenceLevel float64             `json:"confidenceLevel"`
+	Feasible        bool                `json:"feasible"` // False when even the most favourable value misses the target
+	AtBound         bool                `json:"atBound"`  // The target is still met at the far end of the search range
+	Metric          float64             `json:"metric"`   // The target metric at Value
+	Target          GoalTarget          `json:"target"`
+	RetirementAge   float64             `json:"retirementAge,omitempty"`
+	Paths           int                 `json:"paths"`
+	Evaluations     int                 `json:"evaluations"`
+	Iterations      []GoalSeekIteration `json:"iterations"`
+}
+
+// goalCondition is one target to solve for: a quantile of terminal wealth at or
+// above the threshold, or a probability at or below it
+type goalCondition struct {
+	quantile  float64
+	threshold float64
+}
+
+// goalSeeker holds the state shared by the main solve and the band solves
+type goalSeeker struct {
+	req      GoalSeekRequest
+	selected []int   // Indices of the events the variable changes
+	base     float64 // Annual total the selected events start at
+	income   float64 // Annual income, for the savings rate
+	maximize bool
+	integer  bool
+	cache    map[float64][]MCPathMetrics
+}
+
+// SolveGoal bisects the request's variable until the target is just met
+func SolveGoal(req GoalSeekRequest) (*GoalSeekResult, error) {
+	if req.Paths <= 0 {
+		req.Paths = goalSeekDefaultPaths
+	}
+	if req.MaxIterations <= 0 {
+		req.MaxIterations = goalSeekDefaultIterations
+	}
+	if req.Input.MonthsToRun <= 0 {
+		return nil, fmt.Errorf("goal seek needs a positive monthsToRun")
+	}
+
+	gs := &goalSeeker{req: req, cache: make(map[float64][]MCPathMetrics)}
+	if err := gs.init(); err != nil {
+		return nil, err
+	}
+	condition, err := gs.condition()
+	if err != nil {
+		return nil, err
+	}
+
+	var iterations []GoalSeekIteration
+	value, feasible, atBound, err := gs.solve(condition, &iterations)
+	if err != nil {
+		return nil, err
+	}
+	metric, _, err := gs.check(value, condition)
+	if err != nil {
+		return nil, err
+	}
+
+	low, high := value, value
+	for _, edge := range gs.bandConditions(condition) {
+		v, _, _, err := gs.solve(edge, nil)
+		if err != nil {
+			return nil, err
+		}
+		low, high = math.Min(low, v), math.Max(high, v)
+	}
+
+	result := &GoalSeekResult{
+		Variable:        req.Variable,
+		Value:           value,
+		Low:             low,
+		High:            high,
+		ConfidenceLevel: goalSeekConfidenceLevel,
+		Feasible:        feasible,
+		AtBound:         atBound,
+		Metric:          metric,
+		Target:          req.Target,
+		Paths:           req.Paths,
+		Evaluations:     len(gs.cache),
+		Iterations:      iterations,
+	}
+	if req.Variable == GoalVariableRetirementMonth && req.Input.InitialAge > 0 {
+		result.RetirementAge = float64(req.Input.InitialAge) + value/12
+	}
+	return result, nil
+}
+
+// init selects the events, sizes them and fills in the search range
+func (gs *goalSeeker) init() error {
+	req := &gs.req
+	var types []string
+	switch req.Variable {
+	case GoalVariableSpending:
+		types = []string{"EXPENSE", string(EventTypeRecurringExpense)}
+		gs.maximize = true
+	case GoalVariableRetirementMonth:
+		types = []string{"INCOME", string(EventTypeScheduledContribution)}
+		gs.integer = true
+	case GoalVariableSavingsRate, GoalVariableContribution:
+		types = []string{string(EventTypeScheduledContribution)}
+	default:
+		return fmt.Errorf("unknown goal variable %q", req.Variable)
+	}
+
+	selected, err := selectGoalEvents(req.Input.Events, types, req.EventIDs)
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		return fmt.Errorf("no recurring %v events for %s to change", types, req.Variable)
+	}
+	gs.selected = selected
+
+	upper := 0.0
+	switch req.Variable {
+	case GoalVariableRetirementMonth:
+		upper = float64(req.Input.MonthsToRun)
+	case GoalVariableSavingsRate:
+		upper = 1
+		incomes, _ := selectGoalEvents(req.Input.Events, []string{"INCOME"}, nil)
+		gs.income = goalAnnualTotal(req.Input.Events, incomes)
+		if gs.income <= 0 {
+			return fmt.Errorf("a savings rate needs recurring INCOME events")
+		}
+	}
+	if !gs.integer {
+		gs.base = goalAnnualTotal(req.Input.Events, selected)
+		if gs.base <= 0 {
+			return fmt.Errorf("the selected events for %s add up to nothing to scale", req.Variable)
+		}
+		if upper == 0 {
+			upper = 2 * gs.base
+		}
+	}
+
+	if req.Upper == 0 {
+		req.Upper = upper
+	}
+	if req.Lower < 0 || req.Lower >= req.Upper {
+		return fmt.Errorf("invalid search range [%.2f, %.2f]", req.Lower, req.Upper)
+	}
+	if req.Tolerance <= 0 {
+		req.Tolerance = (req.Upper - req.Lower) / 1000
+		if gs.integer {
+			req.Tolerance = 1
+		}
+	}
+	if gs.integer {
+		req.Lower, req.Upper = math.Ceil(req.Lower), math.Floor(req.Upper)
+		req.Tolerance = math.Max(1, math.Round(req.Tolerance))
+	}
+	return nil
+}
+
+// condition validates the target and returns it as a goal condition
+func (gs *goalSeeker) condition() (goalCondition, error) {
+	target := gs.req.Target
+	switch target.Metric {
+	case GoalMetricTerminalWealthP10:
+		return goalCondition{quantile: 0.10, threshold: target.Threshold}, nil
+	case GoalMetricTerminalWealthP50:
+		return goalCondition{quantile: 0.50, threshold: target.Threshold}, nil
+	case GoalMetricBreachProbability, GoalMetricBankruptcyProbability:
+		if target.Threshold < 0 || target.Threshold > 1 {
+			return goalCondition{}, fmt.Errorf("a probability target must be between 0 and 1, got %.4f", target.Threshold)
+		}
+		return goalCondition{threshold: target.Threshold}, nil
+	default:
+		return goalCondition{}, fmt.Errorf("unknown goal metric %q", target.Metric)
+	}
+}
+
+// bandConditions moves the target to either edge of its sampling error
+func (gs *goalSeeker) bandConditions(c goalCondition) []goalCondition {
+	n := float64(gs.req.Paths)
+	if c.quantile > 0 {
+		se := goalSeekConfidenceZ * math.Sqrt(c.quantile*(1-c.quantile)/n)
+		return []goalCondition{
+			{quantile: math.Max(c.quantile-se, 0), threshold: c.threshold},
+			{quantile: math.Min(c.quantile+se, 1), threshold: c.threshold},
+		}
+	}
+	// At X = 0 the binomial error vanishes; one path either way is the resolution
+	se := math.Max(goalSeekConfidenceZ*math.Sqrt(c.threshold*(1-c.threshold)/n), 1/n)
+	return []goalCondition{
+		{threshold: math.Max(c.threshold-se, 0)},
+		{threshold: math.Min(c.threshold+se, 1)},
+	}
+}
+
+// solve bisects between the most favourable end of the range, which must meet the
+// condition, and the far end. It returns the favourable end when nothing meets it.
+func (gs *goalSeeker) solve(c goalCondition, iterations *[]GoalSeekIteration) (value float64, feasible, atBound bool, err error) {
+	good, bad := gs.req.Upper, gs.req.Lower
+	if gs.maximize {
+		good, bad = bad, good
+	}
+	record := func(v float64) (bool, error) {
+		metric, met, err := gs.check(v, c)
+		if err == nil && iterations != nil {
+			*iterations = append(*iterations, GoalSeekIteration{Value: v, Metric: metric, Met: met})
+		}
+		return met, err
+	}
+
+	met, err := record(good)
+	if err != nil || !met {
+		return good, false, false, err
+	}
+	if met, err = record(bad); err != nil || met {
+		return bad, true, true, err
+	}
+
+	for i := 0; i < gs.req.MaxIterations && math.Abs(good-bad) > gs.req.Tolerance; i++ {
+		mid := (good + bad) / 2
+		if gs.integer {
+			mid = math.Floor(mid)
+		}
+		met, err := record(mid)
+		if err != nil {
+			return good, true, false, err
+		}
+		if met {
+			good = mid
+		} else {
+			bad = mid
+		}
+	}
+	return good, true, false, nil
+}
+
+// check evaluates the condition at one value of the variable
+func (gs *goalSeeker) check(value float64, c goalCondition) (metric float64, met bool, err error) {
+	paths, err := gs.evaluate(value)
+	if err != nil {
+		return 0, false, err
+	}
+
+	switch gs.req.Target.Metric {
+	case GoalMetricTerminalWealthP10, GoalMetricTerminalWealthP50:
+		wealth := make([]float64, len(paths))
+		for i, p := range paths {
+			wealth[i] = p.TerminalWealth
+		}
+		sort.Float64s(wealth)
+		metric = quantileSorted(wealth, c.quantile)
+		return metric, metric >= c.threshold, nil
+	case GoalMetricBreachProbability:
+		months := gs.req.Input.MonthsToRun
+		if m := gs.req.Target.Month; m > 0 && m < months {
+			months = m
+		}
+		series := calculateBreachTimeSeries(paths, months)
+		metric = series[len(series)-1].CumulativeBreachProb
+	default:
+		bankrupt := 0
+		for _, p := range paths {
+			if p.IsBankrupt {
+				bankrupt++
+			}
+		}
+		metric = float64(bankrupt) / float64(len(paths))
+	}
+	return metric, metric <= c.threshold, nil
+}
+
+// evaluate runs the Monte Carlo at one value of the variable, once per value
+func (gs *goalSeeker) evaluate(value float64) ([]MCPathMetrics, error) {
+	if paths, ok := gs.cache[value]; ok {
+		return paths, nil
+	}
+
+	input := gs.apply(value)
+	results, err := runMonteCarloPaths(input, gs.req.Paths)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]MCPathMetrics, len(results))
+	for i, result := range results {
+		// A bankrupt path is an outcome; any other failure is an error in the plan
+		if !result.Success && !result.IsBankrupt {
+			return nil, fmt.Errorf("%s %.2f: path %d failed: %s", gs.req.Variable, value, i, result.Error)
+		}
+		paths[i] = extractPathMetrics(result, i, input.Config.RandomSeed+int64(i), input.Config.CashFloor)
+	}
+	gs.cache[value] = paths
+	return paths, nil
+}
+
+// apply returns a copy of the input with the variable set to value. The caller's
+// events and metadata are never modified.
+func (gs *goalSeeker) apply(value float64) SimulationInput {
+	input := gs.req.Input
+	input.Events = append([]FinancialEvent(nil), input.Events...)
+
+	if gs.req.Variable == GoalVariableRetirementMonth {
+		month := int(value)
+		for _, i := range gs.selected {
+			event := &input.Events[i]
+			end := month
+			if own, ok := eventEndMonth(*event); ok && own < end {
+				end = own
+			}
+			metadata := make(map[string]interface{}, len(event.Metadata)+1)
+			for k, v := range event.Metadata {
+				metadata[k] = v
+			}
+			metadata["endDateOffset"] = end
+			event.Metadata = metadata
+		}
+		if input.WithdrawalPolicy != nil {
+			policy := *input.WithdrawalPolicy
+			policy.StartMonth = month
+			input.WithdrawalPolicy = &policy
+		}
+		return input
+	}
+
+	annual := value
+	if gs.req.Variable == GoalVariableSavingsRate {
+		annual = value * gs.income
+	}
+	factor := annual / gs.base
+	for _, i := range gs.selected {
+		input.Events[i].Amount *= factor
+	}
+	return input
+}
+
+// selectGoalEvents returns the indices of the recurring events of the given types, or
+// of the named events when ids is set
+func selectGoalEvents(events []FinancialEvent, types []string, ids []string) ([]int, error) {
+	var selected []int
+	if len(ids) > 0 {
+		for _, id := range ids {
+			found := false
+			for i, event := range events {
+				if event.ID == id {
+					selected = append(selected, i)
+					found = true
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("no event with id %q", id)
+			}
+		}
+		return selected, nil
+	}
+
+	for i, event := range events {
+		if annualOccurrences(event) == 0 {
+			continue
+		}
+		for _, t := range types {
+			if event.Type == t {
+				selected = append(selected, i)
+				break
+			}
+		}
+	}
+	return selected, nil
+}
+
+// goalAnnualTotal is the annual amount of the selected events that start first, so a
+// salary split around a sabbatical counts once
+func goalAnnualTotal(events []FinancialEvent, selected []int) float64 {
+	first := math.MaxInt
+	for _, i := range selected {
+		if events[i].MonthOffset < first {
+			first = events[i].MonthOffset
+		}
+	}
+	total := 0.0
+	for _, i := range selected {
+		if events[i].MonthOffset == first {
+			total += events[i].Amount * annualOccurrences(events[i])
+		}
+	}
+	return total
+}
+
+// annualOccurrences converts an event's amount to a yearly total, matching the
+// preprocessor's schedule (annual INCOME is already an annual amount). One-time
+// events are 0.
+func annualOccurrences(event FinancialEvent) float64 {
+	frequency := event.Frequency
+	if frequency == "" {
+		frequency, _ = event.Metadata["frequency"].(string)
+	}
+	switch frequency {
+	case "monthly":
+		return 12
+	case "biweekly":
+		return 24
+	case "quarterly":
+		return 4
+	case "semiannually":
+		return 2
+	case "annually", "annual":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// eventEndMonth reads an event's end month the way the preprocessor does
+func eventEndMonth(event FinancialEvent) (int, bool) {
+	for _, key := range []string{"endDateOffset", "endMonthOffset"} {
+		switch v := event.Metadata[key].(type) {
+		case float64:
+			return int(v), true
+		case int:
+			return v, true
+		}
+		if _, ok := event.Metadata[key]; ok {
+			return 0, false
+		}
+	}
+	return 0, false
+}
+
+// quantileSorted interpolates the q-quantile of sorted values, as calculatePercentiles does
+func quantileSorted(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	index := q * float64(len(sorted)-1)
+	lower := int(math.Floor(index))
+	upper := int(math.Ceil(index))
+	weight := index - float64(lower)
+	return sorted[lower]*(1-weight) + sorted[upper]*weight
+}
@@ -0,0 +1,124 @@
+package engine
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestSolveGoalSpending(t *testing.T) {
+	input := createRollingTestInput(1e6, 40000)
+	input.Config.RandomSeed = 7
+	input.Config.SimulationMode = "stochastic"
+	input.InitialAge = 60
+	input.StartYear = 2025
+	input.MonthsToRun = 120
+
+	req := GoalSeekRequest{
+		Input:    input,
+		Variable: GoalVariableSpending,
+		Target:   GoalTarget{Metric: GoalMetricTerminalWealthP10, Threshold: 0},
+		Upper:    300000,
+		Paths:    40,
+	}
+	result, err := SolveGoal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Feasible || result.AtBound {
+		t.Fatalf("expected an interior solution, got %+v", result)
+	}
+	if result.Value <= 40000 || result.Low > result.Value || result.High < result.Value || result.High == result.Low {
+		t.Errorf("value %.0f with band [%.0f, %.0f]", result.Value, result.Low, result.High)
+	}
+	if input.Events[0].Amount != 40000.0/12 {
+		t.Errorf("the caller's spending event changed to %.2f", input.Events[0].Amount)
+	}
+
+	// The solved spending keeps P10 terminal wealth above zero and a little more does not,
+	// on the same paths
+	p10 := func(annual float64) float64 {
+		scaled := input
+		scaled.Events = []FinancialEvent{input.Events[0]}
+		scaled.Events[0].Amount = annual / 12
+		results, err := runMonteCarloPaths(scaled, req.Paths)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wealth := make([]float64, len(results))
+		for i, r := range results {
+			wealth[i] = r.FinalNetWorth
+		}
+		sort.Float64s(wealth)
+		return quantileSorted(wealth, 0.10)
+	}
+	tolerance := (req.Upper - req.Lower) / 1000
+	if got := p10(result.Value); got < 0 || math.Abs(got-result.Metric) > 1e-6 {
+		t.Errorf("P10 at the solution %.2f, reported %.2f", got, result.Metric)
+	}
+	if got := p10(result.Value + 2*tolerance); got >= 0 {
+		t.Errorf("P10 %.2f above the solution should miss the target", got)
+	}
+
+	again, err := SolveGoal(req)
+	if err != nil || again.Value != result.Value {
+		t.Errorf("re-solving on the same seeds moved the value: %.2f vs %.2f (%v)", again.Value, result.Value, err)
+	}
+}
+
+func TestSolveGoalRetirementMonth(t *testing.T) {
+	input := createRollingTestInput(300000, 60000)
+	input.Config.RandomSeed = 19
+	input.Config.SimulationMode = "stochastic"
+	input.InitialAge = 45
+	input.StartYear = 2025
+	input.MonthsToRun = 240
+	input.Events = append(input.Events, FinancialEvent{ID: "salary", Type: "INCOME", Amount: 120000, Frequency: "annually",
+		Metadata: map[string]interface{}{"endDateOffset": 200}})
+
+	result, err := SolveGoal(GoalSeekRequest{
+		Input:    input,
+		Variable: GoalVariableRetirementMonth,
+		Target:   GoalTarget{Metric: GoalMetricBankruptcyProbability, Threshold: 0.1},
+		Paths:    30,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Feasible || result.AtBound || result.Value != math.Floor(result.Value) || result.Value <= 0 || result.Value > 200 {
+		t.Fatalf("expected a retirement month inside the salary, got %+v", result)
+	}
+	if result.RetirementAge != 45+result.Value/12 || result.Metric > 0.1 {
+		t.Errorf("retirement age %.2f with bankruptcy probability %.2f", result.RetirementAge, result.Metric)
+	}
+	if input.Events[1].Metadata["endDateOffset"] != 200 {
+		t.Errorf("the caller's salary end changed to %v", input.Events[1].Metadata["endDateOffset"])
+	}
+	last := result.Iterations[len(result.Iterations)-1]
+	if math.Abs(last.Value-result.Value) > 1 || len(result.Iterations) > 2+8 {
+		t.Errorf("bisection on months should narrow to one month in at most eight steps: %+v", result.Iterations)
+	}
+}
+
+func TestSolveGoalValidation(t *testing.T) {
+	input := createRollingTestInput(1e6, 40000)
+	input.Config.RandomSeed = 7
+	input.MonthsToRun = 12
+	wealth := GoalTarget{Metric: GoalMetricTerminalWealthP10}
+	saving := input
+	saving.Events = append(saving.Events, FinancialEvent{ID: "ira", Type: "SCHEDULED_CONTRIBUTION", Amount: 500, Frequency: "monthly"})
+
+	for name, req := range map[string]GoalSeekRequest{
+		"unknown variable":     {Input: input, Variable: "age", Target: wealth},
+		"unknown metric":       {Input: input, Variable: GoalVariableSpending, Target: GoalTarget{Metric: "success_rate"}},
+		"probability above 1":  {Input: input, Variable: GoalVariableSpending, Target: GoalTarget{Metric: GoalMetricBreachProbability, Threshold: 5}},
+		"unknown event":        {Input: input, Variable: GoalVariableSpending, EventIDs: []string{"rent"}, Target: wealth},
+		"nothing to scale":     {Input: input, Variable: GoalVariableContribution, Target: wealth},
+		"savings without wage": {Input: saving, Variable: GoalVariableSavingsRate, Target: wealth},
+		"empty range":          {Input: input, Variable: GoalVariableSpending, Lower: 50000, Upper: 10000, Target: wealth},
+	} {
+		if _, err := SolveGoal(req); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
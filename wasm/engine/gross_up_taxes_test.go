@@ -1,4 +1,4 @@
-package main
+package engine
 
 import (
 	"encoding/json"
@@ -631,7 +631,7 @@ func TestSellFromInvestmentsOnly_Direct(t *testing.T) {
 // TestGrossUp_DetailedEventTrace provides detailed event-by-event analysis
 func TestGrossUp_DetailedEventTrace(t *testing.T) {
 	input := createBasicInput()
-	input.InitialAge = 65 // Above 59.5 to avoid early withdrawal penalty
+	input.InitialAge = 64 // Above 59.5 to avoid early withdrawal penalty, under 65 to leave out Medicare premiums
 	// Start with $2000 cash and $50k in tax-deferred
 	input.InitialAccounts = AccountHoldingsMonthEnd{
 		Cash: 2000,
@@ -825,7 +825,7 @@ func TestGrossUp_MultipleAccountTypes(t *testing.T) {
 // TestGrossUp_RecurringExpensesManyMonths tests gross-up over extended period
 func TestGrossUp_RecurringExpensesManyMonths(t *testing.T) {
 	input := createBasicInput()
-	input.InitialAge = 65 // Avoid early withdrawal penalty
+	input.InitialAge = 64 // Avoid early withdrawal penalty and Medicare premiums
 	input.InitialAccounts = AccountHoldingsMonthEnd{
 		Cash: 0, // Start with zero cash
 		TaxDeferred: &Account{
@@ -910,7 +910,7 @@ func TestGrossUp_RecurringExpensesManyMonths(t *testing.T) {
 // TestGrossUp_ExactMathVerification verifies the exact math of gross-up
 func TestGrossUp_ExactMathVerification(t *testing.T) {
 	input := createBasicInput()
-	input.InitialAge = 65 // Avoid early withdrawal penalty
+	input.InitialAge = 64 // Avoid early withdrawal penalty and Medicare premiums
 	// Start with exactly $0 cash to force full withdrawal
 	input.InitialAccounts = AccountHoldingsMonthEnd{
 		Cash: 0,
@@ -1084,7 +1084,7 @@ func TestGrossUp_TaxableAccountWithGains(t *testing.T) {
 func TestGrossUp_CompareWithAndWithoutTaxes(t *testing.T) {
 	createInput := func(taxEnabled bool, taxRate float64) SimulationInput {
 		input := createBasicInput()
-		input.InitialAge = 65 // Avoid early withdrawal penalty
+		input.InitialAge = 64 // Avoid early withdrawal penalty and Medicare premiums
 		input.InitialAccounts = AccountHoldingsMonthEnd{
 			Cash: 0,
 			TaxDeferred: &Account{
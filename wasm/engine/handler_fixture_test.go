@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"testing"
+)
+
+// newHandlerTestEngine returns an engine for calling event handlers directly.
+// input supplies the plan fields the handler reads and accounts become its
+// InitialAccounts; StartYear defaults to 2025.
+func newHandlerTestEngine(t *testing.T, input SimulationInput, accounts *AccountHoldingsMonthEnd) *SimulationEngine {
+	t.Helper()
+	se := NewSimulationEngine(GetDefaultStochasticConfig())
+	if input.StartYear == 0 {
+		input.StartYear = 2025
+	}
+	input.InitialAccounts = *accounts
+	se.simulationInput = &input
+	return se
+}
+
+// fundTestAccount adds a lot-tracked total-market holding worth amount to account
+func fundTestAccount(t *testing.T, se *SimulationEngine, account *Account, amount float64) {
+	t.Helper()
+	if amount <= 0 {
+		return
+	}
+	if err := se.cashManager.AddHoldingWithLotTracking(account, AssetClassUSStocksTotalMarket, amount, 0); err != nil {
+		t.Fatalf("funding test account with $%.2f: %v", amount, err)
+	}
+}
+
+// processTestEvent runs one event through handler in the event's month
+func processTestEvent(t *testing.T, handler EventHandler, se *SimulationEngine, accounts *AccountHoldingsMonthEnd, event FinancialEvent) error {
+	t.Helper()
+	cashFlow := 0.0
+	return handler.Process(event, accounts, &cashFlow, &EventProcessingContext{SimulationEngine: se, CurrentMonth: event.MonthOffset})
+}
+
+// mustProcessTestEvent is processTestEvent for events that must succeed
+func mustProcessTestEvent(t *testing.T, handler EventHandler, se *SimulationEngine, accounts *AccountHoldingsMonthEnd, event FinancialEvent) {
+	t.Helper()
+	if err := processTestEvent(t, handler, se, accounts, event); err != nil {
+		t.Fatalf("%s: %v", event.Type, err)
+	}
+}
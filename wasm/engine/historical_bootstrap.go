@@ -0,0 +1,244 @@
+package engine
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+/**
+ * Historical Bootstrap Returns
+ *
+ * An alternative to the parametric model (correlated Student-t shocks with
+ * GARCH(1,1) volatility and AR(1) inflation). With SimulationMode set to
+ * "historical_bootstrap", each month's returns are a real month resampled from
+ * monthly_historical_data.json using the stationary block bootstrap
+ * (Politis & Romano, 1994):
+ *
+ * - Each month continues the current block with probability 1 - 1/L and starts
+ *   a new block at a uniformly drawn month with probability 1/L, so block
+ *   lengths are geometric with mean L (BootstrapMeanBlockLength)
+ * - Every asset in a month comes from the same calendar month, which keeps the
+ *   cross-asset correlation; runs of consecutive months keep autocorrelation
+ *   and volatility clustering
+ * - A block never crosses a gap in the data; it restarts instead of wrapping
+ *
+ * Months without stock, bond and inflation data are left out of the pool.
+ * Asset classes the data does not cover fall back to the configured means:
+ * international uses the SPY return when missing, home and rent use their
+ * configured means, "other" uses its mean, and individual stocks take the SPY
+ * return plus the configured premium over SPY.
+ *
+ * All draws come from the path's SeededRNG, so runs are reproducible.
+ */
+
+// SimulationModeHistoricalBootstrap selects block-bootstrapped historical returns
+const SimulationModeHistoricalBootstrap = "historical_bootstrap"
+
+// defaultBootstrapMeanBlockLength is the mean block length in months when none is configured
+const defaultBootstrapMeanBlockLength = 12.0
+
+//go:embed monthly_historical_data.json
+var monthlyHistoricalDataJSON []byte
+
+// bootstrapMonth is one complete month of the bootstrap pool
+type bootstrapMonth struct {
+	Year      int
+	Month     int
+	SPY       float64
+	Bond      float64
+	Inflation float64
+	Intl      *float64
+	Home      *float64
+	Rent      *float64
+}
+
+// bootstrapPool holds the usable months in calendar order
+type bootstrapPool struct {
+	months []bootstrapMonth
+	// follows[i] is true when months[i] is the calendar month after months[i-1]
+	follows []bool
+}
+
+var (
+	historicalBootstrapPool    *bootstrapPool
+	historicalBootstrapPoolErr error
+	historicalBootstrapOnce    sync.Once
+)
+
+// getBootstrapPool parses the embedded historical data once
+func getBootstrapPool() (*bootstrapPool, error) {
+	historicalBootstrapOnce.Do(func() {
+		historicalBootstrapPool, historicalBootstrapPoolErr = buildBootstrapPool(monthlyHistoricalDataJSON)
+	})
+	return historicalBootstrapPool, historicalBootstrapPoolErr
+}
+
+// buildBootstrapPool merges the monthly data of every data-only scenario into one
+// calendar series. Scenarios with events are backtest fixtures and are skipped.
+// When scenarios overlap, each field takes the first non-null value in scenario
+// name order. Nulls are kept as nil so missing data is not mistaken for a 0% month.
+func buildBootstrapPool(data []byte) (*bootstrapPool, error) {
+	var file struct {
+		Scenarios map[string]struct {
+			Events      []json.RawMessage `json:"events"`
+			MonthlyData []struct {
+				Year       int      `json:"year"`
+				Month      int      `json:"month"`
+				SPYReturn  *float64 `json:"spyReturn"`
+				BondReturn *float64 `json:"bondReturn"`
+				Inflation  *float64 `json:"inflation"`
+				IntlReturn *float64 `json:"intlReturn"`
+				HomeReturn *float64 `json:"homeReturn"`
+				RentGrowth *float64 `json:"rentGrowth"`
+			} `json:"monthlyData"`
+		} `json:"scenarios"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse historical data: %v", err)
+	}
+
+	names := make([]string, 0, len(file.Scenarios))
+	for name := range file.Scenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	type mergedMonth struct {
+		year, month                            int
+		spy, bond, inflation, intl, home, rent *float64
+	}
+	firstNonNil := func(current, candidate *float64) *float64 {
+		if current != nil {
+			return current
+		}
+		return candidate
+	}
+
+	merged := make(map[int]*mergedMonth)
+	for _, name := range names {
+		scenario := file.Scenarios[name]
+		if len(scenario.Events) > 0 {
+			continue
+		}
+		for _, m := range scenario.MonthlyData {
+			if m.Month < 1 || m.Month > 12 {
+				return nil, fmt.Errorf("scenario %s has invalid month %d-%d", name, m.Year, m.Month)
+			}
+			key := m.Year*12 + m.Month - 1
+			entry, ok := merged[key]
+			if !ok {
+				entry = &mergedMonth{year: m.Year, month: m.Month}
+				merged[key] = entry
+			}
+			entry.spy = firstNonNil(entry.spy, m.SPYReturn)
+			entry.bond = firstNonNil(entry.bond, m.BondReturn)
+			entry.inflation = firstNonNil(entry.inflation, m.Inflation)
+			entry.intl = firstNonNil(entry.intl, m.IntlReturn)
+			entry.home = firstNonNil(entry.home, m.HomeReturn)
+			entry.rent = firstNonNil(entry.rent, m.RentGrowth)
+		}
+	}
+
+	keys := make([]int, 0, len(merged))
+	for key, entry := range merged {
+		if entry.spy != nil && entry.bond != nil && entry.inflation != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Ints(keys)
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("historical data has no months with stock, bond and inflation data")
+	}
+
+	pool := &bootstrapPool{
+		months:  make([]bootstrapMonth, len(keys)),
+		follows: make([]bool, len(keys)),
+	}
+	for i, key := range keys {
+		entry := merged[key]
+		pool.months[i] = bootstrapMonth{
+			Year:      entry.year,
+			Month:     entry.month,
+			SPY:       *entry.spy,
+			Bond:      *entry.bond,
+			Inflation: *entry.inflation,
+			Intl:      entry.intl,
+			Home:      entry.home,
+			Rent:      entry.rent,
+		}
+		pool.follows[i] = i > 0 && keys[i-1] == key-1
+	}
+	return pool, nil
+}
+
+// nextBootstrapIndex advances the stationary bootstrap by one month
+func (p *bootstrapPool) nextBootstrapIndex(state StochasticState, meanBlockLength float64, rng *SeededRNG) int {
+	next := state.BootstrapIndex + 1
+	if state.BootstrapInBlock && next < len(p.months) && p.follows[next] && rng.Float64() >= 1/meanBlockLength {
+		return next
+	}
+	start := int(rng.Float64() * float64(len(p.months)))
+	if start >= len(p.months) {
+		start = len(p.months) - 1
+	}
+	return start
+}
+
+// generateHistoricalBootstrapReturns draws one month of returns from the historical pool
+func generateHistoricalBootstrapReturns(state StochasticState, config *StochasticModelConfig, rng *SeededRNG) (StochasticReturns, StochasticState, error) {
+	pool, err := getBootstrapPool()
+	if err != nil {
+		return StochasticReturns{}, state, err
+	}
+
+	meanBlockLength := config.BootstrapMeanBlockLength
+	if meanBlockLength <= 0 {
+		meanBlockLength = defaultBootstrapMeanBlockLength
+	}
+
+	index := pool.nextBootstrapIndex(state, meanBlockLength, rng)
+	returns := pool.months[index].returns(config)
+
+	newState := state
+	newState.BootstrapIndex = index
+	newState.BootstrapInBlock = true
+	return returns, newState, nil
+}
+
+// returns maps a historical month onto every asset class, filling the classes the
+// data does not cover from the configured means
+func (m bootstrapMonth) returns(config *StochasticModelConfig) StochasticReturns {
+	meanSPY := AnnualToMonthlyRate(config.MeanSPYReturn)
+	meanIndividual := AnnualToMonthlyRate(config.MeanIndividualStockReturn)
+	meanOther := AnnualToMonthlyRate(config.MeanOtherReturn)
+	meanHome := AnnualToMonthlyRate(config.MeanHomeValueAppreciation)
+	meanRental := AnnualToMonthlyRate(config.MeanRentalIncomeGrowth)
+	if pm := config.PrecomputedMonthly; pm != nil {
+		meanSPY, meanIndividual, meanOther = pm.MeanSPY, pm.MeanIndividual, pm.MeanOther
+		meanHome, meanRental = pm.MeanHome, pm.MeanRental
+	}
+
+	returns := StochasticReturns{
+		SPY:             m.SPY,
+		BND:             m.Bond,
+		Intl:            m.SPY,
+		Other:           meanOther,
+		IndividualStock: m.SPY + (meanIndividual - meanSPY),
+		Home:            meanHome,
+		Rent:            meanRental,
+		Inflation:       m.Inflation,
+	}
+	if m.Intl != nil {
+		returns.Intl = *m.Intl
+	}
+	if m.Home != nil {
+		returns.Home = *m.Home
+	}
+	if m.Rent != nil {
+		returns.Rent = *m.Rent
+	}
+	return returns
+}
@@ -0,0 +1,175 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBuildBootstrapPoolMergesScenarios(t *testing.T) {
+	data := []byte(`{"scenarios": {
+		"a_crash": {"monthlyData": [
+			{"year": 2008, "month": 11, "spyReturn": -0.07, "bondReturn": 0.03, "inflation": null, "intlReturn": -0.06},
+			{"year": 2008, "month": 12, "spyReturn": 0.01, "bondReturn": 0.03, "inflation": null, "intlReturn": 0.06}
+		]},
+		"b_series": {"monthlyData": [
+			{"year": 2008, "month": 11, "spyReturn": -0.08, "bondReturn": 0.02, "inflation": -0.01, "intlReturn": null},
+			{"year": 2008, "month": 12, "spyReturn": 0.02, "bondReturn": 0.02, "inflation": -0.01, "intlReturn": null},
+			{"year": 2009, "month": 1, "spyReturn": -0.08, "bondReturn": 0.0, "inflation": 0.004, "intlReturn": null},
+			{"year": 2009, "month": 3, "spyReturn": 0.08, "bondReturn": 0.01, "inflation": 0.002, "intlReturn": null},
+			{"year": 2009, "month": 4, "spyReturn": 0.09, "bondReturn": null, "inflation": 0.001, "intlReturn": null}
+		]},
+		"c_fixture": {"events": [{"id": "x"}], "monthlyData": [
+			{"year": 2009, "month": 2, "spyReturn": 0.5, "bondReturn": 0.5, "inflation": 0.5}
+		]}
+	}}`)
+
+	pool, err := buildBootstrapPool(data)
+	if err != nil {
+		t.Fatalf("buildBootstrapPool: %v", err)
+	}
+	// 2009-04 lacks a bond return and the fixture scenario is skipped
+	if len(pool.months) != 4 {
+		t.Fatalf("expected 4 usable months, got %d", len(pool.months))
+	}
+	first := pool.months[0]
+	if first.SPY != -0.07 || first.Inflation != -0.01 || first.Intl == nil || *first.Intl != -0.06 {
+		t.Errorf("expected fields merged in scenario order, got %+v", first)
+	}
+	if *pool.months[1].Intl != 0.06 || pool.months[2].Intl != nil {
+		t.Error("missing international returns should stay nil, not 0")
+	}
+	// 2009-02 is missing, so 2009-03 starts a new run
+	want := []bool{false, true, true, false}
+	for i, follows := range want {
+		if pool.follows[i] != follows {
+			t.Errorf("follows[%d] = %v, want %v", i, pool.follows[i], follows)
+		}
+	}
+
+	if _, err := buildBootstrapPool([]byte(`{"scenarios": {}}`)); err == nil {
+		t.Error("expected an error for an empty pool")
+	}
+}
+
+func TestHistoricalBootstrapReturns(t *testing.T) {
+	pool, err := getBootstrapPool()
+	if err != nil {
+		t.Fatalf("embedded historical data: %v", err)
+	}
+	if len(pool.months) < 240 {
+		t.Fatalf("expected at least 20 years of complete months, got %d", len(pool.months))
+	}
+
+	config := GetDefaultStochasticConfig()
+	config.SimulationMode = SimulationModeHistoricalBootstrap
+	config.BootstrapMeanBlockLength = 24
+
+	draw := func(seed int64, months int) ([]StochasticReturns, []int) {
+		rng := NewSeededRNG(seed)
+		state := InitializeStochasticState(config)
+		returns := make([]StochasticReturns, 0, months)
+		indices := make([]int, 0, months)
+		for i := 0; i < months; i++ {
+			r, next, err := GenerateAdvancedStochasticReturnsSeeded(state, &config, rng)
+			if err != nil {
+				t.Fatalf("month %d: %v", i, err)
+			}
+			state = next
+			returns = append(returns, r)
+			indices = append(indices, state.BootstrapIndex)
+		}
+		return returns, indices
+	}
+
+	a, indices := draw(7, 6000)
+	b, _ := draw(7, 6000)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("the same seed should give the same returns (month %d)", i)
+		}
+	}
+
+	// Every draw is a real month, and blocks run through consecutive months
+	continued := 0
+	for i, index := range indices {
+		month := pool.months[index]
+		if a[i].SPY != month.SPY || a[i].BND != month.Bond || a[i].Inflation != month.Inflation {
+			t.Fatalf("month %d does not match historical month %d-%02d", i, month.Year, month.Month)
+		}
+		if i > 0 && index == indices[i-1]+1 {
+			continued++
+		}
+	}
+	// With a mean block length of 24 about 23 in 24 months continue their block
+	share := float64(continued) / float64(len(indices)-1)
+	if share < 0.9 || share > 0.99 {
+		t.Errorf("expected ~96%% of months to continue a block, got %.1f%%", share*100)
+	}
+
+	// The resampled mean tracks the historical mean
+	var sampled, historical float64
+	for _, r := range a {
+		sampled += r.SPY
+	}
+	for _, m := range pool.months {
+		historical += m.SPY
+	}
+	sampled /= float64(len(a))
+	historical /= float64(len(pool.months))
+	if math.Abs(sampled-historical) > 0.004 {
+		t.Errorf("sampled monthly SPY mean %.4f far from historical %.4f", sampled, historical)
+	}
+
+	if _, _, err := GenerateAdvancedStochasticReturnsSeeded(StochasticState{}, &config, nil); err == nil {
+		t.Error("expected an error without a seeded RNG")
+	}
+	config.BootstrapMeanBlockLength = 0.5
+	if err := validateStochasticConfig(&config); err == nil {
+		t.Error("expected a block length below one month to be rejected")
+	}
+}
+
+func TestMonteCarloHistoricalBootstrap(t *testing.T) {
+	run := func(mode string) SimulationResults {
+		config := GetDefaultStochasticConfig()
+		config.RandomSeed = 99
+		config.SimulationMode = mode
+		config.LiteMode = true
+
+		input := SimulationInput{
+			Config:      config,
+			MonthsToRun: 240,
+			StartYear:   2030,
+			InitialAge:  60,
+			InitialAccounts: AccountHoldingsMonthEnd{
+				Cash: 50000,
+				Taxable: &Account{
+					Holdings:   []Holding{{AssetClass: AssetClassUSStocksTotalMarket, Quantity: 800, CurrentMarketPricePerUnit: 1000, CurrentMarketValueTotal: 800000}},
+					TotalValue: 800000,
+				},
+				TaxDeferred: &Account{Holdings: []Holding{}, TotalValue: 0},
+				Roth:        &Account{Holdings: []Holding{}, TotalValue: 0},
+			},
+			Events: []FinancialEvent{
+				{ID: "living", Type: "EXPENSE", Amount: 3500, Frequency: "monthly"},
+			},
+		}
+		return RunMonteCarloSimulation(input, 30)
+	}
+
+	bootstrap := run(SimulationModeHistoricalBootstrap)
+	if !bootstrap.Success {
+		t.Fatalf("bootstrap run failed: %s", bootstrap.Error)
+	}
+	again := run(SimulationModeHistoricalBootstrap)
+	if bootstrap.FinalNetWorthP50 != again.FinalNetWorthP50 {
+		t.Error("bootstrap Monte Carlo should be reproducible for a fixed seed")
+	}
+	parametric := run("stochastic")
+	if !parametric.Success {
+		t.Fatalf("parametric run failed: %s", parametric.Error)
+	}
+	if bootstrap.FinalNetWorthP50 == parametric.FinalNetWorthP50 {
+		t.Error("bootstrap and parametric runs should draw different returns")
+	}
+}
@@ -0,0 +1,311 @@
+package engine
+
+import (
+	"fmt"
+)
+
+/**
+ * Two-Person Households
+ *
+ * A plan can describe up to two household members. Events belong to a member
+ * through metadata "owner" (the member's ID); events without an owner belong to
+ * the first member. The pooled accounts stay household-level, while the engine
+ * tracks per-member data on top of them:
+ *
+ * - Ages: each member's age comes from their birth year (RMDs, catch-up limits)
+ * - Retirement: the owner's INCOME and contribution events stop at retirement
+ * - Tax-deferred ownership: each member owns a share of the tax-deferred balance,
+ *   which drives their RMD; contributions shift the shares
+ * - Contribution limits: each member has their own 401k/IRA limits
+ * - Filing status: married filing jointly while both are alive at the start of
+ *   the tax year (the year of death still files jointly), single afterwards
+ * - Survivor transition: the deceased's earned income and contributions stop,
+ *   their tax-deferred share rolls over to the survivor, the survivor's Social
+ *   Security steps up to the survivor benefit, and pensions continue at
+ *   metadata "survivorBenefitPercent" (default 0)
+ */
+
+// HouseholdMember is one adult in a household plan
+type HouseholdMember struct {
+	ID                    string  `json:"id"`                              // Referenced by event metadata "owner"
+	BirthYear             int     `json:"birthYear"`                       // Ages are StartYear + year offset - BirthYear
+	RetirementMonthOffset *int    `json:"retirementMonthOffset,omitempty"` // Owner's INCOME and contributions stop here
+	DeathMonthOffset      *int    `json:"deathMonthOffset,omitempty"`      // Survivor transition month; nil = alive through the horizon
+	TaxDeferredBalance    float64 `json:"taxDeferredBalance,omitempty"`    // Member's part of the initial tax-deferred balance
+	Sex                   string  `json:"sex,omitempty"`                   // Life table column for longevity draws ("male", "female", "" = unisex)
+}
+
+// Household lists the members of a one- or two-person plan
+type Household struct {
+	Members []HouseholdMember `json:"members"`
+}
+
+// maxHouseholdMembers is the largest household the engine models
+const maxHouseholdMembers = 2
+
+// Validate checks member count, IDs and birth years
+func (h *Household) Validate() error {
+	if len(h.Members) == 0 {
+		return fmt.Errorf("household must have at least one member")
+	}
+	if len(h.Members) > maxHouseholdMembers {
+		return fmt.Errorf("household supports at most %d members, got %d", maxHouseholdMembers, len(h.Members))
+	}
+	seen := make(map[string]bool)
+	for i, m := range h.Members {
+		if m.ID == "" {
+			return fmt.Errorf("household member %d: id is required", i)
+		}
+		if seen[m.ID] {
+			return fmt.Errorf("household member %d: duplicate id %q", i, m.ID)
+		}
+		seen[m.ID] = true
+		if m.BirthYear < 1900 {
+			return fmt.Errorf("household member %q: invalid birthYear %d", m.ID, m.BirthYear)
+		}
+		if m.TaxDeferredBalance < 0 {
+			return fmt.Errorf("household member %q: taxDeferredBalance cannot be negative", m.ID)
+		}
+	}
+	return nil
+}
+
+// householdState tracks per-member data for one simulation path
+type householdState struct {
+	members   []HouseholdMember
+	startYear int
+
+	taxDeferredShare []float64                   // Fraction of the pooled tax-deferred account each member owns
+	trackers         []*ContributionLimitTracker // Per-member 401k/IRA limits
+	lastSSBenefit    []float64                   // Most recent monthly Social Security paid on each member's record
+
+	ssCalculator *SocialSecurityCalculator
+}
+
+// defaultStartYear is the calendar year of month 0 when the input gives none:
+// the year the engine's tax tables describe, so results never depend on the
+// date the simulation runs
+const defaultStartYear = 2024
+
+// resolveStartYear returns the input's start year, or defaultStartYear when unset
+func resolveStartYear(startYear int) int {
+	if startYear <= 0 {
+		return defaultStartYear
+	}
+	return startYear
+}
+
+// newHouseholdState returns nil when the input describes a single person without a household
+func newHouseholdState(input *SimulationInput) *householdState {
+	if input.Household == nil || len(input.Household.Members) == 0 {
+		return nil
+	}
+	members := input.Household.Members
+	hs := &householdState{
+		members:          members,
+		startYear:        resolveStartYear(input.StartYear),
+		taxDeferredShare: make([]float64, len(members)),
+		trackers:         make([]*ContributionLimitTracker, len(members)),
+		lastSSBenefit:    make([]float64, len(members)),
+		ssCalculator:     NewSocialSecurityCalculator(),
+	}
+
+	// Initial ownership follows the per-member balances; without them the first member owns it all
+	total := 0.0
+	for _, m := range members {
+		total += m.TaxDeferredBalance
+	}
+	if total > 0 {
+		for i, m := range members {
+			hs.taxDeferredShare[i] = m.TaxDeferredBalance / total
+		}
+	} else {
+		hs.taxDeferredShare[0] = 1
+	}
+
+	for i := range members {
+		hs.trackers[i] = NewContributionLimitTracker()
+	}
+	return hs
+}
+
+// memberIndex resolves an event owner to a member; unknown or empty owners are the first member
+func (hs *householdState) memberIndex(owner string) int {
+	for i, m := range hs.members {
+		if m.ID == owner {
+			return i
+		}
+	}
+	return 0
+}
+
+// calendarYear returns the calendar year of a simulation month
+func (hs *householdState) calendarYear(monthOffset int) int {
+	return hs.startYear + monthOffset/12
+}
+
+// ageAt returns a member's age in the calendar year of monthOffset
+func (hs *householdState) ageAt(i int, monthOffset int) int {
+	return hs.calendarYear(monthOffset) - hs.members[i].BirthYear
+}
+
+// alive reports whether a member is alive in monthOffset
+func (hs *householdState) alive(i int, monthOffset int) bool {
+	death := hs.members[i].DeathMonthOffset
+	return death == nil || monthOffset < *death
+}
+
+// retired reports whether a member has stopped working by monthOffset
+func (hs *householdState) retired(i int, monthOffset int) bool {
+	retirement := hs.members[i].RetirementMonthOffset
+	return retirement != nil && monthOffset >= *retirement
+}
+
+// survivor returns the other living member, or -1 if there is none
+func (hs *householdState) survivor(i int, monthOffset int) int {
+	for j := range hs.members {
+		if j != i && hs.alive(j, monthOffset) {
+			return j
+		}
+	}
+	return -1
+}
+
+// filingStatus returns the household's filing status for the tax year containing monthOffset
+func (hs *householdState) filingStatus(monthOffset int) FilingStatus {
+	yearStart := monthOffset - monthOffset%12
+	living := 0
+	for i := range hs.members {
+		if hs.alive(i, yearStart) {
+			living++
+		}
+	}
+	if living >= 2 {
+		return FilingStatusMarriedJointly
+	}
+	return FilingStatusSingle
+}
+
+// applyToEvent adjusts an owned event for retirement and death. It returns false
+// when the event should not be processed this month.
+func (hs *householdState) applyToEvent(event FinancialEvent, monthOffset int) (FinancialEvent, bool) {
+	i := hs.memberIndex(getStringFromMetadata(event.Metadata, "owner", ""))
+
+	switch EventType(event.Type) {
+	case EventTypeIncome, EventTypeContribution, EventTypeScheduledContribution, EventTypeAccountContribution:
+		// Earned income and payroll contributions end at retirement or death
+		return event, hs.alive(i, monthOffset) && !hs.retired(i, monthOffset)
+
+	case EventTypeSocialSecurityIncome:
+		if hs.alive(i, monthOffset) {
+			hs.lastSSBenefit[i] = event.Amount
+			return event, true
+		}
+		// The survivor keeps the larger of their own benefit and the survivor benefit:
+		// the deceased's stream pays the difference on top of the survivor's own
+		s := hs.survivor(i, monthOffset)
+		if s < 0 {
+			return event, false
+		}
+		survivorFRA := hs.ssCalculator.GetFullRetirementAge(hs.members[s].BirthYear)
+		benefit := hs.ssCalculator.CalculateSurvivorBenefit(event.Amount, hs.ageAt(s, monthOffset), survivorFRA)
+		event.Amount = benefit - hs.lastSSBenefit[s]
+		return event, event.Amount > 0
+
+	case EventTypePensionIncome:
+		if hs.alive(i, monthOffset) {
+			return event, true
+		}
+		if hs.survivor(i, monthOffset) < 0 {
+			return event, false
+		}
+		event.Amount *= getFloat64FromMetadata(event.Metadata, "survivorBenefitPercent", 0)
+		return event, event.Amount > 0
+	}
+
+	return event, true
+}
+
+// effectiveShares returns each member's share of the tax-deferred account in monthOffset.
+// A deceased member's share rolls over to the survivor (spousal rollover).
+func (hs *householdState) effectiveShares(monthOffset int) []float64 {
+	shares := make([]float64, len(hs.members))
+	living := 0.0
+	firstLiving := -1
+	for i := range hs.members {
+		if hs.alive(i, monthOffset) {
+			living += hs.taxDeferredShare[i]
+			if firstLiving < 0 {
+				firstLiving = i
+			}
+		}
+	}
+	if firstLiving < 0 {
+		return shares
+	}
+	if living <= 0 {
+		shares[firstLiving] = 1
+		return shares
+	}
+	for i := range hs.members {
+		if hs.alive(i, monthOffset) {
+			shares[i] = hs.taxDeferredShare[i] / living
+		}
+	}
+	return shares
+}
+
+// recordTaxDeferredContribution shifts ownership toward the contributing member.
+// balanceAfter is the pooled tax-deferred balance including the contribution.
+func (hs *householdState) recordTaxDeferredContribution(owner string, amount float64, balanceAfter float64) {
+	if amount <= 0 || balanceAfter <= 0 {
+		return
+	}
+	balanceBefore := balanceAfter - amount
+	i := hs.memberIndex(owner)
+	for j := range hs.taxDeferredShare {
+		hs.taxDeferredShare[j] = hs.taxDeferredShare[j] * balanceBefore / balanceAfter
+	}
+	hs.taxDeferredShare[i] += amount / balanceAfter
+}
+
+// requiredMinimumDistribution sums each living member's RMD on their share of basis
+func (hs *householdState) requiredMinimumDistribution(basis float64, monthOffset int) float64 {
+	total := 0.0
+	for i, share := range hs.effectiveShares(monthOffset) {
+		if share > 0 {
+			total += CalculateRMD(hs.ageAt(i, monthOffset), basis*share)
+		}
+	}
+	return total
+}
+
+// contributionTracker returns the owner's limit tracker, rolled to the current year and age
+func (hs *householdState) contributionTracker(owner string, monthOffset int) *ContributionLimitTracker {
+	i := hs.memberIndex(owner)
+	tracker := hs.trackers[i]
+	tracker.ResetForNewYear(hs.calendarYear(monthOffset))
+	tracker.SetUserAge(hs.ageAt(i, monthOffset))
+	return tracker
+}
+
+// calendarYear returns the calendar year of a month offset in the simulation
+func (se *SimulationEngine) calendarYear(monthOffset int) int {
+	startYear := 0
+	if se.simulationInput != nil {
+		startYear = se.simulationInput.StartYear
+	}
+	return resolveStartYear(startYear) + monthOffset/12
+}
+
+// primaryAge returns the primary account holder's age; a household's individual accounts
+// (HSA, Roth conversions) belong to the first member
+func (se *SimulationEngine) primaryAge(monthOffset int) int {
+	if se.household != nil {
+		return se.household.ageAt(0, monthOffset)
+	}
+	if se.simulationInput == nil {
+		return 0
+	}
+	return se.simulationInput.InitialAge + monthOffset/12
+}
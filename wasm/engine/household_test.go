@@ -0,0 +1,214 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func intPtr(v int) *int { return &v }
+
+func testHousehold() *SimulationInput {
+	return &SimulationInput{
+		StartYear:  2030,
+		InitialAge: 68,
+		Household: &Household{Members: []HouseholdMember{
+			{ID: "primary", BirthYear: 1962, TaxDeferredBalance: 300000},
+			{ID: "spouse", BirthYear: 1957, TaxDeferredBalance: 100000, DeathMonthOffset: intPtr(30)},
+		}},
+	}
+}
+
+func TestHouseholdValidate(t *testing.T) {
+	valid := testHousehold().Household
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("valid household rejected: %v", err)
+	}
+
+	cases := map[string]Household{
+		"empty":        {},
+		"three":        {Members: []HouseholdMember{{ID: "a", BirthYear: 1970}, {ID: "b", BirthYear: 1970}, {ID: "c", BirthYear: 1970}}},
+		"duplicate id": {Members: []HouseholdMember{{ID: "a", BirthYear: 1970}, {ID: "a", BirthYear: 1972}}},
+		"missing id":   {Members: []HouseholdMember{{BirthYear: 1970}}},
+		"birth year":   {Members: []HouseholdMember{{ID: "a"}}},
+	}
+	for name, h := range cases {
+		if err := h.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestHouseholdFilingStatusAndSurvivor(t *testing.T) {
+	hs := newHouseholdState(testHousehold())
+
+	// Spouse dies in month 30 (July of year 3): that year still files jointly
+	if got := hs.filingStatus(11); got != FilingStatusMarriedJointly {
+		t.Errorf("year 1: expected married_jointly, got %s", got)
+	}
+	if got := hs.filingStatus(35); got != FilingStatusMarriedJointly {
+		t.Errorf("year of death: expected married_jointly, got %s", got)
+	}
+	if got := hs.filingStatus(47); got != FilingStatusSingle {
+		t.Errorf("year after death: expected single, got %s", got)
+	}
+
+	ss := func(owner string, amount float64) FinancialEvent {
+		return FinancialEvent{Type: string(EventTypeSocialSecurityIncome), Amount: amount, Metadata: map[string]interface{}{"owner": owner}}
+	}
+	if _, ok := hs.applyToEvent(ss("primary", 2000), 29); !ok {
+		t.Fatal("primary benefit should be paid")
+	}
+	if _, ok := hs.applyToEvent(ss("spouse", 3000), 29); !ok {
+		t.Fatal("spouse benefit should be paid while alive")
+	}
+
+	// After death the survivor (at FRA) steps up to the deceased's larger benefit
+	topUp, ok := hs.applyToEvent(ss("spouse", 3000), 30)
+	if !ok || math.Abs(topUp.Amount-1000) > 0.01 {
+		t.Errorf("expected $1000 survivor top-up, got %.2f (ok=%v)", topUp.Amount, ok)
+	}
+
+	// The deceased's salary stops; a pension continues at its survivor percentage
+	if _, ok := hs.applyToEvent(FinancialEvent{Type: string(EventTypeIncome), Amount: 5000, Metadata: map[string]interface{}{"owner": "spouse"}}, 30); ok {
+		t.Error("deceased member's income should stop")
+	}
+	pension := FinancialEvent{Type: string(EventTypePensionIncome), Amount: 2000,
+		Metadata: map[string]interface{}{"owner": "spouse", "survivorBenefitPercent": 0.5}}
+	if got, ok := hs.applyToEvent(pension, 30); !ok || got.Amount != 1000 {
+		t.Errorf("expected $1000 survivor pension, got %.2f (ok=%v)", got.Amount, ok)
+	}
+}
+
+func TestHouseholdDefaultStartYear(t *testing.T) {
+	input := testHousehold()
+	input.StartYear = 0
+	hs := newHouseholdState(input)
+
+	// Without a start year, ages run from the fixed default rather than today's date
+	if got := hs.calendarYear(0); got != defaultStartYear {
+		t.Errorf("expected month 0 in %d, got %d", defaultStartYear, got)
+	}
+
+	// The engine's tax-year and ACA lookups use the same default
+	se := &SimulationEngine{}
+	if got := se.calendarYear(25); got != defaultStartYear+2 {
+		t.Errorf("expected month 25 of a plan with no input in %d, got %d", defaultStartYear+2, got)
+	}
+	se.simulationInput = &SimulationInput{}
+	if got := se.calendarYear(0); got != defaultStartYear {
+		t.Errorf("expected month 0 of a plan without a start year in %d, got %d", defaultStartYear, got)
+	}
+}
+
+func TestHouseholdRetirementStopsOwnedIncome(t *testing.T) {
+	input := testHousehold()
+	input.Household.Members[0].RetirementMonthOffset = intPtr(24)
+	hs := newHouseholdState(input)
+
+	salary := FinancialEvent{Type: string(EventTypeIncome), Amount: 8000}
+	if _, ok := hs.applyToEvent(salary, 23); !ok {
+		t.Error("unowned income belongs to the first member and should be paid before retirement")
+	}
+	if _, ok := hs.applyToEvent(salary, 24); ok {
+		t.Error("income should stop at the owner's retirement")
+	}
+	spouseSalary := FinancialEvent{Type: string(EventTypeIncome), Amount: 4000, Metadata: map[string]interface{}{"owner": "spouse"}}
+	if _, ok := hs.applyToEvent(spouseSalary, 24); !ok {
+		t.Error("spouse income should continue after the primary retires")
+	}
+}
+
+func TestHouseholdRMDUsesPerMemberAgeAndShare(t *testing.T) {
+	hs := newHouseholdState(testHousehold())
+	basis := 400000.0
+
+	// 2030: primary is 68 (no RMD), spouse is 73 and owns 25%
+	want := CalculateRMD(73, basis*0.25)
+	if got := hs.requiredMinimumDistribution(basis, 11); math.Abs(got-want) > 0.01 {
+		t.Errorf("year 1 RMD: got %.2f, want %.2f", got, want)
+	}
+
+	// After the spouse dies the survivor owns the whole account but is still under 73
+	if got := hs.requiredMinimumDistribution(basis, 35); got != 0 {
+		t.Errorf("survivor under 73 should have no RMD, got %.2f", got)
+	}
+
+	// Contributions shift ownership toward the contributor
+	hs.recordTaxDeferredContribution("spouse", 100000, 500000)
+	if share := hs.taxDeferredShare[1]; math.Abs(share-0.4) > 1e-9 {
+		t.Errorf("expected spouse share 0.4 after contribution, got %.4f", share)
+	}
+}
+
+func TestHouseholdContributionLimitsPerMember(t *testing.T) {
+	hs := newHouseholdState(testHousehold())
+
+	limits2030 := GetContributionLimits(2030)
+	limit := limits2030.DeferredContributionLimit + limits2030.DeferredCatchUpLimit
+
+	primary := hs.contributionTracker("primary", 0)
+	primary.TrackContribution("tax_deferred", limit)
+	if room := primary.GetRemainingRoom("tax_deferred"); room != 0 {
+		t.Errorf("primary (68, catch-up eligible) should be at the limit, has %.0f left", room)
+	}
+
+	spouse := hs.contributionTracker("spouse", 0)
+	if got := spouse.GetMaxAllowedContribution("tax_deferred", limit+10000); got != limit {
+		t.Errorf("spouse limit should be independent of the primary's, got %.0f", got)
+	}
+
+	// Limits roll over with the calendar year, and are re-indexed for it
+	limits2031 := GetContributionLimits(2031)
+	next := limits2031.DeferredContributionLimit + limits2031.DeferredCatchUpLimit
+	if room := hs.contributionTracker("primary", 12).GetRemainingRoom("tax_deferred"); room != next {
+		t.Errorf("expected primary's room to reset to %.0f in the next year, got %.0f", next, room)
+	}
+}
+
+func TestHouseholdSimulationSurvivorTransition(t *testing.T) {
+	config := GetDefaultStochasticConfig()
+	config.RandomSeed = 7
+	config.SimulationMode = "stochastic"
+
+	owned := func(owner string) map[string]interface{} {
+		return map[string]interface{}{"owner": owner}
+	}
+	input := SimulationInput{
+		Config:      config,
+		MonthsToRun: 24,
+		InitialAccounts: AccountHoldingsMonthEnd{
+			Cash:        50000,
+			Taxable:     &Account{Holdings: []Holding{}, TotalValue: 0},
+			TaxDeferred: &Account{Holdings: []Holding{}, TotalValue: 0},
+			Roth:        &Account{Holdings: []Holding{}, TotalValue: 0},
+		},
+		StartYear:          2030,
+		InitialAge:         70,
+		WithdrawalStrategy: WithdrawalSequenceTaxEfficient,
+		Household: &Household{Members: []HouseholdMember{
+			{ID: "primary", BirthYear: 1960},
+			{ID: "spouse", BirthYear: 1960, DeathMonthOffset: intPtr(12)},
+		}},
+		Events: []FinancialEvent{
+			{ID: "ss-primary", Type: "SOCIAL_SECURITY_INCOME", Amount: 1500, Frequency: "monthly", Metadata: owned("primary")},
+			{ID: "ss-spouse", Type: "SOCIAL_SECURITY_INCOME", Amount: 2500, Frequency: "monthly", Metadata: owned("spouse")},
+		},
+	}
+
+	result := NewSimulationEngine(config).RunSingleSimulation(input)
+	if !result.Success {
+		t.Fatalf("simulation failed: %s", result.Error)
+	}
+	if len(result.MonthlyData) < 20 {
+		t.Fatalf("expected monthly data for the horizon, got %d months", len(result.MonthlyData))
+	}
+
+	before := result.MonthlyData[6].SocialSecurityIncomeThisMonth
+	after := result.MonthlyData[18].SocialSecurityIncomeThisMonth
+	if math.Abs(before-4000) > 0.01 {
+		t.Errorf("expected $4000 combined benefits while both alive, got %.2f", before)
+	}
+	if math.Abs(after-2500) > 0.01 {
+		t.Errorf("expected survivor to keep the larger $2500 benefit, got %.2f", after)
+	}
+}
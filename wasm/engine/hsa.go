@@ -0,0 +1,138 @@
+package engine
+
+import "math"
+
+/**
+ * Health Savings Account
+ *
+ * Models the HSA as its own tax treatment rather than as generic invested assets:
+ * - Contributions are pre-tax, capped at the self-only or family limit plus the age-55
+ *   catch-up from the ContributionLimitTracker; the excess goes to the taxable account
+ * - HEALTHCARE_COST events are qualified medical expenses, paid from the HSA tax-free.
+ *   In "shoebox" mode they are paid from cash instead and the receipt is saved, leaving
+ *   the HSA invested; saved receipts can be reimbursed tax-free at any later date
+ * - Any other distribution is ordinary income, plus a 20% additional tax before 65
+ *   (IRC §223(f)(4)); from 65 a non-medical withdrawal is taxed like a traditional IRA
+ *
+ * Reference: IRS Publication 969
+ */
+
+// HSA coverage types
+const (
+	HSACoverageSelf   = "self"
+	HSACoverageFamily = "family"
+)
+
+// How HEALTHCARE_COST events use the HSA
+const (
+	HSAMedicalPayFromHSA = "pay_from_hsa" // Default: bills are paid from the HSA while it has a balance
+	HSAMedicalShoebox    = "shoebox"      // Bills are paid from cash and the receipts saved for later
+)
+
+const (
+	hsaPenaltyFreeAge          = 65
+	hsaNonQualifiedPenaltyRate = 0.20
+)
+
+// HSAConfig configures HSA coverage and how medical bills are paid
+type HSAConfig struct {
+	Coverage        string `json:"coverage,omitempty"`        // "self" (default) or "family"; selects the contribution limit
+	MedicalExpenses string `json:"medicalExpenses,omitempty"` // "pay_from_hsa" (default) or "shoebox"
+	ReimburseAge    int    `json:"reimburseAge,omitempty"`    // Shoebox: age to reimburse saved receipts and start paying bills from the HSA (0 = never)
+}
+
+// hsaConfig returns the plan's HSA settings, or the defaults when none are set
+func (se *SimulationEngine) hsaConfig() HSAConfig {
+	if se.simulationInput == nil || se.simulationInput.HSA == nil {
+		return HSAConfig{}
+	}
+	return *se.simulationInput.HSA
+}
+
+// hsaShoeboxing reports whether medical bills are paid from cash to keep the HSA invested
+func (se *SimulationEngine) hsaShoeboxing(monthOffset int) bool {
+	config := se.hsaConfig()
+	if config.MedicalExpenses != HSAMedicalShoebox {
+		return false
+	}
+	return config.ReimburseAge <= 0 || se.primaryAge(monthOffset) < config.ReimburseAge
+}
+
+// enforceHSAContributionLimits caps an HSA contribution at the coverage limit and returns the excess
+func (se *SimulationEngine) enforceHSAContributionLimits(contributionAmount *float64, currentMonth int, owner string) float64 {
+	tracker := se.contributionTrackerFor(owner, currentMonth)
+	tracker.SetHasFamily(se.hsaConfig().Coverage == HSACoverageFamily)
+	if se.household == nil && se.simulationInput != nil {
+		tracker.SetUserAge(se.primaryAge(currentMonth))
+	}
+
+	maxAllowed := tracker.GetMaxAllowedContribution("hsa", *contributionAmount)
+	if err := tracker.TrackContribution("hsa", maxAllowed); err != nil {
+		simLogVerbose("Warning: Failed to track HSA contribution: %v", err)
+	}
+	excess := *contributionAmount - maxAllowed
+	*contributionAmount = maxAllowed
+
+	if excess > 0 {
+		simLogVerbose("🔍 HSA LIMIT: YTD=$%.0f, Limit=$%.0f, Contributing=$%.0f, Excess=$%.0f",
+			tracker.GetYTDContribution("hsa"), tracker.GetLimit("hsa"), maxAllowed, excess)
+	}
+	return excess
+}
+
+// hsaDistributionTax splits an HSA distribution into the part covered by qualified medical
+// expenses and the non-qualified remainder, and returns the additional tax on the remainder
+func hsaDistributionTax(amount, qualifiedExpenses float64, age int) (qualified, nonQualified, penalty float64) {
+	qualified = math.Min(amount, math.Max(0, qualifiedExpenses))
+	nonQualified = amount - qualified
+	if age < hsaPenaltyFreeAge {
+		penalty = nonQualified * hsaNonQualifiedPenaltyRate
+	}
+	return qualified, nonQualified, penalty
+}
+
+// recordHSADistribution taxes an HSA distribution, drawing on saved receipts first. The rest is
+// ordinary income, and the additional tax before 65 is owed with the year's return.
+func (se *SimulationEngine) recordHSADistribution(amount float64, currentMonth int) (nonQualified, penalty float64) {
+	qualified, nonQualified, penalty := hsaDistributionTax(amount, se.hsaReceipts, se.primaryAge(currentMonth))
+	se.hsaReceipts -= qualified
+	se.ordinaryIncomeYTD += nonQualified
+	se.penaltyTaxYTD += penalty
+	return nonQualified, penalty
+}
+
+// payMedicalExpenseFromHSA pays what it can of a qualified medical expense from the HSA and returns
+// the amount paid. Whatever is paid out of pocket while the HSA is open is saved as a receipt.
+func (se *SimulationEngine) payMedicalExpenseFromHSA(amount float64, accounts *AccountHoldingsMonthEnd, currentMonth int) float64 {
+	hsa := GetHSAAccount(accounts)
+	if hsa == nil || hsa.TotalValue <= 0 || amount <= 0 {
+		return 0
+	}
+
+	paid := 0.0
+	if !se.hsaShoeboxing(currentMonth) {
+		saleResult := se.cashManager.SellAssetsFromAccountFIFO(hsa, math.Min(amount, hsa.TotalValue), currentMonth)
+		paid = math.Min(amount, saleResult.TotalProceeds)
+	}
+	se.hsaReceipts += amount - paid
+	return paid
+}
+
+// processHSAShoebox reimburses saved receipts tax-free once the shoebox reimbursement age is reached
+func (se *SimulationEngine) processHSAShoebox(accounts *AccountHoldingsMonthEnd, monthOffset int) {
+	config := se.hsaConfig()
+	if config.MedicalExpenses != HSAMedicalShoebox || config.ReimburseAge <= 0 || se.hsaReceipts <= 0 {
+		return
+	}
+	hsa := GetHSAAccount(accounts)
+	if hsa == nil || hsa.TotalValue <= 0 || se.primaryAge(monthOffset) < config.ReimburseAge {
+		return
+	}
+
+	saleResult := se.cashManager.SellAssetsFromAccountFIFO(hsa, math.Min(se.hsaReceipts, hsa.TotalValue), monthOffset)
+	accounts.Cash += saleResult.TotalProceeds
+	se.recordHSADistribution(saleResult.TotalProceeds, monthOffset)
+
+	simLogEvent("INFO  [Month %d] HSA shoebox reimbursement | Amount: $%.2f | Receipts remaining: $%.2f (tax-free)",
+		monthOffset, saleResult.TotalProceeds, se.hsaReceipts)
+}
@@ -0,0 +1,171 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func newHSATestEngine(t *testing.T, age int, config *HSAConfig, hsaBalance float64) (*SimulationEngine, *AccountHoldingsMonthEnd) {
+	t.Helper()
+	accounts := &AccountHoldingsMonthEnd{Cash: 50000, Taxable: &Account{Holdings: []Holding{}}, HSA: &Account{Holdings: []Holding{}}}
+	se := newHandlerTestEngine(t, SimulationInput{InitialAge: age, HSA: config}, accounts)
+	fundTestAccount(t, se, accounts.HSA, hsaBalance)
+	return se, accounts
+}
+
+func TestHSAContributionLimits(t *testing.T) {
+	contribute := func(se *SimulationEngine, accounts *AccountHoldingsMonthEnd) {
+		mustProcessTestEvent(t, &HSAContributionEventHandler{}, se, accounts, FinancialEvent{ID: "hsa", Type: "HSA_CONTRIBUTION", Amount: 10000})
+	}
+
+	// 2025 family limit $8,550 plus the $1,000 catch-up at 55
+	se, accounts := newHSATestEngine(t, 56, &HSAConfig{Coverage: HSACoverageFamily}, 0)
+	contribute(se, accounts)
+	if math.Abs(accounts.HSA.TotalValue-9550) > 0.01 || math.Abs(accounts.Taxable.TotalValue-450) > 0.01 {
+		t.Errorf("family + catch-up: HSA %.2f (want 9550), taxable %.2f (want 450)", accounts.HSA.TotalValue, accounts.Taxable.TotalValue)
+	}
+	if se.preTaxContributionsYTD != 9550 {
+		t.Errorf("HSA contributions should be deducted, got %.2f", se.preTaxContributionsYTD)
+	}
+	if len(accounts.HSA.Holdings) == 0 {
+		t.Error("contributions should be invested so they grow with the market")
+	}
+
+	// Self-only defaults to $4,300; a second contribution in the year finds no room
+	se, accounts = newHSATestEngine(t, 40, nil, 0)
+	contribute(se, accounts)
+	contribute(se, accounts)
+	if math.Abs(accounts.HSA.TotalValue-4300) > 0.01 || math.Abs(accounts.Taxable.TotalValue-15700) > 0.01 {
+		t.Errorf("self-only: HSA %.2f (want 4300), taxable %.2f (want 15700)", accounts.HSA.TotalValue, accounts.Taxable.TotalValue)
+	}
+}
+
+func TestHSAMedicalExpenses(t *testing.T) {
+	bill := func(amount float64) FinancialEvent {
+		return FinancialEvent{ID: "medical", Type: "HEALTHCARE_COST", Amount: amount}
+	}
+
+	// Bills are paid from the HSA; the part it can't cover becomes a receipt
+	se, accounts := newHSATestEngine(t, 50, nil, 5000)
+	mustProcessTestEvent(t, &HealthcareCostEventHandler{}, se, accounts, bill(3000))
+	if accounts.Cash != 50000 || math.Abs(accounts.HSA.TotalValue-2000) > 0.01 {
+		t.Errorf("paid from HSA: cash %.2f, HSA %.2f", accounts.Cash, accounts.HSA.TotalValue)
+	}
+	mustProcessTestEvent(t, &HealthcareCostEventHandler{}, se, accounts, bill(3000))
+	if math.Abs(accounts.Cash-49000) > 0.01 || math.Abs(se.hsaReceipts-1000) > 0.01 {
+		t.Errorf("HSA shortfall: cash %.2f, receipts %.2f", accounts.Cash, se.hsaReceipts)
+	}
+	if se.ordinaryIncomeYTD != 0 || se.penaltyTaxYTD != 0 {
+		t.Error("qualified medical withdrawals should be tax-free")
+	}
+
+	// Shoebox: pay from cash until 65, then reimburse every receipt tax-free
+	se, accounts = newHSATestEngine(t, 63, &HSAConfig{MedicalExpenses: HSAMedicalShoebox, ReimburseAge: 65}, 20000)
+	mustProcessTestEvent(t, &HealthcareCostEventHandler{}, se, accounts, bill(4000))
+	if accounts.Cash != 46000 || math.Abs(accounts.HSA.TotalValue-20000) > 0.01 || se.hsaReceipts != 4000 {
+		t.Errorf("shoebox: cash %.2f, HSA %.2f, receipts %.2f", accounts.Cash, accounts.HSA.TotalValue, se.hsaReceipts)
+	}
+	se.processHSAShoebox(accounts, 12)
+	if se.hsaReceipts != 4000 {
+		t.Error("receipts should be held until the reimbursement age")
+	}
+	se.processHSAShoebox(accounts, 24)
+	if math.Abs(accounts.Cash-50000) > 0.01 || se.hsaReceipts > 0.01 || se.ordinaryIncomeYTD != 0 {
+		t.Errorf("reimbursement at 65: cash %.2f, receipts %.2f, income %.2f", accounts.Cash, se.hsaReceipts, se.ordinaryIncomeYTD)
+	}
+	if !se.hsaShoeboxing(0) || se.hsaShoeboxing(24) {
+		t.Error("shoeboxing should stop at the reimbursement age")
+	}
+}
+
+func TestHSAWithdrawalTax(t *testing.T) {
+	withdraw := func(se *SimulationEngine, accounts *AccountHoldingsMonthEnd, amount float64, metadata map[string]interface{}) {
+		mustProcessTestEvent(t, &HSAWithdrawalEventHandler{}, se, accounts, FinancialEvent{ID: "w", Type: "HSA_WITHDRAWAL", Amount: amount, Metadata: metadata})
+	}
+
+	// Before 65: receipts first, then ordinary income plus 20%
+	se, accounts := newHSATestEngine(t, 50, nil, 20000)
+	se.hsaReceipts = 3000
+	withdraw(se, accounts, 5000, nil)
+	if math.Abs(se.ordinaryIncomeYTD-2000) > 0.01 || math.Abs(se.penaltyTaxYTD-400) > 0.01 || se.hsaReceipts > 0.01 {
+		t.Errorf("non-qualified before 65: income %.2f (want 2000), penalty %.2f (want 400), receipts %.2f",
+			se.ordinaryIncomeYTD, se.penaltyTaxYTD, se.hsaReceipts)
+	}
+	withdraw(se, accounts, 1000, map[string]interface{}{"qualifiedMedical": true})
+	if math.Abs(se.ordinaryIncomeYTD-2000) > 0.01 {
+		t.Error("a withdrawal for a current medical bill should be tax-free")
+	}
+
+	// From 65: taxed like a traditional IRA, no additional tax
+	se, accounts = newHSATestEngine(t, 65, nil, 20000)
+	withdraw(se, accounts, 5000, nil)
+	if math.Abs(se.ordinaryIncomeYTD-5000) > 0.01 || se.penaltyTaxYTD != 0 {
+		t.Errorf("non-qualified at 65: income %.2f, penalty %.2f", se.ordinaryIncomeYTD, se.penaltyTaxYTD)
+	}
+
+	// Reimbursing receipts withdraws exactly what is on file
+	se, accounts = newHSATestEngine(t, 40, nil, 20000)
+	se.hsaReceipts = 2500
+	withdraw(se, accounts, 0, map[string]interface{}{"reimburseReceipts": true})
+	if math.Abs(accounts.Cash-52500) > 0.01 || se.hsaReceipts > 0.01 || se.ordinaryIncomeYTD != 0 {
+		t.Errorf("receipt reimbursement: cash %.2f, receipts %.2f, income %.2f", accounts.Cash, se.hsaReceipts, se.ordinaryIncomeYTD)
+	}
+}
+
+func TestWithdrawalSequencerHSA(t *testing.T) {
+	sequencer := NewWithdrawalSequencer(NewCashManager(), NewTaxCalculator(GetDefaultTaxConfig(), nil), NewRMDCalculator())
+	newAccounts := func() *AccountHoldingsMonthEnd {
+		return &AccountHoldingsMonthEnd{
+			TaxDeferred: &Account{TotalValue: 10000},
+			Roth:        &Account{TotalValue: 10000},
+			HSA:         &Account{TotalValue: 10000},
+		}
+	}
+
+	// Before 65 the HSA is drawn last, after Roth
+	result, err := sequencer.ExecuteWithdrawal(WithdrawalRequest{Amount: 25000, CurrentAge: 50, HSAReceipts: 1000}, newAccounts(), WithdrawalSequenceTaxEfficient)
+	if err != nil {
+		t.Fatalf("withdrawal: %v", err)
+	}
+	if result.RothWithdrawn != 10000 || result.HSAWithdrawn != 5000 || result.HSANonQualified != 4000 {
+		t.Errorf("age 50: Roth %.0f, HSA %.0f (non-qualified %.0f)", result.RothWithdrawn, result.HSAWithdrawn, result.HSANonQualified)
+	}
+	if want := 10000*0.10 + 4000*0.20; math.Abs(result.EarlyWithdrawalPenalty-want) > 0.01 {
+		t.Errorf("penalty %.2f, want %.2f", result.EarlyWithdrawalPenalty, want)
+	}
+
+	// From 65 it follows tax-deferred, ahead of Roth
+	result, err = sequencer.ExecuteWithdrawal(WithdrawalRequest{Amount: 15000, CurrentAge: 66}, newAccounts(), WithdrawalSequenceTaxEfficient)
+	if err != nil {
+		t.Fatalf("withdrawal: %v", err)
+	}
+	if result.HSAWithdrawn != 5000 || result.RothWithdrawn != 0 || result.EarlyWithdrawalPenalty != 0 {
+		t.Errorf("age 66: HSA %.0f, Roth %.0f, penalty %.2f", result.HSAWithdrawn, result.RothWithdrawn, result.EarlyWithdrawalPenalty)
+	}
+}
+
+func TestHSAInSimulation(t *testing.T) {
+	input := createRollingTestInput(1000000, 48000)
+	input.Config.RandomSeed = 5
+	input.InitialAge = 50
+	input.StartYear = 2025
+	input.HSA = &HSAConfig{Coverage: HSACoverageSelf}
+	input.MonthsToRun = 36
+	input.Events = append(input.Events,
+		FinancialEvent{ID: "salary", Type: "INCOME", Amount: 10000, Frequency: "monthly"},
+		FinancialEvent{ID: "hsa", Type: "HSA_CONTRIBUTION", Amount: 1000, Frequency: "monthly"})
+
+	result := NewSimulationEngine(input.Config).RunSingleSimulation(input)
+	if !result.Success {
+		t.Fatalf("simulation failed: %s", result.Error)
+	}
+	final := result.MonthlyData[len(result.MonthlyData)-1].Accounts.HSA
+	if final == nil {
+		t.Fatal("expected an HSA balance")
+	}
+	// Three years at the self-only limit, held through market moves
+	contributed := 4300.0 + 4400 + 4400
+	if final.TotalValue < contributed*0.6 || final.TotalValue > contributed*1.6 {
+		t.Errorf("HSA balance %.0f should track ~$%.0f of capped contributions", final.TotalValue, contributed)
+	}
+}
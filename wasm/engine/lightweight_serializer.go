@@ -1,7 +1,7 @@
 //go:build js && wasm
 // +build js,wasm
 
-package main
+package engine
 
 import (
 	"syscall/js"
@@ -27,6 +27,7 @@ func convertSingleResultToLightweightJS(result SimulationResult) js.Value {
 
 			// Essential chart data only
 			safeSetFloat64(dataJS, "netWorth", data.NetWorth)
+			safeSetFloat64(dataJS, "netWorthReal", data.NetWorthReal)
 			safeSetFloat64(dataJS, "cashFlow", data.CashFlow)
 			safeSetFloat64(dataJS, "cash", data.Accounts.Cash)
 
@@ -128,6 +129,9 @@ func convertResultsToLightweightJS(results SimulationResults) js.Value {
 	safeSetFloat64(obj, "finalNetWorthP50", results.FinalNetWorthP50)
 	safeSetFloat64(obj, "finalNetWorthP75", results.FinalNetWorthP75)
 	safeSetFloat64(obj, "finalNetWorthP90", results.FinalNetWorthP90)
+	safeSetFloat64(obj, "finalNetWorthRealP10", results.FinalNetWorthRealP10)
+	safeSetFloat64(obj, "finalNetWorthRealP50", results.FinalNetWorthRealP50)
+	safeSetFloat64(obj, "finalNetWorthRealP90", results.FinalNetWorthRealP90)
 	safeSetFloat64(obj, "probabilityOfSuccess", results.ProbabilityOfSuccess)
 
 	// Bankruptcy detection
@@ -22,7 +22,7 @@ func (lm *LiquidityManager) GetDefaultLiquidityTier(assetClass AssetClass) Liqui
 		return LiquidityTierLiquid
 	case AssetClassLeveragedSPY, AssetClassIndividualStock:
 		return LiquidityTierLiquid
-	case AssetClassRealEstatePrimaryHome:
+	case AssetClassRealEstatePrimaryHome, AssetClassRealEstateRental:
 		return LiquidityTierIlliquid
 	case AssetClassOtherAssets:
 		return LiquidityTierSemiLiquid // Conservative default
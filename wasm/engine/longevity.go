@@ -0,0 +1,310 @@
+package engine
+
+import (
+	"fmt"
+)
+
+/**
+ * Stochastic Lifespan
+ *
+ * A fixed horizon hides longevity risk: the plan either "works until 80" or it
+ * doesn't. When SimulationInput.Longevity is set, every path draws a death age
+ * for each person from the period life table (config/life_table_2021.json),
+ * conditional on being alive at the start:
+ *
+ * - Single plans end the path in the month of death
+ * - Household members without a fixed DeathMonthOffset get a drawn one, so the
+ *   survivor transition happens on each path; the path ends at the last death
+ * - The estate left at the last death goes through
+ *   EstateTaxCalculator.CalculateTotalEstateTax (a first death passes to the
+ *   spouse under the marital deduction and ports its unused exemption)
+ * - Monte Carlo results report outcomes conditional on being alive at each age
+ *
+ * MonthsToRun becomes the maximum horizon. Draws use their own SeededRNG
+ * derived from the path seed, so turning the model on does not change a
+ * path's market returns.
+ */
+
+// LongevityConfig enables per-path lifespan draws
+type LongevityConfig struct {
+	Sex                 string  `json:"sex,omitempty"`                 // Life table column for a single person: "male", "female" or "" (unisex)
+	MortalityMultiplier float64 `json:"mortalityMultiplier,omitempty"` // Scales every qx for health (0 = 1.0)
+	StateCode           string  `json:"stateCode,omitempty"`           // State of residence for state estate tax
+}
+
+// PathLifespan records the lifespans drawn for one path and the estate at the last death
+type PathLifespan struct {
+	DeathAges      []int   `json:"deathAges"`             // Drawn age at death per person (household order)
+	LastDeathMonth int     `json:"lastDeathMonth"`        // Month of the last death; -1 when someone outlives the horizon
+	EstateValue    float64 `json:"estateValue,omitempty"` // Net worth at the last death
+	EstateTax      float64 `json:"estateTax,omitempty"`   // Federal + state estate tax on EstateValue
+	NetEstate      float64 `json:"netEstate,omitempty"`   // EstateValue - EstateTax
+}
+
+// AliveConditionalOutcome summarizes the paths in which someone is alive at the end of a year
+type AliveConditionalOutcome struct {
+	Age                int     `json:"age"`                // InitialAge + year
+	ProbabilityAlive   float64 `json:"probabilityAlive"`   // Share of paths with someone alive
+	AlivePaths         int     `json:"alivePaths"`         // Denominator for the fields below
+	ProbabilitySolvent float64 `json:"probabilitySolvent"` // Alive paths with positive net worth and no bankruptcy
+	NetWorthP10        float64 `json:"netWorthP10"`
+	NetWorthP50        float64 `json:"netWorthP50"`
+	NetWorthP90        float64 `json:"netWorthP90"`
+}
+
+// LongevitySummary aggregates lifespan draws and estates across Monte Carlo paths
+type LongevitySummary struct {
+	DeathAgeP10 int `json:"deathAgeP10"` // Primary person's drawn age at death
+	DeathAgeP50 int `json:"deathAgeP50"`
+	DeathAgeP90 int `json:"deathAgeP90"`
+
+	ProbabilityOutlivesHorizon    float64 `json:"probabilityOutlivesHorizon"`    // Someone alive when MonthsToRun ends
+	ProbabilityDepletedWhileAlive float64 `json:"probabilityDepletedWhileAlive"` // Bankrupt before the last death
+
+	// Estates (paths whose last death falls inside the horizon)
+	EstatePaths            int     `json:"estatePaths"`
+	EstateValueP50         float64 `json:"estateValueP50"`
+	NetEstateP10           float64 `json:"netEstateP10"`
+	NetEstateP50           float64 `json:"netEstateP50"`
+	NetEstateP90           float64 `json:"netEstateP90"`
+	EstateTaxMean          float64 `json:"estateTaxMean"`
+	ProbabilityOfEstateTax float64 `json:"probabilityOfEstateTax"`
+
+	ByAge []AliveConditionalOutcome `json:"byAge"`
+}
+
+const (
+	// longevitySeedSalt separates lifespan draws from the path's market-return stream
+	longevitySeedSalt int64 = 0x4c4f4e47
+
+	// MaxLifespanAge is the age by which every drawn lifespan has ended
+	MaxLifespanAge = 120
+)
+
+// Validate checks the life table column and mortality multiplier
+func (lc *LongevityConfig) Validate() error {
+	if err := validateLifeTableSex(lc.Sex); err != nil {
+		return err
+	}
+	if lc.MortalityMultiplier < 0 || lc.MortalityMultiplier > 10 {
+		return fmt.Errorf("longevity: mortalityMultiplier must be between 0 and 10, got %g", lc.MortalityMultiplier)
+	}
+	return nil
+}
+
+// validateLifeTableSex accepts the life table columns and "" for unisex
+func validateLifeTableSex(sex string) error {
+	switch sex {
+	case "", "male", "female":
+		return nil
+	}
+	return fmt.Errorf("longevity: sex must be \"male\", \"female\" or empty, got %q", sex)
+}
+
+// multiplier returns the configured mortality multiplier (default 1)
+func (lc *LongevityConfig) multiplier() float64 {
+	if lc.MortalityMultiplier > 0 {
+		return lc.MortalityMultiplier
+	}
+	return 1
+}
+
+// drawDeathMonth draws the month offset of death for someone aged age at month 0
+func drawDeathMonth(rng *SeededRNG, age int, sex string, multiplier float64) (int, error) {
+	for a := age; ; a++ {
+		q, err := GetMortalityRate(a, sex)
+		if err != nil {
+			return 0, err
+		}
+		if a >= MaxLifespanAge || rng.Float64() < q*multiplier {
+			return (a-age)*12 + int(rng.Float64()*12), nil
+		}
+	}
+}
+
+// applyLongevity draws this path's lifespans and shortens MonthsToRun to the last death.
+// The household is copied so the caller's input is never modified.
+func applyLongevity(input SimulationInput, seed int64) (SimulationInput, *PathLifespan, error) {
+	lc := input.Longevity
+	if err := lc.Validate(); err != nil {
+		return input, nil, err
+	}
+	rng := NewSeededRNG(seed ^ longevitySeedSalt)
+	lifespan := &PathLifespan{LastDeathMonth: -1}
+	lastDeath := 0
+
+	if input.Household == nil || len(input.Household.Members) == 0 {
+		month, err := drawDeathMonth(rng, input.InitialAge, lc.Sex, lc.multiplier())
+		if err != nil {
+			return input, nil, err
+		}
+		lifespan.DeathAges = []int{input.InitialAge + month/12}
+		lastDeath = month
+	} else {
+		startYear := resolveStartYear(input.StartYear)
+		members := make([]HouseholdMember, len(input.Household.Members))
+		copy(members, input.Household.Members)
+		lifespan.DeathAges = make([]int, len(members))
+
+		for i := range members {
+			age := startYear - members[i].BirthYear
+			if err := validateLifeTableSex(members[i].Sex); err != nil {
+				return input, nil, err
+			}
+			// A fixed death month is a scenario choice and is not redrawn
+			month := 0
+			if members[i].DeathMonthOffset != nil {
+				month = *members[i].DeathMonthOffset
+			} else {
+				drawn, err := drawDeathMonth(rng, age, members[i].Sex, lc.multiplier())
+				if err != nil {
+					return input, nil, err
+				}
+				month = drawn
+				members[i].DeathMonthOffset = &drawn
+			}
+			lifespan.DeathAges[i] = age + month/12
+			if month > lastDeath {
+				lastDeath = month
+			}
+		}
+		input.Household = &Household{Members: members}
+	}
+
+	if lastDeath < input.MonthsToRun {
+		lifespan.LastDeathMonth = lastDeath
+		input.MonthsToRun = lastDeath + 1 // The month of death is simulated
+	}
+	return input, lifespan, nil
+}
+
+// settleEstate taxes the estate left at the last death. Net worth is already net of
+// debts and unpaid tax; a two-person household ports the first decedent's exemption.
+func (se *SimulationEngine) settleEstate(lifespan *PathLifespan, netWorth float64, input SimulationInput) {
+	if lifespan.LastDeathMonth < 0 || netWorth <= 0 {
+		return
+	}
+	startYear := resolveStartYear(input.StartYear)
+
+	profile := EstateProfile{
+		GrossEstateValue: netWorth,
+		YearOfDeath:      startYear + lifespan.LastDeathMonth/12,
+	}
+	if input.Longevity != nil {
+		profile.StateCode = input.Longevity.StateCode
+	}
+	if se.relocated {
+		profile.StateCode = se.residenceState
+	}
+	if input.Household != nil && len(input.Household.Members) == 2 {
+		firstDeath := lifespan.LastDeathMonth
+		for _, m := range input.Household.Members {
+			if m.DeathMonthOffset != nil && *m.DeathMonthOffset < firstDeath {
+				firstDeath = *m.DeathMonthOffset
+			}
+		}
+		if firstDeath < lifespan.LastDeathMonth {
+			profile.PortableExemption = se.estateTaxCalculator.getFederalExemption(startYear + firstDeath/12)
+		}
+	}
+
+	lifespan.EstateValue = netWorth
+	lifespan.EstateTax = se.estateTaxCalculator.CalculateTotalEstateTax(profile)
+	lifespan.NetEstate = netWorth - lifespan.EstateTax
+}
+
+// longevityPathOutcome is what the Monte Carlo loop keeps from each path for the summary
+type longevityPathOutcome struct {
+	lifespan        *PathLifespan
+	yearEndNetWorth []float64
+	finalNetWorth   float64
+	bankruptcyMonth int // 0 = never bankrupt
+}
+
+// summarizeLongevity aggregates lifespans, estates and alive-conditional outcomes by age
+func summarizeLongevity(paths []longevityPathOutcome, input SimulationInput) *LongevitySummary {
+	if len(paths) == 0 {
+		return nil
+	}
+	n := float64(len(paths))
+	summary := &LongevitySummary{}
+
+	deathAges := make([]float64, 0, len(paths))
+	estateValues := make([]float64, 0, len(paths))
+	netEstates := make([]float64, 0, len(paths))
+	outlived, depleted, taxed := 0, 0, 0
+	totalEstateTax := 0.0
+
+	for _, p := range paths {
+		if len(p.lifespan.DeathAges) > 0 {
+			deathAges = append(deathAges, float64(p.lifespan.DeathAges[0]))
+		}
+		if p.lifespan.LastDeathMonth < 0 {
+			outlived++
+			if p.bankruptcyMonth > 0 {
+				depleted++
+			}
+			continue
+		}
+		if p.bankruptcyMonth > 0 && p.bankruptcyMonth <= p.lifespan.LastDeathMonth {
+			depleted++
+		}
+		estateValues = append(estateValues, p.lifespan.EstateValue)
+		netEstates = append(netEstates, p.lifespan.NetEstate)
+		totalEstateTax += p.lifespan.EstateTax
+		if p.lifespan.EstateTax > 0 {
+			taxed++
+		}
+	}
+
+	ages := calculatePercentiles(deathAges)
+	summary.DeathAgeP10 = int(ages[0])
+	summary.DeathAgeP50 = int(ages[2])
+	summary.DeathAgeP90 = int(ages[4])
+	summary.ProbabilityOutlivesHorizon = float64(outlived) / n
+	summary.ProbabilityDepletedWhileAlive = float64(depleted) / n
+
+	if len(estateValues) > 0 {
+		summary.EstatePaths = len(estateValues)
+		summary.EstateValueP50 = calculatePercentiles(estateValues)[2]
+		net := calculatePercentiles(netEstates)
+		summary.NetEstateP10, summary.NetEstateP50, summary.NetEstateP90 = net[0], net[2], net[4]
+		summary.EstateTaxMean = totalEstateTax / float64(len(estateValues))
+		summary.ProbabilityOfEstateTax = float64(taxed) / float64(len(estateValues))
+	}
+
+	// Alive-conditional outcomes at each complete year-end of the horizon
+	for year := 0; year < input.MonthsToRun/12; year++ {
+		yearEnd := year*12 + 11
+		values := make([]float64, 0, len(paths))
+		solvent := 0
+		for _, p := range paths {
+			if p.lifespan.LastDeathMonth >= 0 && p.lifespan.LastDeathMonth <= yearEnd {
+				continue
+			}
+			nw := p.finalNetWorth
+			if year < len(p.yearEndNetWorth) {
+				nw = p.yearEndNetWorth[year]
+			}
+			values = append(values, nw)
+			if nw > 0 && (p.bankruptcyMonth == 0 || p.bankruptcyMonth > yearEnd) {
+				solvent++
+			}
+		}
+		if len(values) == 0 {
+			break
+		}
+		pct := calculatePercentiles(values)
+		summary.ByAge = append(summary.ByAge, AliveConditionalOutcome{
+			Age:                input.InitialAge + year,
+			ProbabilityAlive:   float64(len(values)) / n,
+			AlivePaths:         len(values),
+			ProbabilitySolvent: float64(solvent) / float64(len(values)),
+			NetWorthP10:        pct[0],
+			NetWorthP50:        pct[2],
+			NetWorthP90:        pct[4],
+		})
+	}
+
+	return summary
+}
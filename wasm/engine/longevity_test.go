@@ -0,0 +1,225 @@
+package engine
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestMortalityRateFromLifeTable(t *testing.T) {
+	male, err := GetMortalityRate(65, "male")
+	if err != nil {
+		t.Fatalf("life table not loaded: %v", err)
+	}
+	female, _ := GetMortalityRate(65, "female")
+	unisex, _ := GetMortalityRate(65, "")
+	if female >= male {
+		t.Errorf("expected female qx below male at 65, got %.5f vs %.5f", female, male)
+	}
+	if math.Abs(unisex-(male+female)/2) > 1e-12 {
+		t.Errorf("unisex qx should average the columns, got %.5f", unisex)
+	}
+	if q, _ := GetMortalityRate(130, "male"); q != 1 {
+		t.Errorf("ages past the table should die with certainty, got %.3f", q)
+	}
+
+	// Period life expectancy at 65 should be in the high teens to about 20 years
+	expectancy := func(sex string) float64 {
+		alive, years := 1.0, 0.0
+		for age := 65; age < MaxLifespanAge; age++ {
+			q, _ := GetMortalityRate(age, sex)
+			years += alive * (1 - q/2)
+			alive *= 1 - q
+		}
+		return years
+	}
+	if e := expectancy("male"); e < 15 || e > 20 {
+		t.Errorf("male e65 = %.1f, expected 15-20", e)
+	}
+	if e := expectancy("female"); e < 18 || e > 23 {
+		t.Errorf("female e65 = %.1f, expected 18-23", e)
+	}
+}
+
+func TestApplyLongevityDrawsAndTruncates(t *testing.T) {
+	input := SimulationInput{
+		MonthsToRun: 600,
+		InitialAge:  70,
+		StartYear:   2030,
+		Longevity:   &LongevityConfig{Sex: "male"},
+	}
+
+	a, lifespanA, err := applyLongevity(input, 42)
+	if err != nil {
+		t.Fatalf("applyLongevity: %v", err)
+	}
+	_, lifespanB, _ := applyLongevity(input, 42)
+	if lifespanA.DeathAges[0] != lifespanB.DeathAges[0] || lifespanA.LastDeathMonth != lifespanB.LastDeathMonth {
+		t.Error("the same seed should draw the same lifespan")
+	}
+	if lifespanA.DeathAges[0] < 70 || lifespanA.DeathAges[0] >= MaxLifespanAge {
+		t.Errorf("death age %d outside [70, %d)", lifespanA.DeathAges[0], MaxLifespanAge)
+	}
+	// A 600-month horizon from 70 reaches 120, so every draw dies inside it
+	if lifespanA.LastDeathMonth < 0 || a.MonthsToRun != lifespanA.LastDeathMonth+1 {
+		t.Errorf("expected MonthsToRun cut to the death month, got %d (death %d)", a.MonthsToRun, lifespanA.LastDeathMonth)
+	}
+
+	// A short horizon is left alone when the person outlives it
+	input.MonthsToRun = 1
+	input.Longevity.MortalityMultiplier = 0.0001
+	short, lifespan, _ := applyLongevity(input, 42)
+	if lifespan.LastDeathMonth != -1 || short.MonthsToRun != 1 {
+		t.Errorf("expected the horizon to be kept, got death %d, months %d", lifespan.LastDeathMonth, short.MonthsToRun)
+	}
+
+	input.Longevity = &LongevityConfig{Sex: "other"}
+	if _, _, err := applyLongevity(input, 42); err == nil {
+		t.Error("expected an error for an unknown sex")
+	}
+}
+
+func TestApplyLongevityHousehold(t *testing.T) {
+	input := *testHousehold()
+	input.MonthsToRun = 720
+	input.Longevity = &LongevityConfig{}
+	input.Household.Members[0].Sex = "female"
+
+	out, lifespan, err := applyLongevity(input, 9)
+	if err != nil {
+		t.Fatalf("applyLongevity: %v", err)
+	}
+	if input.Household.Members[0].DeathMonthOffset != nil {
+		t.Fatal("the caller's household must not be modified")
+	}
+	members := out.Household.Members
+	if members[0].DeathMonthOffset == nil {
+		t.Fatal("expected a drawn death month for the primary")
+	}
+	// The spouse's fixed death month is a scenario input and is kept
+	if *members[1].DeathMonthOffset != 30 || lifespan.DeathAges[1] != 75 {
+		t.Errorf("expected the fixed spouse death (month 30, age 75), got month %d age %d",
+			*members[1].DeathMonthOffset, lifespan.DeathAges[1])
+	}
+	last := *members[0].DeathMonthOffset
+	if last < 30 {
+		last = 30
+	}
+	if lifespan.LastDeathMonth != last || out.MonthsToRun != last+1 {
+		t.Errorf("path should end at the last death (month %d), got %d / %d", last, lifespan.LastDeathMonth, out.MonthsToRun)
+	}
+
+	// Without a start year, ages come from the fixed default so seeded draws are reproducible
+	unset, fixed := input, input
+	unset.StartYear, fixed.StartYear = 0, defaultStartYear
+	_, unsetLifespan, err := applyLongevity(unset, 9)
+	if err != nil {
+		t.Fatalf("applyLongevity without a start year: %v", err)
+	}
+	_, fixedLifespan, err := applyLongevity(fixed, 9)
+	if err != nil {
+		t.Fatalf("applyLongevity: %v", err)
+	}
+	if !reflect.DeepEqual(unsetLifespan, fixedLifespan) {
+		t.Errorf("expected the %d default, got %+v vs %+v", defaultStartYear, unsetLifespan, fixedLifespan)
+	}
+}
+
+func TestSettleEstateAppliesEstateTax(t *testing.T) {
+	se := NewSimulationEngine(GetDefaultStochasticConfig())
+	input := SimulationInput{StartYear: 2030, Longevity: &LongevityConfig{StateCode: "WA"}}
+
+	small := &PathLifespan{LastDeathMonth: 24}
+	se.settleEstate(small, 1000000, input)
+	if small.EstateTax != 0 || small.NetEstate != 1000000 {
+		t.Errorf("a $1M estate should owe no estate tax, got %.0f", small.EstateTax)
+	}
+
+	large := &PathLifespan{LastDeathMonth: 24}
+	se.settleEstate(large, 30000000, input)
+	want := se.estateTaxCalculator.CalculateTotalEstateTax(EstateProfile{
+		GrossEstateValue: 30000000, StateCode: "WA", YearOfDeath: 2032,
+	})
+	if want <= 0 || math.Abs(large.EstateTax-want) > 0.01 {
+		t.Errorf("expected estate tax %.0f, got %.0f", want, large.EstateTax)
+	}
+	if math.Abs(large.NetEstate-(30000000-want)) > 0.01 {
+		t.Errorf("net estate should subtract the tax, got %.0f", large.NetEstate)
+	}
+
+	// A surviving spouse inherits the first decedent's unused exemption
+	couple := *testHousehold()
+	couple.Longevity = input.Longevity
+	couple.Household.Members[0].DeathMonthOffset = intPtr(60)
+	ported := &PathLifespan{LastDeathMonth: 60}
+	se.settleEstate(ported, 30000000, couple)
+	if ported.EstateTax >= large.EstateTax {
+		t.Errorf("portability should reduce the survivor's estate tax: %.0f vs %.0f", ported.EstateTax, large.EstateTax)
+	}
+
+	// Without a start year the estate is settled under the default year's exemption
+	undated := &PathLifespan{LastDeathMonth: 24}
+	se.settleEstate(undated, 30000000, SimulationInput{Longevity: input.Longevity})
+	want = se.estateTaxCalculator.CalculateTotalEstateTax(EstateProfile{
+		GrossEstateValue: 30000000, StateCode: "WA", YearOfDeath: defaultStartYear + 2,
+	})
+	if math.Abs(undated.EstateTax-want) > 0.01 {
+		t.Errorf("expected estate tax %.0f for a death in %d, got %.0f", want, defaultStartYear+2, undated.EstateTax)
+	}
+
+	alive := &PathLifespan{LastDeathMonth: -1}
+	se.settleEstate(alive, 30000000, input)
+	if alive.EstateTax != 0 || alive.EstateValue != 0 {
+		t.Error("no estate is settled when someone outlives the horizon")
+	}
+}
+
+func TestMonteCarloLongevitySummary(t *testing.T) {
+	config := GetDefaultStochasticConfig()
+	config.RandomSeed = 2024
+	config.SimulationMode = "stochastic"
+	config.LiteMode = true
+
+	input := SimulationInput{
+		Config:      config,
+		MonthsToRun: 360,
+		StartYear:   2030,
+		InitialAge:  75,
+		InitialAccounts: AccountHoldingsMonthEnd{
+			Cash:        500000,
+			Taxable:     &Account{Holdings: []Holding{}, TotalValue: 0},
+			TaxDeferred: &Account{Holdings: []Holding{}, TotalValue: 0},
+			Roth:        &Account{Holdings: []Holding{}, TotalValue: 0},
+		},
+		Events: []FinancialEvent{
+			{ID: "living", Type: "EXPENSE", Amount: 2000, Frequency: "monthly"},
+		},
+		Longevity: &LongevityConfig{Sex: "male"},
+	}
+
+	results := RunMonteCarloSimulation(input, 40)
+	if !results.Success {
+		t.Fatalf("simulation failed: %s", results.Error)
+	}
+	summary := results.Longevity
+	if summary == nil {
+		t.Fatal("expected a longevity summary")
+	}
+	if summary.DeathAgeP10 < 75 || summary.DeathAgeP50 < summary.DeathAgeP10 || summary.DeathAgeP90 < summary.DeathAgeP50 {
+		t.Errorf("death age percentiles out of order: %d/%d/%d", summary.DeathAgeP10, summary.DeathAgeP50, summary.DeathAgeP90)
+	}
+	if summary.EstatePaths == 0 {
+		t.Error("a 30-year horizon from 75 should end most paths at a death")
+	}
+	if len(summary.ByAge) == 0 || summary.ByAge[0].Age != 75 {
+		t.Fatalf("expected alive-conditional outcomes starting at 75, got %+v", summary.ByAge)
+	}
+	for i := 1; i < len(summary.ByAge); i++ {
+		if summary.ByAge[i].ProbabilityAlive > summary.ByAge[i-1].ProbabilityAlive {
+			t.Errorf("survival should not increase with age (age %d)", summary.ByAge[i].Age)
+		}
+	}
+	if last := summary.ByAge[len(summary.ByAge)-1]; last.ProbabilityAlive >= summary.ByAge[0].ProbabilityAlive {
+		t.Error("some paths should die over 30 years")
+	}
+}
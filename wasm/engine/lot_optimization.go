@@ -1,4 +1,4 @@
-package main
+package engine
 
 // PERFORMANCE OPTIMIZATION: Optimized lot term status updates
 // The original UpdateLotTermStatus recalculates every lot every month (420 calls × thousands of lots)
@@ -1,14 +1,20 @@
 //go:build !js || !wasm
 // +build !js !wasm
 
-package main
+package engine
 
 import (
+	"log"
 	"os"
 )
 
-// Main function for non-WASM builds (CLI usage)
-func main() {
+// Main is the entrypoint for non-WASM builds (CLI usage).
+// The pathfinder-wasm command (wasm/main.go) calls it on native platforms.
+func Main() {
+	if err := LoadEmbeddedFinancialConfig(); err != nil {
+		log.Fatalf("Failed to load financial config: %v", err)
+	}
+
 	if len(os.Args) > 1 && os.Args[1] == "backtest" {
 		// Remove "backtest" from args and shift everything left
 		os.Args = append(os.Args[:1], os.Args[2:]...)
@@ -1,4 +1,4 @@
-package main
+package engine
 
 import (
 	"crypto/rand"
@@ -314,6 +314,8 @@ type ShockBuffer8 struct {
 }
 
 // PERF: Pool for shock buffers to avoid allocations
+// sync.Pool is safe for concurrent use, and each buffer is held by a single caller between
+// Get and Put, so parallel Monte Carlo workers never share a buffer mid-generation.
 var shockBufferPool = sync.Pool{
 	New: func() interface{} {
 		return &ShockBuffer8{}
@@ -402,6 +404,8 @@ func GetCachedCholeskyMatrix(correlationMatrix [][]float64) ([][]float64, error)
 
 // PrecomputeConfigParameters pre-calculates monthly parameters and Cholesky matrix
 // PERF: Called once per simulation to avoid repeated calculations during simulation loop
+// CONCURRENCY: Must run before paths fan out to workers. Afterwards CachedCholeskyMatrix and
+// PrecomputedMonthly are treated as read-only and shared by every worker's config copy.
 func PrecomputeConfigParameters(config *StochasticModelConfig) error {
 	// PERF: Validate config once and mark as validated to skip per-month validation
 	if !config.ConfigValidated {
@@ -585,6 +589,19 @@ func validateStochasticConfig(config *StochasticModelConfig) error {
 		return fmt.Errorf("AR(1) rental income phi parameter violates stationarity: phi=%.6f", config.AR1RentalIncomeGrowthPhi)
 	}
 
+	// Validate historical bootstrap block length (0 = default)
+	if config.BootstrapMeanBlockLength != 0 && config.BootstrapMeanBlockLength < 1 {
+		return fmt.Errorf("bootstrap mean block length must be at least 1 month: %.2f", config.BootstrapMeanBlockLength)
+	}
+
+	if err := ValidateTaxLawSchedule(config.TaxLawSchedule); err != nil {
+		return err
+	}
+
+	if err := ValidateStressScenarios(config.StressScenarios); err != nil {
+		return err
+	}
+
 	// Validate fat tail parameter
 	if config.FatTailParameter <= 0 {
 		return fmt.Errorf("fat tail parameter must be positive: %.6f", config.FatTailParameter)
@@ -970,6 +987,9 @@ func CalculateDynamicWithdrawal(portfolioValue, baseWithdrawalAmount float64, co
 func GenerateAdvancedStochasticReturnsSeeded(state StochasticState, config *StochasticModelConfig, rng *SeededRNG) (StochasticReturns, StochasticState, error) {
 	// If no seeded RNG provided, fall back to original behavior
 	if rng == nil {
+		if config.SimulationMode == SimulationModeHistoricalBootstrap {
+			return StochasticReturns{}, state, fmt.Errorf("historical_bootstrap mode requires a non-zero RandomSeed")
+		}
 		return GenerateAdvancedStochasticReturns(state, config)
 	}
 
@@ -1024,6 +1044,11 @@ func GenerateAdvancedStochasticReturnsSeeded(state StochasticState, config *Stoc
 		return returns, newState, nil
 	}
 
+	// HISTORICAL BOOTSTRAP: Resample blocks of real months instead of the parametric model
+	if config.SimulationMode == SimulationModeHistoricalBootstrap {
+		return generateHistoricalBootstrapReturns(state, config, rng)
+	}
+
 	// LITE MODE: Skip GARCH, use constant volatility with simple normal returns (seeded version)
 	// PERF: Optimized with zero-allocation shock generation and cached parameters
 	if config.LiteMode {
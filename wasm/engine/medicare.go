@@ -0,0 +1,94 @@
+package engine
+
+/**
+ * Medicare Premiums and IRMAA
+ *
+ * From 65 each person on Medicare pays the standard Part B premium and the Part D base
+ * beneficiary premium every month as a healthcare expense, plus the income-related
+ * monthly adjustment amounts (IRMAA) set by MAGI from two years earlier: 2027's
+ * premiums follow 2025's MAGI. A Roth conversion or home sale at 63 shows up as higher
+ * premiums at 65.
+ *
+ * Each path keeps its own MAGI history (AGI plus tax-exempt interest), recorded when the
+ * year's taxes are settled; SimulationInput.MAGIHistory supplies the years before the
+ * simulation starts. A year with no recorded MAGI carries no surcharge. Premiums and
+ * tiers come from the tax-year registry (irmaa_brackets_<year>.json), indexed for later
+ * years. Joint filers are tiered on joint MAGI and each spouse pays the surcharge.
+ *
+ * Reference: Social Security Act §1839(i); SSA POMS HI 01101.020
+ */
+
+const (
+	medicareEligibilityAge = 65
+	irmaaLookbackYears     = 2
+)
+
+// seedMAGIHistory starts a path's MAGI history with the years before the simulation
+func (se *SimulationEngine) seedMAGIHistory(input *SimulationInput) {
+	se.magiHistory = make(map[int]float64, len(input.MAGIHistory)+input.MonthsToRun/12+1)
+	for year, magi := range input.MAGIHistory {
+		se.magiHistory[year] = magi
+	}
+}
+
+// medicareEnrollees counts the living members of the plan who are 65 or older
+func (se *SimulationEngine) medicareEnrollees(monthOffset int) int {
+	if se.household == nil {
+		if se.primaryAge(monthOffset) >= medicareEligibilityAge {
+			return 1
+		}
+		return 0
+	}
+	enrollees := 0
+	for i := range se.household.members {
+		if se.household.alive(i, monthOffset) && se.household.ageAt(i, monthOffset) >= medicareEligibilityAge {
+			enrollees++
+		}
+	}
+	return enrollees
+}
+
+// irmaaSurcharges returns the monthly Part B and Part D surcharges per person at a MAGI
+func irmaaSurcharges(brackets []IRMAABracket, magi float64) (partB, partD float64) {
+	for _, bracket := range brackets {
+		if magi > bracket.MAGIThreshold {
+			partB, partD = bracket.PartBSurcharge, bracket.PartDSurcharge
+		}
+	}
+	return partB, partD
+}
+
+// chargeMedicarePremiums pays the month's Medicare premiums from cash
+func (se *SimulationEngine) chargeMedicarePremiums(accounts *AccountHoldingsMonthEnd, monthOffset int) {
+	if monthOffset%12 == 0 {
+		se.medicarePremiumsYTD = 0
+		se.irmaaSurchargeYTD = 0
+	}
+	enrollees := se.medicareEnrollees(monthOffset)
+	if enrollees == 0 {
+		return
+	}
+
+	year := se.calendarYear(monthOffset)
+	se.taxCalculator.SetSimulationYear(year, se.taxThresholdRate())
+	params := se.taxCalculator.YearParameters()
+	if params == nil {
+		return
+	}
+
+	surcharge := 0.0
+	if magi, ok := se.magiHistory[year-irmaaLookbackYears]; ok {
+		partB, partD := irmaaSurcharges(params.IRMAABrackets, magi)
+		surcharge = float64(enrollees) * (partB + partD)
+	}
+	premium := float64(enrollees)*(params.MedicarePartBPremium+params.MedicarePartDBasePremium) + surcharge
+
+	accounts.Cash -= premium
+	se.currentMonthFlows.ExpensesThisMonth += premium
+	se.currentMonthFlows.HealthcareExpensesThisMonth += premium
+	se.medicarePremiumsYTD += premium
+	se.irmaaSurchargeYTD += surcharge
+
+	simLogVerbose("🏥 [MEDICARE] Month %d: %d enrollee(s), premiums $%.2f including IRMAA $%.2f (MAGI year %d)",
+		monthOffset, enrollees, premium, surcharge, year-irmaaLookbackYears)
+}
@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIRMAASurchargeTiers(t *testing.T) {
+	params, err := LookupTaxYear(2024, FilingStatusSingle, 0.025, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params.MedicarePartBPremium != 174.70 || params.MedicarePartDBasePremium != 34.70 {
+		t.Errorf("2024 premiums: Part B %.2f, Part D %.2f", params.MedicarePartBPremium, params.MedicarePartDBasePremium)
+	}
+	cases := []struct {
+		magi, partB, partD float64
+	}{
+		{0, 0, 0},
+		{103000, 0, 0}, // The first tier starts above $103,000
+		{103001, 69.90, 12.90},
+		{150000, 174.70, 33.30},
+		{600000, 419.30, 81.00},
+	}
+	for _, c := range cases {
+		partB, partD := irmaaSurcharges(params.IRMAABrackets, c.magi)
+		if partB != c.partB || partD != c.partD {
+			t.Errorf("MAGI %.0f: surcharges %.2f / %.2f, want %.2f / %.2f", c.magi, partB, partD, c.partB, c.partD)
+		}
+	}
+
+	projected, err := LookupTaxYear(2027, FilingStatusSingle, 0.025, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if projected.MedicarePartBPremium <= 174.70 {
+		t.Errorf("2027 Part B premium should be indexed: %.2f", projected.MedicarePartBPremium)
+	}
+}
+
+func TestMedicarePremiumsUseTwoYearLookback(t *testing.T) {
+	se := NewSimulationEngine(GetDefaultStochasticConfig())
+	input := &SimulationInput{InitialAge: 65, StartYear: 2024, MAGIHistory: map[int]float64{2022: 150000}}
+	se.simulationInput = input
+	se.seedMAGIHistory(input)
+	accounts := &AccountHoldingsMonthEnd{Cash: 10000}
+
+	// 2024 premiums follow 2022 MAGI: the second surcharge tier
+	se.chargeMedicarePremiums(accounts, 0)
+	want := 174.70 + 34.70 + 174.70 + 33.30
+	if math.Abs(10000-accounts.Cash-want) > 0.01 || math.Abs(se.currentMonthFlows.HealthcareExpensesThisMonth-want) > 0.01 {
+		t.Errorf("January 2024: paid %.2f (healthcare %.2f), want %.2f", 10000-accounts.Cash, se.currentMonthFlows.HealthcareExpensesThisMonth, want)
+	}
+	if math.Abs(se.irmaaSurchargeYTD-208.00) > 0.01 {
+		t.Errorf("surcharge %.2f, want 208.00", se.irmaaSurchargeYTD)
+	}
+
+	// No 2023 MAGI on record: the standard premium only, and the year's totals restart
+	se.resetMonthlyFlows()
+	se.chargeMedicarePremiums(accounts, 12)
+	if se.irmaaSurchargeYTD != 0 || se.medicarePremiumsYTD <= 174.70+34.70 || se.medicarePremiumsYTD > 1.03*(174.70+34.70) {
+		t.Errorf("January 2025: premiums %.2f, surcharge %.2f", se.medicarePremiumsYTD, se.irmaaSurchargeYTD)
+	}
+
+	// Each spouse on Medicare pays, on joint MAGI tiers
+	se = NewSimulationEngine(GetDefaultStochasticConfig())
+	input = &SimulationInput{StartYear: 2024, MAGIHistory: map[int]float64{2022: 250000}, Household: &Household{Members: []HouseholdMember{
+		{ID: "a", BirthYear: 1958}, {ID: "b", BirthYear: 1961},
+	}}}
+	se.simulationInput = input
+	se.household = newHouseholdState(input)
+	se.taxCalculator.SetFilingStatus(FilingStatusMarriedJointly)
+	se.seedMAGIHistory(input)
+	if se.medicareEnrollees(0) != 1 || se.medicareEnrollees(36) != 2 {
+		t.Errorf("enrollees: %d in 2024, %d in 2027", se.medicareEnrollees(0), se.medicareEnrollees(36))
+	}
+	se.chargeMedicarePremiums(&AccountHoldingsMonthEnd{}, 0)
+	if math.Abs(se.irmaaSurchargeYTD-(69.90+12.90)) > 0.01 {
+		t.Errorf("joint surcharge %.2f, want the first joint tier", se.irmaaSurchargeYTD)
+	}
+}
+
+func TestIRMAAFollowsRothConversionInSimulation(t *testing.T) {
+	input := createRollingTestInput(1000000, 48000)
+	input.Config.RandomSeed = 5
+	input.InitialAge = 63
+	input.StartYear = 2025
+	input.MonthsToRun = 48
+	input.TaxConfig = &SimpleTaxConfig{Enabled: true, EffectiveRate: 0.22, CapitalGainsRate: 0.15}
+	input.InitialAccounts.TaxDeferred = &Account{TotalValue: 600000, Holdings: []Holding{{
+		ID: "ira", AssetClass: AssetClassUSStocksTotalMarket, Quantity: 600000, CostBasisPerUnit: 1, CostBasisTotal: 600000,
+		CurrentMarketPricePerUnit: 1, CurrentMarketValueTotal: 600000,
+	}}}
+	input.Events = append(input.Events, FinancialEvent{ID: "conversion", Type: "ROTH_CONVERSION", Amount: 300000, MonthOffset: 11})
+
+	result := NewSimulationEngine(input.Config).RunSingleSimulation(input)
+	if !result.Success {
+		t.Fatalf("simulation failed: %s", result.Error)
+	}
+	surcharges := map[int]float64{}
+	for _, month := range result.MonthlyData {
+		if month.MonthOffset%12 != 11 {
+			continue
+		}
+		year := input.StartYear + month.MonthOffset/12
+		if (month.MedicarePremiumsAnnual != nil) != (year >= 2027) {
+			t.Errorf("%d: Medicare premiums reported %v at age %d", year, month.MedicarePremiumsAnnual != nil, 63+month.MonthOffset/12)
+		}
+		if month.IRMAAMedicarePremiumAdjustment != nil {
+			surcharges[year] = *month.IRMAAMedicarePremiumAdjustment
+		}
+	}
+
+	// The 2025 conversion sets 2027's premiums at the top tiers; 2028 follows a quieter 2026
+	if surcharges[2027] < 12*(384.30+74.20) || surcharges[2028] >= surcharges[2027] {
+		t.Errorf("IRMAA surcharges: 2027 %.2f, 2028 %.2f", surcharges[2027], surcharges[2028])
+	}
+	snapshot := generateAnnualSnapshots(result, input)["2027"]
+	if snapshot.IRMAASurcharge != surcharges[2027] || snapshot.MedicarePremiums <= snapshot.IRMAASurcharge {
+		t.Errorf("2027 snapshot: Medicare %.2f, IRMAA %.2f", snapshot.MedicarePremiums, snapshot.IRMAASurcharge)
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package engine
 
 import (
 	"testing"
@@ -111,13 +111,15 @@ func TestEventHandlerCountMatches(t *testing.T) {
 	registry := NewEventHandlerRegistry()
 	registeredTypes := registry.GetRegisteredEventTypes()
 
-	// Should have 64 handlers:
+	// Should have 68 handlers:
 	// - 55 original legacy handlers
 	// - 6 unified handlers: CASHFLOW_INCOME, CASHFLOW_EXPENSE, INSURANCE_PREMIUM,
 	//   INSURANCE_PAYOUT, ACCOUNT_CONTRIBUTION, EXPOSURE_CHANGE
 	// - 2 additional event types added during development
 	// - 1 RateResetEventHandler
-	expectedCount := 64
+	// - 2 rental property handlers: RENTAL_PROPERTY_PURCHASE, RENTAL_PROPERTY_SALE
+	// - 2 debt handlers: REFINANCE, EXTRA_PRINCIPAL_PAYMENT
+	expectedCount := 73
 	actualCount := len(registeredTypes)
 
 	if actualCount != expectedCount {
@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"fmt"
+	"sync"
+)
+
+// runMonteCarloPath runs path i on an engine that is reused across paths.
+// The path seed is baseSeed + i, matching RunIsolatedPath.
+func runMonteCarloPath(engine *SimulationEngine, input SimulationInput, pathIndex int) SimulationResult {
+	// PERF: Reuse engine — update seed + deep-copy accounts (instead of RunIsolatedPath)
+	engine.config.RandomSeed = input.Config.RandomSeed + int64(pathIndex)
+
+	pathInput := input
+	pathInput.Config = engine.config
+	pathInput.InitialAccounts = deepCopyInputAccounts(input.InitialAccounts)
+
+	return engine.RunSingleSimulation(pathInput)
+}
+
+// runMonteCarloPaths runs numberOfRuns paths of input and returns every result in path
+// order. Path i always uses seed RandomSeed + i, so inputs that differ only in the plan see
+// the same market returns path by path (common random numbers).
+func runMonteCarloPaths(input SimulationInput, numberOfRuns int) ([]SimulationResult, error) {
+	if numberOfRuns <= 0 || numberOfRuns > 100000 {
+		return nil, fmt.Errorf("number of runs must be between 1 and 100,000, got %d", numberOfRuns)
+	}
+	if input.Config.RandomSeed == 0 {
+		return nil, fmt.Errorf("MC requires non-zero RandomSeed for reproducibility (PFOS-E)")
+	}
+	if input.Config.SimulationMode == "deterministic" {
+		input.Config.DebugDisableRandomness = true
+	}
+	if err := PrecomputeConfigParameters(&input.Config); err != nil {
+		return nil, fmt.Errorf("failed to precompute config: %v", err)
+	}
+
+	if workers := monteCarloWorkerCount(input.Config, numberOfRuns); workers > 1 {
+		return runMonteCarloPathsParallel(input, numberOfRuns, workers), nil
+	}
+
+	engine := NewSimulationEngine(input.Config)
+	engine.trackMonthlyData = false // MC mode: use incremental metrics
+	results := make([]SimulationResult, numberOfRuns)
+	for i := range results {
+		results[i] = runMonteCarloPath(engine, input, i)
+		results[i].FinancialStressEvents = nil
+	}
+	return results, nil
+}
+
+// runMonteCarloPathsParallel runs numberOfRuns paths on a pool of workers and returns
+// the results indexed by path. Each worker owns its SimulationEngine (and therefore its
+// SeededRNG, stochastic state and event queue), so the only state shared between
+// goroutines is the read-only input and the config precomputed by PrecomputeConfigParameters.
+func runMonteCarloPathsParallel(input SimulationInput, numberOfRuns int, workers int) []SimulationResult {
+	results := make([]SimulationResult, numberOfRuns)
+
+	// Engines are built up front on the calling goroutine so calculator construction
+	// (which reads the global financial config) never overlaps with path execution.
+	engines := make([]*SimulationEngine, workers)
+	for w := range engines {
+		engines[w] = NewSimulationEngine(input.Config)
+		engines[w].trackMonthlyData = false // MC mode: use incremental metrics
+	}
+
+	paths := make(chan int, numberOfRuns)
+	for i := 0; i < numberOfRuns; i++ {
+		paths <- i
+	}
+	close(paths)
+
+	var wg sync.WaitGroup
+	for _, engine := range engines {
+		wg.Add(1)
+		go func(engine *SimulationEngine) {
+			defer wg.Done()
+			for i := range paths {
+				result := runMonteCarloPath(engine, input, i)
+				// MEMORY OPTIMIZATION: Stress events are not aggregated; drop them before the
+				// result waits for the in-order consumer
+				result.FinancialStressEvents = nil
+				results[i] = result
+			}
+		}(engine)
+	}
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,64 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+// monte_carlo_parallel_test.go
+// Tests that the native worker pool produces the same results as the serial MC loop
+
+func createParallelMCTestInput() SimulationInput {
+	input := createMCTestInput()
+	input.MonthsToRun = 120
+	input.Events = []FinancialEvent{
+		{ID: "salary", Type: "INCOME", Amount: 8000, MonthOffset: 0, Frequency: "monthly"},
+		{ID: "living", Type: "EXPENSE", Amount: 6500, MonthOffset: 0, Frequency: "monthly"},
+	}
+	return input
+}
+
+// TestParallelMonteCarloMatchesSerial verifies worker-pool results are bit-identical to serial
+func TestParallelMonteCarloMatchesSerial(t *testing.T) {
+	for _, liteMode := range []bool{false, true} {
+		input := createParallelMCTestInput()
+		input.Config.LiteMode = liteMode
+
+		serialInput := input
+		serialInput.Config.MonteCarloWorkers = 1
+		serial := RunMonteCarloSimulation(serialInput, 40)
+
+		parallelInput := input
+		parallelInput.Config.MonteCarloWorkers = 4
+		parallel := RunMonteCarloSimulation(parallelInput, 40)
+
+		if !serial.Success || !parallel.Success {
+			t.Fatalf("liteMode=%v: MC failed: serial=%q parallel=%q", liteMode, serial.Error, parallel.Error)
+		}
+		if !reflect.DeepEqual(serial, parallel) {
+			t.Errorf("liteMode=%v: parallel results differ from serial: P50 %f vs %f, P10 %f vs %f",
+				liteMode, serial.FinalNetWorthP50, parallel.FinalNetWorthP50,
+				serial.FinalNetWorthP10, parallel.FinalNetWorthP10)
+		}
+	}
+}
+
+// TestMonteCarloWorkerCount verifies the worker count override and clamping
+func TestMonteCarloWorkerCount(t *testing.T) {
+	config := GetDefaultStochasticConfig()
+
+	config.MonteCarloWorkers = 1
+	if got := monteCarloWorkerCount(config, 100); got != 1 {
+		t.Errorf("MonteCarloWorkers=1: got %d workers, want 1", got)
+	}
+
+	config.MonteCarloWorkers = 8
+	if got := monteCarloWorkerCount(config, 3); got != 3 {
+		t.Errorf("workers should be clamped to path count: got %d, want 3", got)
+	}
+
+	config.MonteCarloWorkers = 0
+	if got := monteCarloWorkerCount(config, 1000); got < 1 {
+		t.Errorf("auto worker count must be at least 1, got %d", got)
+	}
+}
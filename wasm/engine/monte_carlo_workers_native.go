@@ -0,0 +1,19 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package engine
+
+import "runtime"
+
+// monteCarloWorkerCount returns how many goroutines RunMonteCarloSimulation should use.
+// Native builds default to GOMAXPROCS; config.MonteCarloWorkers overrides (1 = serial).
+func monteCarloWorkerCount(config StochasticModelConfig, numberOfRuns int) int {
+	workers := config.MonteCarloWorkers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > numberOfRuns {
+		workers = numberOfRuns
+	}
+	return workers
+}
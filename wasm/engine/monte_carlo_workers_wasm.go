@@ -0,0 +1,10 @@
+//go:build js && wasm
+// +build js,wasm
+
+package engine
+
+// monteCarloWorkerCount always returns 1 in WASM: the browser runtime is single-threaded,
+// so goroutines would only add scheduling overhead.
+func monteCarloWorkerCount(config StochasticModelConfig, numberOfRuns int) int {
+	return 1
+}
@@ -1,7 +1,7 @@
 // Performance test for MC optimizations
 // Run with: go test -bench=BenchmarkMonteCarloOptimized -benchtime=3x -benchmem
 
-package main
+package engine
 
 import (
 	"testing"
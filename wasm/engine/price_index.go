@@ -0,0 +1,107 @@
+package engine
+
+import "math"
+
+/**
+ * Per-Path Price Index
+ *
+ * Each path compounds its own monthly inflation draws into a consumer price index,
+ * recorded at the end of every month (1.0 at the start of the simulation). Everything
+ * indexed to inflation reads this one index, so a path with a decade of high inflation
+ * sees it in all of them at once:
+ *
+ *   - COLAs: Social Security and COLA-adjusted pensions step up each January by the
+ *     inflation realized through the end of the prior year
+ *   - Events with applyInflation, from the simulation start or from their own start
+ *   - Tax thresholds: brackets, deductions, contribution limits, IRMAA tiers and state
+ *     amounts are carried from their published year by the path's price level in January
+ *     of the tax year. Years before the simulation starts use the threshold inflation
+ *     rate, and thresholds never fall below their published amounts. An explicit
+ *     TaxThresholdInflationRate keeps the fixed-rate indexing.
+ *
+ * Results report real (today's-dollar) net worth alongside the nominal series: the
+ * nominal amount divided by the price index at that month's end.
+ */
+
+// recordPriceIndex records the price index at the end of monthOffset
+func (se *SimulationEngine) recordPriceIndex(monthOffset int) {
+	if monthOffset < 0 {
+		return
+	}
+	for len(se.priceIndexHistory) < monthOffset {
+		se.priceIndexHistory = append(se.priceIndexHistory, se.eventInflationFactor)
+	}
+	if len(se.priceIndexHistory) == monthOffset {
+		se.priceIndexHistory = append(se.priceIndexHistory, se.cumulativeInflationFactor)
+	} else {
+		se.priceIndexHistory[monthOffset] = se.cumulativeInflationFactor
+	}
+}
+
+// priceLevel returns the price index at the start of monthOffset. Months not yet
+// simulated are projected from the latest level at the mean inflation rate.
+func (se *SimulationEngine) priceLevel(monthOffset int) float64 {
+	if monthOffset <= 0 {
+		return 1
+	}
+	known := len(se.priceIndexHistory)
+	if monthOffset <= known {
+		return se.priceIndexHistory[monthOffset-1]
+	}
+	level := 1.0
+	if known > 0 {
+		level = se.priceIndexHistory[known-1]
+	}
+	return level * math.Pow(1+AnnualToMonthlyRate(se.config.MeanInflation), float64(monthOffset-known))
+}
+
+// PriceIndexAt returns the price index at the end of monthOffset, for restating that
+// month's amounts in today's dollars
+func (se *SimulationEngine) PriceIndexAt(monthOffset int) float64 {
+	return se.priceLevel(monthOffset + 1)
+}
+
+// colaIndex returns the cost-of-living factor paid in monthOffset: the price level at
+// the start of its calendar year
+func (se *SimulationEngine) colaIndex(monthOffset int) float64 {
+	return se.priceLevel(monthOffset - monthOffset%12)
+}
+
+// thresholdIndex returns the index tax thresholds follow on this path, or nil when the
+// configuration fixes a threshold inflation rate
+func (se *SimulationEngine) thresholdIndex(startYear int) ThresholdIndex {
+	if se.config.TaxThresholdInflationRate != 0 {
+		return nil
+	}
+	return pathThresholdIndex{engine: se, startYear: startYear, rate: defaultTaxThresholdInflationRate}
+}
+
+// pathThresholdIndex carries tax thresholds forward by a path's price level
+type pathThresholdIndex struct {
+	engine    *SimulationEngine
+	startYear int
+	rate      float64 // Annual rate for years before the simulation starts
+}
+
+// Factor is the growth in the price level from January of fromYear to January of
+// toYear, never less than 1
+func (ix pathThresholdIndex) Factor(fromYear, toYear int) float64 {
+	if toYear <= ix.startYear {
+		return indexFactor(toYear-fromYear, ix.rate)
+	}
+	return math.Max(1, ix.level(toYear)/ix.level(fromYear))
+}
+
+// level is the price level in January of year relative to the simulation start
+func (ix pathThresholdIndex) level(year int) float64 {
+	if year <= ix.startYear {
+		return 1 / indexFactor(ix.startYear-year, ix.rate)
+	}
+	return ix.engine.priceLevel((year - ix.startYear) * 12)
+}
+
+// setRealNetWorth records a month's price index and its net worth in today's dollars
+func (se *SimulationEngine) setRealNetWorth(md *MonthlyDataSimulation) {
+	md.PriceIndex = se.PriceIndexAt(md.MonthOffset)
+	md.NetWorthReal = md.NetWorth / md.PriceIndex
+}
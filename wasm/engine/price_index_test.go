@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPriceIndexInSimulation(t *testing.T) {
+	run := func(events ...FinancialEvent) SimulationResult {
+		input := createRollingTestInput(0, 0)
+		input.Config.RandomSeed = 17
+		input.StartYear = 2025
+		input.MonthsToRun = 36
+		input.InitialAccounts = AccountHoldingsMonthEnd{Cash: 500000}
+		input.Events = append([]FinancialEvent{{ID: "ss", Type: "SOCIAL_SECURITY_INCOME", Amount: 2000, Frequency: "monthly",
+			Metadata: map[string]interface{}{"isColaAdjusted": true}}}, events...)
+
+		result := NewSimulationEngine(input.Config).RunSingleSimulation(input)
+		if !result.Success {
+			t.Fatalf("simulation failed: %s", result.Error)
+		}
+		return result
+	}
+	baseline := run().MonthlyData
+	result := run(FinancialEvent{ID: "rent", Type: "EXPENSE", Amount: 1000, Frequency: "monthly", MonthOffset: 12,
+		Metadata: map[string]interface{}{"applyInflation": true, "inflationBase": "event_start"}})
+	months := result.MonthlyData
+	if months[35].PriceIndex == 1 {
+		t.Fatal("price index never moved")
+	}
+
+	// COLAs step up each January by the path's inflation through December
+	for _, m := range []int{0, 11, 12, 23, 24, 35} {
+		want := 2000.0
+		if m >= 12 {
+			want *= months[m-m%12-1].PriceIndex
+		}
+		if got := months[m].SocialSecurityIncomeThisMonth; math.Abs(got-want) > 1e-6 {
+			t.Errorf("month %d benefit %.4f, want %.4f", m, got, want)
+		}
+	}
+
+	// An event indexed from its own start grows with the index since then
+	for _, m := range []int{12, 13, 30} {
+		want := 1000 * months[m-1].PriceIndex / months[11].PriceIndex
+		if got := months[m].ExpensesThisMonth - baseline[m].ExpensesThisMonth; math.Abs(got-want) > 1e-6 {
+			t.Errorf("month %d expense %.4f, want %.4f", m, got, want)
+		}
+	}
+
+	// Real net worth deflates each month by that month's price index
+	for _, md := range months {
+		if math.Abs(md.NetWorthReal-md.NetWorth/md.PriceIndex) > 1e-6 {
+			t.Fatalf("month %d real net worth %.2f, nominal %.2f at index %.4f", md.MonthOffset, md.NetWorthReal, md.NetWorth, md.PriceIndex)
+		}
+	}
+	if math.Abs(result.FinalNetWorthReal-result.FinalNetWorth/months[35].PriceIndex) > 1e-6 {
+		t.Errorf("final real net worth %.2f", result.FinalNetWorthReal)
+	}
+}
+
+func TestThresholdsFollowPathPriceIndex(t *testing.T) {
+	se := NewSimulationEngine(GetDefaultStochasticConfig())
+	ix := pathThresholdIndex{engine: se, startYear: 2025, rate: 0.025}
+
+	// Deflation in 2025, then eight percent inflation in 2026
+	for m := 0; m < 12; m++ {
+		se.priceIndexHistory = append(se.priceIndexHistory, math.Pow(0.99, float64(m+1)/12))
+	}
+	for m := 12; m < 24; m++ {
+		se.priceIndexHistory = append(se.priceIndexHistory, 0.99*math.Pow(1.08, float64(m-11)/12))
+	}
+	if got := ix.Factor(2024, 2025); got != 1.025 {
+		t.Errorf("years before the start use the threshold rate: %.4f", got)
+	}
+	if got := ix.Factor(2025, 2026); got != 1 {
+		t.Errorf("thresholds fell with deflation: %.4f", got)
+	}
+	if got := ix.Factor(2026, 2027); math.Abs(got-1.08) > 1e-9 {
+		t.Errorf("2026 -> 2027 factor %.4f, want 1.08", got)
+	}
+
+	// The calculator re-resolves a year when the path's price level changes
+	se.taxCalculator.SetThresholdIndex(ix)
+	se.taxCalculator.SetSimulationYear(2027, 0.025)
+	want, err := LookupTaxYearIndexed(2027, FilingStatusSingle, ix, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := se.taxCalculator.YearParameters().StandardDeduction; got != want.StandardDeduction {
+		t.Errorf("2027 standard deduction %.0f, want %.0f", got, want.StandardDeduction)
+	}
+	fixed, _ := LookupTaxYear(2027, FilingStatusSingle, 0.025, "")
+	if want.StandardDeduction <= fixed.StandardDeduction {
+		t.Errorf("8%% inflation should raise the deduction past fixed-rate indexing: %.0f vs %.0f", want.StandardDeduction, fixed.StandardDeduction)
+	}
+	se.priceIndexHistory = se.priceIndexHistory[:0]
+	se.taxCalculator.SetSimulationYear(2027, 0.025)
+	if got := se.taxCalculator.YearParameters().StandardDeduction; got >= want.StandardDeduction {
+		t.Errorf("stale 2027 parameters after the price level changed: %.0f", got)
+	}
+}
@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+// buyTestHome purchases a $500k home with 20% down at month 0
+func buyTestHome(t *testing.T) (*SimulationEngine, *AccountHoldingsMonthEnd) {
+	t.Helper()
+	se := NewSimulationEngine(GetDefaultStochasticConfig())
+	accounts := &AccountHoldingsMonthEnd{Cash: 150000, Taxable: &Account{Holdings: []Holding{}}}
+
+	purchase := FinancialEvent{
+		ID:       "home",
+		Type:     "REAL_ESTATE_PURCHASE",
+		Amount:   500000,
+		Metadata: map[string]interface{}{"propertyDetails": map[string]interface{}{"downPaymentPercent": 0.20}},
+	}
+	cashFlow := 0.0
+	if err := (&RealEstatePurchaseEventHandler{}).Process(purchase, accounts, &cashFlow, &EventProcessingContext{SimulationEngine: se}); err != nil {
+		t.Fatalf("purchase: %v", err)
+	}
+	if len(se.liabilities) != 1 {
+		t.Fatalf("expected a mortgage liability, got %d", len(se.liabilities))
+	}
+	return se, accounts
+}
+
+func sellTestHome(t *testing.T, se *SimulationEngine, accounts *AccountHoldingsMonthEnd, month int, metadata map[string]interface{}) {
+	t.Helper()
+	sale := FinancialEvent{ID: "sale", Type: "REAL_ESTATE_SALE", Amount: 1, Metadata: metadata}
+	cashFlow := 0.0
+	if err := (&RealEstateSaleEventHandler{}).Process(sale, accounts, &cashFlow, &EventProcessingContext{SimulationEngine: se, CurrentMonth: month}); err != nil {
+		t.Fatalf("sale: %v", err)
+	}
+}
+
+func TestRealEstateSaleRetiresHoldingAndMortgage(t *testing.T) {
+	se, accounts := buyTestHome(t)
+	home := accounts.Taxable.Holdings[0]
+	if home.CurrentMarketPricePerUnit != se.GetPricePerShare(AssetClassRealEstatePrimaryHome) {
+		t.Fatalf("property units should be priced at the home index, got %.2f", home.CurrentMarketPricePerUnit)
+	}
+
+	// Home prices rise 50% over three years
+	se.marketPrices.RealEstate *= 1.5
+	sellTestHome(t, se, accounts, 36, map[string]interface{}{"propertyId": "home"})
+
+	if len(accounts.Taxable.Holdings) != 0 {
+		t.Error("sold property should be removed from holdings")
+	}
+	if len(se.liabilities) != 0 {
+		t.Error("mortgage should be paid off at sale")
+	}
+	// $750k sale - 6% costs - $400k mortgage on top of the $50k left after the down payment
+	if want := 50000 + 750000*0.94 - 400000; math.Abs(accounts.Cash-want) > 0.01 {
+		t.Errorf("cash after sale: got %.2f, want %.2f", accounts.Cash, want)
+	}
+	// $205k gain is inside the $250k single exclusion
+	if se.longTermCapitalGainsYTD != 0 || se.shortTermCapitalGainsYTD != 0 {
+		t.Errorf("gain under the exclusion should not be taxed, got LT %.2f ST %.2f",
+			se.longTermCapitalGainsYTD, se.shortTermCapitalGainsYTD)
+	}
+}
+
+func TestRealEstateSaleSection121(t *testing.T) {
+	// Owned under two years: no exclusion, long-term after a year
+	se, accounts := buyTestHome(t)
+	se.marketPrices.RealEstate *= 1.5
+	sellTestHome(t, se, accounts, 18, map[string]interface{}{"sellingCosts": 30000.0})
+	if want := 750000.0 - 30000 - 500000; math.Abs(se.longTermCapitalGainsYTD-want) > 0.01 {
+		t.Errorf("gain without exclusion: got %.2f, want %.2f", se.longTermCapitalGainsYTD, want)
+	}
+
+	// Joint filers exclude $500k
+	se, accounts = buyTestHome(t)
+	se.taxCalculator.SetFilingStatus(FilingStatusMarriedJointly)
+	se.marketPrices.RealEstate *= 2.5
+	sellTestHome(t, se, accounts, 120, map[string]interface{}{"sellingCostPercent": 0.05})
+	if want := 1250000*0.95 - 500000 - 500000; math.Abs(se.longTermCapitalGainsYTD-want) > 0.01 {
+		t.Errorf("joint gain over exclusion: got %.2f, want %.2f", se.longTermCapitalGainsYTD, want)
+	}
+
+	// A rental or second home gets no exclusion
+	se, accounts = buyTestHome(t)
+	sellTestHome(t, se, accounts, 60, map[string]interface{}{"salePrice": 700000.0, "sellingCosts": 0.0, "primaryResidence": false})
+	if math.Abs(se.longTermCapitalGainsYTD-200000) > 0.01 {
+		t.Errorf("non-residence gain: got %.2f, want 200000", se.longTermCapitalGainsYTD)
+	}
+}
+
+func TestRealEstateSaleWithoutProperty(t *testing.T) {
+	se := NewSimulationEngine(GetDefaultStochasticConfig())
+	accounts := &AccountHoldingsMonthEnd{Cash: 1000, Taxable: &Account{}}
+	sale := FinancialEvent{ID: "sale", Type: "REAL_ESTATE_SALE", Amount: 300000}
+	cashFlow := 0.0
+	if err := (&RealEstateSaleEventHandler{}).Process(sale, accounts, &cashFlow, &EventProcessingContext{SimulationEngine: se}); err != nil {
+		t.Fatalf("sale: %v", err)
+	}
+	if accounts.Cash != 301000 {
+		t.Errorf("without a property holding the sale amount goes to cash, got %.2f", accounts.Cash)
+	}
+}
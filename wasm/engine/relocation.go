@@ -0,0 +1,151 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+/**
+ * State Relocation
+ *
+ * The state of residence starts as TaxConfig.State. RELOCATE moves the household in
+ * the event's month:
+ *   - metadata stateCode: the new state (two-letter code, 50 states or DC)
+ *   - amount: moving costs, paid from cash that month (not deductible since 2018
+ *     outside the armed forces, IRC §217(k))
+ *
+ * From the move, the state of residence drives:
+ *   - State income tax (StateTaxCalculator)
+ *   - The default property tax of a home or rental bought afterwards, from
+ *     PropertyCostEscalator's state rules (metadata stateCode on the purchase overrides)
+ *   - Long-term care costs: a HEALTHCARE_COST with metadata longTermCare is quoted in
+ *     the starting state (or metadata stateCode) and scaled by
+ *     LongTermCareCalculator.GetCostByState for the state the household lives in
+ *   - State estate tax at the last death (instead of Longevity.StateCode)
+ *
+ * The tax year of a move is filed as a part-year resident of each state. As on the
+ * California 540NR and New York IT-203, each state's tax is computed as if the whole
+ * year's income had been taxed as a resident, then multiplied by the share of the
+ * year's income received while living there. Income is attributed by when it is
+ * received; nonresident source rules (wages earned in a state after leaving it) and
+ * credits for taxes paid to another state are not modelled.
+ */
+
+// partYearResidence is a state left during the tax year and the income received while resident
+type partYearResidence struct {
+	stateCode string
+	income    float64
+}
+
+// startResidence sets a path's state of residence at the start of the simulation
+func (se *SimulationEngine) startResidence(stateCode string) {
+	se.residenceState = strings.ToUpper(stateCode)
+	se.startingState = se.residenceState
+	se.relocated = false
+	se.partYearResidences = nil
+	se.taxCalculator.SetState(se.residenceState)
+}
+
+// residentIncomeYTD is the income received so far this tax year, for apportioning a
+// part-year resident's tax
+func (se *SimulationEngine) residentIncomeYTD() float64 {
+	return se.ordinaryIncomeYTD + se.socialSecurityBenefitsYTD + se.capitalGainsYTD + se.qualifiedDividendsYTD
+}
+
+// relocate moves the household to another state, closing the old state's part of the year
+func (se *SimulationEngine) relocate(stateCode string) {
+	received := se.residentIncomeYTD()
+	for _, previous := range se.partYearResidences {
+		received -= previous.income
+	}
+	se.partYearResidences = append(se.partYearResidences, partYearResidence{
+		stateCode: se.residenceState,
+		income:    received,
+	})
+	se.residenceState = stateCode
+	se.relocated = true
+	se.taxCalculator.SetState(stateCode)
+}
+
+// stateIncomeTax returns the year's state income tax, apportioned across the states
+// lived in when the household moved during the year
+func (se *SimulationEngine) stateIncomeTax(income StateIncome) float64 {
+	fullYear := se.taxCalculator.StateIncomeTax(income)
+	total := se.residentIncomeYTD()
+	if len(se.partYearResidences) == 0 || total <= 0 {
+		return fullYear
+	}
+
+	tax := 0.0
+	remainingShare := 1.0
+	for _, previous := range se.partYearResidences {
+		share := clampShare(previous.income / total)
+		tax += share * se.taxCalculator.StateIncomeTaxIn(income, previous.stateCode)
+		remainingShare -= share
+	}
+	return tax + clampShare(remainingShare)*fullYear
+}
+
+func clampShare(share float64) float64 {
+	if share < 0 {
+		return 0
+	}
+	if share > 1 {
+		return 1
+	}
+	return share
+}
+
+// longTermCareCost scales a long-term care cost quoted in one state to the state of residence
+func (se *SimulationEngine) longTermCareCost(amount float64, metadata map[string]interface{}) float64 {
+	if !getBoolFromMetadata(metadata, "longTermCare", false) || se.residenceState == "" {
+		return amount
+	}
+	quotedIn := strings.ToUpper(getStringFromMetadata(metadata, "stateCode", se.startingState))
+	return amount * se.ltcCalculator.GetCostByState(se.residenceState) / se.ltcCalculator.GetCostByState(quotedIn)
+}
+
+// defaultPropertyTax estimates the first year's property tax on a purchase from the
+// state's effective rate and homestead exemption (1.2% of the price without state rules)
+func (se *SimulationEngine) defaultPropertyTax(purchasePrice float64, stateCode string) float64 {
+	return se.propertyCostEscalator.ProjectPropertyTax(PropertyProfile{
+		PurchasePrice: purchasePrice,
+		CurrentValue:  purchasePrice,
+		PropertyTax:   purchasePrice * 0.012,
+		StateCode:     stateCode,
+		YearPurchased: propertyCostBaseYear,
+	}, propertyCostBaseYear)
+}
+
+// propertyStateCode returns the state a purchase is in: metadata stateCode, else the state of residence
+func (se *SimulationEngine) propertyStateCode(details map[string]interface{}) string {
+	return strings.ToUpper(getStringFromMetadata(details, "stateCode", se.residenceState))
+}
+
+// RelocateEventHandler moves the household to the state in metadata stateCode and pays
+// the moving costs
+type RelocateEventHandler struct{}
+
+func (h *RelocateEventHandler) Process(event FinancialEvent, accounts *AccountHoldingsMonthEnd, cashFlow *float64, context *EventProcessingContext) error {
+	se := context.SimulationEngine
+
+	stateCode := strings.ToUpper(getStringFromMetadata(event.Metadata, "stateCode", ""))
+	if _, ok := NewStateTaxCalculator().StateConfig(stateCode); !ok {
+		return fmt.Errorf("relocation event %s: stateCode %q is not a US state or DC", event.ID, stateCode)
+	}
+	from := se.residenceState
+	if stateCode != from {
+		se.relocate(stateCode)
+	}
+
+	if event.Amount > 0 {
+		accounts.Cash -= event.Amount
+		*cashFlow -= event.Amount
+		se.currentMonthFlows.ExpensesThisMonth += event.Amount
+		se.currentMonthFlows.OneTimeEventsImpactThisMonth -= event.Amount
+	}
+
+	simLogEvent("INFO  [Month %d] Event: RELOCATE | %s -> %s | Moving costs: $%.2f",
+		context.CurrentMonth, from, stateCode, event.Amount)
+	return nil
+}
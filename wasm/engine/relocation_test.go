@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPartYearResidentApportionment(t *testing.T) {
+	se := NewSimulationEngine(GetDefaultStochasticConfig())
+	se.taxCalculator.SetSimulationYear(2025, 0.025)
+	se.startResidence("ca")
+	income := StateIncome{Ordinary: 120000}
+	california := se.taxCalculator.StateIncomeTax(income)
+
+	// A quarter of the year's income received in California before moving to Texas
+	se.ordinaryIncomeYTD = 30000
+	se.relocate("TX")
+	se.ordinaryIncomeYTD = 120000
+	if got := se.stateIncomeTax(income); math.Abs(got-0.25*california) > 0.01 {
+		t.Errorf("CA -> TX: %.2f, want %.2f", got, 0.25*california)
+	}
+
+	// Moving on to New York: each state taxes its share at its full-year resident rate
+	se.relocate("NY")
+	se.ordinaryIncomeYTD = 150000
+	income.Ordinary = 150000
+	want := 0.2*se.taxCalculator.StateIncomeTaxIn(income, "CA") + 0.2*se.taxCalculator.StateIncomeTaxIn(income, "NY")
+	if got := se.stateIncomeTax(income); math.Abs(got-want) > 0.01 {
+		t.Errorf("CA -> TX -> NY: %.2f, want %.2f", got, want)
+	}
+
+	// The next tax year is filed as a full-year resident of the new state
+	se.resetTaxYTD(1)
+	if got := se.stateIncomeTax(income); got != se.taxCalculator.StateIncomeTaxIn(income, "NY") {
+		t.Errorf("year after the move: %.2f", got)
+	}
+}
+
+func TestRelocationCostRules(t *testing.T) {
+	se := NewSimulationEngine(GetDefaultStochasticConfig())
+	se.startResidence("CA")
+	care := map[string]interface{}{"longTermCare": true}
+	if got := se.longTermCareCost(9000, care); got != 9000 {
+		t.Errorf("care cost before moving: %.2f", got)
+	}
+	caHomeTax := se.defaultPropertyTax(500000, se.propertyStateCode(nil))
+
+	se.relocate("TX")
+	if got, want := se.longTermCareCost(9000, care), 9000*0.95/1.35; math.Abs(got-want) > 0.01 {
+		t.Errorf("care cost after moving to TX: %.2f, want %.2f", got, want)
+	}
+	if got := se.longTermCareCost(9000, nil); got != 9000 {
+		t.Errorf("ordinary healthcare costs should not be rescaled: %.2f", got)
+	}
+
+	// Texas has no assessment cap and a higher effective rate than California
+	txHomeTax := se.defaultPropertyTax(500000, se.propertyStateCode(nil))
+	if math.Abs(caHomeTax-(500000-7000)*0.0073) > 0.01 || math.Abs(txHomeTax-(500000-40000)*0.0181) > 0.01 {
+		t.Errorf("property tax: CA %.2f, TX %.2f", caHomeTax, txHomeTax)
+	}
+	if got := se.propertyStateCode(map[string]interface{}{"stateCode": "fl"}); got != "FL" {
+		t.Errorf("purchase state override: %s", got)
+	}
+}
+
+func TestRelocateInSimulation(t *testing.T) {
+	run := func(events ...FinancialEvent) []MonthlyDataSimulation {
+		input := createRollingTestInput(0, 0)
+		input.Config.RandomSeed = 11
+		input.InitialAge = 60
+		input.StartYear = 2025
+		input.MonthsToRun = 24
+		input.InitialAccounts = AccountHoldingsMonthEnd{Cash: 50000}
+		input.TaxConfig = &SimpleTaxConfig{Enabled: true, State: "CA"}
+		input.Events = append([]FinancialEvent{{ID: "salary", Type: "INCOME", Amount: 15000, Frequency: "monthly"}}, events...)
+
+		result := NewSimulationEngine(input.Config).RunSingleSimulation(input)
+		if !result.Success {
+			t.Fatalf("simulation failed: %s", result.Error)
+		}
+		return result.MonthlyData
+	}
+	stateTax := func(months []MonthlyDataSimulation, year int) float64 {
+		december := months[year*12+11]
+		if december.StateIncomeTaxAnnual == nil {
+			t.Fatalf("no state tax reported for year %d", year)
+		}
+		return *december.StateIncomeTaxAnnual
+	}
+
+	stayed := run()
+	moved := run(FinancialEvent{ID: "move", Type: "RELOCATE", Amount: 12000, MonthOffset: 6, Metadata: map[string]interface{}{"stateCode": "NV"}})
+
+	// Half the salary was received in California
+	if got, want := stateTax(moved, 0), stateTax(stayed, 0)/2; math.Abs(got-want) > 0.02*want {
+		t.Errorf("move year state tax %.2f, want about %.2f", got, want)
+	}
+	if got := stateTax(moved, 1); got != 0 {
+		t.Errorf("Nevada resident taxed %.2f", got)
+	}
+	if moved[6].ExpensesThisMonth-stayed[6].ExpensesThisMonth != 12000 {
+		t.Errorf("moving costs: %.2f", moved[6].ExpensesThisMonth-stayed[6].ExpensesThisMonth)
+	}
+}
@@ -0,0 +1,496 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+)
+
+/**
+ * Rental Property Subsystem
+ *
+ * RENTAL_PROPERTY_PURCHASE buys an investment property held in the taxable
+ * account as a real_estate_rental holding (priced off the simulated home index)
+ * with an optional mortgage. Each month, before debt payments:
+ *   - Gross rent grows with the stochastic Rent series (AR(1) rental income growth)
+ *   - Collected rent = gross rent * (1 - vacancy rate)
+ *   - Management fees (a share of collected rent) and operating costs (property tax,
+ *     insurance, HOA, maintenance and major repairs from PropertyCostEscalator,
+ *     re-projected on each purchase anniversary) are paid from cash
+ *   - The building (price less the land share) depreciates straight-line over
+ *     27.5 years (residential rental property, IRC §168(c))
+ *
+ * Schedule E net income = collected rent - fees - operating costs - mortgage
+ * interest - depreciation. Net income is taxed as passive income; net losses are
+ * suspended (passive activity rules, IRC §469) and offset later rental income.
+ * The $25,000 active-participation allowance is not modelled. Suspended losses
+ * are released when the property is sold in a taxable sale.
+ *
+ * RENTAL_PROPERTY_SALE sells at the marked-to-market value less selling costs and
+ * pays off the property's mortgage. Gain over the depreciated basis is taxed as
+ * ordinary income up to the depreciation taken (recapture; the 25% cap on
+ * unrecaptured §1250 gain is not modelled) and as a capital gain above that.
+ *
+ * With metadata exchange1031 the gain is deferred instead (like-kind exchange,
+ * IRC §1031). A RENTAL_PROPERTY_PURCHASE with metadata exchangeFromPropertyId
+ * within 6 months (the 180-day exchange period) takes a carryover basis: its price
+ * less the deferred gain. Trading down is taxed as boot up to the deferred gain.
+ * Prior depreciation, suspended losses and the holding period carry over to the
+ * replacement property. An exchange that is not completed in time is taxed.
+ * Sale proceeds stay in cash while the exchange is pending (no intermediary
+ * account is modelled).
+ */
+
+// Rental property defaults
+const (
+	residentialRecoveryMonths         = 330 // 27.5-year straight-line recovery period
+	defaultRentalVacancyRate          = 0.05
+	defaultRentalManagementFeePercent = 0.08
+	defaultRentalLandValuePercent     = 0.20
+	defaultRentalPropertyAgeYears     = 30
+	exchange1031PeriodMonths          = 6 // Replacement must close within 180 days of the sale
+
+	// propertyCostBaseYear is the "current year" PropertyCostEscalator projects
+	// from; rental costs are projected on a calendar shifted so the purchase year
+	// maps to it
+	propertyCostBaseYear = 2024
+)
+
+// rentalProperty tracks the operating and tax state of one rental property
+type rentalProperty struct {
+	id            string // Purchase event ID
+	holdingID     string
+	mortgageID    string
+	purchaseMonth int
+	acquiredMonth int // Start of the holding period (carried over through exchanges)
+
+	// Tax basis
+	basis                   float64 // Cost basis before depreciation (carryover basis after an exchange)
+	depreciableBasis        float64 // Building share of the basis
+	accumulatedDepreciation float64 // Depreciation taken on this property
+	carriedDepreciation     float64 // Depreciation carried over from exchanged properties
+	suspendedLosses         float64 // Passive losses carried forward
+
+	// Operations
+	monthlyRent          float64 // Gross monthly rent at purchase
+	rentIndex            float64 // Cumulative Rent growth since purchase
+	vacancyRate          float64
+	managementFeePercent float64
+	costProfile          PropertyProfile
+	annualOperatingCost  float64
+}
+
+// pendingExchange holds the gain deferred by a 1031 sale until the replacement
+// property is bought
+type pendingExchange struct {
+	fromPropertyID      string
+	amountRealized      float64
+	deferredGain        float64
+	carriedDepreciation float64
+	suspendedLosses     float64
+	acquiredMonth       int
+	deadlineMonth       int
+}
+
+// RentalPropertyPurchaseEventHandler handles rental property purchase events.
+//
+// event.Amount is the purchase price. metadata.propertyDetails takes monthlyRent
+// (required), vacancyRate, managementFeePercent, landValuePercent, yearBuilt,
+// stateCode (default: the state of residence), propertyTaxAnnual,
+// homeInsuranceAnnual, hoaFeesAnnual and the
+// mortgage terms used by REAL_ESTATE_PURCHASE (downPaymentPercent,
+// mortgageInterestRate, mortgageTermYears).
+type RentalPropertyPurchaseEventHandler struct{}
+
+func (h *RentalPropertyPurchaseEventHandler) Process(event FinancialEvent, accounts *AccountHoldingsMonthEnd, cashFlow *float64, context *EventProcessingContext) error {
+	se := context.SimulationEngine
+
+	purchasePrice := event.Amount
+	if purchasePrice <= 0 {
+		return fmt.Errorf("invalid rental property purchase price: %.2f", purchasePrice)
+	}
+	details, ok := event.Metadata["propertyDetails"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("rental property purchase event %s missing required 'propertyDetails' metadata", event.ID)
+	}
+	monthlyRent := getFloat64FromMetadata(details, "monthlyRent", 0)
+	if monthlyRent <= 0 {
+		return fmt.Errorf("rental property purchase event %s missing required 'monthlyRent'", event.ID)
+	}
+
+	downPaymentPercent := 0.20
+	if dp := getFloat64FromMetadata(details, "downPaymentPercent", 0); dp > 0 && dp <= 1.0 {
+		downPaymentPercent = dp
+	}
+	downPaymentAmount := purchasePrice * downPaymentPercent
+	mortgageAmount := purchasePrice - downPaymentAmount
+
+	if accounts.Cash < downPaymentAmount {
+		shortfall := downPaymentAmount - accounts.Cash
+		saleResult, actualAmount := se.cashManager.ExecuteTaxEfficientWithdrawal(accounts, shortfall, context.CurrentMonth)
+		if actualAmount < shortfall {
+			return fmt.Errorf("insufficient funds for rental property down payment: need $%.2f, could only raise $%.2f",
+				shortfall, actualAmount)
+		}
+		se.ProcessCapitalGainsWithTermDifferentiation(saleResult.ShortTermGains, saleResult.LongTermGains)
+	}
+
+	accounts.Cash -= downPaymentAmount
+	*cashFlow -= downPaymentAmount
+	se.currentMonthFlows.ExpensesThisMonth += downPaymentAmount
+	se.currentMonthFlows.OneTimeEventsImpactThisMonth -= downPaymentAmount
+
+	property := &rentalProperty{
+		id:                   event.ID,
+		holdingID:            fmt.Sprintf("rental-%s", event.ID),
+		purchaseMonth:        context.CurrentMonth,
+		acquiredMonth:        context.CurrentMonth,
+		basis:                purchasePrice,
+		monthlyRent:          monthlyRent,
+		rentIndex:            1.0,
+		vacancyRate:          defaultRentalVacancyRate,
+		managementFeePercent: defaultRentalManagementFeePercent,
+	}
+	if rate := getFloat64FromMetadata(details, "vacancyRate", -1); rate >= 0 && rate < 1 {
+		property.vacancyRate = rate
+	}
+	if pct := getFloat64FromMetadata(details, "managementFeePercent", -1); pct >= 0 && pct < 1 {
+		property.managementFeePercent = pct
+	}
+
+	// Complete a pending like-kind exchange: the deferred gain reduces the basis
+	if fromID := getStringFromMetadata(event.Metadata, "exchangeFromPropertyId", ""); fromID != "" {
+		exchange := se.takePendingExchange(fromID)
+		if exchange == nil {
+			simLogVerbose("⚠️ [RENTAL] No pending exchange from %s for %s, buying without carryover basis", fromID, event.ID)
+		} else {
+			boot := math.Max(0, exchange.amountRealized-purchasePrice)
+			recognized := math.Min(boot, exchange.deferredGain)
+			if recognized > 0 {
+				recapture := math.Min(recognized, exchange.carriedDepreciation)
+				se.recognizeRentalGain(recognized, recapture, context.CurrentMonth-exchange.acquiredMonth)
+				exchange.carriedDepreciation -= recapture
+			}
+			property.basis = purchasePrice - (exchange.deferredGain - recognized)
+			property.carriedDepreciation = exchange.carriedDepreciation
+			property.suspendedLosses = exchange.suspendedLosses
+			property.acquiredMonth = exchange.acquiredMonth
+			simLogVerbose("🔁 [RENTAL] Exchange from %s completed: deferred $%.2f, boot taxed $%.2f",
+				fromID, exchange.deferredGain-recognized, recognized)
+		}
+	}
+
+	landValuePercent := defaultRentalLandValuePercent
+	if pct := getFloat64FromMetadata(details, "landValuePercent", -1); pct >= 0 && pct < 1 {
+		landValuePercent = pct
+	}
+	property.depreciableBasis = property.basis * (1 - landValuePercent)
+
+	// Escalator profile on the shifted calendar (purchase year = propertyCostBaseYear)
+	ageYears := defaultRentalPropertyAgeYears
+	if built := getFloat64FromMetadata(details, "yearBuilt", 0); built > 0 && int(built) <= se.currentYear {
+		ageYears = se.currentYear - int(built)
+	}
+	stateCode := se.propertyStateCode(details)
+	property.costProfile = PropertyProfile{
+		PurchasePrice: purchasePrice,
+		CurrentValue:  purchasePrice,
+		YearBuilt:     propertyCostBaseYear - ageYears,
+		PropertyTax:   getFloat64FromMetadata(details, "propertyTaxAnnual", se.defaultPropertyTax(purchasePrice, stateCode)),
+		HomeInsurance: getFloat64FromMetadata(details, "homeInsuranceAnnual", purchasePrice*0.005),
+		HOAFees:       getFloat64FromMetadata(details, "hoaFeesAnnual", 0),
+		StateCode:     stateCode,
+		YearPurchased: propertyCostBaseYear,
+	}
+	property.annualOperatingCost = se.propertyCostEscalator.ProjectTotalPropertyCosts(property.costProfile, propertyCostBaseYear).TotalAnnualCost
+
+	// Property holding priced at the home index, with the (carryover) basis
+	if accounts.Taxable == nil {
+		accounts.Taxable = &Account{Holdings: []Holding{}}
+	}
+	pricePerShare := se.GetPricePerShare(AssetClassRealEstateRental)
+	if pricePerShare <= 0 {
+		pricePerShare = 1.0
+	}
+	shares := purchasePrice / pricePerShare
+	accounts.Taxable.Holdings = append(accounts.Taxable.Holdings, Holding{
+		ID:                        property.holdingID,
+		AssetClass:                AssetClassRealEstateRental,
+		LiquidityTier:             LiquidityTierIlliquid,
+		Quantity:                  shares,
+		CostBasisPerUnit:          property.basis / shares,
+		CostBasisTotal:            property.basis,
+		CurrentMarketPricePerUnit: pricePerShare,
+		CurrentMarketValueTotal:   purchasePrice,
+		UnrealizedGainLossTotal:   purchasePrice - property.basis,
+		Lots: []TaxLot{{
+			ID:               fmt.Sprintf("lot_rental_%s_%d", event.ID, context.CurrentMonth),
+			AssetClass:       AssetClassRealEstateRental,
+			Quantity:         shares,
+			CostBasisPerUnit: property.basis / shares,
+			CostBasisTotal:   property.basis,
+			AcquisitionDate:  property.acquiredMonth,
+		}},
+	})
+	accounts.Taxable.TotalValue += purchasePrice
+
+	// Mortgage interest is a Schedule E expense, not an itemized deduction
+	if mortgageAmount > 0 {
+		interestRate := 0.07 // Investment property loans price above owner-occupied rates
+		if rate := getFloat64FromMetadata(details, "mortgageInterestRate", 0); rate > 0 && rate < 1.0 {
+			interestRate = rate
+		}
+		termYears := 30
+		if term := getFloat64FromMetadata(details, "mortgageTermYears", 0); term > 0 && term <= 50 {
+			termYears = int(term)
+		}
+		property.mortgageID = fmt.Sprintf("mortgage-%s", event.ID)
+		se.liabilities = append(se.liabilities, &LiabilityInfo{
+			ID:                      property.mortgageID,
+			Name:                    fmt.Sprintf("Mortgage for Rental %s", event.ID),
+			Type:                    "MORTGAGE",
+			CurrentPrincipalBalance: mortgageAmount,
+			InterestRate:            interestRate,
+			TermRemainingMonths:     termYears * 12,
+			MonthlyPayment:          CalculateMonthlyPayment(mortgageAmount, interestRate, termYears*12),
+		})
+	}
+
+	se.rentalProperties = append(se.rentalProperties, property)
+
+	simLogVerbose("🏘️ [RENTAL] Purchased %s for $%.2f (basis $%.2f, rent $%.2f/mo, operating costs $%.2f/yr, mortgage $%.2f)",
+		event.ID, purchasePrice, property.basis, monthlyRent, property.annualOperatingCost, mortgageAmount)
+
+	return nil
+}
+
+// RentalPropertySaleEventHandler handles rental property sale events.
+//
+// The property (metadata propertyId, or the only rental) is sold at its
+// marked-to-market value, or at metadata salePrice. Selling costs default to 6%
+// (sellingCosts or sellingCostPercent override). With metadata exchange1031 the
+// gain is deferred into a pending like-kind exchange. Without a rental property,
+// event.Amount is added to cash.
+type RentalPropertySaleEventHandler struct{}
+
+func (h *RentalPropertySaleEventHandler) Process(event FinancialEvent, accounts *AccountHoldingsMonthEnd, cashFlow *float64, context *EventProcessingContext) error {
+	se := context.SimulationEngine
+
+	propertyID := getStringFromMetadata(event.Metadata, "propertyId", "")
+	index := se.findRentalProperty(propertyID)
+	holdingIndex := -1
+	if index >= 0 {
+		holdingIndex = findHoldingByID(accounts, se.rentalProperties[index].holdingID)
+	}
+	if holdingIndex < 0 {
+		simLogVerbose("⚠️ [RENTAL] Rental property %q not found for sale %s, adding sale amount to cash", propertyID, event.ID)
+		accounts.Cash += event.Amount
+		*cashFlow += event.Amount
+		se.currentMonthFlows.IncomeThisMonth += event.Amount
+		se.currentMonthFlows.OneTimeEventsImpactThisMonth += event.Amount
+		return nil
+	}
+
+	property := se.rentalProperties[index]
+	holding := accounts.Taxable.Holdings[holdingIndex]
+
+	salePrice := holding.Quantity * se.GetPricePerShare(AssetClassRealEstateRental)
+	if price := getFloat64FromMetadata(event.Metadata, "salePrice", 0); price > 0 {
+		salePrice = price
+	}
+	sellingCosts := salePrice * defaultHomeSellingCostPercent
+	if pct := getFloat64FromMetadata(event.Metadata, "sellingCostPercent", -1); pct >= 0 && pct < 1 {
+		sellingCosts = salePrice * pct
+	}
+	if costs := getFloat64FromMetadata(event.Metadata, "sellingCosts", -1); costs >= 0 {
+		sellingCosts = costs
+	}
+	amountRealized := salePrice - sellingCosts
+	mortgagePayoff := se.retireLiability(property.mortgageID)
+
+	totalDepreciation := property.accumulatedDepreciation + property.carriedDepreciation
+	gain := amountRealized - (property.basis - property.accumulatedDepreciation)
+
+	if getBoolFromMetadata(event.Metadata, "exchange1031", false) && gain > 0 {
+		se.pendingExchanges = append(se.pendingExchanges, &pendingExchange{
+			fromPropertyID:      property.id,
+			amountRealized:      amountRealized,
+			deferredGain:        gain,
+			carriedDepreciation: totalDepreciation,
+			suspendedLosses:     property.suspendedLosses,
+			acquiredMonth:       property.acquiredMonth,
+			deadlineMonth:       context.CurrentMonth + exchange1031PeriodMonths,
+		})
+		simLogVerbose("🔁 [RENTAL] %s sold into a 1031 exchange, deferring $%.2f gain until month %d",
+			property.id, gain, context.CurrentMonth+exchange1031PeriodMonths)
+	} else {
+		se.recognizeRentalGain(gain, math.Min(math.Max(gain, 0), totalDepreciation), context.CurrentMonth-property.acquiredMonth)
+		se.releaseSuspendedLosses(property.suspendedLosses)
+	}
+
+	holdings := accounts.Taxable.Holdings
+	accounts.Taxable.Holdings = append(holdings[:holdingIndex:holdingIndex], holdings[holdingIndex+1:]...)
+	accounts.Taxable.TotalValue = math.Max(0, accounts.Taxable.TotalValue-holding.CurrentMarketValueTotal)
+	se.rentalProperties = append(se.rentalProperties[:index:index], se.rentalProperties[index+1:]...)
+
+	netProceeds := amountRealized - mortgagePayoff
+	accounts.Cash += netProceeds
+	*cashFlow += netProceeds
+	se.currentMonthFlows.DivestmentProceedsThisMonth += amountRealized
+	se.currentMonthFlows.DebtPaymentsPrincipalThisMonth += mortgagePayoff
+	se.currentMonthFlows.OneTimeEventsImpactThisMonth += netProceeds
+
+	simLogVerbose("🏘️ [RENTAL] Sold %s for $%.2f (costs $%.2f, mortgage payoff $%.2f, gain $%.2f, depreciation $%.2f)",
+		property.id, salePrice, sellingCosts, mortgagePayoff, gain, totalDepreciation)
+
+	return nil
+}
+
+// processRentalProperties collects rent, pays operating costs and records each
+// rental's Schedule E income for the month. Runs before the month's debt
+// payments so mortgage interest is taken on the opening balance.
+func (se *SimulationEngine) processRentalProperties(accounts *AccountHoldingsMonthEnd, monthOffset int) {
+	se.expirePendingExchanges(monthOffset)
+	if len(se.rentalProperties) == 0 {
+		return
+	}
+
+	active := se.rentalProperties[:0]
+	for _, property := range se.rentalProperties {
+		// Drop properties whose holding was removed outside a rental sale
+		if findHoldingByID(accounts, property.holdingID) < 0 {
+			simLogVerbose("⚠️ [RENTAL] Holding %s no longer exists, dropping rental %s", property.holdingID, property.id)
+			continue
+		}
+		active = append(active, property)
+
+		// Placed in service the month after purchase
+		monthsOwned := monthOffset - property.purchaseMonth
+		if monthsOwned <= 0 {
+			continue
+		}
+		if se.currentMonthReturns != nil {
+			property.rentIndex *= 1 + se.currentMonthReturns.Rent
+		}
+		if monthsOwned%12 == 0 {
+			property.annualOperatingCost = se.propertyCostEscalator.ProjectTotalPropertyCosts(
+				property.costProfile, propertyCostBaseYear+monthsOwned/12).TotalAnnualCost
+		}
+
+		collectedRent := property.monthlyRent * property.rentIndex * (1 - property.vacancyRate)
+		managementFees := collectedRent * property.managementFeePercent
+		operatingCosts := property.annualOperatingCost / 12
+		mortgageInterest := 0.0
+		for _, liability := range se.liabilities {
+			if liability.ID == property.mortgageID {
+				mortgageInterest = liability.CurrentPrincipalBalance * liability.InterestRate / 12
+			}
+		}
+		depreciation := math.Min(property.depreciableBasis/residentialRecoveryMonths,
+			property.depreciableBasis-property.accumulatedDepreciation)
+		property.accumulatedDepreciation += depreciation
+
+		netCash := collectedRent - managementFees - operatingCosts
+		accounts.Cash += netCash
+		se.currentMonthFlows.IncomeThisMonth += collectedRent
+		se.currentMonthFlows.ExpensesThisMonth += managementFees + operatingCosts
+		se.currentMonthFlows.HousingExpensesThisMonth += managementFees + operatingCosts
+
+		// Passive income absorbs suspended losses first; losses are suspended
+		scheduleE := netCash - mortgageInterest - depreciation
+		if scheduleE < 0 {
+			property.suspendedLosses -= scheduleE
+		} else {
+			released := math.Min(scheduleE, property.suspendedLosses)
+			property.suspendedLosses -= released
+			if taxable := scheduleE - released; taxable > 0 {
+				se.RegisterIncomeByTaxProfile(taxable, "schedule_e", 0)
+			}
+		}
+	}
+	se.rentalProperties = active
+}
+
+// recognizeRentalGain taxes a rental sale: depreciation recapture as ordinary
+// income, the rest as a capital gain (long-term after a year). A loss is an
+// ordinary §1231 loss.
+func (se *SimulationEngine) recognizeRentalGain(gain, recapture float64, monthsHeld int) {
+	if gain <= 0 {
+		if gain < 0 {
+			se.RegisterIncomeByTaxProfile(gain, "ordinary_income", 0)
+		}
+		return
+	}
+	if recapture > 0 {
+		se.RegisterIncomeByTaxProfile(recapture, "ordinary_income", 0)
+	}
+	if monthsHeld > 12 {
+		se.ProcessCapitalGainsWithTermDifferentiation(0, gain-recapture)
+	} else {
+		se.ProcessCapitalGainsWithTermDifferentiation(gain-recapture, 0)
+	}
+}
+
+// releaseSuspendedLosses deducts carried-forward passive losses on a taxable disposition
+func (se *SimulationEngine) releaseSuspendedLosses(losses float64) {
+	if losses > 0 {
+		se.RegisterIncomeByTaxProfile(-losses, "schedule_e", 0)
+	}
+}
+
+// expirePendingExchanges taxes exchanges whose replacement period has ended
+func (se *SimulationEngine) expirePendingExchanges(monthOffset int) {
+	remaining := se.pendingExchanges[:0]
+	for _, exchange := range se.pendingExchanges {
+		if monthOffset < exchange.deadlineMonth {
+			remaining = append(remaining, exchange)
+			continue
+		}
+		simLogVerbose("⚠️ [RENTAL] Exchange from %s expired without a replacement, recognizing $%.2f gain",
+			exchange.fromPropertyID, exchange.deferredGain)
+		se.recognizeRentalGain(exchange.deferredGain, math.Min(exchange.deferredGain, exchange.carriedDepreciation),
+			monthOffset-exchange.acquiredMonth)
+		se.releaseSuspendedLosses(exchange.suspendedLosses)
+	}
+	se.pendingExchanges = remaining
+}
+
+// takePendingExchange removes and returns the open exchange from a sold property
+func (se *SimulationEngine) takePendingExchange(fromPropertyID string) *pendingExchange {
+	for i, exchange := range se.pendingExchanges {
+		if exchange.fromPropertyID == fromPropertyID || "rental-"+exchange.fromPropertyID == fromPropertyID {
+			se.pendingExchanges = append(se.pendingExchanges[:i:i], se.pendingExchanges[i+1:]...)
+			return exchange
+		}
+	}
+	return nil
+}
+
+// findRentalProperty returns the index of the rental with the given purchase
+// event or holding ID, or of the only rental when no ID is given (-1 if none)
+func (se *SimulationEngine) findRentalProperty(propertyID string) int {
+	if propertyID == "" {
+		if len(se.rentalProperties) == 1 {
+			return 0
+		}
+		return -1
+	}
+	for i, property := range se.rentalProperties {
+		if property.id == propertyID || property.holdingID == propertyID {
+			return i
+		}
+	}
+	return -1
+}
+
+// findHoldingByID returns the index of a holding in the taxable account (-1 if none)
+func findHoldingByID(accounts *AccountHoldingsMonthEnd, holdingID string) int {
+	if accounts.Taxable == nil {
+		return -1
+	}
+	for i, holding := range accounts.Taxable.Holdings {
+		if holding.ID == holdingID {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,205 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+// buyTestRental purchases a rental renting at $3,000/month, all cash unless details say otherwise
+func buyTestRental(t *testing.T, se *SimulationEngine, accounts *AccountHoldingsMonthEnd, id string, price float64, month int, details map[string]interface{}, metadata map[string]interface{}) *rentalProperty {
+	t.Helper()
+	propertyDetails := map[string]interface{}{"monthlyRent": 3000.0, "downPaymentPercent": 1.0}
+	for k, v := range details {
+		propertyDetails[k] = v
+	}
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	metadata["propertyDetails"] = propertyDetails
+	purchase := FinancialEvent{ID: id, Type: "RENTAL_PROPERTY_PURCHASE", Amount: price, Metadata: metadata}
+	cashFlow := 0.0
+	if err := (&RentalPropertyPurchaseEventHandler{}).Process(purchase, accounts, &cashFlow, &EventProcessingContext{SimulationEngine: se, CurrentMonth: month}); err != nil {
+		t.Fatalf("purchase: %v", err)
+	}
+	return se.rentalProperties[len(se.rentalProperties)-1]
+}
+
+func sellTestRental(t *testing.T, se *SimulationEngine, accounts *AccountHoldingsMonthEnd, month int, metadata map[string]interface{}) {
+	t.Helper()
+	sale := FinancialEvent{ID: "sale", Type: "RENTAL_PROPERTY_SALE", Metadata: metadata}
+	cashFlow := 0.0
+	if err := (&RentalPropertySaleEventHandler{}).Process(sale, accounts, &cashFlow, &EventProcessingContext{SimulationEngine: se, CurrentMonth: month}); err != nil {
+		t.Fatalf("sale: %v", err)
+	}
+}
+
+func newRentalTestEngine() (*SimulationEngine, *AccountHoldingsMonthEnd) {
+	se := NewSimulationEngine(GetDefaultStochasticConfig())
+	return se, &AccountHoldingsMonthEnd{Cash: 1000000, Taxable: &Account{Holdings: []Holding{}}}
+}
+
+func TestRentalPropertyMonthlyOperations(t *testing.T) {
+	se, accounts := newRentalTestEngine()
+	property := buyTestRental(t, se, accounts, "duplex", 400000, 0, map[string]interface{}{"downPaymentPercent": 0.25}, nil)
+
+	if len(se.liabilities) != 1 || se.liabilities[0].IsTaxDeductible {
+		t.Fatal("rental mortgage interest should be a Schedule E expense, not an itemized deduction")
+	}
+	if property.annualOperatingCost <= 0 {
+		t.Fatal("expected escalator operating costs")
+	}
+
+	// Nothing happens in the purchase month
+	cash := accounts.Cash
+	se.processRentalProperties(accounts, 0)
+	if accounts.Cash != cash || property.accumulatedDepreciation != 0 {
+		t.Error("rental should be placed in service the month after purchase")
+	}
+
+	se.currentMonthReturns = &StochasticReturns{Rent: 0.01}
+	se.processRentalProperties(accounts, 1)
+
+	collected := 3000 * 1.01 * (1 - defaultRentalVacancyRate)
+	netCash := collected*(1-defaultRentalManagementFeePercent) - property.annualOperatingCost/12
+	if math.Abs(accounts.Cash-cash-netCash) > 0.01 {
+		t.Errorf("net rent: got %.2f, want %.2f", accounts.Cash-cash, netCash)
+	}
+	wantDepreciation := 400000 * (1 - defaultRentalLandValuePercent) / 330
+	if math.Abs(property.accumulatedDepreciation-wantDepreciation) > 0.01 {
+		t.Errorf("monthly depreciation: got %.2f, want %.2f", property.accumulatedDepreciation, wantDepreciation)
+	}
+
+	// $300k at 7% costs $1,750 of interest; with depreciation the month is a passive loss
+	wantLoss := -(netCash - 300000*0.07/12 - wantDepreciation)
+	if math.Abs(property.suspendedLosses-wantLoss) > 0.01 || se.passiveIncomeYTD != 0 {
+		t.Errorf("suspended loss: got %.2f, want %.2f (passive income %.2f)", property.suspendedLosses, wantLoss, se.passiveIncomeYTD)
+	}
+}
+
+func TestRentalPropertySaleRecapture(t *testing.T) {
+	se, accounts := newRentalTestEngine()
+	property := buyTestRental(t, se, accounts, "condo", 400000, 0, map[string]interface{}{"hoaFeesAnnual": 2400.0}, nil)
+	for month := 1; month <= 120; month++ {
+		se.processRentalProperties(accounts, month)
+	}
+	depreciation := property.accumulatedDepreciation
+	if want := 400000 * 0.8 / 330 * 120; math.Abs(depreciation-want) > 0.01 {
+		t.Fatalf("ten years of depreciation: got %.2f, want %.2f", depreciation, want)
+	}
+	suspended := property.suspendedLosses
+
+	ordinaryBefore := se.ordinaryIncomeYTD
+	sellTestRental(t, se, accounts, 120, map[string]interface{}{"salePrice": 500000.0, "sellingCosts": 0.0})
+
+	if len(se.rentalProperties) != 0 || len(accounts.Taxable.Holdings) != 0 {
+		t.Fatal("sold rental should be removed")
+	}
+	// Recapture is ordinary income; suspended losses are released against it
+	if got, want := se.ordinaryIncomeYTD-ordinaryBefore, depreciation-suspended; math.Abs(got-want) > 0.01 {
+		t.Errorf("ordinary income from sale: got %.2f, want %.2f", got, want)
+	}
+	if math.Abs(se.longTermCapitalGainsYTD-100000) > 0.01 {
+		t.Errorf("appreciation should be a long-term gain: got %.2f, want 100000", se.longTermCapitalGainsYTD)
+	}
+}
+
+func TestRentalProperty1031Exchange(t *testing.T) {
+	se, accounts := newRentalTestEngine()
+	first := buyTestRental(t, se, accounts, "first", 400000, 0, nil, nil)
+	for month := 1; month <= 60; month++ {
+		se.processRentalProperties(accounts, month)
+	}
+	depreciation := first.accumulatedDepreciation
+	sellTestRental(t, se, accounts, 60, map[string]interface{}{"propertyId": "first", "salePrice": 500000.0, "sellingCosts": 0.0, "exchange1031": true})
+
+	if se.longTermCapitalGainsYTD != 0 || len(se.pendingExchanges) != 1 {
+		t.Fatalf("exchange should defer the gain, got LT %.2f and %d pending", se.longTermCapitalGainsYTD, len(se.pendingExchanges))
+	}
+	deferred := 500000 - (400000 - depreciation)
+
+	// Trade up to a $600k property: no boot, carryover basis
+	se.processRentalProperties(accounts, 63)
+	ordinaryBefore := se.ordinaryIncomeYTD
+	second := buyTestRental(t, se, accounts, "second", 600000, 63, nil, map[string]interface{}{"exchangeFromPropertyId": "first"})
+	if want := 600000 - deferred; math.Abs(second.basis-want) > 0.01 {
+		t.Errorf("carryover basis: got %.2f, want %.2f", second.basis, want)
+	}
+	if second.carriedDepreciation != depreciation || second.acquiredMonth != 0 {
+		t.Errorf("prior depreciation and holding period should carry over, got %.2f from month %d",
+			second.carriedDepreciation, second.acquiredMonth)
+	}
+	if holding := accounts.Taxable.Holdings[0]; math.Abs(holding.CostBasisTotal-second.basis) > 0.01 {
+		t.Errorf("holding basis %.2f should match the carryover basis %.2f", holding.CostBasisTotal, second.basis)
+	}
+	if se.longTermCapitalGainsYTD != 0 || se.ordinaryIncomeYTD != ordinaryBefore {
+		t.Error("a trade up should not be taxed")
+	}
+
+	// Trading the $600k property down into a $500k one leaves $100k of boot, taxed as recapture first
+	sellTestRental(t, se, accounts, 64, map[string]interface{}{"propertyId": "second", "salePrice": 600000.0, "sellingCosts": 0.0, "exchange1031": true})
+	buyTestRental(t, se, accounts, "third", 500000, 65, nil, map[string]interface{}{"exchangeFromPropertyId": "rental-second"})
+	recapture := math.Min(100000, depreciation+second.accumulatedDepreciation)
+	if got := se.ordinaryIncomeYTD - ordinaryBefore; math.Abs(got-recapture) > 0.01 || math.Abs(se.longTermCapitalGainsYTD-(100000-recapture)) > 0.01 {
+		t.Errorf("boot: ordinary %.2f (want %.2f), LT %.2f (want %.2f)",
+			got, recapture, se.longTermCapitalGainsYTD, 100000-recapture)
+	}
+
+	// An exchange with no replacement is taxed once the 180-day window closes
+	sellTestRental(t, se, accounts, 70, map[string]interface{}{"salePrice": 550000.0, "sellingCosts": 0.0, "exchange1031": true})
+	gainsBefore := se.longTermCapitalGainsYTD
+	se.processRentalProperties(accounts, 75)
+	if len(se.pendingExchanges) != 1 || se.longTermCapitalGainsYTD != gainsBefore {
+		t.Fatal("exchange should stay open inside the exchange period")
+	}
+	se.processRentalProperties(accounts, 76)
+	if len(se.pendingExchanges) != 0 || se.longTermCapitalGainsYTD <= gainsBefore {
+		t.Error("expired exchange should recognize the deferred gain")
+	}
+}
+
+func TestRentalPropertyInSimulation(t *testing.T) {
+	run := func(events ...FinancialEvent) (*SimulationEngine, SimulationResult) {
+		input := createRollingTestInput(1000000, 48000)
+		input.Config.RandomSeed = 11
+		input.InitialAccounts.Cash = 150000
+		input.Events = append(input.Events, events...)
+		input.MonthsToRun = 120
+		se := NewSimulationEngine(input.Config)
+		result := se.RunSingleSimulation(input)
+		if !result.Success {
+			t.Fatalf("simulation failed: %s", result.Error)
+		}
+		return se, result
+	}
+	netWorthAt := func(result SimulationResult, month int) float64 {
+		for _, m := range result.MonthlyData {
+			if m.MonthOffset == month {
+				return m.NetWorth
+			}
+		}
+		t.Fatalf("no monthly data for month %d", month)
+		return 0
+	}
+
+	purchase := FinancialEvent{
+		ID: "fourplex", Type: "RENTAL_PROPERTY_PURCHASE", Amount: 500000, Frequency: "one-time",
+		Metadata: map[string]interface{}{"propertyDetails": map[string]interface{}{"monthlyRent": 4500.0, "downPaymentPercent": 0.25}},
+	}
+	sale := FinancialEvent{ID: "sell-fourplex", Type: "RENTAL_PROPERTY_SALE", MonthOffset: 96, Frequency: "one-time",
+		Metadata: map[string]interface{}{"propertyId": "fourplex"}}
+
+	heldEngine, held := run(purchase)
+	soldEngine, sold := run(purchase, sale)
+	if len(heldEngine.rentalProperties) != 1 || len(heldEngine.liabilities) != 1 {
+		t.Errorf("held rental should still be owned with its mortgage, got %d properties, %d liabilities",
+			len(heldEngine.rentalProperties), len(heldEngine.liabilities))
+	}
+	if len(soldEngine.rentalProperties) != 0 || len(soldEngine.liabilities) != 0 {
+		t.Errorf("sold rental should leave no property or mortgage, got %d properties, %d liabilities",
+			len(soldEngine.rentalProperties), len(soldEngine.liabilities))
+	}
+	// Same returns up to the sale; selling costs come straight off net worth
+	if before, after := netWorthAt(held, 96), netWorthAt(sold, 96); after >= before {
+		t.Errorf("sale month net worth %.0f should be below holding on (%.0f)", after, before)
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package engine
 
 // retirement_features_integration_test.go
 // Integration tests for retirement features:
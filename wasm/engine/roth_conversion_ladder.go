@@ -0,0 +1,198 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+)
+
+/**
+ * Roth Conversion Ladder
+ *
+ * ROTH_CONVERSION_LADDER is a policy rather than a fixed amount. It runs every December
+ * on each path, after the year's income and RMDs are in, and converts what it takes to
+ * fill the target ordinary bracket given that path's year-to-date tax state:
+ *
+ * - Taxable income counts ordinary income, short-term gains and taxable Social Security
+ *   (recomputed for each candidate amount, since conversions make more of it taxable),
+ *   less pre-tax contributions and the standard or itemized deduction
+ * - RothConversionOptimizer.FillBracketConversion finds the amount that lands on the top
+ *   of the bracket with that rate in the year's (indexed) brackets
+ * - Metadata: targetBracket (rate, default 0.12), maxAnnualConversion, minAge, maxAge
+ *
+ * Every conversion, ladder or fixed ROTH_CONVERSION, starts its own five-year clock. Before
+ * 59½ a Roth distribution comes out of contributions first, then conversions oldest first,
+ * then earnings. Conversions withdrawn within five years, counted from January 1 of the
+ * conversion year, owe the 10% additional tax (IRC §408A(d)(3)(F)); earnings are
+ * ordinary income plus 10%. The five-year rule for qualified earnings after 59½ is not
+ * modeled.
+ *
+ * Reference: IRS Publication 590-B, "Ordering Rules for Distributions"
+ */
+
+const (
+	rothLadderDefaultTargetRate  = 0.12
+	rothConversionSeasoningYears = 5
+	rothQualifiedAge             = 60 // Integer approximation of 59½
+	rothEarlyDistributionRate    = 0.10
+)
+
+// RothConversionRung reports one year's conversions and when they can be withdrawn penalty-free
+type RothConversionRung struct {
+	Year         int     `json:"year"`
+	Amount       float64 `json:"amount"` // Converted amount not yet withdrawn
+	SeasonedYear int     `json:"seasonedYear"`
+}
+
+// rothConversion is one year's conversions still in the Roth
+type rothConversion struct {
+	year   int
+	amount float64
+}
+
+// initialRothContributionBasis returns the starting Roth balance's contributions and seasoned conversions
+func initialRothContributionBasis(input *SimulationInput) float64 {
+	if input.RothContributionBasis > 0 {
+		return input.RothContributionBasis
+	}
+	roth := input.InitialAccounts.Roth
+	if roth == nil {
+		return 0
+	}
+	costBasis := 0.0
+	for _, holding := range roth.Holdings {
+		costBasis += holding.CostBasisTotal
+	}
+	if costBasis <= 0 {
+		return roth.TotalValue
+	}
+	return costBasis
+}
+
+// recordRothConversion starts the five-year clock on a conversion
+func (se *SimulationEngine) recordRothConversion(amount float64, monthOffset int) {
+	se.rothConversionsYTD += amount
+	year := se.calendarYear(monthOffset)
+	if n := len(se.rothConversions); n > 0 && se.rothConversions[n-1].year == year {
+		se.rothConversions[n-1].amount += amount
+		return
+	}
+	se.rothConversions = append(se.rothConversions, rothConversion{year: year, amount: amount})
+}
+
+// recordRothDistribution applies the ordering rules to a Roth distribution. Before 59½,
+// unseasoned conversions and earnings owe the additional tax, and earnings are ordinary income.
+func (se *SimulationEngine) recordRothDistribution(amount float64, monthOffset int) (taxableEarnings, penalty float64) {
+	early := se.primaryAge(monthOffset) < rothQualifiedAge
+	year := se.calendarYear(monthOffset)
+
+	fromContributions := math.Min(amount, se.rothContributionBasis)
+	se.rothContributionBasis -= fromContributions
+	remaining := amount - fromContributions
+
+	for len(se.rothConversions) > 0 && remaining > 0 {
+		rung := &se.rothConversions[0]
+		fromRung := math.Min(remaining, rung.amount)
+		if early && year < rung.year+rothConversionSeasoningYears {
+			penalty += fromRung * rothEarlyDistributionRate
+		}
+		rung.amount -= fromRung
+		remaining -= fromRung
+		if rung.amount <= 0.005 {
+			se.rothConversions = se.rothConversions[1:]
+		}
+	}
+
+	if early && remaining > 0 {
+		taxableEarnings = remaining
+		penalty += remaining * rothEarlyDistributionRate
+		se.ordinaryIncomeYTD += taxableEarnings
+	}
+	se.penaltyTaxYTD += penalty
+	return taxableEarnings, penalty
+}
+
+// captureRothConversionRungs reports the conversions still in the Roth by year
+func (se *SimulationEngine) captureRothConversionRungs() []RothConversionRung {
+	if len(se.rothConversions) == 0 {
+		return nil
+	}
+	rungs := make([]RothConversionRung, len(se.rothConversions))
+	for i, rung := range se.rothConversions {
+		rungs[i] = RothConversionRung{Year: rung.year, Amount: rung.amount, SeasonedYear: rung.year + rothConversionSeasoningYears}
+	}
+	return rungs
+}
+
+// rothLadderBracketTop returns the top of this year's ordinary bracket taxed at rate
+func (se *SimulationEngine) rothLadderBracketTop(rate float64) (float64, error) {
+	for _, bracket := range se.taxCalculator.federalBrackets() {
+		if math.Abs(bracket.Rate-rate) < 1e-6 {
+			return bracket.IncomeMax, nil
+		}
+	}
+	return 0, fmt.Errorf("no %.1f%% federal bracket to fill", rate*100)
+}
+
+// rothLadderTaxableIncome returns the year's taxable ordinary income after converting an amount
+func (se *SimulationEngine) rothLadderTaxableIncome(conversion float64) float64 {
+	ordinaryIncome := se.ordinaryIncomeYTD + conversion
+	provisionalIncome := ordinaryIncome + se.qualifiedDividendsYTD + se.capitalGainsYTD + se.taxExemptIncomeYTD
+	taxableSocialSecurity := se.taxCalculator.CalculateTaxableSocialSecurity(provisionalIncome, se.socialSecurityBenefitsYTD)
+	config := se.taxCalculator.config
+	deduction := math.Max(config.StandardDeduction, config.ItemizedDeduction)
+	return ordinaryIncome + taxableSocialSecurity + se.shortTermCapitalGainsYTD - se.preTaxContributionsYTD - deduction
+}
+
+// RothConversionLadderEventHandler converts enough each December to fill the target bracket
+type RothConversionLadderEventHandler struct{}
+
+func (h *RothConversionLadderEventHandler) Process(event FinancialEvent, accounts *AccountHoldingsMonthEnd, cashFlow *float64, context *EventProcessingContext) error {
+	se := context.SimulationEngine
+	currentMonth := context.CurrentMonth
+
+	age := se.primaryAge(currentMonth)
+	minAge := int(getFloat64FromMetadata(event.Metadata, "minAge", 0))
+	maxAge := int(getFloat64FromMetadata(event.Metadata, "maxAge", 0))
+	if age < minAge || (maxAge > 0 && age > maxAge) {
+		return nil
+	}
+	if accounts.TaxDeferred == nil || accounts.TaxDeferred.TotalValue <= 0 {
+		simLogVerbose("ROTH-LADDER: no tax-deferred balance to convert in month %d", currentMonth)
+		return nil
+	}
+	if accounts.Roth == nil {
+		accounts.Roth = &Account{Holdings: []Holding{}, TotalValue: 0}
+	}
+
+	targetRate := getFloat64FromMetadata(event.Metadata, "targetBracket", rothLadderDefaultTargetRate)
+	if targetRate > 1 {
+		targetRate /= 100 // Accept 12 as well as 0.12
+	}
+	se.taxCalculator.SetSimulationYear(se.calendarYear(currentMonth), se.taxThresholdRate())
+	bracketTop, err := se.rothLadderBracketTop(targetRate)
+	if err != nil {
+		return fmt.Errorf("Roth conversion ladder %s: %w", event.ID, err)
+	}
+
+	amount := se.rothConversionOptimizer.FillBracketConversion(se.rothLadderTaxableIncome, bracketTop, accounts.TaxDeferred.TotalValue)
+	if maxAnnual := getFloat64FromMetadata(event.Metadata, "maxAnnualConversion", 0); maxAnnual > 0 {
+		amount = math.Min(amount, maxAnnual)
+	}
+	amount = math.Floor(amount)
+	if amount < 1 {
+		simLogEvent("INFO  [Month %d] Event: ROTH_CONVERSION_LADDER | No room in the %.0f%% bracket (taxable income $%.0f)",
+			currentMonth, targetRate*100, se.rothLadderTaxableIncome(0))
+		return nil
+	}
+
+	conversion := event
+	conversion.Type = string(EventTypeRothConversion)
+	conversion.Amount = amount
+	if err := (&RothConversionEventHandler{}).Process(conversion, accounts, cashFlow, context); err != nil {
+		return fmt.Errorf("Roth conversion ladder %s: %w", event.ID, err)
+	}
+
+	simLogEvent("INFO  [Month %d] Event: ROTH_CONVERSION_LADDER | Amount: $%.2f | Fills the %.0f%% bracket to $%.0f | Penalty-free from %d",
+		currentMonth, amount, targetRate*100, bracketTop, se.calendarYear(currentMonth)+rothConversionSeasoningYears)
+	return nil
+}
@@ -0,0 +1,137 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func newRothLadderTestEngine(t *testing.T, age int, taxDeferred float64) (*SimulationEngine, *AccountHoldingsMonthEnd) {
+	t.Helper()
+	accounts := &AccountHoldingsMonthEnd{Cash: 50000, TaxDeferred: &Account{Holdings: []Holding{}}, Roth: &Account{Holdings: []Holding{}}}
+	se := newHandlerTestEngine(t, SimulationInput{InitialAge: age}, accounts)
+	fundTestAccount(t, se, accounts.TaxDeferred, taxDeferred)
+	return se, accounts
+}
+
+func processRothLadder(t *testing.T, se *SimulationEngine, accounts *AccountHoldingsMonthEnd, month int, metadata map[string]interface{}) {
+	t.Helper()
+	event := FinancialEvent{ID: "ladder", Type: "ROTH_CONVERSION_LADDER", MonthOffset: month, Metadata: metadata}
+	mustProcessTestEvent(t, &RothConversionLadderEventHandler{}, se, accounts, event)
+}
+
+func TestFillBracketConversion(t *testing.T) {
+	opt := NewRothConversionOptimizer(nil, nil)
+
+	// Plain ordinary income: the room in the bracket, capped by the IRA
+	taxable := func(c float64) float64 { return 20000 + c }
+	if got := opt.FillBracketConversion(taxable, 50000, 100000); got != 30000 {
+		t.Errorf("room in bracket: got %.2f, want 30000", got)
+	}
+	if got := opt.FillBracketConversion(taxable, 50000, 10000); got != 10000 {
+		t.Errorf("IRA cap: got %.2f, want 10000", got)
+	}
+
+	// Each converted dollar also makes 85¢ of Social Security taxable
+	withSocialSecurity := func(c float64) float64 { return 20000 + 1.85*c }
+	got := opt.FillBracketConversion(withSocialSecurity, 50000, 100000)
+	if withSocialSecurity(got) > 50000 || withSocialSecurity(got+2) <= 50000 {
+		t.Errorf("Social Security torpedo: converting %.2f gives taxable income %.2f", got, withSocialSecurity(got))
+	}
+}
+
+func TestRothConversionLadderFillsBracket(t *testing.T) {
+	se, accounts := newRothLadderTestEngine(t, 55, 500000)
+	se.ordinaryIncomeYTD = 30000
+	processRothLadder(t, se, accounts, 11, nil)
+
+	bracketTop, err := se.rothLadderBracketTop(0.12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	converted := accounts.Roth.TotalValue
+	if converted <= 0 || math.Abs(se.rothLadderTaxableIncome(0)-bracketTop) > 1 {
+		t.Errorf("converted %.2f, taxable income %.2f, want the 12%% bracket top %.2f", converted, se.rothLadderTaxableIncome(0), bracketTop)
+	}
+	if math.Abs(se.ordinaryIncomeYTD-30000-converted) > 0.01 || se.currentMonthFlows.RothConversionAmountThisMonth != converted {
+		t.Errorf("conversion should be ordinary income and a monthly flow: income %.2f, flow %.2f", se.ordinaryIncomeYTD, se.currentMonthFlows.RothConversionAmountThisMonth)
+	}
+	if rungs := se.captureRothConversionRungs(); len(rungs) != 1 || rungs[0].Year != 2025 || rungs[0].SeasonedYear != 2030 {
+		t.Errorf("rungs: %+v", rungs)
+	}
+
+	// Nothing to convert once the bracket is full
+	processRothLadder(t, se, accounts, 11, nil)
+	if math.Abs(accounts.Roth.TotalValue-converted) > 1 {
+		t.Errorf("a full bracket should convert nothing, Roth %.2f", accounts.Roth.TotalValue)
+	}
+
+	// The annual cap and the age window
+	se, accounts = newRothLadderTestEngine(t, 55, 500000)
+	processRothLadder(t, se, accounts, 11, map[string]interface{}{"maxAnnualConversion": 10000.0, "targetBracket": 22.0})
+	if math.Abs(accounts.Roth.TotalValue-10000) > 0.01 {
+		t.Errorf("capped conversion: Roth %.2f, want 10000", accounts.Roth.TotalValue)
+	}
+	processRothLadder(t, se, accounts, 23, map[string]interface{}{"maxAge": 55.0})
+	if math.Abs(accounts.Roth.TotalValue-10000) > 0.01 {
+		t.Error("no conversion after maxAge")
+	}
+}
+
+func TestRothDistributionOrdering(t *testing.T) {
+	se, _ := newRothLadderTestEngine(t, 50, 0)
+	se.rothContributionBasis = 10000
+	se.recordRothConversion(20000, 11)
+
+	// Contributions come out first and free; the 2025 conversion is unseasoned in 2026
+	taxable, penalty := se.recordRothDistribution(15000, 12)
+	if taxable != 0 || math.Abs(penalty-500) > 0.01 || se.rothContributionBasis != 0 {
+		t.Errorf("2026: taxable %.2f, penalty %.2f (want 500), basis %.2f", taxable, penalty, se.rothContributionBasis)
+	}
+
+	// From 2030 the 2025 conversion is seasoned; after it, earnings are taxed and penalized
+	taxable, penalty = se.recordRothDistribution(20000, 60)
+	if math.Abs(taxable-5000) > 0.01 || math.Abs(penalty-500) > 0.01 {
+		t.Errorf("2030: taxable %.2f (want 5000), penalty %.2f (want 500)", taxable, penalty)
+	}
+	if math.Abs(se.ordinaryIncomeYTD-5000) > 0.01 || math.Abs(se.penaltyTaxYTD-1000) > 0.01 || len(se.rothConversions) != 0 {
+		t.Errorf("income %.2f, additional tax %.2f, rungs %d", se.ordinaryIncomeYTD, se.penaltyTaxYTD, len(se.rothConversions))
+	}
+
+	// After 59½ distributions are qualified
+	se, _ = newRothLadderTestEngine(t, 60, 0)
+	se.recordRothConversion(20000, 0)
+	if taxable, penalty := se.recordRothDistribution(30000, 0); taxable != 0 || penalty != 0 {
+		t.Errorf("qualified distribution: taxable %.2f, penalty %.2f", taxable, penalty)
+	}
+}
+
+func TestRothConversionLadderInSimulation(t *testing.T) {
+	input := createRollingTestInput(1000000, 48000)
+	input.Config.RandomSeed = 7
+	input.InitialAge = 55
+	input.StartYear = 2025
+	input.MonthsToRun = 36
+	input.InitialAccounts.TaxDeferred = &Account{TotalValue: 600000, Holdings: []Holding{{
+		ID: "ira", AssetClass: AssetClassUSStocksTotalMarket, Quantity: 600000, CostBasisPerUnit: 1, CostBasisTotal: 600000,
+		CurrentMarketPricePerUnit: 1, CurrentMarketValueTotal: 600000,
+	}}}
+	input.Events = append(input.Events, FinancialEvent{ID: "ladder", Type: "ROTH_CONVERSION_LADDER", Metadata: map[string]interface{}{"targetBracket": 0.12}})
+
+	result := NewSimulationEngine(input.Config).RunSingleSimulation(input)
+	if !result.Success {
+		t.Fatalf("simulation failed: %s", result.Error)
+	}
+	for _, month := range result.MonthlyData {
+		converted := month.RothConversionAmountThisMonth
+		if month.MonthOffset%12 != 11 {
+			if converted != 0 {
+				t.Errorf("month %d: unexpected conversion %.0f", month.MonthOffset, converted)
+			}
+			continue
+		}
+		// About the standard deduction plus the 12% bracket, less the year's other ordinary income
+		if converted < 30000 || converted > 70000 {
+			t.Errorf("month %d: converted %.0f, want the 12%% bracket's worth", month.MonthOffset, converted)
+		}
+	}
+}
@@ -158,6 +158,34 @@ func (opt *RothConversionOptimizer) CalculateOptimalConversionAmount(
 	return conversionAmount
 }
 
+// FillBracketConversion finds the largest conversion that keeps taxable income within a bracket.
+// taxableIncomeAt returns the year's taxable income after converting an amount; it can rise faster
+// than the conversion itself when the extra income makes more Social Security benefits taxable.
+func (opt *RothConversionOptimizer) FillBracketConversion(
+	taxableIncomeAt func(conversion float64) float64,
+	targetBracketTop float64,
+	iraBalance float64,
+) float64 {
+
+	// Taxable income rises at least dollar for dollar, so the room in the bracket is an upper bound
+	high := opt.CalculateOptimalConversionAmount(taxableIncomeAt(0), targetBracketTop, iraBalance)
+	if high <= 0 || taxableIncomeAt(high) <= targetBracketTop {
+		return high
+	}
+
+	// Search down to the dollar for the conversion that lands on the bracket top
+	low := 0.0
+	for high-low > 1 {
+		mid := (low + high) / 2
+		if taxableIncomeAt(mid) <= targetBracketTop {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return low
+}
+
 // GenerateConversionPlan creates a multi-year Roth conversion strategy
 func (opt *RothConversionOptimizer) GenerateConversionPlan(
 	startAge int,
@@ -12,4 +12,4 @@
 // You do NOT need to run this file directly.
 //
 // For more details, see docs/development/TESTING_GUIDE.md
-package main
+package engine
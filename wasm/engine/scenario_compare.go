@@ -0,0 +1,234 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+)
+
+/**
+ * Paired Scenario Comparison
+ *
+ * CompareScenarios runs every variant of a plan on the same path seeds: path i of
+ * each variant draws the same market returns, inflation and lifespans, so the
+ * difference between two variants on one path is the effect of the plan change
+ * alone. Two independent Monte Carlo runs would add their sampling noise to
+ * every difference, which easily swamps a small plan change.
+ *
+ * The first variant is the baseline. For every other variant, each path's
+ * terminal wealth, runway and lifetime tax are differenced against the same
+ * path of the baseline, and the deltas are summarized as a mean and percentiles.
+ * Runway is the month cash first falls below the cash floor, or the number of
+ * months the path ran if it never does (fewer than MonthsToRun when the
+ * longevity model ends the path at a death). Win share is the fraction of
+ * paths on which a variant ends with the most wealth, with ties split evenly.
+ */
+
+const scenarioCompareDefaultPaths = 200
+
+// ScenarioVariant is one named version of the plan
+type ScenarioVariant struct {
+	Name  string          `json:"name"`
+	Input SimulationInput `json:"input"`
+}
+
+// ScenarioComparisonRequest lists the variants to compare; the first is the baseline
+type ScenarioComparisonRequest struct {
+	Variants []ScenarioVariant `json:"variants"`
+	Paths    int               `json:"paths,omitempty"` // Monte Carlo paths per variant (default 200)
+	Seed     int64             `json:"seed,omitempty"`  // Base seed for every variant (default: the baseline's RandomSeed)
+}
+
+// PairedDelta summarizes per-path differences from the baseline
+type PairedDelta struct {
+	Mean float64 `json:"mean"`
+	P10  float64 `json:"p10"`
+	P25  float64 `json:"p25"`
+	P50  float64 `json:"p50"`
+	P75  float64 `json:"p75"`
+	P90  float64 `json:"p90"`
+}
+
+// ScenarioOutcome is one variant's own distribution and its paired deltas
+type ScenarioOutcome struct {
+	Name                  string  `json:"name"`
+	TerminalWealthP10     float64 `json:"terminalWealthP10"`
+	TerminalWealthP50     float64 `json:"terminalWealthP50"`
+	TerminalWealthP90     float64 `json:"terminalWealthP90"`
+	RunwayP50             float64 `json:"runwayP50"`
+	LifetimeTaxP50        float64 `json:"lifetimeTaxP50"`
+	BankruptcyProbability float64 `json:"bankruptcyProbability"`
+	WinShare              float64 `json:"winShare"` // Share of paths on which this variant ends with the most wealth
+
+	// Against the baseline, path by path (nil for the baseline)
+	TerminalWealthDelta *PairedDelta `json:"terminalWealthDelta,omitempty"`
+	RunwayDelta         *PairedDelta `json:"runwayDelta,omitempty"`      // Months
+	LifetimeTaxDelta    *PairedDelta `json:"lifetimeTaxDelta,omitempty"` // Positive = more tax
+	BeatsBaseline       float64      `json:"beatsBaseline,omitempty"`    // Share of paths with more terminal wealth than the baseline
+}
+
+// ScenarioComparison is the result of CompareScenarios
+type ScenarioComparison struct {
+	Paths    int               `json:"paths"`
+	Seed     int64             `json:"seed"`
+	Baseline string            `json:"baseline"`
+	Variants []ScenarioOutcome `json:"variants"`
+}
+
+// scenarioPaths holds one variant's per-path outcomes, indexed by path
+type scenarioPaths struct {
+	wealth, runway, tax []float64
+	bankrupt            int
+}
+
+// CompareScenarios runs each variant on the same path seeds and reports paired differences
+func CompareScenarios(req ScenarioComparisonRequest) (*ScenarioComparison, error) {
+	if len(req.Variants) < 2 {
+		return nil, fmt.Errorf("a comparison needs at least two variants, got %d", len(req.Variants))
+	}
+	if req.Paths <= 0 {
+		req.Paths = scenarioCompareDefaultPaths
+	}
+	baseline := req.Variants[0].Input
+	if req.Seed == 0 {
+		req.Seed = baseline.Config.RandomSeed
+	}
+
+	names := make([]string, len(req.Variants))
+	seen := make(map[string]bool, len(req.Variants))
+	runs := make([]scenarioPaths, len(req.Variants))
+	for v, variant := range req.Variants {
+		if variant.Name == "" {
+			variant.Name = fmt.Sprintf("variant-%d", v)
+		}
+		if seen[variant.Name] {
+			return nil, fmt.Errorf("duplicate variant name %q", variant.Name)
+		}
+		seen[variant.Name] = true
+		names[v] = variant.Name
+
+		// Pairing is only meaningful over the same calendar months
+		if variant.Input.MonthsToRun != baseline.MonthsToRun || variant.Input.StartYear != baseline.StartYear {
+			return nil, fmt.Errorf("variant %q runs %d months from %d; the baseline runs %d months from %d",
+				variant.Name, variant.Input.MonthsToRun, variant.Input.StartYear, baseline.MonthsToRun, baseline.StartYear)
+		}
+
+		input := variant.Input
+		input.Config.RandomSeed = req.Seed
+		run, err := runScenarioPaths(input, req.Paths)
+		if err != nil {
+			return nil, fmt.Errorf("variant %q: %w", variant.Name, err)
+		}
+		runs[v] = run
+	}
+
+	wins := winShares(runs, req.Paths)
+	comparison := &ScenarioComparison{
+		Paths:    req.Paths,
+		Seed:     req.Seed,
+		Baseline: names[0],
+		Variants: make([]ScenarioOutcome, len(runs)),
+	}
+	for v, run := range runs {
+		wealth := calculatePercentiles(run.wealth)
+		outcome := ScenarioOutcome{
+			Name:                  names[v],
+			TerminalWealthP10:     wealth[0],
+			TerminalWealthP50:     wealth[2],
+			TerminalWealthP90:     wealth[4],
+			RunwayP50:             calculatePercentiles(run.runway)[2],
+			LifetimeTaxP50:        calculatePercentiles(run.tax)[2],
+			BankruptcyProbability: float64(run.bankrupt) / float64(req.Paths),
+			WinShare:              wins[v],
+		}
+		if v > 0 {
+			base := runs[0]
+			outcome.TerminalWealthDelta = pairedDelta(run.wealth, base.wealth)
+			outcome.RunwayDelta = pairedDelta(run.runway, base.runway)
+			outcome.LifetimeTaxDelta = pairedDelta(run.tax, base.tax)
+			beats := 0
+			for i := range run.wealth {
+				if run.wealth[i] > base.wealth[i] {
+					beats++
+				}
+			}
+			outcome.BeatsBaseline = float64(beats) / float64(req.Paths)
+		}
+		comparison.Variants[v] = outcome
+	}
+	return comparison, nil
+}
+
+// runScenarioPaths runs one variant and keeps the per-path outcomes
+func runScenarioPaths(input SimulationInput, paths int) (scenarioPaths, error) {
+	results, err := runMonteCarloPaths(input, paths)
+	if err != nil {
+		return scenarioPaths{}, err
+	}
+
+	run := scenarioPaths{
+		wealth: make([]float64, paths),
+		runway: make([]float64, paths),
+		tax:    make([]float64, paths),
+	}
+	for i, result := range results {
+		// A bankrupt path is an outcome; any other failure is an error in the plan
+		if !result.Success && !result.IsBankrupt {
+			return scenarioPaths{}, fmt.Errorf("path %d failed: %s", i, result.Error)
+		}
+		metrics := extractPathMetrics(result, i, input.Config.RandomSeed+int64(i), input.Config.CashFloor)
+		run.wealth[i] = metrics.TerminalWealth
+		run.runway[i] = float64(result.MonthsSimulated)
+		if metrics.CashFloorBreached {
+			run.runway[i] = float64(metrics.RunwayMonths)
+		}
+		run.tax[i] = result.LifetimeTax
+		if result.IsBankrupt {
+			run.bankrupt++
+		}
+	}
+	return run, nil
+}
+
+// pairedDelta summarizes variant - baseline over matching paths
+func pairedDelta(variant, baseline []float64) *PairedDelta {
+	deltas := make([]float64, len(variant))
+	sum := 0.0
+	for i := range variant {
+		deltas[i] = variant[i] - baseline[i]
+		sum += deltas[i]
+	}
+	pct := calculatePercentiles(deltas)
+	return &PairedDelta{
+		Mean: sum / float64(len(deltas)),
+		P10:  pct[0],
+		P25:  pct[1],
+		P50:  pct[2],
+		P75:  pct[3],
+		P90:  pct[4],
+	}
+}
+
+// winShares credits each path to the variant with the most terminal wealth,
+// splitting ties evenly
+func winShares(runs []scenarioPaths, paths int) []float64 {
+	shares := make([]float64, len(runs))
+	for i := 0; i < paths; i++ {
+		best := math.Inf(-1)
+		var winners []int
+		for v := range runs {
+			switch w := runs[v].wealth[i]; {
+			case w > best:
+				best, winners = w, []int{v}
+			case w == best:
+				winners = append(winners, v)
+			}
+		}
+		for _, v := range winners {
+			shares[v] += 1 / float64(len(winners))
+		}
+	}
+	for v := range shares {
+		shares[v] /= float64(paths)
+	}
+	return shares
+}
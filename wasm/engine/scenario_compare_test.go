@@ -0,0 +1,132 @@
+package engine
+
+import (
+	"testing"
+)
+
+func TestCompareScenariosPairsPaths(t *testing.T) {
+	baseline := createRollingTestInput(1e6, 40000)
+	baseline.Config.RandomSeed = 31
+	baseline.Config.SimulationMode = "stochastic"
+	baseline.InitialAge = 55
+	baseline.StartYear = 2025
+	baseline.MonthsToRun = 120
+	baseline.TaxConfig = &SimpleTaxConfig{Enabled: true, State: "TX"}
+
+	spendMore := baseline
+	spendMore.Events = []FinancialEvent{baseline.Events[0]}
+	spendMore.Events[0].Amount = 45000.0 / 12
+
+	consulting := baseline
+	consulting.Events = append([]FinancialEvent{{ID: "consulting", Type: "INCOME", Amount: 60000, Frequency: "annually",
+		Metadata: map[string]interface{}{"endDateOffset": 36}}}, baseline.Events...)
+
+	identical := baseline
+	identical.Config.RandomSeed = 999 // Overridden: every variant runs on the baseline's seeds
+
+	result, err := CompareScenarios(ScenarioComparisonRequest{
+		Variants: []ScenarioVariant{
+			{Name: "baseline", Input: baseline},
+			{Name: "spend more", Input: spendMore},
+			{Name: "consulting", Input: consulting},
+			{Name: "identical", Input: identical},
+		},
+		Paths: 40,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Seed != 31 || result.Baseline != "baseline" || len(result.Variants) != 4 {
+		t.Fatalf("unexpected comparison %+v", result)
+	}
+	base, more, work, same := result.Variants[0], result.Variants[1], result.Variants[2], result.Variants[3]
+
+	// The same plan on the same seeds differs on no path
+	if d := same.TerminalWealthDelta; d.Mean != 0 || d.P10 != 0 || d.P90 != 0 || same.BeatsBaseline != 0 {
+		t.Errorf("identical variant deltas %+v", d)
+	}
+	if base.TerminalWealthDelta != nil {
+		t.Error("the baseline has no deltas against itself")
+	}
+
+	// Spending more loses on every path, by far less than the spread between paths
+	d := more.TerminalWealthDelta
+	if d.P90 >= 0 || more.BeatsBaseline != 0 {
+		t.Errorf("spending more should end with less on every path: %+v", d)
+	}
+	if d.P90-d.P10 >= (base.TerminalWealthP90-base.TerminalWealthP10)/4 {
+		t.Errorf("paired spread %.0f is not much narrower than the unpaired spread %.0f",
+			d.P90-d.P10, base.TerminalWealthP90-base.TerminalWealthP10)
+	}
+
+	// Three years of consulting income win every path and cost tax
+	if work.BeatsBaseline != 1 || work.WinShare != 1 || work.LifetimeTaxDelta.P10 <= 0 {
+		t.Errorf("consulting: beats %.2f, wins %.2f, tax delta %+v", work.BeatsBaseline, work.WinShare, work.LifetimeTaxDelta)
+	}
+	if base.WinShare != 0 || more.WinShare != 0 || base.LifetimeTaxP50 < 0 {
+		t.Errorf("win shares %.2f/%.2f/%.2f/%.2f", base.WinShare, more.WinShare, work.WinShare, same.WinShare)
+	}
+	if work.RunwayDelta.P10 < 0 {
+		t.Errorf("extra income shortened the runway: %+v", work.RunwayDelta)
+	}
+}
+
+// TestCompareScenariosLongevityRunway verifies a path that never breaches the
+// cash floor reports the months it ran, not MonthsToRun, when a death ends it
+func TestCompareScenariosLongevityRunway(t *testing.T) {
+	baseline := createRollingTestInput(2e6, 40000)
+	baseline.Config.RandomSeed = 17
+	baseline.Config.SimulationMode = "stochastic"
+	baseline.InitialAge = 85
+	baseline.StartYear = 2025
+	baseline.MonthsToRun = 360
+	baseline.Longevity = &LongevityConfig{Sex: "male"}
+
+	spendMore := baseline
+	spendMore.Events = []FinancialEvent{baseline.Events[0]}
+	spendMore.Events[0].Amount = 50000.0 / 12
+
+	result, err := CompareScenarios(ScenarioComparisonRequest{
+		Variants: []ScenarioVariant{
+			{Name: "baseline", Input: baseline},
+			{Name: "spend more", Input: spendMore},
+		},
+		Paths: 30,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	base, more := result.Variants[0], result.Variants[1]
+
+	// An 85-year-old is very unlikely to reach 115, so most paths end well short of the horizon
+	if base.RunwayP50 <= 0 || base.RunwayP50 >= float64(baseline.MonthsToRun) {
+		t.Errorf("median runway %.0f should be the months lived, not the %d-month horizon",
+			base.RunwayP50, baseline.MonthsToRun)
+	}
+	// Both variants draw the same lifespans on the same seeds, and neither runs
+	// out of cash, so each path's runway is identical
+	if d := more.RunwayDelta; d.Mean != 0 || d.P10 != 0 || d.P90 != 0 {
+		t.Errorf("paired runway deltas should be zero when only spending differs: %+v", d)
+	}
+	if more.TerminalWealthDelta.P90 >= 0 {
+		t.Errorf("spending more should still end with less on every path: %+v", more.TerminalWealthDelta)
+	}
+}
+
+func TestCompareScenariosValidation(t *testing.T) {
+	input := createRollingTestInput(1e6, 40000)
+	input.Config.RandomSeed = 31
+	input.MonthsToRun = 24
+	longer := input
+	longer.MonthsToRun = 36
+
+	for name, variants := range map[string][]ScenarioVariant{
+		"one variant":       {{Name: "a", Input: input}},
+		"duplicate names":   {{Name: "a", Input: input}, {Name: "a", Input: input}},
+		"different horizon": {{Name: "a", Input: input}, {Name: "b", Input: longer}},
+	} {
+		if _, err := CompareScenarios(ScenarioComparisonRequest{Variants: variants, Paths: 2}); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
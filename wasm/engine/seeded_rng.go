@@ -6,7 +6,7 @@
 // - PCG32 is fast, simple (~20 lines), statistically excellent, and algorithm is fixed forever (we control it)
 // - This enables long-term reproducibility: same seed + same inputs → identical results
 
-package main
+package engine
 
 import (
 	"math"
@@ -334,7 +334,7 @@ func assetClassToReturnKey(assetClass string) string {
 		return "IndividualStock"
 	case "cash": // AssetClassCash
 		return "" // Cash has no return
-	case "real_estate_primary_home": // AssetClassRealEstatePrimaryHome
+	case "real_estate_primary_home", "real_estate_rental": // AssetClassRealEstatePrimaryHome, AssetClassRealEstateRental
 		return "" // Real estate has separate returns
 	default:
 		return ""
@@ -1,4 +1,4 @@
-package main
+package engine
 
 import (
 	"fmt"
@@ -37,6 +37,7 @@ type SimulationEngine struct {
 	currentMonthReturns *StochasticReturns
 	marketPrices        MarketPrices                // Current market prices per share for share-based calculations
 	backtestReturns     map[int]StochasticReturns   // Historical returns for true backtesting (month offset -> returns)
+	stressOverlay       map[int]stressAdjustment    // Scripted stress shocks by month offset (nil = none)
 	taxCalculator       *TaxCalculator
 	cashManager         *CashManager
 	seededRng           *SeededRNG                  // Seeded RNG for deterministic stochastic simulation (nil if not seeded)
@@ -57,6 +58,9 @@ type SimulationEngine struct {
 	goalPrioritizer          *GoalPrioritizer
 	taxAwareRebalancer       *TaxAwareRebalancer
 
+	// Per-member state for two-person households (nil for single-person plans)
+	household *householdState
+
 	ledger              *SimpleLedger
 	strategyProcessor   *StrategyProcessor
 	eventRegistry       *EventHandlerRegistry
@@ -80,6 +84,45 @@ type SimulationEngine struct {
 	qualifiedCharitableDistributionsYTD float64
 	itemizedDeductibleInterestYTD       float64
 	preTaxContributionsYTD              float64
+	penaltyTaxYTD                       float64 // Additional tax on non-qualified distributions
+
+	// Pensions and IRA/401(k) distributions this tax year, for state retirement-income exclusions
+	pensionIncomeYTD           float64
+	retirementDistributionsYTD float64
+
+	// State of residence (RELOCATE) and the states left during this tax year
+	residenceState     string
+	startingState      string
+	relocated          bool
+	partYearResidences []partYearResidence
+
+	// HSA medical expenses paid out of pocket and not yet reimbursed from the account
+	hsaReceipts float64
+
+	// 529 beneficiaries' shares of the FiveTwoNine account (nil until there is a 529)
+	fiveTwoNine *fiveTwoNineState
+
+	// Roth basis by source for the pre-59½ ordering rules: contributions, then each year's conversions
+	rothContributionBasis float64
+	rothConversions       []rothConversion
+	rothConversionsYTD    float64
+
+	// Marketplace coverage this tax year, reconciled against the premium tax credit in December
+	acaCoverage acaCoverageYear
+
+	// SOCIAL_SECURITY_CLAIM benefits, and each member's wages this year for the earnings test
+	socialSecurityClaims []*socialSecurityClaim
+	memberWagesYTD       [maxHouseholdMembers]float64
+
+	// Retirement withdrawal policy and the spending it has realized (nil without one)
+	withdrawal *pathWithdrawal
+
+	// Total tax on the returns settled so far this path
+	lifetimeTax float64
+
+	// Medicare premiums paid this calendar year, and the IRMAA surcharge within them
+	medicarePremiumsYTD float64
+	irmaaSurchargeYTD   float64
 
 	// PFOS-E: Additional tax profile tracking
 	selfEmploymentIncomeYTD float64 // Schedule C income
@@ -99,6 +142,7 @@ type SimulationEngine struct {
 	// Stochastic inflation tracking for event amount adjustment
 	cumulativeInflationFactor      float64 // Compound factor updated AFTER market update
 	eventInflationFactor           float64 // Factor available to events (lags by 1 month)
+	priceIndexHistory              []float64 // Cumulative factor at the end of each month
 
 	// MAGI history for IRMAA two-year look-back
 	magiHistory map[int]float64 // Year -> MAGI
@@ -119,6 +163,12 @@ type SimulationEngine struct {
 
 	// Active liabilities tracking
 	liabilities []*LiabilityInfo
+	// Paid-off liabilities with a refinance/prepayment comparison still to report
+	paidOffLiabilities []*LiabilityInfo
+
+	// Rental properties and 1031 exchanges awaiting a replacement property
+	rentalProperties []*rentalProperty
+	pendingExchanges []*pendingExchange
 
 	// Advanced bankruptcy and financial stress tracking
 	isBankrupt               bool
@@ -216,6 +266,7 @@ func NewSimulationEngine(config StochasticModelConfig) *SimulationEngine {
 
 	simLogVerbose("🔍 [DEBUG] About to create tax calculator")
 	taxCalculator := NewTaxCalculator(taxConfig, stateTaxCalculator)
+	taxCalculator.SetTaxLawSchedule(config.TaxLawSchedule)
 	simLogVerbose("🔍 [DEBUG] Tax calculator created")
 
 	simLogVerbose("🔍 [DEBUG] About to create cash manager")
@@ -260,8 +311,14 @@ func NewSimulationEngine(config StochasticModelConfig) *SimulationEngine {
 		simLogVerbose("🔍 [DEBUG] Seeded RNG initialized with seed %d", config.RandomSeed)
 	}
 
+	stressOverlay, err := buildStressOverlay(config.StressScenarios)
+	if err != nil {
+		simLogVerbose("⚠️ [STRESS] Ignoring invalid stress scenarios: %v", err)
+	}
+
 	return &SimulationEngine{
 		config:                              config,
+		stressOverlay:                       stressOverlay,
 		stochasticState:                     stochasticState,
 		currentYear:                         -1,
 		currentMonthReturns:                 nil,
@@ -326,6 +383,7 @@ func (se *SimulationEngine) ResetSimulationState() {
 	se.currentMonthReturns = nil
 	se.stochasticState = InitializeStochasticState(se.config)
 	se.cashManager = NewCashManagerWithConfig(&se.config)
+	se.cashManager.SummaryOnly = !se.trackMonthlyData // PERF: skip SoldLots/SaleTransactions in MC
 
 	// Reset seeded RNG if configured for deterministic simulation
 	if se.config.RandomSeed > 0 {
@@ -356,17 +414,36 @@ func (se *SimulationEngine) ResetSimulationState() {
 	se.qualifiedCharitableDistributionsYTD = 0
 	se.itemizedDeductibleInterestYTD = 0
 	se.preTaxContributionsYTD = 0
+	se.penaltyTaxYTD = 0
+	se.pensionIncomeYTD = 0
+	se.retirementDistributionsYTD = 0
+	se.hsaReceipts = 0
+	se.fiveTwoNine = nil
+	se.rothContributionBasis = 0
+	se.rothConversions = nil
+	se.rothConversionsYTD = 0
+	se.acaCoverage = acaCoverageYear{}
+	se.socialSecurityClaims = nil
+	se.memberWagesYTD = [maxHouseholdMembers]float64{}
+	se.withdrawal = nil
+	se.lifetimeTax = 0
+	se.medicarePremiumsYTD = 0
+	se.irmaaSurchargeYTD = 0
 	se.selfEmploymentIncomeYTD = 0
 	se.passiveIncomeYTD = 0
 	se.taxExemptIncomeYTD = 0
 	se.driverContributions = make(map[string]float64)
 	se.magiHistory = make(map[int]float64)
 	se.liabilities = make([]*LiabilityInfo, 0)
+	se.paidOffLiabilities = nil
+	se.rentalProperties = nil
+	se.pendingExchanges = nil
 	se.isBankrupt = false
 	se.bankruptcyMonth = -1
 	se.priorYearEndTaxDeferredBalance = 0
 	se.cumulativeInflationFactor = 1.0
 	se.eventInflationFactor = 1.0
+	se.priceIndexHistory = se.priceIndexHistory[:0]
 
 	// Initialize expense history tracking for cash reserve calculations
 	se.expenseHistorySize = 6 // Track 6 months of expenses
@@ -506,7 +583,7 @@ func (se *SimulationEngine) GetPricePerShare(assetClass AssetClass) float64 {
 		return se.marketPrices.BND
 	case AssetClassInternationalStocks:
 		return se.marketPrices.INTL
-	case AssetClassRealEstatePrimaryHome:
+	case AssetClassRealEstatePrimaryHome, AssetClassRealEstateRental:
 		return se.marketPrices.RealEstate
 	case AssetClassIndividualStock:
 		return se.marketPrices.Individual
@@ -737,6 +814,11 @@ func (se *SimulationEngine) ApplyMarketGrowth(accounts *AccountHoldingsMonthEnd,
 			return fmt.Errorf("failed to generate stochastic returns: %v", err)
 		}
 
+		// Scripted stress shocks ride on top of the generated returns
+		if adjustment, stressed := se.stressOverlay[currentMonthOffset]; stressed {
+			returns = adjustment.apply(returns)
+		}
+
 		se.currentMonthReturns = &returns
 		se.stochasticState = newState
 		monthlyReturns = returns
@@ -1172,6 +1254,24 @@ func (se *SimulationEngine) deepCopyAccounts(original AccountHoldingsMonthEnd) A
 		}
 	}
 
+	// HSA and 529 are optional accounts - only copied when present
+	for _, pair := range []struct{ src, dst **Account }{
+		{&original.HSA, &copy.HSA},
+		{&original.FiveTwoNine, &copy.FiveTwoNine},
+	} {
+		if *pair.src == nil {
+			continue
+		}
+		newAccount := &Account{
+			TotalValue: (*pair.src).TotalValue,
+			Holdings:   make([]Holding, len((*pair.src).Holdings)),
+		}
+		for i, holding := range (*pair.src).Holdings {
+			newAccount.Holdings[i] = holding
+		}
+		*pair.dst = newAccount
+	}
+
 	return copy
 }
 
@@ -1184,7 +1284,7 @@ func (se *SimulationEngine) getAssetClassReturn(assetClass AssetClass, returns S
 		return returns.BND
 	case AssetClassInternationalStocks:
 		return returns.Intl
-	case AssetClassRealEstatePrimaryHome:
+	case AssetClassRealEstatePrimaryHome, AssetClassRealEstateRental:
 		return returns.Home
 	case AssetClassLeveragedSPY:
 		// Leveraged ETF return: (LeverageFactor * SPY_Return) - Costs
@@ -1230,6 +1330,20 @@ func (se *SimulationEngine) RunSingleSimulation(input SimulationInput) Simulatio
 		// Non-fatal - fallback to computing on the fly
 	}
 
+	// Longevity: draw this path's lifespans and end the path at the last death
+	var lifespan *PathLifespan
+	if input.Longevity != nil {
+		var err error
+		input, lifespan, err = applyLongevity(input, se.config.RandomSeed)
+		if err != nil {
+			return SimulationResult{
+				Success:     false,
+				MonthlyData: []MonthlyDataSimulation{},
+				Error:       err.Error(),
+			}
+		}
+	}
+
 	// BANKRUPTCY DEBUG: Log starting configuration
 	simLogVerbose("💰 [BANKRUPTCY-DEBUG] STARTING CONFIG: InitialCash=$%.2f, MonthsToRun=%d",
 		input.InitialAccounts.Cash, input.MonthsToRun)
@@ -1336,6 +1450,22 @@ func (se *SimulationEngine) RunSingleSimulation(input SimulationInput) Simulatio
 		accounts.Roth = &Account{TotalValue: 0, Holdings: make([]Holding, 0, 10)}
 	}
 
+	// The 529 and HSA are optional; initializeAccountsForQueue opens empty ones
+	if input.InitialAccounts.FiveTwoNine != nil {
+		accounts.FiveTwoNine = &Account{
+			TotalValue: input.InitialAccounts.FiveTwoNine.TotalValue,
+			Holdings:   input.InitialAccounts.FiveTwoNine.Holdings,  // ✅ PRESERVE
+		}
+		initializeMissingTaxLots(accounts.FiveTwoNine, 0)
+	}
+	if input.InitialAccounts.HSA != nil {
+		accounts.HSA = &Account{
+			TotalValue: input.InitialAccounts.HSA.TotalValue,
+			Holdings:   input.InitialAccounts.HSA.Holdings,  // ✅ PRESERVE
+		}
+		initializeMissingTaxLots(accounts.HSA, 0)
+	}
+
 	// Priority queue simulation system
 	// Full discrete event simulation engine with proper financial modeling
 	// Track totals for verification
@@ -1401,6 +1531,11 @@ func (se *SimulationEngine) RunSingleSimulation(input SimulationInput) Simulatio
 	var result SimulationResult
 	result = se.runQueueSimulationLoop(input, accounts)
 
+	if lifespan != nil {
+		se.settleEstate(lifespan, result.FinalNetWorth, input)
+		result.Lifespan = lifespan
+	}
+
 	return result
 }
 
@@ -1411,6 +1546,22 @@ func (se *SimulationEngine) runQueueSimulationLoop(input SimulationInput, accoun
 	// Store simulation input for access by event handlers
 	se.simulationInput = &input
 	se.taxesDisabled = input.TaxConfig == nil || !input.TaxConfig.Enabled
+	se.household = newHouseholdState(&input)
+	se.fiveTwoNine = newFiveTwoNineState(&input)
+	se.rothContributionBasis = initialRothContributionBasis(&input)
+	se.rothConversions = nil
+	se.acaCoverage = acaCoverageYear{}
+	se.socialSecurityClaims = nil
+	se.seedMAGIHistory(&input)
+	if se.household != nil {
+		se.taxCalculator.SetFilingStatus(se.household.filingStatus(0))
+	}
+	se.startResidence(taxStateCode(&input))
+	se.taxCalculator.SetThresholdIndex(se.thresholdIndex(input.StartYear))
+	se.taxCalculator.SetSimulationYear(input.StartYear, se.taxThresholdRate())
+	if err := se.startWithdrawalPolicy(&input); err != nil {
+		return SimulationResult{Success: false, MonthlyData: []MonthlyDataSimulation{}, Error: err.Error()}
+	}
 
 	// Create and populate the event queue FIRST (before initializing accounts)
 	// This is required because initializeAccountsForQueue checks for investment events
@@ -1526,6 +1677,7 @@ func (se *SimulationEngine) runQueueSimulationLoop(input SimulationInput, accoun
 
 				// Calculate final net worth for the month
 				currentMonthData.NetWorth = se.calculateNetWorth(accounts)
+				se.setRealNetWorth(currentMonthData)
 
 				// PERF: Track incremental metrics for MC (avoids need to iterate MonthlyData later)
 				cash := accounts.Cash
@@ -1563,6 +1715,8 @@ func (se *SimulationEngine) runQueueSimulationLoop(input SimulationInput, accoun
 				currentMonthData.SalaryIncomeThisMonth = se.currentMonthFlows.SalaryIncomeThisMonth
 				currentMonthData.BonusIncomeThisMonth = se.currentMonthFlows.BonusIncomeThisMonth
 				currentMonthData.RSUIncomeThisMonth = se.currentMonthFlows.RSUIncomeThisMonth
+				currentMonthData.SocialSecurityIncomeThisMonth = se.currentMonthFlows.SocialSecurityIncomeThisMonth
+				currentMonthData.PensionIncomeThisMonth = se.currentMonthFlows.PensionIncomeThisMonth
             currentMonthData.HousingExpensesThisMonth = se.currentMonthFlows.HousingExpensesThisMonth
             currentMonthData.TransportationExpensesThisMonth = se.currentMonthFlows.TransportationExpensesThisMonth
             currentMonthData.FoodExpensesThisMonth = se.currentMonthFlows.FoodExpensesThisMonth
@@ -1573,6 +1727,7 @@ func (se *SimulationEngine) runQueueSimulationLoop(input SimulationInput, accoun
 			currentMonthData.ContributionsTaxableThisMonth = se.currentMonthFlows.ContributionsTaxableThisMonth
 			currentMonthData.ContributionsTaxDeferredThisMonth = se.currentMonthFlows.ContributionsTaxDeferredThisMonth
 			currentMonthData.ContributionsRothThisMonth = se.currentMonthFlows.ContributionsRothThisMonth
+			currentMonthData.RothConversionAmountThisMonth = se.currentMonthFlows.RothConversionAmountThisMonth
 
 			// Copy YTD tax tracking fields
 			currentMonthData.OrdinaryIncomeForTaxYTD = se.ordinaryIncomeYTD
@@ -1585,6 +1740,13 @@ func (se *SimulationEngine) runQueueSimulationLoop(input SimulationInput, accoun
 			currentMonthData.PreTaxContributionsYTD = se.preTaxContributionsYTD
 			currentMonthData.TaxWithholdingYTD = se.taxWithholdingYTD
 
+            // Medicare premiums paid over the year and the IRMAA surcharge within them
+            if (currentMonth+1)%12 == 0 && se.medicarePremiumsYTD > 0 {
+                premiums, surcharge := se.medicarePremiumsYTD, se.irmaaSurchargeYTD
+                currentMonthData.MedicarePremiumsAnnual = &premiums
+                currentMonthData.IRMAAMedicarePremiumAdjustment = &surcharge
+            }
+
             // If December, populate annual tax breakdown fields from last tax calculation
             if (currentMonth+1)%12 == 0 && se.lastTaxCalculationResults != nil {
                 tr := se.lastTaxCalculationResults
@@ -1593,7 +1755,7 @@ func (se *SimulationEngine) runQueueSimulationLoop(input SimulationInput, accoun
                     val := v
                     *dst = &val
                 }
-                // Total tax (including IRMAA premiums already added in ProcessAnnualTaxes)
+                // Total tax (Medicare premiums are healthcare expenses, not tax)
                 setFloatPtr(&currentMonthData.TaxPaidAnnual, tr.TotalTax)
                 // Federal/state/cap gains/AMT
                 setFloatPtr(&currentMonthData.FederalIncomeTaxAnnual, tr.FederalIncomeTax)
@@ -1610,10 +1772,22 @@ func (se *SimulationEngine) runQueueSimulationLoop(input SimulationInput, accoun
                 setFloatPtr(&currentMonthData.MedicareTaxAnnual, tr.MedicareTax)
                 setFloatPtr(&currentMonthData.AdditionalMedicareTaxAnnual, tr.AdditionalMedicareTax)
                 setFloatPtr(&currentMonthData.TotalFICATaxAnnual, tr.TotalFICATax)
+                // ACA premium tax credit reconciliation
+                if tr.ACACoverageMonths > 0 {
+                    setFloatPtr(&currentMonthData.ACAModifiedAGIAnnual, tr.ACAModifiedAGI)
+                    setFloatPtr(&currentMonthData.PremiumTaxCreditAnnual, tr.PremiumTaxCredit)
+                    setFloatPtr(&currentMonthData.AdvancePremiumTaxCreditAnnual, tr.AdvancePremiumTaxCredit)
+                    setFloatPtr(&currentMonthData.ExcessAdvanceCreditRepaymentAnnual, tr.ExcessAdvanceCreditRepayment)
+                    setFloatPtr(&currentMonthData.PremiumTaxCreditLostToConversionsAnnual, tr.PremiumTaxCreditLostToConversions)
+                }
                 // RMD amount tracked for year
                 if se.lastRMDAmount > 0 {
                     setFloatPtr(&currentMonthData.RMDAmountAnnual, se.lastRMDAmount)
                 }
+                if se.household != nil {
+                    filingStatus := string(se.taxCalculator.FilingStatus())
+                    currentMonthData.ActiveFilingStatus = &filingStatus
+                }
             }
 
             // PERF: Only append monthly data if tracking enabled (skip for MC)
@@ -1678,6 +1852,7 @@ func (se *SimulationEngine) runQueueSimulationLoop(input SimulationInput, accoun
 	// Save the final month's data if it was created
         if currentMonthData != nil {
             currentMonthData.NetWorth = se.calculateNetWorth(accounts)
+            se.setRealNetWorth(currentMonthData)
             currentMonthData.Accounts = se.deepCopyAccounts(accounts)
 
 		// Copy monthly flows to monthly data for final month too
@@ -1708,6 +1883,7 @@ func (se *SimulationEngine) runQueueSimulationLoop(input SimulationInput, accoun
 		currentMonthData.ContributionsTaxableThisMonth = se.currentMonthFlows.ContributionsTaxableThisMonth
 		currentMonthData.ContributionsTaxDeferredThisMonth = se.currentMonthFlows.ContributionsTaxDeferredThisMonth
 		currentMonthData.ContributionsRothThisMonth = se.currentMonthFlows.ContributionsRothThisMonth
+		currentMonthData.RothConversionAmountThisMonth = se.currentMonthFlows.RothConversionAmountThisMonth
 
 		// Copy YTD tax tracking fields for final month
 		currentMonthData.OrdinaryIncomeForTaxYTD = se.ordinaryIncomeYTD
@@ -1720,6 +1896,13 @@ func (se *SimulationEngine) runQueueSimulationLoop(input SimulationInput, accoun
 		currentMonthData.PreTaxContributionsYTD = se.preTaxContributionsYTD
 		currentMonthData.TaxWithholdingYTD = se.taxWithholdingYTD
 
+            // Medicare premiums paid over the year and the IRMAA surcharge within them
+            if (currentMonth+1)%12 == 0 && se.medicarePremiumsYTD > 0 {
+                premiums, surcharge := se.medicarePremiumsYTD, se.irmaaSurchargeYTD
+                currentMonthData.MedicarePremiumsAnnual = &premiums
+                currentMonthData.IRMAAMedicarePremiumAdjustment = &surcharge
+            }
+
             // If this is December, populate annual tax breakdown fields on the final month as well
             if (currentMonth+1)%12 == 0 && se.lastTaxCalculationResults != nil {
                 tr := se.lastTaxCalculationResults
@@ -1741,9 +1924,21 @@ func (se *SimulationEngine) runQueueSimulationLoop(input SimulationInput, accoun
                 setFloatPtr(&currentMonthData.MedicareTaxAnnual, tr.MedicareTax)
                 setFloatPtr(&currentMonthData.AdditionalMedicareTaxAnnual, tr.AdditionalMedicareTax)
                 setFloatPtr(&currentMonthData.TotalFICATaxAnnual, tr.TotalFICATax)
+                // ACA premium tax credit reconciliation
+                if tr.ACACoverageMonths > 0 {
+                    setFloatPtr(&currentMonthData.ACAModifiedAGIAnnual, tr.ACAModifiedAGI)
+                    setFloatPtr(&currentMonthData.PremiumTaxCreditAnnual, tr.PremiumTaxCredit)
+                    setFloatPtr(&currentMonthData.AdvancePremiumTaxCreditAnnual, tr.AdvancePremiumTaxCredit)
+                    setFloatPtr(&currentMonthData.ExcessAdvanceCreditRepaymentAnnual, tr.ExcessAdvanceCreditRepayment)
+                    setFloatPtr(&currentMonthData.PremiumTaxCreditLostToConversionsAnnual, tr.PremiumTaxCreditLostToConversions)
+                }
                 if se.lastRMDAmount > 0 {
                     setFloatPtr(&currentMonthData.RMDAmountAnnual, se.lastRMDAmount)
                 }
+                if se.household != nil {
+                    filingStatus := string(se.taxCalculator.FilingStatus())
+                    currentMonthData.ActiveFilingStatus = &filingStatus
+                }
             }
 
             // PERF: Only append final month data if tracking enabled (skip for MC)
@@ -1765,6 +1960,7 @@ func (se *SimulationEngine) runQueueSimulationLoop(input SimulationInput, accoun
 			NetWorth:    se.calculateNetWorth(accounts),
 			Accounts:    se.deepCopyAccounts(accounts),
 		}
+		se.setRealNetWorth(&initialMonth)
 		monthlyDataList = append(monthlyDataList, initialMonth)
 	}
 
@@ -1774,6 +1970,7 @@ func (se *SimulationEngine) runQueueSimulationLoop(input SimulationInput, accoun
 
 	// Calculate final values
 	finalNetWorth := se.calculateNetWorth(accounts)
+	finalPriceIndex := se.PriceIndexAt(currentMonth)
 
 	simLogVerbose("🏁 [QUEUE-COMPLETE] Final: Cash=$%.0f, NetWorth=$%.0f, Months=%d",
 		accounts.Cash, finalNetWorth, len(monthlyDataList))
@@ -1783,19 +1980,20 @@ func (se *SimulationEngine) runQueueSimulationLoop(input SimulationInput, accoun
 	simLogVerbose("✅ [PROGRESS-COMPLETE] Simulation finished: %d months (%.1f years) - Final Net Worth: $%.0f",
 		len(monthlyDataList), actualYears, finalNetWorth)
 
-	// TODO: Integrate estate tax into terminal wealth calculation
-	// EstateTaxCalculator exists (estate_tax_calculator.go) with federal + 12 state taxes.
-	// Would subtract estate tax from FinalNetWorth for after-tax bequest analysis.
-	// Relevant for high-net-worth scenarios (federal exemption ~$13.6M in 2024).
+	// Estate tax is settled by RunSingleSimulation when the longevity model ends the
+	// path at a death (see longevity.go); FinalNetWorth stays pre-estate-tax.
 
 	// Build and return result
 	result := SimulationResult{
 		Success:           bankruptcyMonth == 0,
 		MonthlyData:       monthlyDataList,
 		FinalNetWorth:     finalNetWorth,
+		FinalNetWorthReal: finalNetWorth / finalPriceIndex,
+		FinalPriceIndex:   finalPriceIndex,
 		BankruptcyMonth:   bankruptcyMonth,
 		BankruptcyTrigger: bankruptcyTrigger,
 		IsBankrupt:        bankruptcyMonth > 0,
+		MonthsSimulated:   currentMonth + 1,
 		Metadata: map[string]interface{}{
 			"simulationCompleted": true,
 		},
@@ -1804,6 +2002,8 @@ func (se *SimulationEngine) runQueueSimulationLoop(input SimulationInput, accoun
 		MinCashMonth:           se.minCashMonth,
 		CashFloorBreachedMonth: se.cashFloorBreachedMonth,
 		YearEndNetWorth:        se.yearEndNetWorth,
+		Spending:               se.pathSpending(),
+		LifetimeTax:            se.lifetimeTax,
 	}
 	return result
 }
@@ -1859,18 +2059,25 @@ func (se *SimulationEngine) processQueuedEvent(
 		}
 
 		if inflationBase == "event_start" {
-			// Inflation from event start date: approximate using average monthly stochastic inflation
-			monthsFromStart := queuedEvent.MonthOffset - event.MonthOffset
-			if monthsFromStart > 0 && queuedEvent.MonthOffset > 0 {
-				// Use geometric mean of monthly inflation rates observed so far
-				avgMonthlyInflation := math.Pow(se.eventInflationFactor, 1.0/float64(queuedEvent.MonthOffset)) - 1.0
-				inflationMultiplier := math.Pow(1+avgMonthlyInflation, float64(monthsFromStart))
-				event.Amount *= inflationMultiplier
+			// Inflation from the event's own start, on the path's price index
+			startMonth := event.MonthOffset
+			if start, ok := event.Metadata["inflationStartMonth"].(float64); ok {
+				startMonth = int(start)
+			}
+			if queuedEvent.MonthOffset > startMonth {
+				event.Amount *= se.priceLevel(queuedEvent.MonthOffset) / se.priceLevel(startMonth)
 			}
 		} else {
-			// Inflation from simulation start (default): use lagged cumulative factor
-			// eventInflationFactor = cumulative inflation through end of prior month
-			event.Amount *= se.eventInflationFactor
+			// Inflation from simulation start (default): price index through end of prior month
+			event.Amount *= se.priceLevel(queuedEvent.MonthOffset)
+		}
+	}
+
+	// Owned events stop at the owner's retirement or death; survivor benefits replace them
+	if se.household != nil {
+		var ok bool
+		if event, ok = se.household.applyToEvent(event, queuedEvent.MonthOffset); !ok {
+			return nil
 		}
 	}
 
@@ -2147,9 +2354,6 @@ func (se *SimulationEngine) updateMonthlyDataFromEvent(
 	case "CONTRIBUTION", "401K_CONTRIBUTION", "IRA_CONTRIBUTION":
 		monthlyData.ContributionsToInvestmentsThisMonth += event.Amount
 
-	case "ROTH_CONVERSION":
-		monthlyData.RothConversionAmountThisMonth += event.Amount
-
 	case "ASSET_SALE", "WITHDRAWAL":
 		// This is tracked in the cash manager
 		// monthlyData.DivestmentProceedsThisMonth is updated there
@@ -2500,15 +2704,27 @@ func RunMonteCarloSimulation(input SimulationInput, numberOfRuns int) Simulation
 	failedPaths := 0
 	bankruptcyCount := 0
 	maxErrors := numberOfRuns / 10 // Allow up to 10% failures
+	var longevityPaths []longevityPathOutcome
+	var spendingPaths []*PathSpending
 
 	// Track max months for breach time series
 	maxMonthsObserved := 0
 
-	// PERF: Create one engine and reuse across all paths
-	// NewSimulationEngine creates ~15 expensive calculator objects (TaxCalculator, RMDCalculator, etc.)
-	// ResetSimulationState() (called inside RunSingleSimulation) resets per-path state while preserving them
-	engine := NewSimulationEngine(input.Config)
-	engine.trackMonthlyData = false // MC mode: use incremental metrics
+	// PERF: On native builds, fan paths out across a worker pool (see monte_carlo_parallel.go).
+	// Results are still consumed below in path order, so aggregates are bit-identical to serial.
+	workers := monteCarloWorkerCount(input.Config, numberOfRuns)
+	var parallelResults []SimulationResult
+	var engine *SimulationEngine
+	if workers > 1 {
+		simLogVerbose("🔧 MONTE-CARLO: Running paths on %d workers", workers)
+		parallelResults = runMonteCarloPathsParallel(input, numberOfRuns, workers)
+	} else {
+		// PERF: Create one engine and reuse across all paths
+		// NewSimulationEngine creates ~15 expensive calculator objects (TaxCalculator, RMDCalculator, etc.)
+		// ResetSimulationState() (called inside RunSingleSimulation) resets per-path state while preserving them
+		engine = NewSimulationEngine(input.Config)
+		engine.trackMonthlyData = false // MC mode: use incremental metrics
+	}
 
 	simLogVerbose("🔧 MONTE-CARLO: Starting %d Monte Carlo runs (baseSeed=%d, cashFloor=%.2f)", numberOfRuns, baseSeed, cashFloor)
 	for i := 0; i < numberOfRuns; i++ {
@@ -2516,15 +2732,13 @@ func RunMonteCarloSimulation(input SimulationInput, numberOfRuns int) Simulation
 			simLogVerbose("🔧 MONTE-CARLO: Run %d/%d", i+1, numberOfRuns)
 		}
 
-		// PERF: Reuse engine — update seed + deep-copy accounts (instead of RunIsolatedPath)
-		pathSeed := baseSeed + int64(i)
-		engine.config.RandomSeed = pathSeed
-
-		pathInput := input
-		pathInput.Config = engine.config
-		pathInput.InitialAccounts = deepCopyInputAccounts(input.InitialAccounts)
-
-		result := engine.RunSingleSimulation(pathInput)
+		var result SimulationResult
+		if parallelResults != nil {
+			result = parallelResults[i]
+			parallelResults[i] = SimulationResult{} // Release for GC once consumed
+		} else {
+			result = runMonteCarloPath(engine, input, i)
+		}
 
 		// Track max months for breach time series
 		// CRITICAL FIX: In MC mode, MonthlyData is empty (trackMonthlyData=false)
@@ -2567,6 +2781,18 @@ func RunMonteCarloSimulation(input SimulationInput, numberOfRuns int) Simulation
 			pathMetrics = append(pathMetrics, metrics)
 			finalNetWorths = append(finalNetWorths, finalNetWorth)
 			successfulPaths++
+
+			if result.Lifespan != nil {
+				longevityPaths = append(longevityPaths, longevityPathOutcome{
+					lifespan:        result.Lifespan,
+					yearEndNetWorth: result.YearEndNetWorth,
+					finalNetWorth:   finalNetWorth,
+					bankruptcyMonth: result.BankruptcyMonth,
+				})
+			}
+			if result.Spending != nil {
+				spendingPaths = append(spendingPaths, result.Spending)
+			}
 		}
 
 		// MEMORY OPTIMIZATION: Clear heavy data after extracting metrics
@@ -2579,7 +2805,7 @@ func RunMonteCarloSimulation(input SimulationInput, numberOfRuns int) Simulation
 		// Check memory every 50 paths (not 10) to reduce ReadMemStats overhead in WASM
 		// Only force GC if heap usage is above threshold (50MB)
 		// This avoids expensive stop-the-world GC when memory is not under pressure
-		if (i+1)%50 == 0 {
+		if parallelResults == nil && (i+1)%50 == 0 {
 			var memStats runtime.MemStats
 			runtime.ReadMemStats(&memStats)
 			const gcThresholdMB = 50 * 1024 * 1024 // 50MB
@@ -2617,12 +2843,14 @@ func RunMonteCarloSimulation(input SimulationInput, numberOfRuns int) Simulation
 	percentiles := calculatePercentiles(finalNetWorths)
 
 	// Calculate extended percentiles (P5, P10, P25, P50, P75, P90, P95)
-	var terminalWealth, minCash []float64
+	var terminalWealth, terminalWealthReal, minCash []float64
 	for _, m := range pathMetrics {
 		terminalWealth = append(terminalWealth, m.TerminalWealth)
+		terminalWealthReal = append(terminalWealthReal, m.TerminalWealthReal)
 		minCash = append(minCash, m.MinCash)
 	}
 	twPct := calculatePercentilesExtended(terminalWealth)
+	realPct := calculatePercentilesExtended(terminalWealthReal)
 	mcPct := calculatePercentilesExtended(minCash)
 
 	// Calculate runway percentiles (conditional on breach)
@@ -2699,6 +2927,15 @@ func RunMonteCarloSimulation(input SimulationInput, numberOfRuns int) Simulation
 		FinalNetWorthP5:  twPct[0],
 		FinalNetWorthP95: twPct[6],
 
+		// Final net worth in today's dollars
+		FinalNetWorthRealP5:  realPct[0],
+		FinalNetWorthRealP10: realPct[1],
+		FinalNetWorthRealP25: realPct[2],
+		FinalNetWorthRealP50: realPct[3],
+		FinalNetWorthRealP75: realPct[4],
+		FinalNetWorthRealP90: realPct[5],
+		FinalNetWorthRealP95: realPct[6],
+
 		// Min cash KPIs
 		MinCashP5:  mcPct[0],
 		MinCashP50: mcPct[3],
@@ -2728,6 +2965,9 @@ func RunMonteCarloSimulation(input SimulationInput, numberOfRuns int) Simulation
 		BaseSeed:        baseSeed,
 		SuccessfulPaths: successfulPaths,
 		FailedPaths:     failedPaths,
+
+		Longevity: summarizeLongevity(longevityPaths, input),
+		Spending:  summarizeSpending(spendingPaths),
 	}
 }
 
@@ -2852,6 +3092,7 @@ func extractPathMetrics(result SimulationResult, pathIndex int, pathSeed int64,
 		metrics.CashFloorBreached = result.CashFloorBreachedMonth >= 0
 		metrics.RunwayMonths = result.CashFloorBreachedMonth
 		metrics.TerminalWealth = result.FinalNetWorth
+		metrics.TerminalWealthReal = result.FinalNetWorthReal
 		metrics.YearEndNetWorth = result.YearEndNetWorth
 	} else {
 		// Fall back to iterating MonthlyData (deterministic mode or legacy)
@@ -2868,6 +3109,7 @@ func extractPathMetrics(result SimulationResult, pathIndex int, pathSeed int64,
 		}
 		if len(result.MonthlyData) > 0 {
 			metrics.TerminalWealth = result.MonthlyData[len(result.MonthlyData)-1].NetWorth
+			metrics.TerminalWealthReal = result.MonthlyData[len(result.MonthlyData)-1].NetWorthReal
 		}
 		if metrics.MinCash == math.MaxFloat64 {
 			metrics.MinCash = 0 // No monthly data
@@ -3083,12 +3325,11 @@ func (se *SimulationEngine) ProcessAnnualTaxes(accounts *AccountHoldingsMonthEnd
 		return nil
 	}
 
-	// Skip entire year-end tax calculation when taxes are disabled
-	if se.taxesDisabled {
-		taxYear := monthOffset / 12
-		se.resetTaxYTD(taxYear)
-		return nil
-	}
+	// Non-qualified 529 distributions are judged against the whole year's qualified expenses
+	se.settleFiveTwoNineYear(monthOffset)
+
+	// Benefits claimed before full retirement age are reduced for the year's wages
+	se.settleSocialSecurityEarningsTest(accounts, monthOffset)
 
 	// Note: RMDs are processed by the system event handler (processRMDCheck)
 	// which fires before TAX_CHECK in December. Do NOT process RMDs here
@@ -3105,32 +3346,22 @@ func (se *SimulationEngine) ProcessAnnualTaxes(accounts *AccountHoldingsMonthEnd
 	// Calculate current year for Medicare processing
 	currentYear := se.simulationInput.StartYear + monthOffset/12
 
-	// Set simulation year on tax calculator for threshold inflation indexing
-	thresholdRate := se.config.TaxThresholdInflationRate
-	if thresholdRate == 0 {
-		thresholdRate = 0.025 // Default 2.5% annual indexing
-	}
-	se.taxCalculator.SetSimulationYear(currentYear, thresholdRate)
+	// Set simulation year on tax calculator for the year's brackets and threshold indexing
+	se.taxCalculator.SetSimulationYear(currentYear, se.taxThresholdRate())
 
-	// Calculate MAGI for current year (AGI + tax-exempt interest + foreign income exclusions)
-	// For simplified calculation, we'll use AGI as MAGI approximation
+	// Calculate AGI for the current year (MAGI for the ACA and IRMAA adds to it below)
 	currentYearMAGI := adjustedOrdinaryIncome + se.capitalGainsYTD + se.qualifiedDividendsYTD
 
-	// Store MAGI in history for future IRMAA calculations
-	se.magiHistory[currentYear] = currentYearMAGI
-
-	// Get MAGI from two years ago for IRMAA calculation
-	lookbackYear := currentYear - 2
-	lookbackMAGI := se.magiHistory[lookbackYear] // Will be 0 if not available
+	// Record IRMAA MAGI (AGI + tax-exempt interest) for the premiums two years out,
+	// even when taxes are disabled
+	se.magiHistory[currentYear] = currentYearMAGI + se.taxExemptIncomeYTD
 
-	// Calculate Medicare premiums and IRMAA (for age 65+)
-	totalMedicarePremium, partBPremium, partDPremium := se.taxCalculator.CalculateIRMAAEnhanced(
-		currentYearMAGI, lookbackMAGI, currentYear, age)
-
-	// Store individual premium components for detailed healthcare reporting
-	// These will be used in the Healthcare tab of the Focus Year View
-	_ = partBPremium // Part B premium for UI display
-	_ = partDPremium // Part D premium for UI display
+	// Skip the rest of the year-end tax calculation when taxes are disabled
+	if se.taxesDisabled {
+		taxYear := monthOffset / 12
+		se.resetTaxYTD(taxYear)
+		return nil
+	}
 
 	// Calculate and pay annual taxes
 	simLogVerbose("🎯 [TAX-CALCULATION] ProcessAnnualTaxes called: ordinaryIncome=$%.2f, capitalGains=$%.2f, dividends=$%.2f",
@@ -3150,18 +3381,34 @@ func (se *SimulationEngine) ProcessAnnualTaxes(accounts *AccountHoldingsMonthEnd
 		se.selfEmploymentIncomeYTD,
 	)
 
+	// The state return applies its Social Security and retirement-income rules, which need
+	// the year's pensions, distributions and the filers' ages, and is apportioned in a
+	// year the household moved
+	stateIncomeTax := se.stateIncomeTax(StateIncome{
+		Ordinary:                adjustedOrdinaryIncome - taxableSocialSecurity,
+		TaxableSocialSecurity:   taxableSocialSecurity,
+		Pensions:                se.pensionIncomeYTD,
+		RetirementDistributions: se.retirementDistributionsYTD + se.rothConversionsYTD,
+		ShortTermCapitalGains:   se.shortTermCapitalGainsYTD,
+		LongTermCapitalGains:    se.longTermCapitalGainsYTD + se.qualifiedDividendsYTD,
+		FilerAges:               se.filerAges(monthOffset),
+	})
+	taxResult.TotalTax += stateIncomeTax - taxResult.StateIncomeTax
+	taxResult.StateIncomeTax = stateIncomeTax
+
 	simLogVerbose("🎯 [TAX-RESULT] Tax calculation completed: TotalTax=$%.2f, FederalTax=$%.2f, StateTax=$%.2f",
 		taxResult.TotalTax, taxResult.FederalIncomeTax, taxResult.StateIncomeTax)
 
-	// TODO: Integrate ACA Premium Tax Credit for early retirees (age < 65)
-	// CalculateACASubsidy() exists in tax.go but is not called here.
-	// For ages 50-65, ACA subsidies can reduce healthcare costs by $500-1500/month.
-	// Would need: healthcare expense events, MAGI-based subsidy calculation, FPL lookups.
+	// Settle the year's advance premium tax credit against the credit on actual MAGI
+	se.reconcilePremiumTaxCredit(currentYear, currentYearMAGI, taxableSocialSecurity, &taxResult)
+
+	// Medicare premiums are paid monthly as healthcare expenses; report the year's IRMAA surcharge
+	taxResult.IRMAAPremium = se.irmaaSurchargeYTD
 
-	// Add Medicare premiums to tax liability (annual cost)
-	annualMedicareCost := totalMedicarePremium * 12
-	taxResult.IRMAAPremium = annualMedicareCost
-	taxResult.TotalTax += annualMedicareCost
+	// Additional taxes on non-qualified distributions are owed with the return
+	taxResult.PenaltyTax = se.penaltyTaxYTD
+	taxResult.TotalTax += se.penaltyTaxYTD
+	se.lifetimeTax += taxResult.TotalTax
 
 	// Store tax calculation results for MonthlyData
 	se.lastTaxCalculationResults = &taxResult
@@ -3177,6 +3424,28 @@ func (se *SimulationEngine) ProcessAnnualTaxes(accounts *AccountHoldingsMonthEnd
 	return nil
 }
 
+// filerAges returns the ages of the living filers on the year's return
+func (se *SimulationEngine) filerAges(monthOffset int) []int {
+	if se.household == nil {
+		return []int{se.primaryAge(monthOffset)}
+	}
+	ages := make([]int, 0, len(se.household.members))
+	for i := range se.household.members {
+		if se.household.alive(i, monthOffset) {
+			ages = append(ages, se.household.ageAt(i, monthOffset))
+		}
+	}
+	return ages
+}
+
+// taxStateCode returns the state whose income tax a simulation charges
+func taxStateCode(input *SimulationInput) string {
+	if input.TaxConfig != nil && input.TaxConfig.State != "" {
+		return input.TaxConfig.State
+	}
+	return GetDefaultTaxConfigDetailed().State
+}
+
 // processRMDs handles Required Minimum Distributions
 func (se *SimulationEngine) processRMDs(accounts *AccountHoldingsMonthEnd, age int) error {
 	taxDeferredAccount := GetTaxDeferredAccount(accounts)
@@ -3213,6 +3482,7 @@ func (se *SimulationEngine) processRMDs(accounts *AccountHoldingsMonthEnd, age i
 		// Add remaining RMD to cash and ordinary income
 		accounts.Cash += withdrawalAmount
 		se.ordinaryIncomeYTD += withdrawalAmount
+		se.retirementDistributionsYTD += withdrawalAmount
 	}
 
 	return nil
@@ -3457,19 +3727,20 @@ func (se *SimulationEngine) ProcessCapitalGains(amount float64) {
 }
 
 // enforcePreTaxContributionLimits enforces 401k/403b/Traditional IRA contribution limits
-func (se *SimulationEngine) enforcePreTaxContributionLimits(contributionAmount *float64, currentMonth int) float64 {
+func (se *SimulationEngine) enforcePreTaxContributionLimits(contributionAmount *float64, currentMonth int, owner string) float64 {
 	// Use comprehensive contribution limit tracker
 	// Note: Age should be set externally before calling this function
 	// Note: Reset is handled in ProcessAnnualTaxes (December) or manually for testing
 	// Check if contribution is allowed and get capped amount
-	maxAllowed := se.contributionLimitTracker.GetMaxAllowedContribution("tax_deferred", *contributionAmount)
+	tracker := se.contributionTrackerFor(owner, currentMonth)
+	maxAllowed := tracker.GetMaxAllowedContribution("tax_deferred", *contributionAmount)
 
 	if maxAllowed <= 0 {
 		// Already at limit, route everything to taxable
 		excess := *contributionAmount
 		*contributionAmount = 0
-		ytd := se.contributionLimitTracker.GetYTDContribution("tax_deferred")
-		limit := se.contributionLimitTracker.GetLimit("tax_deferred")
+		ytd := tracker.GetYTDContribution("tax_deferred")
+		limit := tracker.GetLimit("tax_deferred")
 		simLogVerbose("🔍 PRE-TAX LIMIT EXCEEDED: YTD=$%.0f, Limit=$%.0f, Requested=$%.0f, All excess",
 			ytd, limit, excess)
 		return excess
@@ -3477,9 +3748,9 @@ func (se *SimulationEngine) enforcePreTaxContributionLimits(contributionAmount *
 
 	if maxAllowed >= *contributionAmount {
 		// Within limit, track contribution and no excess
-		se.contributionLimitTracker.TrackContribution("tax_deferred", *contributionAmount)
-		ytd := se.contributionLimitTracker.GetYTDContribution("tax_deferred")
-		limit := se.contributionLimitTracker.GetLimit("tax_deferred")
+		tracker.TrackContribution("tax_deferred", *contributionAmount)
+		ytd := tracker.GetYTDContribution("tax_deferred")
+		limit := tracker.GetLimit("tax_deferred")
 		simLogVerbose("🔍 PRE-TAX WITHIN LIMIT: YTD=$%.0f, Limit=$%.0f, Contributing=$%.0f",
 			ytd, limit, *contributionAmount)
 		return 0
@@ -3488,28 +3759,29 @@ func (se *SimulationEngine) enforcePreTaxContributionLimits(contributionAmount *
 	// Partially exceeds limit
 	excess := *contributionAmount - maxAllowed
 	*contributionAmount = maxAllowed
-	se.contributionLimitTracker.TrackContribution("tax_deferred", maxAllowed)
-	ytd := se.contributionLimitTracker.GetYTDContribution("tax_deferred")
-	limit := se.contributionLimitTracker.GetLimit("tax_deferred")
+	tracker.TrackContribution("tax_deferred", maxAllowed)
+	ytd := tracker.GetYTDContribution("tax_deferred")
+	limit := tracker.GetLimit("tax_deferred")
 	simLogVerbose("🔍 PRE-TAX PARTIAL LIMIT: YTD=$%.0f, Limit=$%.0f, Contributing=$%.0f, Excess=$%.0f",
 		ytd, limit, *contributionAmount, excess)
 	return excess
 }
 
 // enforceRothContributionLimits enforces Roth IRA contribution limits
-func (se *SimulationEngine) enforceRothContributionLimits(contributionAmount *float64, currentMonth int) float64 {
+func (se *SimulationEngine) enforceRothContributionLimits(contributionAmount *float64, currentMonth int, owner string) float64 {
 	// Use comprehensive contribution limit tracker
 	// Note: Age should be set externally before calling this function
 	// Note: Reset is handled in ProcessAnnualTaxes (December) or manually for testing
 	// Note: "roth" account type uses IRA limits ($7k base + $1k catch-up at age 50+)
-	maxAllowed := se.contributionLimitTracker.GetMaxAllowedContribution("roth", *contributionAmount)
+	tracker := se.contributionTrackerFor(owner, currentMonth)
+	maxAllowed := tracker.GetMaxAllowedContribution("roth", *contributionAmount)
 
 	if maxAllowed <= 0 {
 		// Already at limit, route everything to taxable
 		excess := *contributionAmount
 		*contributionAmount = 0
-		ytd := se.contributionLimitTracker.GetYTDContribution("roth")
-		limit := se.contributionLimitTracker.GetLimit("roth")
+		ytd := tracker.GetYTDContribution("roth")
+		limit := tracker.GetLimit("roth")
 		simLogVerbose("🔍 ROTH LIMIT EXCEEDED: YTD=$%.0f, Limit=$%.0f, Requested=$%.0f, All excess",
 			ytd, limit, excess)
 		return excess
@@ -3517,9 +3789,9 @@ func (se *SimulationEngine) enforceRothContributionLimits(contributionAmount *fl
 
 	if maxAllowed >= *contributionAmount {
 		// Within limit, track contribution and no excess
-		se.contributionLimitTracker.TrackContribution("roth", *contributionAmount)
-		ytd := se.contributionLimitTracker.GetYTDContribution("roth")
-		limit := se.contributionLimitTracker.GetLimit("roth")
+		tracker.TrackContribution("roth", *contributionAmount)
+		ytd := tracker.GetYTDContribution("roth")
+		limit := tracker.GetLimit("roth")
 		simLogVerbose("🔍 ROTH WITHIN LIMIT: YTD=$%.0f, Limit=$%.0f, Contributing=$%.0f",
 			ytd, limit, *contributionAmount)
 		return 0
@@ -3528,14 +3800,44 @@ func (se *SimulationEngine) enforceRothContributionLimits(contributionAmount *fl
 	// Partially exceeds limit
 	excess := *contributionAmount - maxAllowed
 	*contributionAmount = maxAllowed
-	se.contributionLimitTracker.TrackContribution("roth", maxAllowed)
-	ytd := se.contributionLimitTracker.GetYTDContribution("roth")
-	limit := se.contributionLimitTracker.GetLimit("roth")
+	tracker.TrackContribution("roth", maxAllowed)
+	ytd := tracker.GetYTDContribution("roth")
+	limit := tracker.GetLimit("roth")
 	simLogVerbose("🔍 ROTH PARTIAL LIMIT: YTD=$%.0f, Limit=$%.0f, Contributing=$%.0f, Excess=$%.0f",
 		ytd, limit, *contributionAmount, excess)
 	return excess
 }
 
+// contributionTrackerFor returns the limit tracker for an event owner, rolled to the current year.
+// Single-person plans share the engine-wide tracker; household members each have their own.
+func (se *SimulationEngine) contributionTrackerFor(owner string, currentMonth int) *ContributionLimitTracker {
+	if se.household == nil {
+		if se.simulationInput != nil {
+			se.contributionLimitTracker.ResetForNewYear(se.simulationInput.StartYear + currentMonth/12)
+		}
+		return se.contributionLimitTracker
+	}
+	return se.household.contributionTracker(owner, currentMonth)
+}
+
+// taxThresholdRate is the annual rate tax thresholds are indexed at (-1 disables indexing)
+func (se *SimulationEngine) taxThresholdRate() float64 {
+	if se.config.TaxThresholdInflationRate == 0 {
+		return defaultTaxThresholdInflationRate
+	}
+	return se.config.TaxThresholdInflationRate
+}
+
+// recordTaxDeferredOwnership credits a tax-deferred contribution to its owner's share of the account
+func (se *SimulationEngine) recordTaxDeferredOwnership(owner string, amount float64, accounts *AccountHoldingsMonthEnd) {
+	if se.household == nil {
+		return
+	}
+	if taxDeferredAccount := GetTaxDeferredAccount(accounts); taxDeferredAccount != nil {
+		se.household.recordTaxDeferredContribution(owner, amount, taxDeferredAccount.TotalValue)
+	}
+}
+
 // resetTaxYTD resets year-to-date tax tracking
 func (se *SimulationEngine) resetTaxYTD(taxYear int) {
 	se.taxWithholdingYTD = 0
@@ -3553,6 +3855,13 @@ func (se *SimulationEngine) resetTaxYTD(taxYear int) {
 	se.qualifiedCharitableDistributionsYTD = 0
 	se.itemizedDeductibleInterestYTD = 0
 	se.preTaxContributionsYTD = 0
+	se.penaltyTaxYTD = 0
+	se.pensionIncomeYTD = 0
+	se.retirementDistributionsYTD = 0
+	se.rothConversionsYTD = 0
+	se.acaCoverage = acaCoverageYear{}
+	se.partYearResidences = nil
+	se.memberWagesYTD = [maxHouseholdMembers]float64{}
 
 	// Note: unpaidTaxLiability is NOT reset here
 	// It's set in December and paid in April, then reset to 0 in TAX_PAYMENT handler
@@ -3985,68 +4294,6 @@ func (se *SimulationEngine) processGeneralDebtPayment(accounts *AccountHoldingsM
 	}
 }
 
-// Add withdrawal strategy functions
-type WithdrawalStrategy string
-
-const (
-	ConstantWithdrawal  WithdrawalStrategy = "CONSTANT_WITHDRAWAL"
-	InflationAdjusted   WithdrawalStrategy = "INFLATION_ADJUSTED"
-	VPWWithdrawal       WithdrawalStrategy = "VPW_WITHDRAWAL"
-	GuardRailWithdrawal WithdrawalStrategy = "GUARDRAIL_WITHDRAWAL"
-)
-
-// Calculate withdrawal amount based on strategy
-func CalculateWithdrawalAmount(
-	strategy WithdrawalStrategy,
-	portfolioValue float64,
-	baseWithdrawalRate float64,
-	inflationRate float64,
-	lastWithdrawal float64,
-	config StochasticModelConfig,
-) float64 {
-	switch strategy {
-	case ConstantWithdrawal:
-		return portfolioValue * baseWithdrawalRate / 12 // Monthly withdrawal
-
-	case InflationAdjusted:
-		if lastWithdrawal > 0 {
-			return lastWithdrawal * (1 + inflationRate) // Adjust last withdrawal for inflation
-		}
-		return portfolioValue * baseWithdrawalRate / 12
-
-	case VPWWithdrawal:
-		// Variable Percentage Withdrawal - adjust based on portfolio performance
-		adjustedRate := baseWithdrawalRate * (1 + (inflationRate - 0.025)) // Adjust for inflation vs target
-		return portfolioValue * adjustedRate / 12
-
-	case GuardRailWithdrawal:
-		return calculateDynamicWithdrawal(portfolioValue, lastWithdrawal, config)
-
-	default:
-		return portfolioValue * baseWithdrawalRate / 12
-	}
-}
-
-// Calculate dynamic withdrawal with guardrails
-func calculateDynamicWithdrawal(portfolioValue float64, baseWithdrawalAmount float64, config StochasticModelConfig) float64 {
-	if portfolioValue <= 0 || baseWithdrawalAmount <= 0 {
-		return 0
-	}
-
-	currentWithdrawalRate := baseWithdrawalAmount / portfolioValue
-
-	if currentWithdrawalRate > config.Guardrails.UpperGuardrail {
-		// Spending cut triggered
-		return baseWithdrawalAmount * (1 - config.Guardrails.SpendingCutPct)
-	} else if currentWithdrawalRate < config.Guardrails.LowerGuardrail {
-		// Spending bonus triggered
-		return baseWithdrawalAmount * (1 + config.Guardrails.SpendingBonusPct)
-	}
-
-	// No adjustment needed
-	return baseWithdrawalAmount
-}
-
 // Mathematical helper functions are defined in math.go
 
 // Helper function to get string from metadata map
@@ -4411,6 +4658,7 @@ func (se *SimulationEngine) processManualRMD(event FinancialEvent, accounts *Acc
 
 		// RMD is taxable income
 		se.ProcessIncome(saleResult.TotalProceeds, false, 0)
+		se.retirementDistributionsYTD += saleResult.TotalProceeds
 	}
 }
 
@@ -4489,6 +4737,11 @@ func (se *SimulationEngine) processInvestmentContributionWithFIFO(accounts *Acco
 			accounts.Roth = &Account{Holdings: []Holding{}, TotalValue: 0}
 		}
 		targetAcct = accounts.Roth
+	case "hsa":
+		if accounts.HSA == nil {
+			accounts.HSA = &Account{Holdings: []Holding{}, TotalValue: 0}
+		}
+		targetAcct = accounts.HSA
 	default:
 		// Default to taxable
 		if accounts.Taxable == nil {
@@ -4502,6 +4755,9 @@ func (se *SimulationEngine) processInvestmentContributionWithFIFO(accounts *Acco
 		// Return error so caller can restore cash
 		return fmt.Errorf("failed to create holding: %w", err)
 	}
+	if targetAccount == "roth" {
+		se.rothContributionBasis += amount
+	}
 	simLogVerbose("INVESTMENT-CONTRIBUTION SUCCESS: account value now %.0f", targetAcct.TotalValue)
 	return nil
 }
@@ -4612,6 +4868,7 @@ func (se *SimulationEngine) ApplyImmediateWithdrawalTax(saleResult LotSaleResult
 
 		// Track as ordinary income for year-end tax calculation
 		se.ordinaryIncomeYTD += saleResult.TaxDeferredProceeds
+		se.retirementDistributionsYTD += saleResult.TaxDeferredProceeds
 		se.taxWithholdingYTD += taxDeferredTax
 
 		simLogVerbose("TAX-WITHHOLDING: Tax-deferred withdrawal $%.0f, withheld $%.0f (%.0f%%)",
@@ -4646,9 +4903,24 @@ func (se *SimulationEngine) ApplyImmediateWithdrawalTax(saleResult LotSaleResult
 		}
 	}
 
-	// Roth withdrawals: Tax-free, no withholding
+	// HSA withdrawals: tax-free against medical receipts, otherwise ordinary income plus the additional tax
+	if saleResult.HSAProceeds > 0 {
+		nonQualified, penalty := se.recordHSADistribution(saleResult.HSAProceeds, se.currentMonthOffset)
+		hsaTax := nonQualified*effectiveRate + penalty
+		totalTaxWithheld += hsaTax
+		se.taxWithholdingYTD += hsaTax
+		simLogVerbose("TAX-WITHHOLDING: HSA withdrawal $%.0f ($%.0f non-qualified), withheld $%.0f",
+			saleResult.HSAProceeds, nonQualified, hsaTax)
+	}
+
+	// Roth withdrawals: tax-free from contributions and seasoned conversions, otherwise the additional tax before 59½
 	if saleResult.RothProceeds > 0 {
-		simLogVerbose("TAX-WITHHOLDING: Roth withdrawal $%.0f, no tax withheld (tax-free)", saleResult.RothProceeds)
+		taxableEarnings, penalty := se.recordRothDistribution(saleResult.RothProceeds, se.currentMonthOffset)
+		rothTax := taxableEarnings*effectiveRate + penalty
+		totalTaxWithheld += rothTax
+		se.taxWithholdingYTD += rothTax
+		simLogVerbose("TAX-WITHHOLDING: Roth withdrawal $%.0f ($%.0f taxable earnings), withheld $%.0f",
+			saleResult.RothProceeds, taxableEarnings, rothTax)
 	}
 
 	// Cash: No tax
@@ -4824,6 +5096,8 @@ func (se *SimulationEngine) ProcessDebtPayments(accounts *AccountHoldingsMonthEn
 		// Keep liability if still has balance
 		if liability.CurrentPrincipalBalance > 0 && liability.TermRemainingMonths > 0 {
 			remainingLiabilities = append(remainingLiabilities, liability)
+		} else {
+			se.recordPaidOffLiability(liability)
 		}
 	}
 
@@ -1,7 +1,7 @@
 // Deterministic simulation mode - single path with constant growth rates
 // Provides detailed event-by-event tracing and monthly/yearly snapshots
 
-package main
+package engine
 
 import (
 	"fmt"
@@ -14,7 +14,8 @@ func RunDeterministicSimulation(input SimulationInput) DeterministicResults {
 	simLogVerbose("🎯 [DETERMINISTIC] Starting deterministic simulation")
 
 	// PFOS-E requires explicit seed for stochastic mode (reproducibility requirement)
-	if input.Config.SimulationMode == "stochastic" && input.Config.RandomSeed == 0 {
+	isSampledMode := input.Config.SimulationMode == "stochastic" || input.Config.SimulationMode == SimulationModeHistoricalBootstrap
+	if isSampledMode && input.Config.RandomSeed == 0 {
 		return DeterministicResults{
 			Success: false,
 			Error:   "Stochastic mode requires a non-zero RandomSeed for reproducibility (PFOS-E requirement)",
@@ -22,7 +23,7 @@ func RunDeterministicSimulation(input SimulationInput) DeterministicResults {
 	}
 
 	// Determine simulation mode: if seed is provided, use stochastic mode
-	isStochasticMode := input.Config.SimulationMode == "stochastic" && input.Config.RandomSeed != 0
+	isStochasticMode := isSampledMode && input.Config.RandomSeed != 0
 	if !isStochasticMode {
 		// Force deterministic mode by setting DebugDisableRandomness
 		input.Config.DebugDisableRandomness = true
@@ -76,16 +77,21 @@ func RunDeterministicSimulation(input SimulationInput) DeterministicResults {
 		EventTrace:                 eventTrace,
 		YearlyData:                 yearlyData,
 		FinalNetWorth:              result.FinalNetWorth,
+		FinalNetWorthReal:          result.FinalNetWorthReal,
 		IsBankrupt:                 result.IsBankrupt,
 		BankruptcyMonth:            result.BankruptcyMonth,
 		ComprehensiveMonthlyStates: comprehensiveStates,
 		SimulationMode:             input.Config.SimulationMode,
+		Spending:                   result.Spending,
 	}
 
 	// Add stochastic-specific fields
 	if isStochasticMode {
 		deterministicResult.Seed = input.Config.RandomSeed
 		deterministicResult.ModelDescription = "PCG32 seeded GARCH(1,1) with Student-t(5)"
+		if input.Config.SimulationMode == SimulationModeHistoricalBootstrap {
+			deterministicResult.ModelDescription = "PCG32 seeded stationary block bootstrap of historical months"
+		}
 		deterministicResult.RealizedPathVariables = engine.GetRealizedPathVariables()
 	}
 
@@ -161,6 +167,22 @@ func (se *SimulationEngine) runDeterministicSimulationWithTrace(input Simulation
 		accounts.Roth = &Account{TotalValue: 0, Holdings: make([]Holding, 0, 10)}
 	}
 
+	// The 529 and HSA are optional; initializeAccountsForQueue opens empty ones
+	if input.InitialAccounts.FiveTwoNine != nil {
+		accounts.FiveTwoNine = &Account{
+			TotalValue: input.InitialAccounts.FiveTwoNine.TotalValue,
+			Holdings:   input.InitialAccounts.FiveTwoNine.Holdings,
+		}
+		initializeMissingTaxLots(accounts.FiveTwoNine, 0)
+	}
+	if input.InitialAccounts.HSA != nil {
+		accounts.HSA = &Account{
+			TotalValue: input.InitialAccounts.HSA.TotalValue,
+			Holdings:   input.InitialAccounts.HSA.Holdings,
+		}
+		initializeMissingTaxLots(accounts.HSA, 0)
+	}
+
 	// Run simulation with event tracing
 	return se.runQueueSimulationLoopWithTrace(input, accounts)
 }
@@ -170,6 +192,17 @@ func (se *SimulationEngine) runQueueSimulationLoopWithTrace(input SimulationInpu
 	// Store simulation input
 	se.simulationInput = &input
 	se.taxesDisabled = input.TaxConfig == nil || !input.TaxConfig.Enabled
+	se.taxCalculator.SetThresholdIndex(se.thresholdIndex(input.StartYear))
+	se.taxCalculator.SetSimulationYear(input.StartYear, se.taxThresholdRate())
+	se.rothContributionBasis = initialRothContributionBasis(&input)
+	se.rothConversions = nil
+	se.acaCoverage = acaCoverageYear{}
+	se.socialSecurityClaims = nil
+	se.seedMAGIHistory(&input)
+	se.startResidence(taxStateCode(&input))
+	if err := se.startWithdrawalPolicy(&input); err != nil {
+		return SimulationResult{Success: false, Error: err.Error()}, nil, nil
+	}
 
 	// Create and populate the event queue
 	eventQueue := PreprocessAndPopulateQueue(input)
@@ -231,6 +264,7 @@ func (se *SimulationEngine) runQueueSimulationLoopWithTrace(input SimulationInpu
 				// Do NOT call ProcessDebtPayments here - it would double-count payments
 
 				currentMonthData.NetWorth = se.calculateNetWorth(accounts)
+				se.setRealNetWorth(currentMonthData)
 				currentMonthData.Accounts = se.deepCopyAccounts(accounts)
 
 				// Copy monthly flows
@@ -241,6 +275,7 @@ func (se *SimulationEngine) runQueueSimulationLoopWithTrace(input SimulationInpu
 				currentMonthData.DivestmentProceedsThisMonth = se.currentMonthFlows.DivestmentProceedsThisMonth
 				currentMonthData.TaxWithheldThisMonth = se.currentMonthFlows.TaxWithheldThisMonth
 				currentMonthData.TaxesPaidThisMonth = se.currentMonthFlows.TaxesPaidThisMonth
+				currentMonthData.RothConversionAmountThisMonth = se.currentMonthFlows.RothConversionAmountThisMonth
 
 				// Copy market returns for investment growth calculation
 				if se.currentMonthReturns != nil {
@@ -334,6 +369,7 @@ func (se *SimulationEngine) runQueueSimulationLoopWithTrace(input SimulationInpu
 	// Save final month's data
 	if currentMonthData != nil {
 		currentMonthData.NetWorth = se.calculateNetWorth(accounts)
+		se.setRealNetWorth(currentMonthData)
 		currentMonthData.Accounts = se.deepCopyAccounts(accounts)
 		currentMonthData.IncomeThisMonth = se.currentMonthFlows.IncomeThisMonth
 		currentMonthData.ExpensesThisMonth = se.currentMonthFlows.ExpensesThisMonth
@@ -363,17 +399,22 @@ func (se *SimulationEngine) runQueueSimulationLoopWithTrace(input SimulationInpu
 
 	// Build result
 	isBankrupt := bankruptcyMonth > 0
-	finalNetWorth := 0.0
+	finalNetWorth, finalNetWorthReal, finalPriceIndex := 0.0, 0.0, 1.0
 	if len(monthlyDataList) > 0 {
-		finalNetWorth = monthlyDataList[len(monthlyDataList)-1].NetWorth
+		last := monthlyDataList[len(monthlyDataList)-1]
+		finalNetWorth, finalNetWorthReal, finalPriceIndex = last.NetWorth, last.NetWorthReal, last.PriceIndex
 	}
 
 	result := SimulationResult{
-		Success:         true,
-		MonthlyData:     monthlyDataList,
-		FinalNetWorth:   finalNetWorth,
-		IsBankrupt:      isBankrupt,
-		BankruptcyMonth: bankruptcyMonth,
+		Success:           true,
+		MonthlyData:       monthlyDataList,
+		FinalNetWorth:     finalNetWorth,
+		FinalNetWorthReal: finalNetWorthReal,
+		FinalPriceIndex:   finalPriceIndex,
+		IsBankrupt:        isBankrupt,
+		BankruptcyMonth:   bankruptcyMonth,
+		Spending:          se.pathSpending(),
+		LifetimeTax:       se.lifetimeTax,
 	}
 	if isBankrupt {
 		result.BankruptcyTrigger = bankruptcyTrigger
@@ -431,6 +472,8 @@ func convertToMonthSnapshots(monthlyData []MonthlyDataSimulation, input Simulati
 			Age:           age,
 
 			NetWorth:           md.NetWorth,
+			NetWorthReal:       md.NetWorthReal,
+			PriceIndex:         md.PriceIndex,
 			CashBalance:        md.Accounts.Cash,
 			TaxableBalance:     getAccountValue(md.Accounts.Taxable),
 			TaxDeferredBalance: getAccountValue(md.Accounts.TaxDeferred),
@@ -491,6 +534,7 @@ func aggregateToYearlyData(monthlySnapshots []DeterministicMonthSnapshot, input
 		yd := yearMap[year]
 		yd.Months = append(yd.Months, *ms)
 		yd.EndNetWorth = ms.NetWorth
+		yd.EndNetWorthReal = ms.NetWorthReal
 		yd.TotalIncome += ms.IncomeThisMonth
 		yd.TotalExpenses += ms.ExpensesThisMonth
 		yd.TotalTaxes += ms.TaxesThisMonth
@@ -1,4 +1,4 @@
-package main
+package engine
 
 import (
 	"math"
@@ -124,13 +124,19 @@ func (calc *SocialSecurityCalculator) CalculateMonthlyBenefit(
 
 // getAdjustmentFactor returns benefit adjustment factor for claiming age vs FRA
 func (calc *SocialSecurityCalculator) getAdjustmentFactor(claimingAge int, fra int) float64 {
-	if claimingAge == fra {
+	return calc.adjustmentFactorForMonths((claimingAge - fra) * 12)
+}
+
+// adjustmentFactorForMonths returns the benefit adjustment factor for claiming
+// monthsFromFRA months after FRA (negative = early)
+func (calc *SocialSecurityCalculator) adjustmentFactorForMonths(monthsFromFRA int) float64 {
+	if monthsFromFRA == 0 {
 		return 1.0 // 100% at FRA
 	}
 
-	if claimingAge < fra {
+	if monthsFromFRA < 0 {
 		// Early claiming reduction
-		monthsEarly := (fra - claimingAge) * 12
+		monthsEarly := -monthsFromFRA
 
 		// First 36 months: 5/9 of 1% per month
 		// After 36 months: 5/12 of 1% per month
@@ -145,9 +151,8 @@ func (calc *SocialSecurityCalculator) getAdjustmentFactor(claimingAge int, fra i
 		return 1.0 - reduction
 	}
 
-	// Delayed claiming (after FRA)
-	yearsDelayed := claimingAge - fra
-	delayedCredit := float64(yearsDelayed) * calc.delayedCreditPerYear
+	// Delayed claiming (after FRA): 2/3 of 1% per month
+	delayedCredit := float64(monthsFromFRA) * calc.delayedCreditPerYear / 12
 	return 1.0 + delayedCredit
 }
 
@@ -451,6 +456,23 @@ func (calc *SocialSecurityCalculator) GetFullRetirementAge(birthYear int) int {
 	return 67
 }
 
+// GetFullRetirementAgeMonths returns FRA in months, including the two-month steps
+// for birth years 1938-1942 and 1955-1959
+func (calc *SocialSecurityCalculator) GetFullRetirementAgeMonths(birthYear int) int {
+	switch {
+	case birthYear <= 1937:
+		return 65 * 12
+	case birthYear <= 1942:
+		return 65*12 + 2*(birthYear-1937)
+	case birthYear <= 1954:
+		return 66 * 12
+	case birthYear <= 1959:
+		return 66*12 + 2*(birthYear-1954)
+	default:
+		return 67 * 12
+	}
+}
+
 // CalculateCoordinatedStrategy calculates optimal claiming for married couple
 func (calc *SocialSecurityCalculator) CalculateCoordinatedStrategy(
 	primaryProfile SocialSecurityProfile,
@@ -0,0 +1,318 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+/**
+ * Social Security Claims
+ *
+ * SOCIAL_SECURITY_CLAIM derives the benefit from an earnings record instead of taking
+ * a monthly amount from the user's SSA statement. Metadata:
+ *   - earningsHistory: {"<year>": earnings} with the covered earnings of past years
+ *   - or currentSalary with yearsWorked (default: every year since 22); past years are
+ *     estimated at the same place in the wage distribution as the current salary
+ *   - projectedYears: future years at currentSalary, growing with average wages
+ *     (default 0), on top of either of the above
+ *   - birthYear (default: the owner's, else StartYear - InitialAge), birthMonth (1-12)
+ *   - claimingAge: 62-70, fractions allowed (default: full retirement age)
+ *   - trustFundDepletionYear, trustFundHaircut: from that calendar year benefits are
+ *     cut by the haircut (default 0.23, the 2024 Trustees Report's payable share)
+ *   - owner: household member
+ *
+ * The PIA follows the SSA formula in the wage levels of the benefit-formula year:
+ * each year's earnings are capped at that year's taxable maximum and indexed by
+ * average wage growth, the highest 35 years are averaged into the AIME, and the bend
+ * points give the PIA. It is then expressed in simulation start-year dollars, and
+ * benefits grow from there with the path's inflation (COLA, set each January). Real
+ * wage growth between now and eligibility and post-claim earnings are not credited.
+ *
+ * The benefit is the PIA times the early-claiming reduction or delayed retirement
+ * credits for the claiming month. Before full retirement age the earnings test runs
+ * against the owner's simulated wages: at year end $1 of every $2 above the limit
+ * ($1 of $3 above the higher limit, counting wages before FRA, in the FRA year) is
+ * withheld from that year's benefits. December's Social Security income is net of the
+ * year's withholding. At FRA the benefit is recomputed as if claimed later by the
+ * months withheld.
+ *
+ * When the owner dies, the surviving member's benefit steps up to the survivor
+ * benefit on this record, as for SOCIAL_SECURITY_INCOME.
+ *
+ * References:
+ * - SSA "Primary Insurance Amount" and "Benefits Planner: Retirement Earnings Test"
+ * - 2024 OASDI Trustees Report (combined trust fund reserves depleted in 2035)
+ */
+
+const (
+	ssBenefitFormulaYear      = 2024   // Bend points and taxable maximum below
+	ssTaxableMaximum          = 168600 // Contribution and benefit base
+	ssWageGrowthRate          = 0.035  // Average wage index growth
+	ssComputationYears        = 35
+	ssCareerStartAge          = 22
+	ssDefaultTrustFundHaircut = 0.23
+)
+
+// socialSecurityClaim is one SOCIAL_SECURITY_CLAIM's benefit on a simulation path
+type socialSecurityClaim struct {
+	id         string
+	member     int
+	pia        float64 // Monthly, start-year dollars
+	claimMonth int
+	fraMonth   int
+
+	depletionYear int
+	haircut       float64
+
+	// Earnings test: benefits paid before FRA this year, wages before the FRA month,
+	// and the months of benefits withheld so far
+	paidBeforeFRAYTD   float64
+	monthsBeforeFRAYTD int
+	wagesBeforeFRA     float64
+	withheldMonths     int
+}
+
+// estimatePrimaryInsuranceAmount returns the monthly PIA in startYear dollars from
+// nominal covered earnings by calendar year
+func estimatePrimaryInsuranceAmount(calc *SocialSecurityCalculator, earnings map[int]float64, startYear int) float64 {
+	indexed := make([]float64, 0, len(earnings))
+	for year, amount := range earnings {
+		if amount <= 0 {
+			continue
+		}
+		wageLevel := math.Pow(1+ssWageGrowthRate, float64(year-ssBenefitFormulaYear))
+		indexed = append(indexed, math.Min(amount, ssTaxableMaximum*wageLevel)/wageLevel)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(indexed)))
+	if len(indexed) > ssComputationYears {
+		indexed = indexed[:ssComputationYears]
+	}
+
+	total := 0.0
+	for _, amount := range indexed {
+		total += amount
+	}
+	aime := total / (ssComputationYears * 12)
+	return calc.EstimatePIAFromAIME(aime, ssBenefitFormulaYear) *
+		math.Pow(1+ssWageGrowthRate, float64(startYear-ssBenefitFormulaYear))
+}
+
+// claimEarnings reads a claim's earnings record from its metadata
+func claimEarnings(metadata map[string]interface{}, birthYear, startYear int) (map[int]float64, error) {
+	earnings := make(map[int]float64)
+	if history, ok := metadata["earningsHistory"].(map[string]interface{}); ok {
+		for key, value := range history {
+			year, err := strconv.Atoi(key)
+			amount, isNumber := value.(float64)
+			if err != nil || !isNumber {
+				return nil, fmt.Errorf("earningsHistory entry %q: want a year and an amount", key)
+			}
+			earnings[year] = amount
+		}
+	}
+
+	salary := getFloat64FromMetadata(metadata, "currentSalary", 0)
+	if salary > 0 {
+		wageLevel := func(year int) float64 {
+			return math.Pow(1+ssWageGrowthRate, float64(year-startYear))
+		}
+		if len(earnings) == 0 {
+			yearsWorked := int(getFloat64FromMetadata(metadata, "yearsWorked", float64(startYear-birthYear-ssCareerStartAge)))
+			for year := startYear - yearsWorked; year < startYear; year++ {
+				earnings[year] = salary * wageLevel(year)
+			}
+		}
+		projectedYears := int(getFloat64FromMetadata(metadata, "projectedYears", 0))
+		for year := startYear; year < startYear+projectedYears; year++ {
+			earnings[year] = salary * wageLevel(year)
+		}
+	}
+
+	if len(earnings) == 0 {
+		return nil, fmt.Errorf("an earningsHistory or currentSalary is required")
+	}
+	return earnings, nil
+}
+
+// newSocialSecurityClaim derives a claim's PIA, claiming month and FRA month
+func (se *SimulationEngine) newSocialSecurityClaim(event FinancialEvent) (*socialSecurityClaim, error) {
+	calc := NewSocialSecurityCalculator()
+	startYear := se.calendarYear(0)
+
+	member := 0
+	birthYear := startYear
+	if se.household != nil {
+		member = se.household.memberIndex(getStringFromMetadata(event.Metadata, "owner", ""))
+		birthYear = se.household.members[member].BirthYear
+	} else if se.simulationInput != nil {
+		birthYear = startYear - se.simulationInput.InitialAge
+	}
+	birthYear = int(getFloat64FromMetadata(event.Metadata, "birthYear", float64(birthYear)))
+	birthMonth := int(getFloat64FromMetadata(event.Metadata, "birthMonth", 1))
+	if birthMonth < 1 || birthMonth > 12 {
+		return nil, fmt.Errorf("birthMonth %d must be 1-12", birthMonth)
+	}
+
+	fra := calc.GetFullRetirementAgeMonths(birthYear)
+	claimingAge := getFloat64FromMetadata(event.Metadata, "claimingAge", float64(fra)/12)
+	if claimingAge < 62 || claimingAge > 70 {
+		return nil, fmt.Errorf("claimingAge %.1f must be between 62 and 70", claimingAge)
+	}
+
+	earnings, err := claimEarnings(event.Metadata, birthYear, startYear)
+	if err != nil {
+		return nil, err
+	}
+
+	birthMonthOffset := (birthYear-startYear)*12 + birthMonth - 1
+	return &socialSecurityClaim{
+		id:            event.ID,
+		member:        member,
+		pia:           estimatePrimaryInsuranceAmount(calc, earnings, startYear),
+		claimMonth:    birthMonthOffset + int(math.Round(claimingAge*12)),
+		fraMonth:      birthMonthOffset + fra,
+		depletionYear: int(getFloat64FromMetadata(event.Metadata, "trustFundDepletionYear", 0)),
+		haircut:       getFloat64FromMetadata(event.Metadata, "trustFundHaircut", ssDefaultTrustFundHaircut),
+	}, nil
+}
+
+// socialSecurityClaim returns the path's state for a claim event, creating it on first use
+func (se *SimulationEngine) socialSecurityClaim(event FinancialEvent) (*socialSecurityClaim, error) {
+	for _, claim := range se.socialSecurityClaims {
+		if claim.id == event.ID {
+			return claim, nil
+		}
+	}
+	claim, err := se.newSocialSecurityClaim(event)
+	if err != nil {
+		return nil, err
+	}
+	se.socialSecurityClaims = append(se.socialSecurityClaims, claim)
+	return claim, nil
+}
+
+// benefit returns the monthly benefit on the record in monthOffset for a claim starting
+// in claimMonth: the PIA with the claiming adjustment, COLAs and any trust fund haircut
+func (c *socialSecurityClaim) benefit(se *SimulationEngine, calc *SocialSecurityCalculator, claimMonth, monthOffset int) float64 {
+	year := se.calendarYear(monthOffset)
+	benefit := c.pia * calc.adjustmentFactorForMonths(claimMonth-c.fraMonth) * se.colaIndex(monthOffset)
+	if c.depletionYear > 0 && year >= c.depletionYear {
+		benefit *= 1 - c.haircut
+	}
+	return benefit
+}
+
+// effectiveClaimMonth is the claiming month the benefit is computed from: at FRA, months
+// withheld under the earnings test move it later
+func (c *socialSecurityClaim) effectiveClaimMonth(monthOffset int) int {
+	if c.claimMonth >= c.fraMonth || monthOffset < c.fraMonth {
+		return c.claimMonth
+	}
+	if delayed := c.claimMonth + c.withheldMonths; delayed < c.fraMonth {
+		return delayed
+	}
+	return c.fraMonth
+}
+
+// survivorBenefitBase is the deceased's benefit a survivor benefit is figured from. A worker
+// who died before claiming counts at the PIA, plus delayed credits earned after FRA.
+func (c *socialSecurityClaim) survivorBenefitBase(se *SimulationEngine, calc *SocialSecurityCalculator, deathMonth, monthOffset int) float64 {
+	if c.claimMonth < deathMonth {
+		return c.benefit(se, calc, c.effectiveClaimMonth(monthOffset), monthOffset)
+	}
+	return c.benefit(se, calc, max(deathMonth, c.fraMonth), monthOffset)
+}
+
+// recordWages adds earned income to its owner's wages for the earnings test
+func (se *SimulationEngine) recordWages(metadata map[string]interface{}, amount float64) {
+	member := 0
+	if se.household != nil {
+		member = se.household.memberIndex(getStringFromMetadata(metadata, "owner", ""))
+	}
+	se.memberWagesYTD[member] += amount
+}
+
+// settleSocialSecurityEarningsTest withholds the year's excess-earnings reduction from
+// benefits paid before full retirement age. Runs in December.
+func (se *SimulationEngine) settleSocialSecurityEarningsTest(accounts *AccountHoldingsMonthEnd, monthOffset int) {
+	calc := NewSocialSecurityCalculator()
+	year := se.calendarYear(monthOffset)
+	for _, claim := range se.socialSecurityClaims {
+		paid, months := claim.paidBeforeFRAYTD, claim.monthsBeforeFRAYTD
+		claim.paidBeforeFRAYTD, claim.monthsBeforeFRAYTD = 0, 0
+		if paid <= 0 {
+			continue
+		}
+
+		wages, limit, ratio := se.memberWagesYTD[claim.member], calc.earningsTestLimitBeforeFRA, 0.5
+		if year >= se.calendarYear(claim.fraMonth) {
+			wages, limit, ratio = claim.wagesBeforeFRA, calc.earningsTestLimitFRAYear, 1.0/3.0
+		}
+		limit *= indexFactor(year-ssBenefitFormulaYear, ssWageGrowthRate)
+		withheld := math.Min((wages-limit)*ratio, paid)
+		if withheld <= 0 {
+			continue
+		}
+
+		// SSA withholds whole months, so any partly withheld month is credited back at FRA
+		claim.withheldMonths += int(math.Ceil(withheld/paid*float64(months) - 1e-9))
+		accounts.Cash -= withheld
+		se.socialSecurityBenefitsYTD -= withheld
+		se.currentMonthFlows.IncomeThisMonth -= withheld
+		se.currentMonthFlows.SocialSecurityIncomeThisMonth -= withheld
+
+		simLogEvent("INFO  [Month %d] Earnings test: %s | Wages: $%.2f over $%.2f | Withheld: $%.2f",
+			monthOffset, claim.id, wages, limit, withheld)
+	}
+}
+
+// SocialSecurityClaimEventHandler pays the benefit derived from an earnings record from
+// the claiming month on. It runs every month so the claim can start mid-plan and switch
+// to survivor benefits.
+type SocialSecurityClaimEventHandler struct{}
+
+func (h *SocialSecurityClaimEventHandler) Process(event FinancialEvent, accounts *AccountHoldingsMonthEnd, cashFlow *float64, context *EventProcessingContext) error {
+	se := context.SimulationEngine
+	m := context.CurrentMonth
+
+	claim, err := se.socialSecurityClaim(event)
+	if err != nil {
+		return fmt.Errorf("social security claim %s: %w", event.ID, err)
+	}
+	calc := NewSocialSecurityCalculator()
+
+	var amount float64
+	if se.household != nil && !se.household.alive(claim.member, m) {
+		s := se.household.survivor(claim.member, m)
+		if s < 0 {
+			return nil
+		}
+		deceased := se.household.members[claim.member]
+		base := claim.survivorBenefitBase(se, calc, *deceased.DeathMonthOffset, m)
+		survivorFRA := calc.GetFullRetirementAge(se.household.members[s].BirthYear)
+		amount = calc.CalculateSurvivorBenefit(base, se.household.ageAt(s, m), survivorFRA) - se.household.lastSSBenefit[s]
+	} else {
+		if m == claim.fraMonth {
+			claim.wagesBeforeFRA = se.memberWagesYTD[claim.member]
+		}
+		if m < claim.claimMonth {
+			return nil
+		}
+		amount = claim.benefit(se, calc, claim.effectiveClaimMonth(m), m)
+		if m < claim.fraMonth {
+			claim.paidBeforeFRAYTD += amount
+			claim.monthsBeforeFRAYTD++
+		}
+		if se.household != nil {
+			se.household.lastSSBenefit[claim.member] = amount
+		}
+	}
+	if amount <= 0 {
+		return nil
+	}
+
+	payment := FinancialEvent{ID: event.ID, Type: string(EventTypeSocialSecurityIncome), Amount: amount}
+	return (&SocialSecurityIncomeEventHandler{}).Process(payment, accounts, cashFlow, context)
+}
@@ -0,0 +1,181 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPrimaryInsuranceAmountFromEarnings(t *testing.T) {
+	calc := NewSocialSecurityCalculator()
+
+	// 35 years above the taxable maximum: AIME is the 2024 maximum of $14,050
+	maxEarner := make(map[int]float64)
+	for year := 1990; year < 2025; year++ {
+		maxEarner[year] = 1e6
+	}
+	want := 1174*0.90 + (7078-1174)*0.32 + (14050-7078)*0.15
+	if got := estimatePrimaryInsuranceAmount(calc, maxEarner, 2024); math.Abs(got-want) > 0.01 {
+		t.Errorf("maximum earner PIA %.2f, want %.2f", got, want)
+	}
+
+	// Only the highest 35 years count, and zero years pull the average down
+	maxEarner[1985] = 1000
+	if got := estimatePrimaryInsuranceAmount(calc, maxEarner, 2024); math.Abs(got-want) > 0.01 {
+		t.Errorf("36th year changed the PIA: %.2f", got)
+	}
+	short := map[int]float64{2020: 1e6, 2021: 1e6, 2022: 1e6, 2023: 1e6, 2024: 1e6}
+	if got := estimatePrimaryInsuranceAmount(calc, short, 2024); math.Abs(got-(1174*0.90+(14050*5.0/35-1174)*0.32)) > 0.01 {
+		t.Errorf("five-year career PIA %.2f", got)
+	}
+
+	// Expressed in start-year dollars
+	if got := estimatePrimaryInsuranceAmount(calc, maxEarner, 2026); math.Abs(got-want*1.035*1.035) > 0.01 {
+		t.Errorf("2026 PIA %.2f, want %.2f", got, want*1.035*1.035)
+	}
+
+	// A salary history is estimated at the same place in the wage distribution
+	earnings, err := claimEarnings(map[string]interface{}{"currentSalary": 80000.0, "yearsWorked": 3.0, "projectedYears": 2.0}, 1980, 2025)
+	if err != nil || len(earnings) != 5 || math.Abs(earnings[2023]-80000/1.035/1.035) > 0.01 || math.Abs(earnings[2026]-80000*1.035) > 0.01 {
+		t.Errorf("salary history %v (%v)", earnings, err)
+	}
+	if _, err := claimEarnings(map[string]interface{}{"earningsHistory": map[string]interface{}{"last year": 50000.0}}, 1980, 2025); err == nil {
+		t.Error("expected an error for a non-year key")
+	}
+}
+
+func TestClaimingAdjustmentByMonth(t *testing.T) {
+	calc := NewSocialSecurityCalculator()
+	if got := calc.GetFullRetirementAgeMonths(1957); got != 66*12+6 {
+		t.Errorf("FRA for 1957: %d months", got)
+	}
+	if got := calc.GetFullRetirementAgeMonths(1963); got != 67*12 {
+		t.Errorf("FRA for 1963: %d months", got)
+	}
+	cases := map[int]float64{-60: 0.70, -36: 0.80, -6: 1 - 6*5.0/900, 0: 1, 18: 1.12, 36: 1.24}
+	for months, want := range cases {
+		if got := calc.adjustmentFactorForMonths(months); math.Abs(got-want) > 1e-9 {
+			t.Errorf("%d months from FRA: %.4f, want %.4f", months, got, want)
+		}
+	}
+	if calc.getAdjustmentFactor(62, 67) != calc.adjustmentFactorForMonths(-60) {
+		t.Error("yearly and monthly adjustment factors disagree")
+	}
+}
+
+func TestSocialSecurityClaimInSimulation(t *testing.T) {
+	run := func(claim map[string]interface{}, events ...FinancialEvent) []MonthlyDataSimulation {
+		input := createRollingTestInput(0, 0)
+		input.Config.RandomSeed = 5
+		input.InitialAge = 62
+		input.StartYear = 2025
+		input.MonthsToRun = 96
+		input.InitialAccounts = AccountHoldingsMonthEnd{Cash: 100000}
+		metadata := map[string]interface{}{"currentSalary": 60000.0, "yearsWorked": 40.0}
+		for k, v := range claim {
+			metadata[k] = v
+		}
+		input.Events = append([]FinancialEvent{{ID: "ss", Type: "SOCIAL_SECURITY_CLAIM", Frequency: "monthly", Metadata: metadata}}, events...)
+
+		result := NewSimulationEngine(input.Config).RunSingleSimulation(input)
+		if !result.Success {
+			t.Fatalf("simulation failed: %s", result.Error)
+		}
+		return result.MonthlyData
+	}
+	benefit := func(months []MonthlyDataSimulation, m int) float64 {
+		return months[m].SocialSecurityIncomeThisMonth
+	}
+
+	// Born January 1963: 62 at the start, FRA (67) in month 60, 70 in month 96
+	early := run(map[string]interface{}{"claimingAge": 62.0})
+	atFRA := run(map[string]interface{}{"claimingAge": 67.0})
+	if benefit(early, 0) <= 0 || benefit(atFRA, 59) != 0 || benefit(atFRA, 60) <= 0 {
+		t.Fatalf("claim months: %.2f at 62, %.2f/%.2f around FRA", benefit(early, 0), benefit(atFRA, 59), benefit(atFRA, 60))
+	}
+	if got := benefit(early, 70) / benefit(atFRA, 70); math.Abs(got-0.70) > 1e-6 {
+		t.Errorf("claiming at 62 pays %.4f of the FRA benefit, want 0.70", got)
+	}
+
+	// Still earning $60,000: the earnings test withholds a year's worth, credited back at FRA
+	salary := FinancialEvent{ID: "salary", Type: "INCOME", Amount: 5000, Frequency: "monthly", Metadata: map[string]interface{}{"endDateOffset": 12.0}}
+	working := run(map[string]interface{}{"claimingAge": 62.0}, salary)
+	yearBenefits := func(months []MonthlyDataSimulation) float64 {
+		total := 0.0
+		for m := 0; m < 12; m++ {
+			total += benefit(months, m)
+		}
+		return total
+	}
+	withheld := yearBenefits(early) - yearBenefits(working)
+	if want := (60000 - 22320*1.035) / 2; math.Abs(withheld-want) > 0.01 {
+		t.Errorf("earnings test withheld %.2f, want %.2f", withheld, want)
+	}
+	if got := benefit(working, 60) / benefit(early, 60); math.Abs(got-0.75/0.70) > 1e-6 {
+		t.Errorf("benefit after FRA recomputation %.4f of the unreduced claim, want %.4f", got, 0.75/0.70)
+	}
+	if benefit(working, 59) != benefit(early, 59) {
+		t.Error("recomputation should wait until FRA")
+	}
+
+	// Trust fund depletion cuts benefits from the given year
+	haircut := run(map[string]interface{}{"claimingAge": 62.0, "trustFundDepletionYear": 2028.0})
+	if benefit(haircut, 35) != benefit(early, 35) || math.Abs(benefit(haircut, 36)/benefit(early, 36)-0.77) > 1e-6 {
+		t.Errorf("trust fund haircut: %.2f / %.2f", benefit(haircut, 36), benefit(early, 36))
+	}
+}
+
+func TestSocialSecurityClaimDefaultStartYear(t *testing.T) {
+	event := FinancialEvent{ID: "ss", Type: "SOCIAL_SECURITY_CLAIM", Metadata: map[string]interface{}{
+		"currentSalary": 60000.0, "yearsWorked": 40.0, "claimingAge": 62.0,
+	}}
+	claim := func(startYear int) *socialSecurityClaim {
+		se := NewSimulationEngine(GetDefaultStochasticConfig())
+		se.simulationInput = &SimulationInput{InitialAge: 60, StartYear: startYear}
+		c, err := se.newSocialSecurityClaim(event)
+		if err != nil {
+			t.Fatalf("newSocialSecurityClaim (start year %d): %v", startYear, err)
+		}
+		return c
+	}
+
+	// Without a start year the birth year, wage scaling and claim month follow the
+	// fixed default, not the date the test runs
+	unset, fixed := claim(0), claim(defaultStartYear)
+	if unset.pia != fixed.pia || unset.claimMonth != fixed.claimMonth || unset.fraMonth != fixed.fraMonth {
+		t.Errorf("claim without a start year (PIA %.2f, months %d/%d) differs from %d (PIA %.2f, months %d/%d)",
+			unset.pia, unset.claimMonth, unset.fraMonth, defaultStartYear, fixed.pia, fixed.claimMonth, fixed.fraMonth)
+	}
+	if unset.claimMonth != 24 {
+		t.Errorf("a 60-year-old claiming at 62 should claim in month 24, got %d", unset.claimMonth)
+	}
+}
+
+func TestSocialSecurityClaimSurvivor(t *testing.T) {
+	input := createRollingTestInput(0, 0)
+	input.Config.RandomSeed = 9
+	input.StartYear = 2025
+	input.MonthsToRun = 36
+	input.InitialAccounts = AccountHoldingsMonthEnd{Cash: 100000}
+	death := 12
+	input.Household = &Household{Members: []HouseholdMember{
+		{ID: "primary", BirthYear: 1957, DeathMonthOffset: &death},
+		{ID: "spouse", BirthYear: 1957},
+	}}
+	input.Events = []FinancialEvent{
+		{ID: "ss-primary", Type: "SOCIAL_SECURITY_CLAIM", Frequency: "monthly", Metadata: map[string]interface{}{
+			"owner": "primary", "claimingAge": 68.0, "currentSalary": 150000.0}},
+		{ID: "ss-spouse", Type: "SOCIAL_SECURITY_CLAIM", Frequency: "monthly", Metadata: map[string]interface{}{
+			"owner": "spouse", "claimingAge": 68.0, "currentSalary": 30000.0}},
+	}
+
+	result := NewSimulationEngine(input.Config).RunSingleSimulation(input)
+	if !result.Success {
+		t.Fatalf("simulation failed: %s", result.Error)
+	}
+	before, after := result.MonthlyData[11].SocialSecurityIncomeThisMonth, result.MonthlyData[12].SocialSecurityIncomeThisMonth
+
+	// Both claimed at 68; after the death the survivor keeps the larger benefit
+	if after >= before || after <= before/2 {
+		t.Errorf("household benefits %.2f before the death, %.2f after", before, after)
+	}
+}
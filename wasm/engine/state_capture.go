@@ -64,6 +64,9 @@ func (se *SimulationEngine) CaptureComprehensiveMonthState(
 		HSA:         captureAccountState(accounts.HSA),
 		FiveTwoNine: captureAccountState(accounts.FiveTwoNine),
 
+		// 529 balances by beneficiary
+		FiveTwoNinePlans: se.captureFiveTwoNineState(&accounts),
+
 		// Aggregates
 		NetWorth:         netWorth,
 		TotalAssets:      totalAssets,
@@ -172,6 +175,14 @@ func (se *SimulationEngine) captureTaxState() ComprehensiveTaxState {
 		TaxWithholdingYTD:    se.taxWithholdingYTD,
 		EstimatedPaymentsYTD: se.estimatedPaymentsYTD,
 
+		// Additional taxes and HSA receipts
+		PenaltyTaxYTD:           se.penaltyTaxYTD,
+		HSAUnreimbursedReceipts: se.hsaReceipts,
+
+		// Roth ordering-rule basis and conversions on the five-year clock
+		RothContributionBasis: se.rothContributionBasis,
+		RothConversions:       se.captureRothConversionRungs(),
+
 		// Accrual tracking
 		UnpaidTaxLiability: se.unpaidTaxLiability,
 
@@ -253,11 +264,11 @@ func (se *SimulationEngine) estimateEffectiveTaxRate() float64 {
 
 // captureLiabilityState captures all debt/liability state
 func (se *SimulationEngine) captureLiabilityState(calendarYear, calendarMonth int) []ComprehensiveLiabilityState {
-	if len(se.liabilities) == 0 {
+	if len(se.liabilities) == 0 && len(se.paidOffLiabilities) == 0 {
 		return nil
 	}
 
-	states := make([]ComprehensiveLiabilityState, 0, len(se.liabilities))
+	states := make([]ComprehensiveLiabilityState, 0, len(se.liabilities)+len(se.paidOffLiabilities))
 	for _, liability := range se.liabilities {
 		if liability == nil {
 			continue
@@ -308,6 +319,11 @@ func (se *SimulationEngine) captureLiabilityState(calendarYear, calendarMonth in
 			TotalInterestRemaining: totalInterestRemaining,
 			PayoffDate:             calculatePayoffDate(calendarYear, calendarMonth, liability.TermRemainingMonths),
 
+			// Refinance/prepayment comparison
+			InterestSavedVsSchedule: liability.InterestSaved,
+			RefinanceCosts:          liability.RefinanceCosts,
+			NetSavingsVsSchedule:    liability.InterestSaved - liability.RefinanceCosts,
+
 			// Flags
 			IsTaxDeductible: liability.IsTaxDeductible,
 			IsActive:        liability.CurrentPrincipalBalance > 0,
@@ -315,6 +331,21 @@ func (se *SimulationEngine) captureLiabilityState(calendarYear, calendarMonth in
 		states = append(states, state)
 	}
 
+	// Paid-off loans keep reporting what their refinances and prepayments saved
+	for _, liability := range se.paidOffLiabilities {
+		states = append(states, ComprehensiveLiabilityState{
+			LiabilityID:             liability.ID,
+			Name:                    liability.Name,
+			Type:                    liability.Type,
+			InterestRate:            liability.InterestRate,
+			InterestSavedVsSchedule: liability.InterestSaved,
+			RefinanceCosts:          liability.RefinanceCosts,
+			NetSavingsVsSchedule:    liability.InterestSaved - liability.RefinanceCosts,
+			IsTaxDeductible:         liability.IsTaxDeductible,
+			IsActive:                false,
+		})
+	}
+
 	return states
 }
 
@@ -0,0 +1,370 @@
+package engine
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"sync"
+)
+
+/**
+ * State Income Tax Calculator
+ *
+ * Calculates state income tax for all 50 states plus DC from config/state_taxes_2025.json:
+ *
+ * - Marginal brackets by filing status (flat-tax states have one bracket; TX, FL, NV, WY,
+ *   SD, AK, TN, NH and WA have none). Head of household and married filing separately
+ *   use the single tables
+ * - Standard deductions, personal and dependent exemptions, and exemption credits
+ * - Social Security: most states exempt it; the rest tax the federally taxable amount,
+ *   some only below an AGI limit or before an age (CO, CT, MN, MT, NM, RI, UT, VT, WV)
+ * - Retirement income: pensions and IRA/401(k) distributions exempt outright (IL, PA at
+ *   59½, IA at 55, ...) or up to an age-tiered exclusion per person or per return
+ *   (GA $35,000/$65,000, NY $20,000, NJ $75,000/$100,000, ...)
+ * - Capital gains: partial long-term exclusions (AR, ND, SC, VT, WI, ...), a long-term
+ *   rate cap (HI, MT), MA's 8.5% short-term rate and WA's separate 7% / 9.9% tax on
+ *   long-term gains above its deduction
+ *
+ * Published amounts are for 2025. For later years the tax calculator scales every dollar
+ * amount by its threshold inflation rate (taxing income/f at 2025 law and multiplying
+ * by f), much as most states index their brackets. Local income taxes are not modeled.
+ *
+ * References:
+ * - Tax Foundation, State Individual Income Tax Rates and Brackets, 2025
+ * - State revenue department 2025 forms and instructions
+ */
+
+// StateTaxBracket is a marginal rate that applies from IncomeMin to the next bracket's IncomeMin
+type StateTaxBracket struct {
+	IncomeMin float64 `json:"min"`
+	Rate      float64 `json:"rate"` // Marginal tax rate as decimal (e.g., 0.093 for 9.3%)
+}
+
+// StateFilingAmounts is a dollar amount by filing status; MarriedJointly defaults to Single
+type StateFilingAmounts struct {
+	Single         float64 `json:"single"`
+	MarriedJointly float64 `json:"marriedJointly"`
+}
+
+func (a StateFilingAmounts) forStatus(status FilingStatus) float64 {
+	if status == FilingStatusMarriedJointly && a.MarriedJointly != 0 {
+		return a.MarriedJointly
+	}
+	return a.Single
+}
+
+// StateBrackets are a state's ordinary income brackets by filing status
+type StateBrackets struct {
+	Single         []StateTaxBracket `json:"single"`
+	MarriedJointly []StateTaxBracket `json:"marriedJointly"`
+}
+
+func (b StateBrackets) forStatus(status FilingStatus) []StateTaxBracket {
+	if status == FilingStatusMarriedJointly && len(b.MarriedJointly) > 0 {
+		return b.MarriedJointly
+	}
+	return b.Single
+}
+
+// StateSocialSecurityRules describe how much of the federally taxable benefit a state taxes
+type StateSocialSecurityRules struct {
+	Taxable        bool               `json:"taxable"`
+	ExemptFromAge  float64            `json:"exemptFromAge"`  // 0 = no age exemption
+	ExemptBelowAGI StateFilingAmounts `json:"exemptBelowAGI"` // 0 = no income exemption
+}
+
+// taxed returns the part of the federally taxable benefit the state taxes
+func (r StateSocialSecurityRules) taxed(taxable, agi float64, ages []int, status FilingStatus) float64 {
+	if !r.Taxable {
+		return 0
+	}
+	byAge, byAGI := r.ExemptFromAge > 0, r.ExemptBelowAGI.Single > 0
+	if !byAge && !byAGI {
+		return taxable
+	}
+	if (!byAge || anyFilerReached(ages, r.ExemptFromAge)) && (!byAGI || agi < r.ExemptBelowAGI.forStatus(status)) {
+		return 0
+	}
+	return taxable
+}
+
+// StateRetirementExclusion is the retirement income excluded from fromAge, per person or per return
+type StateRetirementExclusion struct {
+	FromAge   float64            `json:"fromAge"`
+	PerPerson float64            `json:"perPerson"`
+	PerReturn StateFilingAmounts `json:"perReturn"`
+}
+
+// StateRetirementIncomeRules describe a state's pension and IRA/401(k) exemptions
+type StateRetirementIncomeRules struct {
+	PensionsExempt      bool                       `json:"pensionsExempt"`
+	DistributionsExempt bool                       `json:"distributionsExempt"`
+	ExemptFromAge       float64                    `json:"exemptFromAge"` // 0 = no age exemption
+	Exclusions          []StateRetirementExclusion `json:"exclusions"`
+}
+
+// excluded returns the retirement income (and taxed Social Security) the state doesn't tax
+func (r StateRetirementIncomeRules) excluded(income StateIncome, taxedSocialSecurity float64, status FilingStatus) float64 {
+	pensions, distributions := income.Pensions, income.RetirementDistributions
+	exempt := 0.0
+	byAge := r.ExemptFromAge > 0 && anyFilerReached(income.FilerAges, r.ExemptFromAge)
+	if r.PensionsExempt || byAge {
+		exempt += pensions
+		pensions = 0
+	}
+	if r.DistributionsExempt || byAge {
+		exempt += distributions
+		distributions = 0
+	}
+
+	// Each filer gets the largest tier reached; a per-return tier applies once
+	perPerson, perReturn := 0.0, 0.0
+	for _, age := range agesOrUnknown(income.FilerAges) {
+		best := 0.0
+		for _, tier := range r.Exclusions {
+			if float64(age) < tier.FromAge {
+				continue
+			}
+			best = math.Max(best, tier.PerPerson)
+			perReturn = math.Max(perReturn, tier.PerReturn.forStatus(status))
+		}
+		perPerson += best
+	}
+	eligible := pensions + distributions + taxedSocialSecurity
+	return exempt + math.Min(eligible, math.Max(perPerson, perReturn))
+}
+
+// StateCapitalGainsRules describe a state's treatment of capital gains
+type StateCapitalGainsRules struct {
+	LongTermExclusion float64           `json:"longTermExclusion"` // Fraction of long-term gains excluded
+	LongTermMaxRate   float64           `json:"longTermMaxRate"`   // 0 = taxed at ordinary rates
+	ShortTermRate     float64           `json:"shortTermRate"`     // 0 = taxed with ordinary income
+	LongTermRates     []StateTaxBracket `json:"longTermRates"`     // Separate tax on long-term gains alone
+}
+
+// StateTaxConfig holds complete tax configuration for a state
+type StateTaxConfig struct {
+	StateCode string `json:"-"`
+	StateName string `json:"name"`
+
+	Brackets           StateBrackets      `json:"brackets"`
+	StandardDeduction  StateFilingAmounts `json:"standardDeduction"`
+	PersonalExemption  float64            `json:"personalExemption"` // Per filer
+	DependentExemption float64            `json:"dependentExemption"`
+	PersonalCredit     float64            `json:"personalCredit"` // Per filer
+	DependentCredit    float64            `json:"dependentCredit"`
+
+	SocialSecurity   StateSocialSecurityRules   `json:"socialSecurity"`
+	RetirementIncome StateRetirementIncomeRules `json:"retirementIncome"`
+	CapitalGains     StateCapitalGainsRules     `json:"capitalGains"`
+}
+
+// HasIncomeTax reports whether the state taxes wages and retirement income
+func (c StateTaxConfig) HasIncomeTax() bool {
+	return len(c.Brackets.Single) > 0
+}
+
+// StateIncome is a year's income as a state return sees it
+type StateIncome struct {
+	Ordinary                float64 // Wages, interest, pensions and distributions; excludes Social Security
+	TaxableSocialSecurity   float64 // Federally taxable benefit
+	Pensions                float64 // Included in Ordinary
+	RetirementDistributions float64 // IRA/401(k) distributions and Roth conversions, included in Ordinary
+	ShortTermCapitalGains   float64
+	LongTermCapitalGains    float64 // Including qualified dividends
+	FilerAges               []int
+	Dependents              int
+}
+
+// scaled returns the income with every dollar amount multiplied by factor
+func (i StateIncome) scaled(factor float64) StateIncome {
+	i.Ordinary *= factor
+	i.TaxableSocialSecurity *= factor
+	i.Pensions *= factor
+	i.RetirementDistributions *= factor
+	i.ShortTermCapitalGains *= factor
+	i.LongTermCapitalGains *= factor
+	return i
+}
+
+// StateTaxCalculator calculates state income tax
+type StateTaxCalculator struct {
+	stateConfigs map[string]StateTaxConfig
+	baseYear     int // Tax year of the published amounts
+}
+
+// stateTaxesFile is the layout of config/state_taxes_<year>.json
+type stateTaxesFile struct {
+	Metadata struct {
+		TaxYear int `json:"taxYear"`
+	} `json:"_metadata"`
+	States map[string]StateTaxConfig `json:"states"`
+}
+
+var (
+	stateTaxesData *stateTaxesFile
+	stateTaxesErr  error
+	stateTaxesOnce sync.Once
+)
+
+// loadStateTaxes parses the embedded state tax file once
+func loadStateTaxes() (*stateTaxesFile, error) {
+	stateTaxesOnce.Do(func() {
+		data, err := embeddedConfigs.ReadFile("config/state_taxes_2025.json")
+		if err != nil {
+			stateTaxesErr = err
+			return
+		}
+		var file stateTaxesFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			stateTaxesErr = err
+			return
+		}
+		for code, config := range file.States {
+			config.StateCode = code
+			file.States[code] = config
+		}
+		stateTaxesData = &file
+	})
+	return stateTaxesData, stateTaxesErr
+}
+
+// NewStateTaxCalculator creates a calculator with all state configurations
+func NewStateTaxCalculator() *StateTaxCalculator {
+	file, err := loadStateTaxes()
+	if err != nil {
+		panic("CRITICAL: State tax data not available: " + err.Error())
+	}
+	return &StateTaxCalculator{stateConfigs: file.States, baseYear: file.Metadata.TaxYear}
+}
+
+// StateConfig returns a state's tax configuration by two-letter code
+func (calc *StateTaxCalculator) StateConfig(stateCode string) (StateTaxConfig, bool) {
+	config, exists := calc.stateConfigs[strings.ToUpper(stateCode)]
+	return config, exists
+}
+
+// CalculateIncomeTax returns a year's state income tax at the published amounts
+func (calc *StateTaxCalculator) CalculateIncomeTax(income StateIncome, stateCode string, filingStatus FilingStatus) float64 {
+	config, exists := calc.StateConfig(stateCode)
+	if !exists {
+		// Unknown state - return 0 (fail gracefully)
+		return 0
+	}
+
+	longTerm := math.Max(0, income.LongTermCapitalGains)
+	shortTerm := math.Max(0, income.ShortTermCapitalGains)
+
+	// Taxes levied on capital gains apart from other income
+	separateTax := 0.0
+	if len(config.CapitalGains.LongTermRates) > 0 {
+		separateTax += calculateStateBracketTax(longTerm, config.CapitalGains.LongTermRates)
+		longTerm = 0
+	}
+	if !config.HasIncomeTax() {
+		return separateTax
+	}
+	if config.CapitalGains.ShortTermRate > 0 {
+		separateTax += shortTerm * config.CapitalGains.ShortTermRate
+		shortTerm = 0
+	}
+	longTerm *= 1 - config.CapitalGains.LongTermExclusion
+
+	agi := income.Ordinary + income.TaxableSocialSecurity + income.ShortTermCapitalGains + income.LongTermCapitalGains
+	socialSecurity := config.SocialSecurity.taxed(income.TaxableSocialSecurity, agi, income.FilerAges, filingStatus)
+	excluded := config.RetirementIncome.excluded(income, socialSecurity, filingStatus)
+
+	filers := 1.0
+	if filingStatus == FilingStatusMarriedJointly {
+		filers = 2
+	}
+	deductions := config.StandardDeduction.forStatus(filingStatus) +
+		config.PersonalExemption*filers + config.DependentExemption*float64(income.Dependents)
+
+	brackets := config.Brackets.forStatus(filingStatus)
+	otherIncome := income.Ordinary + socialSecurity - excluded + shortTerm
+	tax := calculateStateBracketTax(math.Max(0, otherIncome+longTerm-deductions), brackets)
+	if config.CapitalGains.LongTermMaxRate > 0 && longTerm > 0 {
+		baseTax := calculateStateBracketTax(math.Max(0, otherIncome-deductions), brackets)
+		tax = baseTax + math.Min(tax-baseTax, longTerm*config.CapitalGains.LongTermMaxRate)
+	}
+
+	credits := config.PersonalCredit*filers + config.DependentCredit*float64(income.Dependents)
+	return math.Max(0, tax-credits) + separateTax
+}
+
+// CalculateIncomeTaxForYear returns a year's state income tax with the published amounts
+// indexed from the base year at indexRate
+func (calc *StateTaxCalculator) CalculateIncomeTaxForYear(income StateIncome, stateCode string, filingStatus FilingStatus, year int, indexRate float64) float64 {
+	return calc.CalculateIncomeTaxIndexed(income, stateCode, filingStatus, year, RateIndex(indexRate))
+}
+
+// CalculateIncomeTaxIndexed returns a year's state income tax with the published amounts
+// carried from the base year by index
+func (calc *StateTaxCalculator) CalculateIncomeTaxIndexed(income StateIncome, stateCode string, filingStatus FilingStatus, year int, index ThresholdIndex) float64 {
+	factor := index.Factor(calc.baseYear, year)
+	return factor * calc.CalculateIncomeTax(income.scaled(1/factor), stateCode, filingStatus)
+}
+
+// CalculateStateTax calculates total state income tax liability on ordinary income and
+// long-term capital gains
+func (calc *StateTaxCalculator) CalculateStateTax(
+	ordinaryIncome float64,
+	capitalGains float64,
+	stateCode string,
+	filingStatus FilingStatus,
+	numDependents int,
+) float64 {
+	return calc.CalculateIncomeTax(StateIncome{
+		Ordinary:             ordinaryIncome,
+		LongTermCapitalGains: capitalGains,
+		Dependents:           numDependents,
+	}, stateCode, filingStatus)
+}
+
+// calculateStateBracketTax applies marginal brackets whose tops are the next bracket's minimum
+func calculateStateBracketTax(income float64, brackets []StateTaxBracket) float64 {
+	var tax float64
+	for i, bracket := range brackets {
+		if income <= bracket.IncomeMin {
+			break
+		}
+		top := income
+		if i+1 < len(brackets) && brackets[i+1].IncomeMin < top {
+			top = brackets[i+1].IncomeMin
+		}
+		tax += (top - bracket.IncomeMin) * bracket.Rate
+	}
+	return tax
+}
+
+// GetEffectiveStateRate returns effective tax rate for a given income level
+func (calc *StateTaxCalculator) GetEffectiveStateRate(
+	income float64,
+	stateCode string,
+	filingStatus FilingStatus,
+) float64 {
+	if income <= 0 {
+		return 0
+	}
+	tax := calc.CalculateStateTax(income, 0, stateCode, filingStatus, 0)
+	return tax / income
+}
+
+// agesOrUnknown returns the ages to apply age rules to: one filer of unknown age by default
+func agesOrUnknown(ages []int) []int {
+	if len(ages) == 0 {
+		return []int{0}
+	}
+	return ages
+}
+
+// anyFilerReached reports whether a filer has reached an age
+func anyFilerReached(ages []int, age float64) bool {
+	for _, filerAge := range ages {
+		if float64(filerAge) >= age {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStateTaxDataCoversAllStates(t *testing.T) {
+	calc := NewStateTaxCalculator()
+	codes := []string{
+		"AL", "AK", "AZ", "AR", "CA", "CO", "CT", "DE", "DC", "FL", "GA", "HI", "ID", "IL", "IN", "IA", "KS",
+		"KY", "LA", "ME", "MD", "MA", "MI", "MN", "MS", "MO", "MT", "NE", "NV", "NH", "NJ", "NM", "NY", "NC",
+		"ND", "OH", "OK", "OR", "PA", "RI", "SC", "SD", "TN", "TX", "UT", "VT", "VA", "WA", "WV", "WI", "WY",
+	}
+	if len(calc.stateConfigs) != len(codes) {
+		t.Errorf("%d jurisdictions loaded, want %d", len(calc.stateConfigs), len(codes))
+	}
+	noIncomeTax := map[string]bool{"AK": true, "FL": true, "NV": true, "NH": true, "SD": true, "TN": true, "TX": true, "WA": true, "WY": true}
+	for _, code := range codes {
+		config, ok := calc.StateConfig(code)
+		if !ok || config.StateName == "" || config.StateCode != code {
+			t.Errorf("%s: missing or unnamed", code)
+			continue
+		}
+		if config.HasIncomeTax() == noIncomeTax[code] {
+			t.Errorf("%s: income tax %v", code, config.HasIncomeTax())
+		}
+		for _, brackets := range [][]StateTaxBracket{config.Brackets.Single, config.Brackets.MarriedJointly} {
+			for i := 1; i < len(brackets); i++ {
+				if brackets[i].IncomeMin <= brackets[i-1].IncomeMin {
+					t.Errorf("%s: brackets out of order at %v", code, brackets[i])
+				}
+			}
+		}
+	}
+	if _, ok := calc.StateConfig("ca"); !ok {
+		t.Error("state codes should be case-insensitive")
+	}
+}
+
+func TestStateRetirementIncomeRules(t *testing.T) {
+	calc := NewStateTaxCalculator()
+	retiree := func(pensions, distributions float64, ages ...int) StateIncome {
+		return StateIncome{Ordinary: pensions + distributions, Pensions: pensions, RetirementDistributions: distributions, FilerAges: ages}
+	}
+
+	// Illinois exempts retirement income at any age; Pennsylvania from 59½
+	if tax := calc.CalculateIncomeTax(retiree(40000, 40000, 50), "IL", FilingStatusSingle); tax != 0 {
+		t.Errorf("IL retirement income taxed: %.2f", tax)
+	}
+	if tax := calc.CalculateIncomeTax(retiree(0, 50000, 55), "PA", FilingStatusSingle); math.Abs(tax-1535) > 0.01 {
+		t.Errorf("PA at 55: %.2f, want 1535", tax)
+	}
+	if tax := calc.CalculateIncomeTax(retiree(0, 50000, 60), "PA", FilingStatusSingle); tax != 0 {
+		t.Errorf("PA at 60: %.2f, want 0", tax)
+	}
+
+	// Georgia's exclusion is per person by age tier: $65,000 each at 65, $35,000 at 62
+	joint := calc.CalculateIncomeTax(retiree(0, 120000, 66, 63), "GA", FilingStatusMarriedJointly)
+	if joint != 0 {
+		t.Errorf("GA couple at 66/63 excludes $100,000: tax %.2f", joint)
+	}
+	single := calc.CalculateIncomeTax(retiree(0, 100000, 66), "GA", FilingStatusSingle)
+	if want := (100000 - 65000 - 12000) * 0.0519; math.Abs(single-want) > 0.01 {
+		t.Errorf("GA single at 66: %.2f, want %.2f", single, want)
+	}
+
+	// New York excludes $20,000 from 59½: (30,000 - 20,000 - 8,000) at 4%
+	if tax := calc.CalculateIncomeTax(retiree(0, 30000, 60), "NY", FilingStatusSingle); math.Abs(tax-80) > 0.01 {
+		t.Errorf("NY at 60: %.2f, want 80", tax)
+	}
+
+	// Colorado taxes Social Security before 65, inside its $20,000 exclusion from 55
+	benefits := StateIncome{Ordinary: 30000, TaxableSocialSecurity: 20000, RetirementDistributions: 30000}
+	benefits.FilerAges = []int{60}
+	at60 := calc.CalculateIncomeTax(benefits, "CO", FilingStatusSingle)
+	benefits.FilerAges = []int{66}
+	at66 := calc.CalculateIncomeTax(benefits, "CO", FilingStatusSingle)
+	if math.Abs(at60-(50000-20000-15000)*0.044) > 0.01 || at66 != 0 {
+		t.Errorf("CO: %.2f at 60, %.2f at 66", at60, at66)
+	}
+
+	// Connecticut exempts Social Security below $75,000 AGI
+	below := calc.CalculateIncomeTax(StateIncome{Ordinary: 40000, TaxableSocialSecurity: 20000}, "CT", FilingStatusSingle)
+	above := calc.CalculateIncomeTax(StateIncome{Ordinary: 80000, TaxableSocialSecurity: 20000}, "CT", FilingStatusSingle)
+	if below != calc.CalculateIncomeTax(StateIncome{Ordinary: 40000}, "CT", FilingStatusSingle) ||
+		above != calc.CalculateIncomeTax(StateIncome{Ordinary: 100000}, "CT", FilingStatusSingle) {
+		t.Errorf("CT should tax benefits only above the AGI limit: %.2f below, %.2f above", below, above)
+	}
+}
+
+func TestStateCapitalGainsRules(t *testing.T) {
+	calc := NewStateTaxCalculator()
+
+	// Washington: no income tax, 7% on long-term gains above $278,000, 9.9% above another $1M
+	if tax := calc.CalculateIncomeTax(StateIncome{Ordinary: 500000, ShortTermCapitalGains: 100000}, "WA", FilingStatusSingle); tax != 0 {
+		t.Errorf("WA taxes wages or short-term gains: %.2f", tax)
+	}
+	tax := calc.CalculateIncomeTax(StateIncome{LongTermCapitalGains: 1500000}, "WA", FilingStatusSingle)
+	if want := 1000000*0.07 + 222000*0.099; math.Abs(tax-want) > 0.01 {
+		t.Errorf("WA $1.5M gains: %.2f, want %.2f", tax, want)
+	}
+
+	// Massachusetts taxes short-term gains at 8.5%, apart from its 5% on other income
+	if tax := calc.CalculateIncomeTax(StateIncome{ShortTermCapitalGains: 10000}, "MA", FilingStatusSingle); math.Abs(tax-850) > 0.01 {
+		t.Errorf("MA short-term gains: %.2f, want 850", tax)
+	}
+	if tax := calc.CalculateIncomeTax(StateIncome{LongTermCapitalGains: 10000}, "MA", FilingStatusSingle); math.Abs(tax-(10000-4400)*0.05) > 0.01 {
+		t.Errorf("MA long-term gains: %.2f", tax)
+	}
+
+	// Arizona excludes a quarter of long-term gains; Hawaii caps their rate at 7.25%
+	if tax := calc.CalculateIncomeTax(StateIncome{Ordinary: 50000, LongTermCapitalGains: 40000}, "AZ", FilingStatusSingle); math.Abs(tax-(50000+30000-15000)*0.025) > 0.01 {
+		t.Errorf("AZ: %.2f", tax)
+	}
+	base := calc.CalculateIncomeTax(StateIncome{Ordinary: 400000}, "HI", FilingStatusSingle)
+	withGains := calc.CalculateIncomeTax(StateIncome{Ordinary: 400000, LongTermCapitalGains: 100000}, "HI", FilingStatusSingle)
+	if math.Abs(withGains-base-7250) > 0.01 {
+		t.Errorf("HI gains taxed %.2f, want 7250", withGains-base)
+	}
+}
+
+func TestStateTaxIndexing(t *testing.T) {
+	calc := NewStateTaxCalculator()
+	income := StateIncome{Ordinary: 120000, LongTermCapitalGains: 20000}
+	published := calc.CalculateIncomeTax(income, "CA", FilingStatusSingle)
+	if got := calc.CalculateIncomeTaxForYear(income, "CA", FilingStatusSingle, 2025, 0.03); got != published {
+		t.Errorf("2025 should use the published amounts: %.2f vs %.2f", got, published)
+	}
+
+	// Ten years of 3% indexing: the same real income owes the same real tax
+	factor := math.Pow(1.03, 10)
+	later := calc.CalculateIncomeTaxForYear(income.scaled(factor), "CA", FilingStatusSingle, 2035, 0.03)
+	if math.Abs(later-published*factor) > 0.01 {
+		t.Errorf("2035 tax %.2f, want %.2f", later, published*factor)
+	}
+
+	tc := NewTaxCalculator(GetDefaultTaxConfigDetailed(), nil)
+	tc.SetState("ny")
+	tc.SetSimulationYear(2025, 0.025)
+	if got, want := tc.CalculateStateIncomeTax(100000), calc.CalculateIncomeTax(StateIncome{Ordinary: 100000}, "NY", FilingStatusSingle); got != want {
+		t.Errorf("tax calculator NY: %.2f, want %.2f", got, want)
+	}
+}
+
+func TestStateRetirementRulesInSimulation(t *testing.T) {
+	run := func(state string) float64 {
+		input := createRollingTestInput(0, 0)
+		input.Config.RandomSeed = 3
+		input.InitialAge = 66
+		input.StartYear = 2025
+		input.MonthsToRun = 12
+		input.InitialAccounts = AccountHoldingsMonthEnd{Cash: 100000}
+		input.TaxConfig = &SimpleTaxConfig{Enabled: true, State: state}
+		input.Events = []FinancialEvent{{ID: "pension", Type: "PENSION_INCOME", Amount: 5000, Frequency: "monthly"}}
+
+		result := NewSimulationEngine(input.Config).RunSingleSimulation(input)
+		if !result.Success {
+			t.Fatalf("%s: simulation failed: %s", state, result.Error)
+		}
+		december := result.MonthlyData[len(result.MonthlyData)-1]
+		if december.StateIncomeTaxAnnual == nil {
+			t.Fatalf("%s: no state tax reported", state)
+		}
+		return *december.StateIncomeTaxAnnual
+	}
+
+	// A $60,000 pension: Illinois exempts it; California taxes it; Texas has no income tax
+	il, ca, tx := run("IL"), run("CA"), run("TX")
+	if il > 100 || ca < 1500 || tx != 0 {
+		t.Errorf("state tax on a pension: IL %.2f, CA %.2f, TX %.2f", il, ca, tx)
+	}
+}
@@ -0,0 +1,295 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+)
+
+/**
+ * Sequence-of-Returns Stress Overlays
+ *
+ * StochasticModelConfig.StressScenarios scripts market shocks on top of the
+ * generated returns (parametric, bootstrap or deterministic), e.g. "a 2008-style
+ * crash in the first year of retirement, stochastic afterwards".
+ *
+ * Each shock has a start month, a drawdown phase and a recovery phase:
+ *   - Drawdown: over DurationMonths each asset loses (or gains) its AssetShocks
+ *     change in total, spread evenly in log terms
+ *   - Recovery: over RecoveryMonths the same change is reversed, spread by
+ *     RecoveryShape ("linear" evenly, "v" front-loaded, "u" back-loaded).
+ *     With no recovery months the loss is permanent
+ *   - Inflation: ExcessInflation (annual) is added for InflationMonths
+ *
+ * The overlay multiplies each month's generated return:
+ * (1 + r) * exp(adjustment) - 1. A shock with a full recovery leaves the asset's
+ * level unchanged once it ends, so only the order of returns changes - which is
+ * the sequence risk the overlay is meant to show. Overlapping shocks compound.
+ *
+ * Historical backtests replay real returns and ignore the overlay.
+ */
+
+// Built-in stress presets
+const (
+	StressPresetDotCom2000       = "dot_com_2000"
+	StressPresetGFC2008          = "gfc_2008"
+	StressPresetStagflation1970s = "stagflation_1970s"
+)
+
+// Recovery shapes
+const (
+	StressRecoveryLinear = "linear"
+	StressRecoveryV      = "v"
+	StressRecoveryU      = "u"
+)
+
+// stressPresets approximate the peak-to-trough total returns and recovery times
+// of each episode (S&P 500 total return, MSCI EAFE, Bloomberg US Aggregate,
+// Case-Shiller national index, CPI-U)
+var stressPresets = map[string]StressShock{
+	// Mar 2000 - Oct 2002 bear market; S&P 500 total return regained its peak in mid-2006
+	StressPresetDotCom2000: {
+		Name:           "2000 dot-com crash",
+		DurationMonths: 31,
+		RecoveryMonths: 44,
+		RecoveryShape:  StressRecoveryLinear,
+		AssetShocks: StressAssetShocks{
+			SPY:             -0.45,
+			Intl:            -0.48,
+			IndividualStock: -0.65,
+			Other:           -0.30,
+			BND:             0.20,
+		},
+	},
+	// Oct 2007 - Mar 2009; sharp rebound, total return regained its peak by Mar 2012
+	StressPresetGFC2008: {
+		Name:           "2008 global financial crisis",
+		DurationMonths: 17,
+		RecoveryMonths: 36,
+		RecoveryShape:  StressRecoveryV,
+		AssetShocks: StressAssetShocks{
+			SPY:             -0.51,
+			Intl:            -0.57,
+			IndividualStock: -0.60,
+			Other:           -0.45,
+			BND:             0.06,
+			Home:            -0.20,
+		},
+	},
+	// Jan 1973 - Sep 1974 bear market followed by a decade of high inflation
+	StressPresetStagflation1970s: {
+		Name:            "1970s stagflation",
+		DurationMonths:  21,
+		RecoveryMonths:  72,
+		RecoveryShape:   StressRecoveryU,
+		ExcessInflation: 0.05,
+		InflationMonths: 108,
+		AssetShocks: StressAssetShocks{
+			SPY:             -0.43,
+			Intl:            -0.35,
+			IndividualStock: -0.50,
+			Other:           -0.30,
+			BND:             -0.05,
+		},
+	},
+}
+
+// StressScenarioPresets returns the built-in stress presets by name
+func StressScenarioPresets() map[string]StressShock {
+	presets := make(map[string]StressShock, len(stressPresets))
+	for name, preset := range stressPresets {
+		preset.Preset = name
+		presets[name] = preset
+	}
+	return presets
+}
+
+// stressAdjustment is the log-return added to each asset (and the monthly
+// inflation added) in one month
+type stressAdjustment struct {
+	spy, bnd, intl, other, individualStock, home float64
+	inflation                                    float64
+}
+
+// resolveStressShock fills a shock's unset fields from its preset and checks it
+func resolveStressShock(shock StressShock) (StressShock, error) {
+	resolved := shock
+	if shock.Preset != "" {
+		preset, ok := stressPresets[shock.Preset]
+		if !ok {
+			return StressShock{}, fmt.Errorf("unknown stress preset %q", shock.Preset)
+		}
+		resolved = preset
+		resolved.Preset = shock.Preset
+		resolved.StartMonth = shock.StartMonth
+		if shock.Name != "" {
+			resolved.Name = shock.Name
+		}
+		if shock.DurationMonths != 0 {
+			resolved.DurationMonths = shock.DurationMonths
+		}
+		if shock.RecoveryMonths != 0 {
+			resolved.RecoveryMonths = shock.RecoveryMonths
+		}
+		if shock.RecoveryShape != "" {
+			resolved.RecoveryShape = shock.RecoveryShape
+		}
+		if shock.ExcessInflation != 0 {
+			resolved.ExcessInflation = shock.ExcessInflation
+		}
+		if shock.InflationMonths != 0 {
+			resolved.InflationMonths = shock.InflationMonths
+		}
+		overrideShock(&resolved.AssetShocks.SPY, shock.AssetShocks.SPY)
+		overrideShock(&resolved.AssetShocks.BND, shock.AssetShocks.BND)
+		overrideShock(&resolved.AssetShocks.Intl, shock.AssetShocks.Intl)
+		overrideShock(&resolved.AssetShocks.Other, shock.AssetShocks.Other)
+		overrideShock(&resolved.AssetShocks.IndividualStock, shock.AssetShocks.IndividualStock)
+		overrideShock(&resolved.AssetShocks.Home, shock.AssetShocks.Home)
+	}
+	if resolved.Name == "" {
+		resolved.Name = resolved.Preset
+	}
+	if resolved.RecoveryShape == "" {
+		resolved.RecoveryShape = StressRecoveryLinear
+	}
+	if resolved.InflationMonths == 0 {
+		resolved.InflationMonths = resolved.DurationMonths
+	}
+
+	if resolved.StartMonth < 0 {
+		return StressShock{}, fmt.Errorf("stress scenario %q: start month must not be negative: %d", resolved.Name, resolved.StartMonth)
+	}
+	if resolved.DurationMonths < 1 {
+		return StressShock{}, fmt.Errorf("stress scenario %q: duration must be at least 1 month: %d", resolved.Name, resolved.DurationMonths)
+	}
+	if resolved.RecoveryMonths < 0 || resolved.InflationMonths < 0 {
+		return StressShock{}, fmt.Errorf("stress scenario %q: recovery and inflation months must not be negative", resolved.Name)
+	}
+	switch resolved.RecoveryShape {
+	case StressRecoveryLinear, StressRecoveryV, StressRecoveryU:
+	default:
+		return StressShock{}, fmt.Errorf("stress scenario %q: unknown recovery shape %q (expected %q, %q or %q)",
+			resolved.Name, resolved.RecoveryShape, StressRecoveryLinear, StressRecoveryV, StressRecoveryU)
+	}
+	for _, change := range resolved.AssetShocks.values() {
+		if change <= -1 || math.IsNaN(change) || math.IsInf(change, 0) {
+			return StressShock{}, fmt.Errorf("stress scenario %q: asset shock must be greater than -100%%: %.4f", resolved.Name, change)
+		}
+	}
+	if resolved.ExcessInflation <= -1 || math.IsNaN(resolved.ExcessInflation) {
+		return StressShock{}, fmt.Errorf("stress scenario %q: invalid excess inflation %.4f", resolved.Name, resolved.ExcessInflation)
+	}
+	return resolved, nil
+}
+
+// overrideShock replaces a preset's asset shock when the caller set one
+func overrideShock(target *float64, value float64) {
+	if value != 0 {
+		*target = value
+	}
+}
+
+func (s StressAssetShocks) values() []float64 {
+	return []float64{s.SPY, s.BND, s.Intl, s.Other, s.IndividualStock, s.Home}
+}
+
+// ValidateStressScenarios checks every configured shock and its preset
+func ValidateStressScenarios(shocks []StressShock) error {
+	for _, shock := range shocks {
+		if _, err := resolveStressShock(shock); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildStressOverlay turns the configured shocks into per-month adjustments
+func buildStressOverlay(shocks []StressShock) (map[int]stressAdjustment, error) {
+	if len(shocks) == 0 {
+		return nil, nil
+	}
+	overlay := make(map[int]stressAdjustment)
+	for _, shock := range shocks {
+		resolved, err := resolveStressShock(shock)
+		if err != nil {
+			return nil, err
+		}
+		logShock := stressAdjustment{
+			spy:             math.Log1p(resolved.AssetShocks.SPY),
+			bnd:             math.Log1p(resolved.AssetShocks.BND),
+			intl:            math.Log1p(resolved.AssetShocks.Intl),
+			other:           math.Log1p(resolved.AssetShocks.Other),
+			individualStock: math.Log1p(resolved.AssetShocks.IndividualStock),
+			home:            math.Log1p(resolved.AssetShocks.Home),
+		}
+
+		for m := 0; m < resolved.DurationMonths; m++ {
+			month := resolved.StartMonth + m
+			overlay[month] = overlay[month].plus(logShock, 1/float64(resolved.DurationMonths))
+		}
+
+		weights := stressRecoveryWeights(resolved.RecoveryShape, resolved.RecoveryMonths)
+		for m, weight := range weights {
+			month := resolved.StartMonth + resolved.DurationMonths + m
+			overlay[month] = overlay[month].plus(logShock, -weight)
+		}
+
+		if resolved.ExcessInflation != 0 {
+			monthlyExcess := math.Pow(1+resolved.ExcessInflation, 1.0/12) - 1
+			for m := 0; m < resolved.InflationMonths; m++ {
+				month := resolved.StartMonth + m
+				adj := overlay[month]
+				adj.inflation += monthlyExcess
+				overlay[month] = adj
+			}
+		}
+	}
+	return overlay, nil
+}
+
+// stressRecoveryWeights splits a recovery over its months; the weights sum to 1
+func stressRecoveryWeights(shape string, months int) []float64 {
+	if months <= 0 {
+		return nil
+	}
+	weights := make([]float64, months)
+	total := 0.0
+	for m := range weights {
+		switch shape {
+		case StressRecoveryV:
+			weights[m] = float64(months - m)
+		case StressRecoveryU:
+			weights[m] = float64(m + 1)
+		default:
+			weights[m] = 1
+		}
+		total += weights[m]
+	}
+	for m := range weights {
+		weights[m] /= total
+	}
+	return weights
+}
+
+// plus adds scale times another adjustment's asset log-returns
+func (a stressAdjustment) plus(b stressAdjustment, scale float64) stressAdjustment {
+	a.spy += b.spy * scale
+	a.bnd += b.bnd * scale
+	a.intl += b.intl * scale
+	a.other += b.other * scale
+	a.individualStock += b.individualStock * scale
+	a.home += b.home * scale
+	return a
+}
+
+// apply overlays the adjustment on one month's generated returns
+func (a stressAdjustment) apply(r StochasticReturns) StochasticReturns {
+	r.SPY = (1+r.SPY)*math.Exp(a.spy) - 1
+	r.BND = (1+r.BND)*math.Exp(a.bnd) - 1
+	r.Intl = (1+r.Intl)*math.Exp(a.intl) - 1
+	r.Other = (1+r.Other)*math.Exp(a.other) - 1
+	r.IndividualStock = (1+r.IndividualStock)*math.Exp(a.individualStock) - 1
+	r.Home = (1+r.Home)*math.Exp(a.home) - 1
+	r.Inflation += a.inflation
+	return r
+}
@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStressOverlayShape(t *testing.T) {
+	overlay, err := buildStressOverlay([]StressShock{{Preset: StressPresetGFC2008, StartMonth: 12}})
+	if err != nil {
+		t.Fatalf("buildStressOverlay: %v", err)
+	}
+	preset := stressPresets[StressPresetGFC2008]
+
+	if _, ok := overlay[11]; ok {
+		t.Error("overlay should not touch months before the start")
+	}
+	drawdown := 0.0
+	for m := 12; m < 12+preset.DurationMonths; m++ {
+		drawdown += overlay[m].spy
+	}
+	if got := math.Exp(drawdown) - 1; math.Abs(got-preset.AssetShocks.SPY) > 1e-9 {
+		t.Errorf("cumulative SPY drawdown %.4f, want %.4f", got, preset.AssetShocks.SPY)
+	}
+
+	total := drawdown
+	recoveryStart := 12 + preset.DurationMonths
+	for m := recoveryStart; m < recoveryStart+preset.RecoveryMonths; m++ {
+		total += overlay[m].spy
+	}
+	if math.Abs(total) > 1e-9 {
+		t.Errorf("a full recovery should leave the SPY level unchanged, net log change %.6f", total)
+	}
+	if overlay[recoveryStart].spy <= overlay[recoveryStart+preset.RecoveryMonths-1].spy {
+		t.Error("a V-shaped recovery should be front-loaded")
+	}
+	if overlay[12].bnd <= 0 {
+		t.Error("bonds should gain during the GFC drawdown")
+	}
+
+	// Stagflation adds inflation for longer than the drawdown
+	overlay, err = buildStressOverlay([]StressShock{{Preset: StressPresetStagflation1970s}})
+	if err != nil {
+		t.Fatalf("buildStressOverlay: %v", err)
+	}
+	wantMonthly := math.Pow(1.05, 1.0/12) - 1
+	if math.Abs(overlay[0].inflation-wantMonthly) > 1e-12 || math.Abs(overlay[107].inflation-wantMonthly) > 1e-12 {
+		t.Errorf("expected %.5f excess monthly inflation through month 107, got %.5f / %.5f",
+			wantMonthly, overlay[0].inflation, overlay[107].inflation)
+	}
+	if overlay[108].inflation != 0 {
+		t.Error("excess inflation should end after InflationMonths")
+	}
+}
+
+func TestStressShockValidation(t *testing.T) {
+	resolved, err := resolveStressShock(StressShock{Preset: StressPresetDotCom2000, StartMonth: 6, AssetShocks: StressAssetShocks{SPY: -0.30}})
+	if err != nil {
+		t.Fatalf("resolveStressShock: %v", err)
+	}
+	if resolved.AssetShocks.SPY != -0.30 || resolved.AssetShocks.Intl != -0.48 || resolved.DurationMonths != 31 {
+		t.Errorf("overrides should replace only the fields set, got %+v", resolved)
+	}
+
+	invalid := []StressShock{
+		{Preset: "great_depression"},
+		{Name: "no duration", AssetShocks: StressAssetShocks{SPY: -0.2}},
+		{Name: "wipeout", DurationMonths: 6, AssetShocks: StressAssetShocks{SPY: -1}},
+		{Name: "bad shape", DurationMonths: 6, RecoveryMonths: 6, RecoveryShape: "w"},
+		{Preset: StressPresetGFC2008, StartMonth: -1},
+	}
+	for _, shock := range invalid {
+		if err := ValidateStressScenarios([]StressShock{shock}); err == nil {
+			t.Errorf("expected an error for %+v", shock)
+		}
+	}
+
+	config := GetDefaultStochasticConfig()
+	config.StressScenarios = invalid[:1]
+	if err := validateStochasticConfig(&config); err == nil {
+		t.Error("config validation should reject an unknown stress preset")
+	}
+}
+
+func TestStressOverlayInSimulation(t *testing.T) {
+	run := func(shocks []StressShock) SimulationResult {
+		input := createRollingTestInput(1000000, 60000)
+		input.Config.RandomSeed = 7
+		input.Config.StressScenarios = shocks
+		input.MonthsToRun = 120
+		result := NewSimulationEngine(input.Config).RunSingleSimulation(input)
+		if !result.Success {
+			t.Fatalf("simulation failed: %s", result.Error)
+		}
+		return result
+	}
+
+	baseline := run(nil)
+	early := run([]StressShock{{Preset: StressPresetGFC2008, StartMonth: 0}})
+	late := run([]StressShock{{Preset: StressPresetGFC2008, StartMonth: 60}})
+
+	if early.FinalNetWorth >= baseline.FinalNetWorth {
+		t.Errorf("an early crash should cost wealth: stressed %.0f, baseline %.0f", early.FinalNetWorth, baseline.FinalNetWorth)
+	}
+	// Same shock, same recovery - withdrawing through the early crash locks in more of the loss
+	if early.FinalNetWorth >= late.FinalNetWorth {
+		t.Errorf("sequence risk: crash at retirement (%.0f) should end below the same crash later (%.0f)",
+			early.FinalNetWorth, late.FinalNetWorth)
+	}
+}
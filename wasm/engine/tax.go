@@ -1,10 +1,11 @@
-package main
+package engine
 
 import (
 	"encoding/json"
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 )
 
 // RMD calculation and processing functions for WASM engine
@@ -226,12 +227,21 @@ type TaxCalculationResult struct {
 
 	NIITTax             float64 `json:"niitTax"`             // Net Investment Income Tax (3.8%)
 	IRMAAPremium        float64 `json:"irmaaPremium"`
+	PenaltyTax          float64 `json:"penaltyTax,omitempty"` // Additional tax on non-qualified distributions (HSA 20%)
 	TotalTax            float64 `json:"totalTax"`            // Total tax liability (before withholding/payments)
 	NetTaxDueOrRefund   float64 `json:"netTaxDueOrRefund"`   // Net amount due after withholding/estimated payments
 	EffectiveRate       float64 `json:"effectiveRate"`
 	MarginalRate        float64 `json:"marginalRate"`
 	AdjustedGrossIncome float64 `json:"adjustedGrossIncome"`
 	TaxableIncome       float64 `json:"taxableIncome"`
+
+	// ACA premium tax credit reconciliation (Form 8962); the net credit or repayment is in TotalTax
+	ACACoverageMonths                 int     `json:"acaCoverageMonths,omitempty"`
+	ACAModifiedAGI                    float64 `json:"acaModifiedAgi,omitempty"`
+	PremiumTaxCredit                  float64 `json:"premiumTaxCredit,omitempty"`        // Allowed on the year's actual MAGI
+	AdvancePremiumTaxCredit           float64 `json:"advancePremiumTaxCredit,omitempty"` // Paid to the insurer during the year
+	ExcessAdvanceCreditRepayment      float64 `json:"excessAdvanceCreditRepayment,omitempty"`
+	PremiumTaxCreditLostToConversions float64 `json:"premiumTaxCreditLostToConversions,omitempty"` // Credit the year's Roth conversions cost
 }
 
 // Tax calculator structure
@@ -240,9 +250,15 @@ type TaxCalculator struct {
 	stateTaxCalculator *StateTaxCalculator
 
 	// Inflation-adjusted threshold support
-	baseYear                  int     // Year the hardcoded thresholds represent (2024)
-	simulationYear            int     // Current simulation year
-	thresholdInflationRate    float64 // Annual rate for indexing thresholds
+	baseYear               int            // Year the hardcoded thresholds represent (2024)
+	simulationYear         int            // Current simulation year
+	thresholdInflationRate float64        // Annual rate for indexing thresholds
+	thresholdIndex         ThresholdIndex // Replaces the rate when set (a path's price level)
+	thresholdLevel         float64        // Index factor for simulationYear when yearParams were resolved
+
+	// Year-indexed federal parameters from the tax-year registry (nil until a year is set)
+	taxLawSchedule string
+	yearParams     *TaxYearParameters
 }
 
 // Create new tax calculator
@@ -254,19 +270,105 @@ func NewTaxCalculator(config TaxConfigDetailed, stateTaxCalc *StateTaxCalculator
 	}
 }
 
-// SetSimulationYear updates the current year and threshold inflation rate for indexing
+// SetSimulationYear updates the current year and threshold inflation rate for indexing,
+// and switches brackets, deduction and wage base to that tax year's
 func (tc *TaxCalculator) SetSimulationYear(year int, thresholdInflationRate float64) {
+	level := tc.indexFor(thresholdInflationRate).Factor(tc.baseYear, year)
+	if tc.yearParams != nil && tc.simulationYear == year && tc.thresholdInflationRate == thresholdInflationRate &&
+		tc.thresholdLevel == level {
+		return
+	}
 	tc.simulationYear = year
 	tc.thresholdInflationRate = thresholdInflationRate
+	tc.thresholdLevel = level
+	tc.resolveYearParameters()
+}
+
+// SetThresholdIndex indexes thresholds by index instead of the threshold inflation rate
+// (nil restores the rate)
+func (tc *TaxCalculator) SetThresholdIndex(index ThresholdIndex) {
+	tc.thresholdIndex = index
+	tc.yearParams = nil
+	tc.resolveYearParameters()
+}
+
+// indexFor returns the threshold index, or rate when none is set
+func (tc *TaxCalculator) indexFor(rate float64) ThresholdIndex {
+	if tc.thresholdIndex != nil {
+		return tc.thresholdIndex
+	}
+	return RateIndex(rate)
+}
+
+// SetTaxLawSchedule selects the tax-law schedule applied to year-indexed parameters
+func (tc *TaxCalculator) SetTaxLawSchedule(schedule string) {
+	tc.taxLawSchedule = schedule
+	tc.resolveYearParameters()
+}
+
+// SetFilingStatus switches the filing status and its standard deduction (e.g. after a spouse dies)
+func (tc *TaxCalculator) SetFilingStatus(status FilingStatus) {
+	if tc.config.FilingStatus == status {
+		return
+	}
+	tc.config.FilingStatus = status
+	tc.config.StandardDeduction = GetStandardDeduction(status)
+	tc.resolveYearParameters()
+}
+
+// YearParameters returns the federal parameters for the current tax year (nil before a year is set)
+func (tc *TaxCalculator) YearParameters() *TaxYearParameters {
+	return tc.yearParams
+}
+
+// resolveYearParameters looks up the registry for the current year and filing status.
+// Until a year is set the calculator uses the brackets from the loaded config.
+func (tc *TaxCalculator) resolveYearParameters() {
+	if tc.simulationYear <= 0 {
+		return
+	}
+	params, err := LookupTaxYearIndexed(tc.simulationYear, tc.config.FilingStatus, tc.indexFor(tc.thresholdInflationRate), tc.taxLawSchedule)
+	if err != nil {
+		simLogVerbose("⚠️ [TAX] No tax-year parameters for %d, using loaded brackets: %v", tc.simulationYear, err)
+		tc.yearParams = nil
+		return
+	}
+	tc.yearParams = &params
+	tc.config.StandardDeduction = params.StandardDeduction
+}
+
+// federalBrackets returns the ordinary brackets for the current tax year
+func (tc *TaxCalculator) federalBrackets() []TaxBracket {
+	if tc.yearParams != nil {
+		return tc.yearParams.OrdinaryBrackets
+	}
+	return GetFederalTaxBrackets(tc.config.FilingStatus)
+}
+
+// ltcgBrackets returns the long-term capital gains brackets for the current tax year
+func (tc *TaxCalculator) ltcgBrackets() []CapitalGainsBracket {
+	if tc.yearParams != nil {
+		return tc.yearParams.LTCGBrackets
+	}
+	return GetLTCGBrackets(tc.config.FilingStatus)
+}
+
+// socialSecurityWageBase returns the wage base for the current tax year
+func (tc *TaxCalculator) socialSecurityWageBase() float64 {
+	if tc.yearParams != nil {
+		return tc.yearParams.SocialSecurityWageBase
+	}
+	return GetSocialSecurityWageBase()
+}
+
+// FilingStatus returns the filing status used for the current tax year
+func (tc *TaxCalculator) FilingStatus() FilingStatus {
+	return tc.config.FilingStatus
 }
 
 // inflationAdjust adjusts a base-year threshold to the current simulation year
 func (tc *TaxCalculator) inflationAdjust(baseValue float64) float64 {
-	yearsElapsed := tc.simulationYear - tc.baseYear
-	if yearsElapsed <= 0 || tc.thresholdInflationRate <= 0 {
-		return baseValue
-	}
-	return baseValue * math.Pow(1+tc.thresholdInflationRate, float64(yearsElapsed))
+	return baseValue * tc.indexFor(tc.thresholdInflationRate).Factor(tc.baseYear, tc.simulationYear)
 }
 
 
@@ -306,163 +408,36 @@ func (tc *TaxCalculator) CalculateFederalIncomeTax(taxableIncome float64) float6
 		return 0
 	}
 
-	// Use config loader to get tax brackets from external config
-	brackets := GetFederalTaxBrackets(tc.config.FilingStatus)
-	return calculateProgressiveTax(taxableIncome, brackets)
+	return calculateProgressiveTax(taxableIncome, tc.federalBrackets())
 }
 
-// Calculate state income tax
-func (tc *TaxCalculator) CalculateStateIncomeTax(taxableIncome float64) float64 {
-	if taxableIncome <= 0 {
-		return 0
-	}
+// SetState sets the two-letter code of the state whose income tax is charged
+func (tc *TaxCalculator) SetState(stateCode string) {
+	tc.config.State = strings.ToUpper(stateCode)
+}
 
-	// Use comprehensive state tax calculator
-	if tc.stateTaxCalculator != nil {
-		// Note: This function receives combined taxableIncome, so we treat it all as ordinary income
-		// Capital gains are handled separately in comprehensive tax calculation
-		return tc.stateTaxCalculator.CalculateStateTax(
-			taxableIncome, // ordinaryIncome
-			0,             // capitalGains (handled separately)
-			tc.config.State,
-			tc.config.FilingStatus,
-			0, // numDependents - would need to be added to config if needed
-		)
-	}
-
-	// Fallback to hardcoded calculations if calculator not available (legacy support)
-	switch tc.config.State {
-	case "CA":
-		return tc.calculateCaliforniaIncomeTax(taxableIncome)
-	case "NY":
-		return tc.calculateNewYorkIncomeTax(taxableIncome)
-	case "TX", "FL", "WA", "NV", "SD", "TN", "WY", "AK", "NH":
-		// No state income tax
+// Calculate state income tax on ordinary income
+func (tc *TaxCalculator) CalculateStateIncomeTax(income float64) float64 {
+	if income <= 0 {
 		return 0
-	default:
-		// Use configurable flat tax rate for other states
-		flatRate := tc.getStateFlatTaxRate(tc.config.State)
-		return taxableIncome * flatRate
 	}
+	return tc.StateIncomeTax(StateIncome{Ordinary: income})
 }
 
-// California state income tax (progressive)
-func (tc *TaxCalculator) calculateCaliforniaIncomeTax(taxableIncome float64) float64 {
-	var brackets []TaxBracket
-	if tc.config.FilingStatus == FilingStatusMarriedJointly {
-		brackets = []TaxBracket{
-			{IncomeMin: 0, IncomeMax: 23200, Rate: 0.01},
-			{IncomeMin: 23200, IncomeMax: 55500, Rate: 0.02},
-			{IncomeMin: 55500, IncomeMax: 87500, Rate: 0.04},
-			{IncomeMin: 87500, IncomeMax: 122000, Rate: 0.06},
-			{IncomeMin: 122000, IncomeMax: 154500, Rate: 0.08},
-			{IncomeMin: 154500, IncomeMax: 186600, Rate: 0.093},
-			{IncomeMin: 186600, IncomeMax: 318500, Rate: 0.103},
-			{IncomeMin: 318500, IncomeMax: 638900, Rate: 0.113},
-			{IncomeMin: 638900, IncomeMax: math.Inf(1), Rate: 0.123},
-		}
-	} else {
-		brackets = []TaxBracket{
-			{IncomeMin: 0, IncomeMax: 10099, Rate: 0.01},
-			{IncomeMin: 10099, IncomeMax: 23942, Rate: 0.02},
-			{IncomeMin: 23942, IncomeMax: 37788, Rate: 0.04},
-			{IncomeMin: 37788, IncomeMax: 52455, Rate: 0.06},
-			{IncomeMin: 52455, IncomeMax: 66295, Rate: 0.08},
-			{IncomeMin: 66295, IncomeMax: 338639, Rate: 0.093},
-			{IncomeMin: 338639, IncomeMax: 406364, Rate: 0.103},
-			{IncomeMin: 406364, IncomeMax: 677278, Rate: 0.113},
-			{IncomeMin: 677278, IncomeMax: math.Inf(1), Rate: 0.123},
-		}
-	}
-	return calculateProgressiveTax(taxableIncome, brackets)
+// StateIncomeTax returns the state's income tax for the simulation year, with the state's
+// published amounts indexed at the threshold inflation rate
+func (tc *TaxCalculator) StateIncomeTax(income StateIncome) float64 {
+	return tc.StateIncomeTaxIn(income, tc.config.State)
 }
 
-// New York state income tax (simplified progressive)
-func (tc *TaxCalculator) calculateNewYorkIncomeTax(taxableIncome float64) float64 {
-	var brackets []TaxBracket
-	if tc.config.FilingStatus == FilingStatusMarriedJointly {
-		brackets = []TaxBracket{
-			{IncomeMin: 0, IncomeMax: 17150, Rate: 0.04},
-			{IncomeMin: 17150, IncomeMax: 23600, Rate: 0.045},
-			{IncomeMin: 23600, IncomeMax: 27900, Rate: 0.0525},
-			{IncomeMin: 27900, IncomeMax: 161550, Rate: 0.059},
-			{IncomeMin: 161550, IncomeMax: 323200, Rate: 0.0645},
-			{IncomeMin: 323200, IncomeMax: 2155350, Rate: 0.0685},
-			{IncomeMin: 2155350, IncomeMax: math.Inf(1), Rate: 0.103},
-		}
-	} else {
-		brackets = []TaxBracket{
-			{IncomeMin: 0, IncomeMax: 8500, Rate: 0.04},
-			{IncomeMin: 8500, IncomeMax: 11700, Rate: 0.045},
-			{IncomeMin: 11700, IncomeMax: 13900, Rate: 0.0525},
-			{IncomeMin: 13900, IncomeMax: 80650, Rate: 0.059},
-			{IncomeMin: 80650, IncomeMax: 215400, Rate: 0.0645},
-			{IncomeMin: 215400, IncomeMax: 1077550, Rate: 0.0685},
-			{IncomeMin: 1077550, IncomeMax: math.Inf(1), Rate: 0.103},
-		}
+// StateIncomeTaxIn returns another state's resident income tax on the same basis, for
+// apportioning a part-year resident's return
+func (tc *TaxCalculator) StateIncomeTaxIn(income StateIncome, stateCode string) float64 {
+	if tc.stateTaxCalculator == nil {
+		tc.stateTaxCalculator = NewStateTaxCalculator()
 	}
-	return calculateProgressiveTax(taxableIncome, brackets)
-}
-
-// getStateFlatTaxRate returns the flat tax rate for states that use flat tax systems
-func (tc *TaxCalculator) getStateFlatTaxRate(state string) float64 {
-	stateRates := map[string]float64{
-		"AL": 0.05,  // Alabama: 2-5%, using 5%
-		"AZ": 0.025, // Arizona: 2.5% flat
-		"AR": 0.055, // Arkansas: 2-5.5%, using 5.5%
-		"CO": 0.044, // Colorado: 4.4% flat
-		"CT": 0.055, // Connecticut: 3-6.99%, using 5.5%
-		"DE": 0.066, // Delaware: 0-6.6%, using 6.6%
-		"FL": 0.0,   // Florida: No state income tax
-		"GA": 0.0575, // Georgia: 1-5.75%, using 5.75%
-		"HI": 0.0825, // Hawaii: 1.4-11%, using 8.25%
-		"ID": 0.058, // Idaho: 1.125-5.8%, using 5.8%
-		"IL": 0.0495, // Illinois: 4.95% flat
-		"IN": 0.0323, // Indiana: 3.23% flat
-		"IA": 0.0853, // Iowa: 0.33-8.53%, using 8.53%
-		"KS": 0.057, // Kansas: 3.1-5.7%, using 5.7%
-		"KY": 0.05,  // Kentucky: 5% flat
-		"LA": 0.06,  // Louisiana: 1.85-6%, using 6%
-		"ME": 0.075, // Maine: 5.8-7.15%, using 7.5%
-		"MD": 0.0575, // Maryland: 2-5.75%, using 5.75%
-		"MA": 0.05,  // Massachusetts: 5% flat
-		"MI": 0.0425, // Michigan: 4.25% flat
-		"MN": 0.0985, // Minnesota: 5.35-9.85%, using 9.85%
-		"MS": 0.05,  // Mississippi: 0-5%, using 5%
-		"MO": 0.054, // Missouri: 1.5-5.4%, using 5.4%
-		"MT": 0.0675, // Montana: 1-6.75%, using 6.75%
-		"NE": 0.0684, // Nebraska: 2.46-6.84%, using 6.84%
-		"NV": 0.0,   // Nevada: No state income tax
-		"NH": 0.0,   // New Hampshire: No state income tax on wages
-		"NJ": 0.1075, // New Jersey: 1.4-10.75%, using 10.75%
-		"NM": 0.059, // New Mexico: 1.7-5.9%, using 5.9%
-		"NC": 0.049, // North Carolina: 4.9% flat
-		"ND": 0.029, // North Dakota: 1.1-2.9%, using 2.9%
-		"OH": 0.0399, // Ohio: 0-3.99%, using 3.99%
-		"OK": 0.05,  // Oklahoma: 0.25-5%, using 5%
-		"OR": 0.099, // Oregon: 4.75-9.9%, using 9.9%
-		"PA": 0.0307, // Pennsylvania: 3.07% flat
-		"RI": 0.0599, // Rhode Island: 3.75-5.99%, using 5.99%
-		"SC": 0.07,  // South Carolina: 0-7%, using 7%
-		"SD": 0.0,   // South Dakota: No state income tax
-		"TN": 0.0,   // Tennessee: No state income tax on wages
-		"TX": 0.0,   // Texas: No state income tax
-		"UT": 0.049, // Utah: 4.9% flat
-		"VT": 0.0875, // Vermont: 3.35-8.75%, using 8.75%
-		"VA": 0.0575, // Virginia: 2-5.75%, using 5.75%
-		"WA": 0.0,   // Washington: No state income tax
-		"WV": 0.065, // West Virginia: 3-6.5%, using 6.5%
-		"WI": 0.0765, // Wisconsin: 3.54-7.65%, using 7.65%
-		"WY": 0.0,   // Wyoming: No state income tax
-		"NY": 0.0685, // New York: 4-8.82%, using 6.85% (handled by progressive system above)
-	}
-
-	if rate, exists := stateRates[state]; exists {
-		return rate
-	}
-
-	// Default to 5% if state not found
-	return 0.05
+	return tc.stateTaxCalculator.CalculateIncomeTaxIndexed(income, stateCode, tc.config.FilingStatus,
+		tc.simulationYear, tc.indexFor(tc.thresholdInflationRate))
 }
 
 // Calculate long-term capital gains tax
@@ -474,8 +449,8 @@ func (tc *TaxCalculator) CalculateCapitalGainsTax(ordinaryIncome, ltcgIncome, st
 	// Short-term capital gains are taxed as ordinary income
 	stcgTax := tc.CalculateFederalIncomeTax(ordinaryIncome+stcgIncome) - tc.CalculateFederalIncomeTax(ordinaryIncome)
 
-	// Long-term capital gains have preferential rates - use config loader
-	ltcgBrackets := GetLTCGBrackets(tc.config.FilingStatus)
+	// Long-term capital gains have preferential rates
+	ltcgBrackets := tc.ltcgBrackets()
 
 	ltcgTax := 0.0
 	remainingLTCG := ltcgIncome
@@ -623,8 +598,8 @@ func (tc *TaxCalculator) CalculateFICATaxes(employmentIncome float64, filingStat
 	// Get FICA tax rates from configuration
 	socialSecurityRate, medicareRate, additionalMedicareRate := GetFICATaxRates()
 
-	// Get wage base from config
-	socialSecurityWageBase := GetSocialSecurityWageBase()
+	// Get wage base for the tax year
+	socialSecurityWageBase := tc.socialSecurityWageBase()
 
 	// Additional Medicare tax thresholds (2024)
 	// Get threshold from config based on filing status
@@ -653,8 +628,8 @@ func (tc *TaxCalculator) CalculateSelfEmploymentTax(selfEmploymentIncome float64
 	// Additional Medicare rate is the same as regular FICA
 	_, _, additionalMedicareRate := GetFICATaxRates()
 
-	// Get wage bases from config
-	socialSecurityWageBase := GetSocialSecurityWageBase()
+	// Get wage base for the tax year
+	socialSecurityWageBase := tc.socialSecurityWageBase()
 
 	// Apply SE deduction (92.35% of SE income subject to SE tax)
 	adjustedSEIncome := selfEmploymentIncome * seDeduction
@@ -798,10 +773,13 @@ func (tc *TaxCalculator) CalculateComprehensiveTaxWithFICA(
 	adjustedGrossIncome := ordinaryIncome + ltcgIncome + stcgIncome + qualifiedDividends
 
 	// Calculate deductions — take the higher of standard or itemized
-	// Note: SALT cap ($10k) should be applied when computing ItemizedDeduction.
-	// The user-provided ItemizedDeduction value should already reflect the SALT cap.
 	deduction := math.Max(tc.config.StandardDeduction, tc.config.ItemizedDeduction)
 
+	// Apply SALT cap to itemized deductions
+	if tc.config.ItemizedDeduction > tc.config.StandardDeduction && tc.config.SaltCap > 0 {
+		deduction = math.Min(deduction, tc.config.StandardDeduction+tc.config.SaltCap)
+	}
+
 	// Calculate taxable income
 	taxableIncome := math.Max(0, adjustedGrossIncome-deduction)
 
@@ -809,8 +787,12 @@ func (tc *TaxCalculator) CalculateComprehensiveTaxWithFICA(
 	ordinaryTaxableIncome := math.Max(0, ordinaryIncome-deduction)
 	federalIncomeTax := tc.CalculateFederalIncomeTax(ordinaryTaxableIncome)
 
-	// Calculate state tax on full taxable income
-	stateIncomeTax := tc.CalculateStateIncomeTax(taxableIncome)
+	// State tax applies the state's own deductions and capital gains rules
+	stateIncomeTax := tc.StateIncomeTax(StateIncome{
+		Ordinary:              ordinaryIncome,
+		ShortTermCapitalGains: stcgIncome,
+		LongTermCapitalGains:  ltcgIncome + qualifiedDividends,
+	})
 
 	// Calculate capital gains tax (this function already calculates incremental tax correctly)
 	capitalGainsTax := tc.CalculateCapitalGainsTax(ordinaryIncome, ltcgIncome+qualifiedDividends, stcgIncome)
@@ -868,7 +850,12 @@ func (tc *TaxCalculator) CalculateComprehensiveTaxWithFICA(
 	// Marginal rate calculation (simplified - approximate next dollar impact)
 	marginalIncome := adjustedGrossIncome + 1000
 	marginalTaxableIncome := math.Max(0, marginalIncome-deduction)
-	marginalTax := tc.CalculateFederalIncomeTax(marginalTaxableIncome) + tc.CalculateStateIncomeTax(marginalTaxableIncome)
+	marginalStateTax := tc.StateIncomeTax(StateIncome{
+		Ordinary:              ordinaryIncome + 1000,
+		ShortTermCapitalGains: stcgIncome,
+		LongTermCapitalGains:  ltcgIncome + qualifiedDividends,
+	})
+	marginalTax := tc.CalculateFederalIncomeTax(marginalTaxableIncome) + marginalStateTax
 	marginalRate := math.Max(0, (marginalTax-(federalIncomeTax+stateIncomeTax))/1000)
 
 	return TaxCalculationResult{
@@ -957,10 +944,14 @@ func (tc *TaxCalculator) CalculateFederalWithholding(grossPay float64, payFreque
 
 	// Step 2: Subtract standard deduction (IRS Percentage Method)
 	standardDeduction := GetStandardDeduction(filingStatus)
+	brackets := GetFederalTaxBrackets(filingStatus)
+	if tc.yearParams != nil && tc.yearParams.FilingStatus == filingStatus {
+		standardDeduction = tc.yearParams.StandardDeduction
+		brackets = tc.yearParams.OrdinaryBrackets
+	}
 	adjustedAnnualPay := math.Max(0, annualGrossPay-standardDeduction)
 
-	// Step 3: Calculate annual tax on adjusted pay using progressive brackets from config
-	brackets := GetFederalTaxBrackets(filingStatus)
+	// Step 3: Calculate annual tax on adjusted pay using progressive brackets
 
 	annualTax := calculateProgressiveTax(adjustedAnnualPay, brackets)
 
@@ -1237,10 +1228,10 @@ func calculateProgressiveStateTax(stateData struct {
 
 // ACAContributionBracket represents income-based contribution percentages for ACA subsidies
 type ACAContributionBracket struct {
-	FPLMin     float64
-	FPLMax     float64
-	PercentMin float64
-	PercentMax float64
+	FPLMin     float64 `json:"fplMin"`
+	FPLMax     float64 `json:"fplMax"`
+	PercentMin float64 `json:"percentMin"`
+	PercentMax float64 `json:"percentMax"`
 }
 
 // CalculateACASubsidy calculates the ACA premium tax credit (subsidy) based on income and benchmark premium
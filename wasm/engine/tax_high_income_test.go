@@ -1,4 +1,4 @@
-package main
+package engine
 
 import (
 	"math"
@@ -282,3 +282,22 @@ func TestHighIncomeTaxCalculation(t *testing.T) {
 		t.Logf("✅ All December snapshots across %d years have complete tax data", len(decemberMonths))
 	})
 }
+
+// TestItemizedDeductionSaltCap verifies itemized deductions above the standard
+// deduction are limited to the standard deduction plus the SALT cap
+func TestItemizedDeductionSaltCap(t *testing.T) {
+	config := TaxConfigDetailed{
+		FilingStatus:      FilingStatusSingle,
+		StandardDeduction: 14600,
+		ItemizedDeduction: 60000,
+		SaltCap:           10000,
+	}
+	capped := NewTaxCalculator(config, nil).CalculateComprehensiveTaxWithFICA(300000, 0, 0, 0, 0, 0, 0, 0)
+
+	config.ItemizedDeduction = 24600 // Exactly the cap
+	atCap := NewTaxCalculator(config, nil).CalculateComprehensiveTaxWithFICA(300000, 0, 0, 0, 0, 0, 0, 0)
+
+	if math.Abs(capped.FederalIncomeTax-atCap.FederalIncomeTax) > 0.01 {
+		t.Errorf("federal tax with $60k itemized = %.2f, want the capped %.2f", capped.FederalIncomeTax, atCap.FederalIncomeTax)
+	}
+}
@@ -0,0 +1,744 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+/**
+ * Tax-Year Registry
+ *
+ * Federal parameters keyed by tax year and filing status: ordinary brackets,
+ * standard deduction, LTCG thresholds, IRMAA tiers, the Social Security wage
+ * base, contribution limits and the ACA premium tax credit tables.
+ *
+ * Published years come from the embedded config files, one file per year:
+ *   - tax_brackets_<year>.json        brackets, LTCG thresholds, standard deduction
+ *   - contribution_limits_<year>.json contribution limits, Social Security wage base
+ *   - irmaa_brackets_<year>.json      Medicare Part B/D premiums and surcharge tiers
+ *   - aca_<year>.json                 poverty line, applicable percentages, repayment limits
+ * Adding next year's file is all it takes to publish it.
+ *
+ * Each family of parameters is resolved on its own: a year with a published
+ * file uses it as-is; a later year is indexed forward from the latest earlier
+ * published year at the threshold inflation rate and rounded down the way the
+ * IRS rounds that amount; a year before the first file uses the first file.
+ *
+ * A tax-law schedule then applies scheduled (or hypothetical) law changes to
+ * every year from their effective year. The published years reflect current
+ * law, which needs no changes.
+ */
+
+// Tax-law schedules selectable with StochasticModelConfig.TaxLawSchedule ("" = current law)
+const (
+	TaxLawScheduleCurrentLaw = "current_law"
+	TaxLawScheduleTCJASunset = "tcja_sunset"
+)
+
+// defaultTaxThresholdInflationRate indexes thresholds when the plan does not set a rate
+const defaultTaxThresholdInflationRate = 0.025
+
+// TaxYearParameters are the federal parameters in force for one tax year and filing status
+type TaxYearParameters struct {
+	Year         int          `json:"year"`
+	FilingStatus FilingStatus `json:"filingStatus"`
+	BaseYear     int          `json:"baseYear"`             // Published year the brackets come from
+	Projected    bool         `json:"projected"`            // Brackets indexed forward from BaseYear
+	LawChanges   []string     `json:"lawChanges,omitempty"` // Scheduled changes applied to this year
+
+	OrdinaryBrackets  []TaxBracket          `json:"ordinaryBrackets"`
+	LTCGBrackets      []CapitalGainsBracket `json:"ltcgBrackets"`
+	StandardDeduction float64               `json:"standardDeduction"`
+
+	IRMAABrackets            []IRMAABracket     `json:"irmaaBrackets"`            // Monthly surcharges per person by MAGI threshold
+	MedicarePartBPremium     float64            `json:"medicarePartBPremium"`     // Standard monthly Part B premium
+	MedicarePartDBasePremium float64            `json:"medicarePartDBasePremium"` // Monthly Part D base beneficiary premium
+	SocialSecurityWageBase   float64            `json:"socialSecurityWageBase"`
+	ContributionLimits       ContributionLimits `json:"contributionLimits"`
+
+	ACA ACAParameters `json:"aca"` // Premium tax credit rules for coverage in this year
+}
+
+// TaxLawChange is a scheduled change to federal law, applied to every year from EffectiveYear
+type TaxLawChange struct {
+	Name          string
+	EffectiveYear int
+	Apply         func(p *TaxYearParameters)
+}
+
+// taxLawSchedules maps each schedule to its changes in effective-year order.
+// The 2025 reconciliation act made the TCJA rates permanent, so current law needs
+// no changes; tcja_sunset models the expiry that was scheduled before it.
+var taxLawSchedules = map[string][]TaxLawChange{
+	TaxLawScheduleCurrentLaw: nil,
+	TaxLawScheduleTCJASunset: {
+		{Name: "TCJA individual provisions expire", EffectiveYear: 2026, Apply: applyTCJASunset},
+	},
+}
+
+// ValidateTaxLawSchedule reports whether a schedule name is known ("" = current law)
+func ValidateTaxLawSchedule(schedule string) error {
+	if schedule == "" {
+		return nil
+	}
+	if _, ok := taxLawSchedules[schedule]; !ok {
+		return fmt.Errorf("unknown tax law schedule %q (expected %q or %q)",
+			schedule, TaxLawScheduleCurrentLaw, TaxLawScheduleTCJASunset)
+	}
+	return nil
+}
+
+// ThresholdIndex carries amounts published for one tax year forward to a later year
+type ThresholdIndex interface {
+	Factor(fromYear, toYear int) float64
+}
+
+// RateIndex indexes thresholds at a fixed annual rate (<= 0 holds them flat)
+type RateIndex float64
+
+// Factor is the cumulative indexing from fromYear to toYear (1 when not projecting)
+func (r RateIndex) Factor(fromYear, toYear int) float64 {
+	return indexFactor(toYear-fromYear, float64(r))
+}
+
+// LookupTaxYear returns the federal parameters for a tax year and filing status.
+// indexRate projects years past the latest published year (<= 0 holds them flat).
+func LookupTaxYear(year int, status FilingStatus, indexRate float64, schedule string) (TaxYearParameters, error) {
+	return LookupTaxYearIndexed(year, status, RateIndex(indexRate), schedule)
+}
+
+// LookupTaxYearIndexed is LookupTaxYear with years past the latest published year
+// projected by index
+func LookupTaxYearIndexed(year int, status FilingStatus, index ThresholdIndex, schedule string) (TaxYearParameters, error) {
+	if err := ValidateTaxLawSchedule(schedule); err != nil {
+		return TaxYearParameters{}, err
+	}
+	registry, err := getTaxYearRegistry()
+	if err != nil {
+		return TaxYearParameters{}, err
+	}
+	key := registryFilingStatus(status)
+	params := TaxYearParameters{Year: year, FilingStatus: status}
+
+	base := publishedYearFor(registry.brackets, year)
+	factor := index.Factor(base, year)
+	brackets := registry.brackets[base]
+	params.BaseYear = base
+	params.Projected = year > base
+	params.OrdinaryBrackets = indexTaxBrackets(brackets.ordinaryFor(key), factor)
+	params.LTCGBrackets = indexCapitalGainsBrackets(brackets.ltcgFor(key), factor)
+	params.StandardDeduction = indexAmount(brackets.deductionFor(key), factor, 50)
+
+	base = publishedYearFor(registry.contributions, year)
+	factor = index.Factor(base, year)
+	contributions := registry.contributions[base]
+	params.SocialSecurityWageBase = indexWageBase(contributions.wageBase, factor)
+	params.ContributionLimits = indexContributionLimits(contributions.limits, factor)
+	params.ContributionLimits.Year = year
+
+	base = publishedYearFor(registry.irmaa, year)
+	factor = index.Factor(base, year)
+	irmaa := registry.irmaa[base]
+	params.IRMAABrackets = indexIRMAABrackets(irmaa.forStatus(key), factor)
+	params.MedicarePartBPremium = math.Round(irmaa.partBPremium*factor*100) / 100
+	params.MedicarePartDBasePremium = math.Round(irmaa.partDBasePremium*factor*100) / 100
+
+	base = publishedYearFor(registry.aca, year)
+	params.ACA = indexACAParameters(registry.aca[base].forStatus(key), index.Factor(base, year))
+
+	for _, change := range taxLawSchedules[schedule] {
+		if year >= change.EffectiveYear {
+			change.Apply(&params)
+			params.LawChanges = append(params.LawChanges, change.Name)
+		}
+	}
+	return params, nil
+}
+
+// PublishedTaxYears lists the years with published brackets, oldest first
+func PublishedTaxYears() ([]int, error) {
+	registry, err := getTaxYearRegistry()
+	if err != nil {
+		return nil, err
+	}
+	return sortedYears(registry.brackets), nil
+}
+
+// taxYearRegistry holds every published year, by parameter family
+type taxYearRegistry struct {
+	brackets      map[int]publishedBrackets
+	contributions map[int]publishedContributions
+	irmaa         map[int]publishedIRMAA
+	aca           map[int]publishedACA
+}
+
+type publishedBrackets struct {
+	ordinary          map[FilingStatus][]TaxBracket
+	ltcg              map[FilingStatus][]CapitalGainsBracket
+	standardDeduction map[FilingStatus]float64
+}
+
+type publishedContributions struct {
+	limits   ContributionLimits
+	wageBase float64
+}
+
+type publishedIRMAA struct {
+	single           []IRMAABracket
+	joint            []IRMAABracket
+	partBPremium     float64
+	partDBasePremium float64
+}
+
+type publishedACA struct {
+	params          ACAParameters // Repayment limits left empty; they depend on filing status
+	repaymentSingle []ACARepaymentLimit
+	repaymentOther  []ACARepaymentLimit
+}
+
+var (
+	taxYearRegistryData *taxYearRegistry
+	taxYearRegistryErr  error
+	taxYearRegistryOnce sync.Once
+)
+
+// getTaxYearRegistry parses the embedded per-year config files once
+func getTaxYearRegistry() (*taxYearRegistry, error) {
+	taxYearRegistryOnce.Do(func() {
+		taxYearRegistryData, taxYearRegistryErr = buildTaxYearRegistry(embeddedConfigs)
+	})
+	return taxYearRegistryData, taxYearRegistryErr
+}
+
+// buildTaxYearRegistry reads every per-year file in files' config directory
+func buildTaxYearRegistry(files fs.FS) (*taxYearRegistry, error) {
+	registry := &taxYearRegistry{
+		brackets:      map[int]publishedBrackets{},
+		contributions: map[int]publishedContributions{},
+		irmaa:         map[int]publishedIRMAA{},
+		aca:           map[int]publishedACA{},
+	}
+
+	if err := readTaxYearFiles(files, "config/tax_brackets_*.json", func(data []byte) error {
+		year, brackets, err := parsePublishedBrackets(data)
+		if err == nil {
+			registry.brackets[year] = brackets
+		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := readTaxYearFiles(files, "config/contribution_limits_*.json", func(data []byte) error {
+		year, contributions, err := parsePublishedContributions(data)
+		if err == nil {
+			registry.contributions[year] = contributions
+		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := readTaxYearFiles(files, "config/irmaa_brackets_*.json", func(data []byte) error {
+		year, irmaa, err := parsePublishedIRMAA(data)
+		if err == nil {
+			registry.irmaa[year] = irmaa
+		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := readTaxYearFiles(files, "config/aca_*.json", func(data []byte) error {
+		year, aca, err := parsePublishedACA(data)
+		if err == nil {
+			registry.aca[year] = aca
+		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if len(registry.brackets) == 0 || len(registry.contributions) == 0 || len(registry.irmaa) == 0 || len(registry.aca) == 0 {
+		return nil, fmt.Errorf("tax-year registry needs at least one published year of brackets, contribution limits, IRMAA tiers and ACA tables")
+	}
+	return registry, nil
+}
+
+// readTaxYearFiles calls parse with the contents of every file matching pattern
+func readTaxYearFiles(files fs.FS, pattern string, parse func([]byte) error) error {
+	names, err := fs.Glob(files, pattern)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		data, err := fs.ReadFile(files, name)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", name, err)
+		}
+		if err := parse(data); err != nil {
+			return fmt.Errorf("parse %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// bracketFileKeys maps filing statuses to the key infixes used in tax_brackets_<year>.json
+var bracketFileKeys = map[FilingStatus]string{
+	FilingStatusSingle:            "Single",
+	FilingStatusMarriedJointly:    "MFJ",
+	FilingStatusMarriedSeparately: "MFS",
+	FilingStatusHeadOfHousehold:   "HOH",
+}
+
+// deductionFileKeys maps filing statuses to the standardDeduction keys
+var deductionFileKeys = map[FilingStatus]string{
+	FilingStatusSingle:            "single",
+	FilingStatusMarriedJointly:    "marriedFilingJointly",
+	FilingStatusMarriedSeparately: "marriedFilingSeparately",
+	FilingStatusHeadOfHousehold:   "headOfHousehold",
+}
+
+// parsePublishedBrackets reads one tax_brackets_<year>.json file. Keys carry the
+// year (federalTaxBracketsSingle2025), so the file is read by its metadata year.
+func parsePublishedBrackets(data []byte) (int, publishedBrackets, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return 0, publishedBrackets{}, err
+	}
+	year, err := metadataTaxYear(raw)
+	if err != nil {
+		return 0, publishedBrackets{}, err
+	}
+
+	suffix := strconv.Itoa(year)
+	published := publishedBrackets{
+		ordinary:          map[FilingStatus][]TaxBracket{},
+		ltcg:              map[FilingStatus][]CapitalGainsBracket{},
+		standardDeduction: map[FilingStatus]float64{},
+	}
+	for status, key := range bracketFileKeys {
+		if msg, ok := raw["federalTaxBrackets"+key+suffix]; ok {
+			var brackets []TaxBracket
+			if err := json.Unmarshal(msg, &brackets); err != nil {
+				return 0, publishedBrackets{}, err
+			}
+			published.ordinary[status] = brackets
+		}
+		if msg, ok := raw["ltcgBrackets"+key+suffix]; ok {
+			var brackets []CapitalGainsBracket
+			if err := json.Unmarshal(msg, &brackets); err != nil {
+				return 0, publishedBrackets{}, err
+			}
+			published.ltcg[status] = brackets
+		}
+	}
+
+	var deductions map[string]json.RawMessage
+	if err := json.Unmarshal(raw["standardDeduction"], &deductions); err != nil {
+		return 0, publishedBrackets{}, fmt.Errorf("standardDeduction: %w", err)
+	}
+	for status, key := range deductionFileKeys {
+		if msg, ok := deductions[key]; ok {
+			var amount float64
+			if err := json.Unmarshal(msg, &amount); err != nil {
+				return 0, publishedBrackets{}, fmt.Errorf("standardDeduction.%s: %w", key, err)
+			}
+			published.standardDeduction[status] = amount
+		}
+	}
+
+	if len(published.ordinary[FilingStatusSingle]) == 0 || len(published.ltcg[FilingStatusSingle]) == 0 ||
+		published.standardDeduction[FilingStatusSingle] == 0 {
+		return 0, publishedBrackets{}, fmt.Errorf("tax year %d is missing single-filer brackets or standard deduction", year)
+	}
+	return year, published, nil
+}
+
+// parsePublishedContributions reads one contribution_limits_<year>.json file
+func parsePublishedContributions(data []byte) (int, publishedContributions, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return 0, publishedContributions{}, err
+	}
+	year, err := metadataTaxYear(raw)
+	if err != nil {
+		return 0, publishedContributions{}, err
+	}
+	var config ContributionLimitsConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return 0, publishedContributions{}, err
+	}
+	if config.RetirementContributions.E401k.BaseLimit <= 0 || config.SocialSecurityWageBase <= 0 {
+		return 0, publishedContributions{}, fmt.Errorf("tax year %d is missing the 401(k) limit or wage base", year)
+	}
+
+	rc := config.RetirementContributions
+	limits := ContributionLimits{
+		Year:                      year,
+		DeferredContributionLimit: rc.E401k.BaseLimit,
+		DeferredCatchUpLimit:      rc.E401k.CatchUpLimit,
+		DeferredCatchUpAge:        rc.E401k.CatchUpAge,
+		IRAContributionLimit:      rc.IRA.BaseLimit,
+		IRACatchUpLimit:           rc.IRA.CatchUpLimit,
+		IRACatchUpAge:             rc.IRA.CatchUpAge,
+		HSAIndividualLimit:        config.HSAContributions.Individual,
+		HSAFamilyLimit:            config.HSAContributions.Family,
+		HSACatchUpLimit:           config.HSAContributions.CatchUpLimit,
+		HSACatchUpAge:             config.HSAContributions.CatchUpAge,
+		SIMPLEContributionLimit:   rc.Simple.BaseLimit,
+		SIMPLECatchUpLimit:        rc.Simple.CatchUpLimit,
+		SIMPLECatchUpAge:          rc.Simple.CatchUpAge,
+		SEPContributionLimit:      rc.SEP.MaxAmount,
+		SEPCompensationPercentage: rc.SEP.LimitPercentage,
+		OverallDCPlanLimit:        rc.SEP.MaxAmount, // The SEP cap is the section 415(c) limit
+	}
+	return year, publishedContributions{limits: limits, wageBase: config.SocialSecurityWageBase}, nil
+}
+
+// parsePublishedIRMAA reads one irmaa_brackets_<year>.json file. The year comes
+// from the effective date; Part B and Part D tiers share their income ranges.
+func parsePublishedIRMAA(data []byte) (int, publishedIRMAA, error) {
+	var config IRMAAConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return 0, publishedIRMAA{}, err
+	}
+	if len(config.Metadata.EffectiveDate) < 4 {
+		return 0, publishedIRMAA{}, fmt.Errorf("missing effectiveDate")
+	}
+	year, err := strconv.Atoi(config.Metadata.EffectiveDate[:4])
+	if err != nil {
+		return 0, publishedIRMAA{}, fmt.Errorf("invalid effectiveDate %q", config.Metadata.EffectiveDate)
+	}
+
+	partB := config.PartBPremiums.IRMAAAAdjustments
+	partD := config.PartDPremiums.IRMAAAAdjustments
+	if len(partB) == 0 || len(partB) != len(partD) {
+		return 0, publishedIRMAA{}, fmt.Errorf("IRMAA %d needs matching Part B and Part D tiers", year)
+	}
+	published := publishedIRMAA{
+		partBPremium:     config.PartBPremiums.StandardMonthlyPremium,
+		partDBasePremium: config.PartDPremiums.BaseBeneficiaryPremium,
+	}
+	for i := range partB {
+		singleMin, _ := parseIncomeValues(partB[i].IncomeRange.Single.Min, partB[i].IncomeRange.Single.Max)
+		jointMin, _ := parseIncomeValues(partB[i].IncomeRange.MarriedFilingJointly.Min, partB[i].IncomeRange.MarriedFilingJointly.Max)
+		published.single = append(published.single, IRMAABracket{
+			MAGIThreshold:  singleMin,
+			PartBSurcharge: partB[i].IRMAAAmount,
+			PartDSurcharge: partD[i].MonthlyIRMAAAmount,
+		})
+		published.joint = append(published.joint, IRMAABracket{
+			MAGIThreshold:  jointMin,
+			PartBSurcharge: partB[i].IRMAAAmount,
+			PartDSurcharge: partD[i].MonthlyIRMAAAmount,
+		})
+	}
+	return year, published, nil
+}
+
+// parsePublishedACA reads one aca_<year>.json file. The year is the coverage year, whose
+// poverty line is the prior year's HHS guideline.
+func parsePublishedACA(data []byte) (int, publishedACA, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return 0, publishedACA{}, err
+	}
+	year, err := metadataTaxYear(raw)
+	if err != nil {
+		return 0, publishedACA{}, err
+	}
+	var config struct {
+		PovertyGuidelines struct {
+			FirstPerson      float64 `json:"firstPerson"`
+			AdditionalPerson float64 `json:"additionalPerson"`
+		} `json:"povertyGuidelines"`
+		MinimumFPLPercent     float64 `json:"minimumFPLPercent"`
+		MaximumFPLPercent     float64 `json:"maximumFPLPercent"`
+		ApplicablePercentages []struct {
+			FPLMin         float64 `json:"fplMin"`
+			FPLMax         float64 `json:"fplMax"`
+			InitialPercent float64 `json:"initialPercent"`
+			FinalPercent   float64 `json:"finalPercent"`
+		} `json:"applicablePercentages"`
+		RepaymentLimitations []struct {
+			FPLMax float64 `json:"fplMax"`
+			Single float64 `json:"single"`
+			Other  float64 `json:"other"`
+		} `json:"repaymentLimitations"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return 0, publishedACA{}, err
+	}
+	if config.PovertyGuidelines.FirstPerson <= 0 || len(config.ApplicablePercentages) == 0 {
+		return 0, publishedACA{}, fmt.Errorf("coverage year %d is missing the poverty line or applicable percentages", year)
+	}
+
+	published := publishedACA{params: ACAParameters{
+		PovertyLineFirstPerson:      config.PovertyGuidelines.FirstPerson,
+		PovertyLineAdditionalPerson: config.PovertyGuidelines.AdditionalPerson,
+		MinimumFPLPercent:           config.MinimumFPLPercent,
+		MaximumFPLPercent:           config.MaximumFPLPercent,
+	}}
+	for _, band := range config.ApplicablePercentages {
+		fplMax := band.FPLMax
+		if fplMax <= 0 {
+			fplMax = math.Inf(1) // The top band of the enhanced credit has no upper bound
+		}
+		published.params.ApplicablePercentages = append(published.params.ApplicablePercentages, ACAContributionBracket{
+			FPLMin: band.FPLMin, FPLMax: fplMax, PercentMin: band.InitialPercent, PercentMax: band.FinalPercent,
+		})
+	}
+	for _, limit := range config.RepaymentLimitations {
+		published.repaymentSingle = append(published.repaymentSingle, ACARepaymentLimit{FPLMax: limit.FPLMax, Limit: limit.Single})
+		published.repaymentOther = append(published.repaymentOther, ACARepaymentLimit{FPLMax: limit.FPLMax, Limit: limit.Other})
+	}
+	return year, published, nil
+}
+
+// metadataTaxYear reads _metadata.taxYear
+func metadataTaxYear(raw map[string]json.RawMessage) (int, error) {
+	var metadata struct {
+		TaxYear int `json:"taxYear"`
+	}
+	if msg, ok := raw["_metadata"]; ok {
+		if err := json.Unmarshal(msg, &metadata); err != nil {
+			return 0, fmt.Errorf("_metadata: %w", err)
+		}
+	}
+	if metadata.TaxYear == 0 {
+		return 0, fmt.Errorf("missing _metadata.taxYear")
+	}
+	return metadata.TaxYear, nil
+}
+
+// registryFilingStatus maps a filing status to the status its parameters are published under
+func registryFilingStatus(status FilingStatus) FilingStatus {
+	switch status {
+	case FilingStatusMarriedJointly, FilingStatusQualifyingWidow:
+		return FilingStatusMarriedJointly
+	case FilingStatusMarriedSeparately, FilingStatusHeadOfHousehold:
+		return status
+	default:
+		return FilingStatusSingle
+	}
+}
+
+// Years that do not publish MFS or HOH tables fall back to the single-filer table
+
+func (b publishedBrackets) ordinaryFor(status FilingStatus) []TaxBracket {
+	if brackets, ok := b.ordinary[status]; ok {
+		return brackets
+	}
+	return b.ordinary[FilingStatusSingle]
+}
+
+func (b publishedBrackets) ltcgFor(status FilingStatus) []CapitalGainsBracket {
+	if brackets, ok := b.ltcg[status]; ok {
+		return brackets
+	}
+	return b.ltcg[FilingStatusSingle]
+}
+
+func (b publishedBrackets) deductionFor(status FilingStatus) float64 {
+	if amount, ok := b.standardDeduction[status]; ok {
+		return amount
+	}
+	return b.standardDeduction[FilingStatusSingle]
+}
+
+// forStatus returns the joint tiers for married filers, the single tiers otherwise
+// (married-filing-separately tiers are not published separately in the config)
+func (p publishedIRMAA) forStatus(status FilingStatus) []IRMAABracket {
+	if status == FilingStatusMarriedJointly {
+		return p.joint
+	}
+	return p.single
+}
+
+// forStatus returns the year's tables with the repayment limits for the filing status
+// (the single column applies only to single filers)
+func (p publishedACA) forStatus(status FilingStatus) ACAParameters {
+	params := p.params
+	if status == FilingStatusSingle {
+		params.RepaymentLimits = p.repaymentSingle
+	} else {
+		params.RepaymentLimits = p.repaymentOther
+	}
+	return params
+}
+
+// publishedYearFor picks the published year that year's parameters derive from:
+// the latest published year not after it, or the first published year
+func publishedYearFor[V any](published map[int]V, year int) int {
+	years := sortedYears(published)
+	base := years[0]
+	for _, y := range years {
+		if y <= year {
+			base = y
+		}
+	}
+	return base
+}
+
+func sortedYears[V any](published map[int]V) []int {
+	years := make([]int, 0, len(published))
+	for y := range published {
+		years = append(years, y)
+	}
+	sort.Ints(years)
+	return years
+}
+
+// indexFactor is the cumulative indexing over years at rate (1 when not projecting)
+func indexFactor(years int, rate float64) float64 {
+	if years <= 0 || rate <= 0 {
+		return 1
+	}
+	return math.Pow(1+rate, float64(years))
+}
+
+// indexAmount scales a published amount and rounds it down to a multiple of step.
+// Published amounts (factor 1) are returned exactly.
+func indexAmount(amount, factor, step float64) float64 {
+	if factor == 1 || math.IsInf(amount, 0) {
+		return amount
+	}
+	return math.Floor(amount*factor/step) * step
+}
+
+// indexTaxBrackets copies brackets with thresholds indexed and rounded down to $25
+func indexTaxBrackets(brackets []TaxBracket, factor float64) []TaxBracket {
+	indexed := make([]TaxBracket, len(brackets))
+	for i, b := range brackets {
+		indexed[i] = TaxBracket{
+			IncomeMin: indexAmount(b.IncomeMin, factor, 25),
+			IncomeMax: indexAmount(b.IncomeMax, factor, 25),
+			Rate:      b.Rate,
+		}
+	}
+	return indexed
+}
+
+// indexCapitalGainsBrackets copies LTCG brackets with thresholds indexed and rounded down to $25
+func indexCapitalGainsBrackets(brackets []CapitalGainsBracket, factor float64) []CapitalGainsBracket {
+	indexed := make([]CapitalGainsBracket, len(brackets))
+	for i, b := range brackets {
+		indexed[i] = CapitalGainsBracket{
+			IncomeMin: indexAmount(b.IncomeMin, factor, 25),
+			IncomeMax: indexAmount(b.IncomeMax, factor, 25),
+			Rate:      b.Rate,
+		}
+	}
+	return indexed
+}
+
+// indexWageBase indexes the Social Security wage base, which SSA rounds to the nearest $300
+func indexWageBase(wageBase, factor float64) float64 {
+	if factor == 1 {
+		return wageBase
+	}
+	return math.Round(wageBase*factor/300) * 300
+}
+
+// indexContributionLimits indexes each limit with its statutory rounding increment
+func indexContributionLimits(limits ContributionLimits, factor float64) ContributionLimits {
+	limits.DeferredContributionLimit = indexAmount(limits.DeferredContributionLimit, factor, 500)
+	limits.DeferredCatchUpLimit = indexAmount(limits.DeferredCatchUpLimit, factor, 500)
+	limits.IRAContributionLimit = indexAmount(limits.IRAContributionLimit, factor, 500)
+	limits.IRACatchUpLimit = indexAmount(limits.IRACatchUpLimit, factor, 100)
+	limits.HSAIndividualLimit = indexAmount(limits.HSAIndividualLimit, factor, 50)
+	limits.HSAFamilyLimit = indexAmount(limits.HSAFamilyLimit, factor, 50)
+	limits.SIMPLEContributionLimit = indexAmount(limits.SIMPLEContributionLimit, factor, 500)
+	limits.SIMPLECatchUpLimit = indexAmount(limits.SIMPLECatchUpLimit, factor, 500)
+	limits.SEPContributionLimit = indexAmount(limits.SEPContributionLimit, factor, 1000)
+	limits.OverallDCPlanLimit = indexAmount(limits.OverallDCPlanLimit, factor, 1000)
+	return limits
+}
+
+// indexIRMAABrackets indexes MAGI thresholds (rounded to the nearest $1,000 as SSA does)
+// and grows the surcharges at the same rate, rounded to the cent
+func indexIRMAABrackets(brackets []IRMAABracket, factor float64) []IRMAABracket {
+	indexed := make([]IRMAABracket, len(brackets))
+	for i, b := range brackets {
+		indexed[i] = b
+		if factor != 1 {
+			indexed[i].MAGIThreshold = math.Round(b.MAGIThreshold*factor/1000) * 1000
+			indexed[i].PartBSurcharge = math.Round(b.PartBSurcharge*factor*100) / 100
+			indexed[i].PartDSurcharge = math.Round(b.PartDSurcharge*factor*100) / 100
+		}
+	}
+	return indexed
+}
+
+// indexACAParameters indexes the poverty line (rounded down to $10) and the repayment
+// limits (rounded down to $25). Applicable percentages are held at the latest published table.
+func indexACAParameters(params ACAParameters, factor float64) ACAParameters {
+	params.PovertyLineFirstPerson = indexAmount(params.PovertyLineFirstPerson, factor, 10)
+	params.PovertyLineAdditionalPerson = indexAmount(params.PovertyLineAdditionalPerson, factor, 10)
+	if factor != 1 && len(params.RepaymentLimits) > 0 {
+		limits := make([]ACARepaymentLimit, len(params.RepaymentLimits))
+		for i, limit := range params.RepaymentLimits {
+			limits[i] = ACARepaymentLimit{FPLMax: limit.FPLMax, Limit: indexAmount(limit.Limit, factor, 25)}
+		}
+		params.RepaymentLimits = limits
+	}
+	return params
+}
+
+// Pre-TCJA (2017) law: tops of the 10%, 15%, 25%, 28%, 33% and 35% brackets,
+// the standard deduction, and the personal exemption (Rev. Proc. 2016-55)
+var (
+	preTCJARates           = []float64{0.10, 0.15, 0.25, 0.28, 0.33, 0.35, 0.396}
+	preTCJABracketTops2017 = map[FilingStatus][]float64{
+		FilingStatusSingle:            {9325, 37950, 91900, 191650, 416700, 418400},
+		FilingStatusMarriedJointly:    {18650, 75900, 153100, 233350, 416700, 470700},
+		FilingStatusMarriedSeparately: {9325, 37950, 76550, 116675, 208350, 235350},
+		FilingStatusHeadOfHousehold:   {13350, 50800, 131200, 212500, 416700, 444550},
+	}
+	preTCJAStandardDeduction2017 = map[FilingStatus]float64{
+		FilingStatusSingle:            6350,
+		FilingStatusMarriedJointly:    12700,
+		FilingStatusMarriedSeparately: 6350,
+		FilingStatusHeadOfHousehold:   9350,
+	}
+)
+
+const preTCJAPersonalExemption2017 = 4050
+
+// applyTCJASunset reverts the ordinary brackets and standard deduction to pre-2018 law.
+// The 10% bracket top carried over unchanged into TCJA, so its ratio to the 2017 value
+// approximates the indexing since 2017 and scales every 2017 amount to the year.
+// Personal exemptions (one per filer, no dependents) fold into the deduction; their
+// phase-out and the Pease limitation are not modelled. LTCG thresholds are unchanged.
+func applyTCJASunset(p *TaxYearParameters) {
+	key := registryFilingStatus(p.FilingStatus)
+	tops := preTCJABracketTops2017[key]
+	if len(p.OrdinaryBrackets) == 0 || p.OrdinaryBrackets[0].IncomeMax <= 0 {
+		return
+	}
+	scale := p.OrdinaryBrackets[0].IncomeMax / tops[0]
+
+	brackets := make([]TaxBracket, len(preTCJARates))
+	lower := 0.0
+	for i, rate := range preTCJARates {
+		upper := math.Inf(1)
+		if i < len(tops) {
+			upper = math.Floor(tops[i]*scale/25) * 25
+		}
+		brackets[i] = TaxBracket{IncomeMin: lower, IncomeMax: upper, Rate: rate}
+		lower = upper
+	}
+	p.OrdinaryBrackets = brackets
+
+	exemptions := 1.0
+	if key == FilingStatusMarriedJointly {
+		exemptions = 2
+	}
+	p.StandardDeduction = math.Floor((preTCJAStandardDeduction2017[key]+exemptions*preTCJAPersonalExemption2017)*scale/50) * 50
+}
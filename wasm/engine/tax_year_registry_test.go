@@ -0,0 +1,164 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTaxYearRegistryPublishedYears(t *testing.T) {
+	years, err := PublishedTaxYears()
+	if err != nil {
+		t.Fatalf("PublishedTaxYears: %v", err)
+	}
+	if len(years) < 3 || years[0] != 2024 || years[len(years)-1] < 2026 {
+		t.Fatalf("expected published years 2024 through at least 2026, got %v", years)
+	}
+
+	cases := []struct {
+		year              int
+		status            FilingStatus
+		tenPercentTop     float64
+		standardDeduction float64
+		deferredLimit     float64
+		wageBase          float64
+	}{
+		{2024, FilingStatusSingle, 11600, 14600, 23000, 168600},
+		{2024, FilingStatusMarriedJointly, 23200, 29200, 23000, 168600},
+		{2025, FilingStatusSingle, 11925, 15750, 23500, 176100},
+		{2025, FilingStatusMarriedJointly, 23850, 31500, 23500, 176100},
+		{2026, FilingStatusSingle, 12400, 16100, 24500, 184500},
+		{2026, FilingStatusMarriedJointly, 24800, 32200, 24500, 184500},
+		{2026, FilingStatusHeadOfHousehold, 17700, 24150, 24500, 184500},
+	}
+	for _, c := range cases {
+		p, err := LookupTaxYear(c.year, c.status, 0.03, "")
+		if err != nil {
+			t.Fatalf("LookupTaxYear(%d, %s): %v", c.year, c.status, err)
+		}
+		if p.Projected || p.BaseYear != c.year {
+			t.Errorf("%d %s: published year reported as projected from %d", c.year, c.status, p.BaseYear)
+		}
+		if got := p.OrdinaryBrackets[0].IncomeMax; got != c.tenPercentTop {
+			t.Errorf("%d %s: 10%% bracket top %.0f, want %.0f", c.year, c.status, got, c.tenPercentTop)
+		}
+		if p.StandardDeduction != c.standardDeduction {
+			t.Errorf("%d %s: standard deduction %.0f, want %.0f", c.year, c.status, p.StandardDeduction, c.standardDeduction)
+		}
+		if p.ContributionLimits.DeferredContributionLimit != c.deferredLimit {
+			t.Errorf("%d: 401(k) limit %.0f, want %.0f", c.year, p.ContributionLimits.DeferredContributionLimit, c.deferredLimit)
+		}
+		if p.SocialSecurityWageBase != c.wageBase {
+			t.Errorf("%d: wage base %.0f, want %.0f", c.year, p.SocialSecurityWageBase, c.wageBase)
+		}
+		if len(p.LTCGBrackets) == 0 || len(p.IRMAABrackets) == 0 {
+			t.Errorf("%d %s: missing LTCG or IRMAA tiers", c.year, c.status)
+		}
+	}
+}
+
+func TestTaxYearRegistryProjection(t *testing.T) {
+	published, err := LookupTaxYear(2026, FilingStatusSingle, 0.025, "")
+	if err != nil {
+		t.Fatalf("LookupTaxYear(2026): %v", err)
+	}
+	projected, err := LookupTaxYear(2030, FilingStatusSingle, 0.025, "")
+	if err != nil {
+		t.Fatalf("LookupTaxYear(2030): %v", err)
+	}
+	if !projected.Projected || projected.BaseYear != 2026 {
+		t.Fatalf("2030 should be projected from 2026, got base %d projected %v", projected.BaseYear, projected.Projected)
+	}
+
+	factor := math.Pow(1.025, 4)
+	for i, b := range projected.OrdinaryBrackets[:len(projected.OrdinaryBrackets)-1] {
+		want := math.Floor(published.OrdinaryBrackets[i].IncomeMax*factor/25) * 25
+		if b.IncomeMax != want {
+			t.Errorf("bracket %d top %.0f, want %.0f (indexed and rounded down to $25)", i, b.IncomeMax, want)
+		}
+		if b.Rate != published.OrdinaryBrackets[i].Rate {
+			t.Errorf("bracket %d rate changed under current law", i)
+		}
+	}
+	if math.Mod(projected.StandardDeduction, 50) != 0 || projected.StandardDeduction <= published.StandardDeduction {
+		t.Errorf("projected standard deduction %.0f should grow and round to $50", projected.StandardDeduction)
+	}
+	if math.Mod(projected.ContributionLimits.DeferredContributionLimit, 500) != 0 {
+		t.Errorf("projected 401(k) limit %.0f should round to $500", projected.ContributionLimits.DeferredContributionLimit)
+	}
+	if projected.ContributionLimits.Year != 2030 {
+		t.Errorf("projected contribution limits should carry year 2030, got %d", projected.ContributionLimits.Year)
+	}
+
+	// A zero rate holds the latest published year flat
+	flat, _ := LookupTaxYear(2030, FilingStatusSingle, 0, "")
+	if flat.OrdinaryBrackets[0].IncomeMax != published.OrdinaryBrackets[0].IncomeMax {
+		t.Errorf("zero index rate should keep 2026 brackets, got %.0f", flat.OrdinaryBrackets[0].IncomeMax)
+	}
+
+	// Years before the first published year use the first published year
+	early, _ := LookupTaxYear(2020, FilingStatusSingle, 0.025, "")
+	if early.BaseYear != 2024 || early.Projected || early.OrdinaryBrackets[0].IncomeMax != 11600 {
+		t.Errorf("2020 should use 2024 brackets, got base %d top %.0f", early.BaseYear, early.OrdinaryBrackets[0].IncomeMax)
+	}
+}
+
+func TestTaxYearRegistryTCJASunset(t *testing.T) {
+	if _, err := LookupTaxYear(2026, FilingStatusSingle, 0.025, "no_such_law"); err == nil {
+		t.Error("expected an error for an unknown tax law schedule")
+	}
+
+	before, _ := LookupTaxYear(2025, FilingStatusSingle, 0.025, TaxLawScheduleTCJASunset)
+	if len(before.LawChanges) != 0 || before.OrdinaryBrackets[1].Rate != 0.12 {
+		t.Errorf("sunset should not apply before 2026, got changes %v", before.LawChanges)
+	}
+
+	current, _ := LookupTaxYear(2026, FilingStatusMarriedJointly, 0.025, "")
+	sunset, err := LookupTaxYear(2026, FilingStatusMarriedJointly, 0.025, TaxLawScheduleTCJASunset)
+	if err != nil {
+		t.Fatalf("LookupTaxYear(tcja_sunset): %v", err)
+	}
+	if len(sunset.LawChanges) != 1 {
+		t.Fatalf("expected one law change applied, got %v", sunset.LawChanges)
+	}
+	rates := []float64{0.10, 0.15, 0.25, 0.28, 0.33, 0.35, 0.396}
+	if len(sunset.OrdinaryBrackets) != len(rates) {
+		t.Fatalf("expected %d pre-TCJA brackets, got %d", len(rates), len(sunset.OrdinaryBrackets))
+	}
+	for i, rate := range rates {
+		if sunset.OrdinaryBrackets[i].Rate != rate {
+			t.Errorf("bracket %d rate %.3f, want %.3f", i, sunset.OrdinaryBrackets[i].Rate, rate)
+		}
+	}
+	if sunset.StandardDeduction >= current.StandardDeduction {
+		t.Errorf("pre-TCJA deduction plus exemptions (%.0f) should be below current law (%.0f)",
+			sunset.StandardDeduction, current.StandardDeduction)
+	}
+
+	calc := NewTaxCalculator(TaxConfigDetailed{FilingStatus: FilingStatusMarriedJointly}, nil)
+	calc.SetTaxLawSchedule(TaxLawScheduleTCJASunset)
+	calc.SetSimulationYear(2026, 0.025)
+	sunsetTax := calc.CalculateFederalIncomeTax(250000)
+	calc.SetTaxLawSchedule("")
+	if currentTax := calc.CalculateFederalIncomeTax(250000); sunsetTax <= currentTax {
+		t.Errorf("sunset tax %.0f should exceed current-law tax %.0f", sunsetTax, currentTax)
+	}
+}
+
+func TestTaxCalculatorSwitchesTaxYear(t *testing.T) {
+	calc := NewTaxCalculator(TaxConfigDetailed{FilingStatus: FilingStatusSingle}, nil)
+
+	calc.SetSimulationYear(2024, 0.025)
+	tax2024 := calc.CalculateFederalIncomeTax(100000)
+	if calc.YearParameters().StandardDeduction != 14600 {
+		t.Errorf("2024 standard deduction %.0f, want 14600", calc.YearParameters().StandardDeduction)
+	}
+
+	calc.SetSimulationYear(2026, 0.025)
+	tax2026 := calc.CalculateFederalIncomeTax(100000)
+	if tax2026 >= tax2024 {
+		t.Errorf("wider 2026 brackets should lower tax on the same income: 2024 %.2f, 2026 %.2f", tax2024, tax2026)
+	}
+	if calc.YearParameters().SocialSecurityWageBase != 184500 {
+		t.Errorf("2026 wage base %.0f, want 184500", calc.YearParameters().SocialSecurityWageBase)
+	}
+}
@@ -7,7 +7,7 @@
 //
 // These tests verify the conservation law holds across complex simulation scenarios.
 
-package main
+package engine
 
 import (
 	"fmt"
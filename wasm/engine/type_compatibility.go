@@ -29,9 +29,16 @@ const (
 	EventTypeLiabilityPayment                 EventType = "LIABILITY_PAYMENT"
 	EventTypeDebtPayment                      EventType = "DEBT_PAYMENT"
 	EventTypeRateReset                        EventType = "RATE_RESET"
+	EventTypeRefinance                        EventType = "REFINANCE"
+	EventTypeExtraPrincipalPayment            EventType = "EXTRA_PRINCIPAL_PAYMENT"
+	EventTypeRothConversionLadder             EventType = "ROTH_CONVERSION_LADDER"
 	EventTypeRealEstatePurchase               EventType = "REAL_ESTATE_PURCHASE"
 	EventTypeRealEstateSale                   EventType = "REAL_ESTATE_SALE"
+	EventTypeRentalPropertyPurchase           EventType = "RENTAL_PROPERTY_PURCHASE"
+	EventTypeRentalPropertySale               EventType = "RENTAL_PROPERTY_SALE"
+	EventTypeRelocate                         EventType = "RELOCATE"
 	EventTypeSocialSecurityIncome             EventType = "SOCIAL_SECURITY_INCOME"
+	EventTypeSocialSecurityClaim              EventType = "SOCIAL_SECURITY_CLAIM"
 	EventTypeHealthcareCost                   EventType = "HEALTHCARE_COST"
 	EventTypeStrategyAssetAllocationSet       EventType = "STRATEGY_ASSET_ALLOCATION_SET"
 	EventTypeStrategyRebalancingRuleSet       EventType = "STRATEGY_REBALANCING_RULE_SET"
@@ -60,12 +67,14 @@ const (
 	EventTypeLongTermCarePayout               EventType = "LONG_TERM_CARE_PAYOUT"
 	EventTypeFiveTwoNineContribution          EventType = "FIVE_TWO_NINE_CONTRIBUTION"
 	EventTypeFiveTwoNineWithdrawal            EventType = "FIVE_TWO_NINE_WITHDRAWAL"
+	EventTypeFiveTwoNineRothRollover          EventType = "FIVE_TWO_NINE_ROTH_ROLLOVER"
 	EventTypeHSAContribution                  EventType = "HSA_CONTRIBUTION"
 	EventTypeHSAWithdrawal                    EventType = "HSA_WITHDRAWAL"
 	EventTypeTuitionPayment                   EventType = "TUITION_PAYMENT"
 	EventTypeBusinessIncome                   EventType = "BUSINESS_INCOME"
 	EventTypeQuarterlyEstimatedTaxPayment     EventType = "QUARTERLY_ESTIMATED_TAX_PAYMENT"
 	EventTypeHealthInsurancePremium           EventType = "HEALTH_INSURANCE_PREMIUM"
+	EventTypeACAMarketplaceCoverage           EventType = "ACA_MARKETPLACE_COVERAGE"
 	EventTypePropertyInsurance                EventType = "PROPERTY_INSURANCE"
 	EventTypeCarPurchase                      EventType = "CAR_PURCHASE"
 	EventTypeHomeRenovation                   EventType = "HOME_RENOVATION"
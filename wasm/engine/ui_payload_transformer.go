@@ -1,5 +1,5 @@
 // UI Payload Transformer - Converts raw simulation results to UI-ready SimulationPayload
-package main
+package engine
 
 import (
 	"fmt"
@@ -268,6 +268,11 @@ func generatePlanSummary(results SimulationResults, input SimulationInput, sampl
 		P5FinalValue:  results.FinalNetWorthP5,
 		P95FinalValue: results.FinalNetWorthP95,
 
+		// Final values in today's dollars
+		P10FinalValueReal: results.FinalNetWorthRealP10,
+		P50FinalValueReal: results.FinalNetWorthRealP50,
+		P90FinalValueReal: results.FinalNetWorthRealP90,
+
 		// Min cash KPIs
 		MinCashP5:  results.MinCashP5,
 		MinCashP50: results.MinCashP50,
@@ -324,6 +329,8 @@ func generatePlanSummary(results SimulationResults, input SimulationInput, sampl
 		ProbabilityOfBankruptcy: results.ProbabilityOfBankruptcy,
 		BankruptcyCount:         results.BankruptcyCount,
 		NumberOfRuns:            results.NumberOfRuns,
+		Longevity:               results.Longevity,
+		Spending:                results.Spending,
 	}
 }
 
@@ -412,6 +419,8 @@ func generateAnnualSnapshots(medianPath SimulationResult, input SimulationInput)
 				Amount:  lastMonth.NetWorth - previousNetWorth,
 				Percent: calculatePercentageChange(previousNetWorth, lastMonth.NetWorth),
 			},
+			NetWorthReal: math.Max(lastMonth.NetWorthReal, 0),
+			PriceIndex:   lastMonth.PriceIndex,
 
 			BalanceSheet: generateBalanceSheet(lastMonth),
 			CashFlow:     cashFlow,
@@ -424,6 +433,13 @@ func generateAnnualSnapshots(medianPath SimulationResult, input SimulationInput)
 			StrategyAnalysis:   generateStrategyAnalysis(lastMonth),
 		}
 
+		if lastMonth.MedicarePremiumsAnnual != nil {
+			snapshot.MedicarePremiums = *lastMonth.MedicarePremiumsAnnual
+		}
+		if lastMonth.IRMAAMedicarePremiumAdjustment != nil {
+			snapshot.IRMAASurcharge = *lastMonth.IRMAAMedicarePremiumAdjustment
+		}
+
 		snapshots[strconv.Itoa(year)] = snapshot
 	}
 
@@ -1205,60 +1221,68 @@ func generateNetWorthChart(samplePaths []SimulationResult, medianPath Simulation
 
     years := len(medianPath.MonthlyData) / 12
 
-    // Build sample path yearly net worth series
+    // Build sample path yearly net worth series, nominal and in today's dollars
     samplePathValues := make([][]float64, 0, len(samplePaths))
+    realPathValues := make([][]float64, 0, len(samplePaths))
     for _, path := range samplePaths {
         yearVals := make([]float64, 0, years)
+        realVals := make([]float64, 0, years)
         for y := 0; y < years; y++ {
             idx := y*12 + 11
             if idx < len(path.MonthlyData) {
                 yearVals = append(yearVals, path.MonthlyData[idx].NetWorth)
+                realVals = append(realVals, path.MonthlyData[idx].NetWorthReal)
             }
         }
         if len(yearVals) == years {
             samplePathValues = append(samplePathValues, yearVals)
+            realPathValues = append(realPathValues, realVals)
         }
     }
 
-    // Compute percentiles across sample paths per year
-    ts := make([]NetWorthTimeSeriesPoint, 0, years)
-    for y := 0; y < years; y++ {
-        vals := make([]float64, 0, len(samplePathValues))
-        for _, sp := range samplePathValues {
+    // yearPercentiles returns P10-P90 of year y across the series, or the median
+    // path's value when no sample path covers it
+    yearPercentiles := func(series [][]float64, y int, median float64) [5]float64 {
+        vals := make([]float64, 0, len(series))
+        for _, sp := range series {
             if y < len(sp) {
                 vals = append(vals, sp[y])
             }
         }
-        var p10, p25, p50, p75, p90 float64
         if len(vals) == 0 {
-            idx := y*12 + 11
-            v := medianPath.MonthlyData[idx].NetWorth
-            p10, p25, p50, p75, p90 = v, v, v, v, v
-        } else {
-            sort.Float64s(vals)
-            getPct := func(p float64) float64 {
-                if len(vals) == 1 {
-                    return vals[0]
-                }
-                pos := p * float64(len(vals)-1)
-                lo := int(pos)
-                hi := lo
-                if float64(lo) < pos {
-                    hi = lo + 1
-                }
-                if hi >= len(vals) {
-                    hi = len(vals) - 1
-                }
-                w := pos - float64(lo)
-                return vals[lo]*(1-w) + vals[hi]*w
+            return [5]float64{median, median, median, median, median}
+        }
+        sort.Float64s(vals)
+        getPct := func(p float64) float64 {
+            if len(vals) == 1 {
+                return vals[0]
+            }
+            pos := p * float64(len(vals)-1)
+            lo := int(pos)
+            hi := lo
+            if float64(lo) < pos {
+                hi = lo + 1
             }
-            p10 = getPct(0.10)
-            p25 = getPct(0.25)
-            p50 = getPct(0.50)
-            p75 = getPct(0.75)
-            p90 = getPct(0.90)
+            if hi >= len(vals) {
+                hi = len(vals) - 1
+            }
+            w := pos - float64(lo)
+            return vals[lo]*(1-w) + vals[hi]*w
         }
-        ts = append(ts, NetWorthTimeSeriesPoint{Year: startYear + y, P10: p10, P25: p25, P50: p50, P75: p75, P90: p90})
+        return [5]float64{getPct(0.10), getPct(0.25), getPct(0.50), getPct(0.75), getPct(0.90)}
+    }
+
+    // Compute percentiles across sample paths per year
+    ts := make([]NetWorthTimeSeriesPoint, 0, years)
+    for y := 0; y < years; y++ {
+        median := medianPath.MonthlyData[y*12+11]
+        nominal := yearPercentiles(samplePathValues, y, median.NetWorth)
+        todays := yearPercentiles(realPathValues, y, median.NetWorthReal)
+        ts = append(ts, NetWorthTimeSeriesPoint{
+            Year: startYear + y,
+            P10:  nominal[0], P25: nominal[1], P50: nominal[2], P75: nominal[3], P90: nominal[4],
+            RealP10: todays[0], RealP25: todays[1], RealP50: todays[2], RealP75: todays[3], RealP90: todays[4],
+        })
     }
 
     recMax := 0.0
@@ -1543,6 +1567,8 @@ func generateEventMarkers(medianPath SimulationResult, input SimulationInput) []
 				event.Type == "LIABILITY_ADD" ||
 				event.Type == "REAL_ESTATE_PURCHASE" ||
 				event.Type == "REAL_ESTATE_SALE" ||
+				event.Type == "RENTAL_PROPERTY_PURCHASE" ||
+				event.Type == "RENTAL_PROPERTY_SALE" ||
 				event.Type == "RSU_VESTING" ||
 				event.Type == "ROTH_CONVERSION" ||
 				event.Type == "MEGA_BACKDOOR_ROTH" ||
@@ -2291,11 +2317,12 @@ func generateSpreadsheetData(samplePaths []SimulationResult, input SimulationInp
 	// Collect annual data from each path
 	// Structure: pathIdx -> yearIdx -> {income, expenses, taxes, savings, netWorth}
 	type yearlyMetrics struct {
-		income   float64
-		expenses float64
-		taxes    float64
-		savings  float64
-		netWorth float64
+		income       float64
+		expenses     float64
+		taxes        float64
+		savings      float64
+		netWorth     float64
+		netWorthReal float64
 	}
 
 	allPathsData := make([][]yearlyMetrics, len(samplePaths))
@@ -2325,11 +2352,12 @@ func generateSpreadsheetData(samplePaths []SimulationResult, input SimulationInp
 			decemberIdx := y*12 + 11
 			if decemberIdx < len(path.MonthlyData) {
 				pathYearData[y] = yearlyMetrics{
-					income:   yearIncome,
-					expenses: yearExpenses,
-					taxes:    yearTaxes,
-					savings:  yearSavings,
-					netWorth: path.MonthlyData[decemberIdx].NetWorth,
+					income:       yearIncome,
+					expenses:     yearExpenses,
+					taxes:        yearTaxes,
+					savings:      yearSavings,
+					netWorth:     path.MonthlyData[decemberIdx].NetWorth,
+					netWorthReal: path.MonthlyData[decemberIdx].NetWorthReal,
 				}
 			}
 		}
@@ -2357,7 +2385,7 @@ func generateSpreadsheetData(samplePaths []SimulationResult, input SimulationInp
 	// Compute percentiles for each year
 	spreadsheetYears := make([]SpreadsheetYearData, 0, years)
 	for y := 0; y < years; y++ {
-		var incomes, expenses, taxes, savings, netWorths []float64
+		var incomes, expenses, taxes, savings, netWorths, netWorthsReal []float64
 
 		for _, pathData := range allPathsData {
 			if pathData == nil || y >= len(pathData) {
@@ -2368,6 +2396,7 @@ func generateSpreadsheetData(samplePaths []SimulationResult, input SimulationInp
 			taxes = append(taxes, pathData[y].taxes)
 			savings = append(savings, pathData[y].savings)
 			netWorths = append(netWorths, pathData[y].netWorth)
+			netWorthsReal = append(netWorthsReal, pathData[y].netWorthReal)
 		}
 
 		// Sort all slices for percentile calculation
@@ -2376,6 +2405,7 @@ func generateSpreadsheetData(samplePaths []SimulationResult, input SimulationInp
 		sort.Float64s(taxes)
 		sort.Float64s(savings)
 		sort.Float64s(netWorths)
+		sort.Float64s(netWorthsReal)
 
 		spreadsheetYears = append(spreadsheetYears, SpreadsheetYearData{
 			Year: startYear + y,
@@ -2405,6 +2435,11 @@ func generateSpreadsheetData(samplePaths []SimulationResult, input SimulationInp
 				P50: getPercentile(netWorths, 0.50),
 				P90: getPercentile(netWorths, 0.90),
 			},
+			NetWorthReal: SpreadsheetPercentiles{
+				P10: getPercentile(netWorthsReal, 0.10),
+				P50: getPercentile(netWorthsReal, 0.50),
+				P90: getPercentile(netWorthsReal, 0.90),
+			},
 		})
 	}
 
@@ -2537,6 +2572,7 @@ func aggregateNetWorthTrajectory(samplePaths []SimulationResult, input Simulatio
 
 		// Collect net worth with path index, and annual spending
 		pathNetWorths := make([]pathNW, 0, len(samplePaths))
+		realNetWorths := make([]float64, 0, len(samplePaths))
 		annualSpending := make([]float64, 0, len(samplePaths))
 		solventCount := 0
 
@@ -2550,6 +2586,7 @@ func aggregateNetWorthTrajectory(samplePaths []SimulationResult, input Simulatio
 			if monthOffset < len(path.MonthlyData) {
 				nw := path.MonthlyData[monthOffset].NetWorth
 				pathNetWorths = append(pathNetWorths, pathNW{nw: nw, index: i})
+				realNetWorths = append(realNetWorths, path.MonthlyData[monthOffset].NetWorthReal)
 				if nw > 0 {
 					solventCount++
 				}
@@ -2560,6 +2597,7 @@ func aggregateNetWorthTrajectory(samplePaths []SimulationResult, input Simulatio
 				annualSpending = append(annualSpending, yearSpending)
 			} else {
 				pathNetWorths = append(pathNetWorths, pathNW{nw: 0, index: i})
+				realNetWorths = append(realNetWorths, 0)
 				var yearSpending float64
 				for m := yearStartMonth; m <= monthOffset && m < len(path.MonthlyData); m++ {
 					yearSpending += path.MonthlyData[m].ExpensesThisMonth
@@ -2582,6 +2620,7 @@ func aggregateNetWorthTrajectory(samplePaths []SimulationResult, input Simulatio
 		for i, pnw := range pathNetWorths {
 			netWorths[i] = pnw.nw
 		}
+		sort.Float64s(realNetWorths)
 		sort.Float64s(annualSpending)
 
 		pctPathsFunded := float64(solventCount) / float64(len(samplePaths))
@@ -2600,6 +2639,11 @@ func aggregateNetWorthTrajectory(samplePaths []SimulationResult, input Simulatio
 			P50:            getPct(netWorths, 0.50),
 			P75:            getPct(netWorths, 0.75),
 			P90:            getPct(netWorths, 0.90),
+			RealP10:        getPct(realNetWorths, 0.10),
+			RealP25:        getPct(realNetWorths, 0.25),
+			RealP50:        getPct(realNetWorths, 0.50),
+			RealP75:        getPct(realNetWorths, 0.75),
+			RealP90:        getPct(realNetWorths, 0.90),
 			PctPathsFunded: pctPathsFunded,
 			SpendingP10:    getPct(annualSpending, 0.10),
 			SpendingP50:    getPct(annualSpending, 0.50),
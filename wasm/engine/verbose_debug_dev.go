@@ -1,7 +1,7 @@
 //go:build !production
 // +build !production
 
-package main
+package engine
 
 // VERBOSE_DEBUG controls verbose logging for development builds
 const VERBOSE_DEBUG = true
@@ -1,7 +1,7 @@
 //go:build production
 // +build production
 
-package main
+package engine
 
 // VERBOSE_DEBUG disables verbose logging for production builds
 const VERBOSE_DEBUG = false
@@ -1,7 +1,7 @@
 //go:build js && wasm
 // +build js,wasm
 
-package main
+package engine
 
 import (
 	"encoding/json"
@@ -236,19 +236,23 @@ func runSimulationWithUIPayload(this js.Value, inputs []js.Value) interface{} {
     // Always apply full defaults (GARCH, volatility, correlation, FatTailParameter, etc.)
     // then restore user-provided overrides. Previously this only applied when all three
     // means were 0, which left GARCH/volatility/correlation empty when means were overridden.
-    // CRITICAL: Preserve RandomSeed, SimulationMode, CashFloor, LiteMode, and any user mean overrides.
+    // CRITICAL: Preserve RandomSeed, SimulationMode, BootstrapMeanBlockLength, CashFloor, LiteMode, StressScenarios, and any user mean overrides.
     savedSeed := input.Config.RandomSeed
     savedMode := input.Config.SimulationMode
+    savedBlockLength := input.Config.BootstrapMeanBlockLength
     savedCashFloor := input.Config.CashFloor
     savedLiteMode := input.Config.LiteMode
+    savedStress := input.Config.StressScenarios
     savedMeanSPY := input.Config.MeanSPYReturn
     savedMeanBond := input.Config.MeanBondReturn
     savedMeanInflation := input.Config.MeanInflation
     input.Config = GetDefaultStochasticConfig()
     input.Config.RandomSeed = savedSeed
     input.Config.SimulationMode = savedMode
+    input.Config.BootstrapMeanBlockLength = savedBlockLength
     input.Config.CashFloor = savedCashFloor
     input.Config.LiteMode = savedLiteMode
+    input.Config.StressScenarios = savedStress
     // Restore user mean overrides (non-zero values override defaults)
     if savedMeanSPY != 0 { input.Config.MeanSPYReturn = savedMeanSPY }
     if savedMeanBond != 0 { input.Config.MeanBondReturn = savedMeanBond }
@@ -693,14 +697,21 @@ func generateQuickstartPlan(this js.Value, inputs []js.Value) interface{} {
 	return js.Global().Get("JSON").Call("parse", string(resultJSON))
 }
 
-// runBacktest executes historical backtesting scenarios
+// runBacktest replays the plan from every historical start year (rolling cohorts)
+// Input: {"mode": "rolling", "windowMonths": 360, "input": SimulationInput}
+// Named scenarios read historical_data.json from disk and are only available
+// through the backtest CLI, not in the browser.
 func runBacktest(this js.Value, inputs []js.Value) interface{} {
 	simLogVerbose("🔬 BACKTEST: runBacktest called")
 
 	// Parse input JSON
 	inputJSON := inputs[0].String()
 
-	var backtestInput map[string]interface{}
+	var backtestInput struct {
+		Mode         string          `json:"mode"`
+		WindowMonths int             `json:"windowMonths"`
+		Input        SimulationInput `json:"input"`
+	}
 	if err := json.Unmarshal([]byte(inputJSON), &backtestInput); err != nil {
 		return map[string]interface{}{
 			"success": false,
@@ -708,32 +719,98 @@ func runBacktest(this js.Value, inputs []js.Value) interface{} {
 		}
 	}
 
-	scenarioName, ok := backtestInput["scenarioName"].(string)
-	if !ok {
+	if backtestInput.Mode != "rolling" {
 		return map[string]interface{}{
 			"success": false,
-			"error":   "Missing or invalid scenarioName in input",
+			"error":   "Unsupported backtest mode \"" + backtestInput.Mode + "\" - use mode \"rolling\"",
 		}
 	}
 
-	simLogVerbose("🔬 BACKTEST: Running scenario '%s'", scenarioName)
+	simLogVerbose("🔬 BACKTEST: Rolling cohorts, window %d months", backtestInput.WindowMonths)
 
-	// TODO: Restore historical data functionality after fixing data loading
-	// SIMPLIFIED: Access scenario directly from unified historical data structure
-	// if historicalData == nil {
-	// 	return map[string]interface{}{
-	// 		"success": false,
-	// 		"error":   "Historical data not loaded - run InitializeFinancialData first",
-	// 	}
-	// }
+	result, err := RunRollingBacktest(backtestInput.Input, backtestInput.WindowMonths)
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   "Rolling backtest failed: " + err.Error(),
+		}
+	}
 
-	return map[string]interface{}{
-		"success": false,
-		"error":   "Historical backtesting temporarily disabled - focus on quickstart integration",
+	resultJSON, err := json.Marshal(map[string]interface{}{
+		"success": true,
+		"rolling": result,
+	})
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   "Failed to serialize result: " + err.Error(),
+		}
+	}
+	return js.Global().Get("JSON").Call("parse", string(resultJSON))
+}
+
+// solveGoal bisects one plan input against a Monte Carlo target on fixed path seeds
+// Input: {"input": SimulationInput, "variable": "spending", "target": {"metric": "terminal_wealth_p10", "threshold": 0}}
+func solveGoal(this js.Value, inputs []js.Value) interface{} {
+	var request GoalSeekRequest
+	if err := json.Unmarshal([]byte(inputs[0].String()), &request); err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   "Failed to parse goal seek request: " + err.Error(),
+		}
+	}
+
+	result, err := SolveGoal(request)
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   "Goal seek failed: " + err.Error(),
+		}
 	}
 
-	// TODO: Restore the full backtest functionality after fixing historicalData loading
-	// The original implementation relied on historicalData global variable
+	resultJSON, err := json.Marshal(map[string]interface{}{
+		"success": true,
+		"goal":    result,
+	})
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   "Failed to serialize result: " + err.Error(),
+		}
+	}
+	return js.Global().Get("JSON").Call("parse", string(resultJSON))
+}
+
+// compareScenarios runs plan variants on shared path seeds and reports paired differences
+// Input: {"variants": [{"name": "baseline", "input": SimulationInput}, ...], "paths": 200}
+func compareScenarios(this js.Value, inputs []js.Value) interface{} {
+	var request ScenarioComparisonRequest
+	if err := json.Unmarshal([]byte(inputs[0].String()), &request); err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   "Failed to parse comparison request: " + err.Error(),
+		}
+	}
+
+	result, err := CompareScenarios(request)
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   "Scenario comparison failed: " + err.Error(),
+		}
+	}
+
+	resultJSON, err := json.Marshal(map[string]interface{}{
+		"success":    true,
+		"comparison": result,
+	})
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   "Failed to serialize result: " + err.Error(),
+		}
+	}
+	return js.Global().Get("JSON").Call("parse", string(resultJSON))
 }
 
 // convertMonthlyScenarioToHistorical function removed - converting monthly to annual data
@@ -771,6 +848,8 @@ func registerWASMFunctions() {
     registerJSFunc("goCalculateGoalFormSuggestions", calculateGoalFormSuggestions)
     registerJSFunc("goCalculateQuickstartGoalAnalysis", calculateQuickstartGoalAnalysis)
     registerJSFunc("goPreviewFireTarget", previewFireTarget)
+    registerJSFunc("goSolveGoal", solveGoal)
+    registerJSFunc("goCompareScenarios", compareScenarios)
 }
 
 // Phase 3: Financial calculation functions implementation
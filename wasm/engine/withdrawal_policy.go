@@ -0,0 +1,426 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+)
+
+/**
+ * Retirement Withdrawal Policies
+ *
+ * A WithdrawalPolicy sets each retirement year's spending from the state of the path at
+ * the start of that year: the portfolio value, the primary person's age, last year's
+ * spending, and the inflation and investment return realized since. The engine asks the
+ * policy once a year from WithdrawalPolicyConfig.StartMonth and spends a twelfth of the
+ * answer each month as an expense, which the cash check funds from the portfolio like any
+ * other shortfall. Spending never exceeds the portfolio.
+ *
+ *   - guyton_klinger: inflation-adjusted spending with three decision rules. No inflation
+ *     raise after a year with a negative return while the withdrawal rate is above its
+ *     initial value; a 10% cut when the rate rises 20% above the initial rate (capital
+ *     preservation, not applied in the final 15 years before EndAge); a 10% raise when it
+ *     falls 20% below (prosperity). Inflation raises are capped at 6%.
+ *   - vpw: the Bogleheads Variable Percentage Withdrawal table. Each year spends the
+ *     payment that would empty the portfolio by EndAge at the allocation's expected real
+ *     return (5.0% stocks, 1.9% bonds), paid at the start of the year.
+ *   - rmd: the portfolio divided by the IRS Uniform Lifetime Table divisor for the age.
+ *     Before 72 the divisor grows by one per year of age.
+ *   - floor_ceiling: Vanguard's dynamic spending. The target is InitialRate of the
+ *     portfolio, kept within 5% above and 2.5% below last year's real spending.
+ *   - kitces_ratchet: inflation-adjusted spending from InitialRate that rises 10% when the
+ *     real portfolio is 50% above where it started, at most once every three years, and
+ *     is never cut.
+ *
+ * Each path records its realized spending by retirement year, nominal and in today's
+ * dollars, so Monte Carlo results report the distribution of spending as well as of
+ * balances.
+ *
+ * References: Guyton & Klinger (2006), Journal of Financial Planning; Bogleheads wiki,
+ * "Variable percentage withdrawal"; Vanguard (2020), "From assets to income"; Kitces
+ * (2015), "The Ratcheting Safe Withdrawal Rate"
+ */
+
+// Withdrawal policy types
+const (
+	WithdrawalPolicyGuytonKlinger = "guyton_klinger"
+	WithdrawalPolicyVPW           = "vpw"
+	WithdrawalPolicyRMD           = "rmd"
+	WithdrawalPolicyFloorCeiling  = "floor_ceiling"
+	WithdrawalPolicyKitcesRatchet = "kitces_ratchet"
+)
+
+const (
+	defaultWithdrawalEndAge = 100
+
+	// VPW table real returns
+	vpwStockReturn = 0.050
+	vpwBondReturn  = 0.019
+
+	guytonKlingerInflationCap      = 0.06
+	guytonKlingerPreservationYears = 15
+)
+
+// WithdrawalPolicyConfig selects the rule that sets retirement spending. Zero fields take
+// the policy's published defaults.
+type WithdrawalPolicyConfig struct {
+	Type        string  `json:"type"`                  // guyton_klinger, vpw, rmd, floor_ceiling or kitces_ratchet
+	StartMonth  int     `json:"startMonth,omitempty"`  // First month of retirement spending
+	InitialRate float64 `json:"initialRate,omitempty"` // First-year spending as a share of the portfolio (0 = 5% Guyton-Klinger, 4% otherwise)
+	EndAge      int     `json:"endAge,omitempty"`      // Planning horizon for VPW and the capital preservation rule (0 = 100)
+
+	StockAllocation float64 `json:"stockAllocation,omitempty"` // VPW: share in stocks (0 = 0.6)
+	Guardrail       float64 `json:"guardrail,omitempty"`       // Guyton-Klinger: rate change that triggers a rule (0 = 0.20)
+	Adjustment      float64 `json:"adjustment,omitempty"`      // Guyton-Klinger and ratchet: size of a cut or raise (0 = 0.10)
+	Ceiling         float64 `json:"ceiling,omitempty"`         // Floor-ceiling: largest real raise (0 = 0.05)
+	Floor           float64 `json:"floor,omitempty"`           // Floor-ceiling: largest real cut (0 = 0.025)
+	RatchetTrigger  float64 `json:"ratchetTrigger,omitempty"`  // Ratchet: real portfolio growth that triggers a raise (0 = 0.50)
+	RatchetYears    int     `json:"ratchetYears,omitempty"`    // Ratchet: minimum years between raises (0 = 3)
+}
+
+// WithdrawalState is the path's state at the start of a retirement year
+type WithdrawalState struct {
+	Year                int     // Retirement year, 0 for the first
+	Age                 int     // Primary person's age
+	PortfolioValue      float64 // Cash and investment accounts
+	InitialPortfolio    float64 // Portfolio value when spending started
+	LastWithdrawal      float64 // Last year's spending (0 in the first year)
+	Inflation           float64 // Inflation over the last year
+	CumulativeInflation float64 // Price level relative to the first retirement year
+	PortfolioReturn     float64 // Investment return over the last year
+}
+
+// WithdrawalPolicy sets a retirement year's spending
+type WithdrawalPolicy interface {
+	AnnualWithdrawal(state WithdrawalState) float64
+}
+
+// PathSpending records the spending a withdrawal policy realized on one path
+type PathSpending struct {
+	Annual     []float64 `json:"annual"`     // Spending by retirement year
+	AnnualReal []float64 `json:"annualReal"` // The same in today's dollars
+	MeanReal   float64   `json:"meanReal"`   // Average year in today's dollars
+	MinReal    float64   `json:"minReal"`    // Lowest year in today's dollars
+}
+
+// SpendingSummary is the distribution of realized spending across paths, in today's dollars
+type SpendingSummary struct {
+	MeanP10 float64 `json:"meanP10"` // Each path's average retirement year
+	MeanP25 float64 `json:"meanP25"`
+	MeanP50 float64 `json:"meanP50"`
+	MeanP75 float64 `json:"meanP75"`
+	MeanP90 float64 `json:"meanP90"`
+
+	MinP10 float64 `json:"minP10"` // Each path's lowest retirement year
+	MinP50 float64 `json:"minP50"`
+	MinP90 float64 `json:"minP90"`
+
+	ByYear []SpendingYearPercentiles `json:"byYear"`
+}
+
+// SpendingYearPercentiles is the spread of one retirement year's spending across paths
+type SpendingYearPercentiles struct {
+	Year  int     `json:"year"`  // Retirement year, 0 for the first
+	Paths int     `json:"paths"` // Paths that reached the year
+	P10   float64 `json:"p10"`
+	P25   float64 `json:"p25"`
+	P50   float64 `json:"p50"`
+	P75   float64 `json:"p75"`
+	P90   float64 `json:"p90"`
+}
+
+// NewWithdrawalPolicy builds the policy a configuration selects
+func NewWithdrawalPolicy(config WithdrawalPolicyConfig) (WithdrawalPolicy, error) {
+	endAge := config.EndAge
+	if endAge == 0 {
+		endAge = defaultWithdrawalEndAge
+	}
+	switch config.Type {
+	case WithdrawalPolicyGuytonKlinger:
+		return &guytonKlingerPolicy{
+			initialRate: orDefault(config.InitialRate, 0.05),
+			guardrail:   orDefault(config.Guardrail, 0.20),
+			adjustment:  orDefault(config.Adjustment, 0.10),
+			endAge:      endAge,
+		}, nil
+	case WithdrawalPolicyVPW:
+		if config.StockAllocation < 0 || config.StockAllocation > 1 {
+			return nil, fmt.Errorf("withdrawal policy stockAllocation %.2f must be between 0 and 1", config.StockAllocation)
+		}
+		return &vpwPolicy{stockAllocation: orDefault(config.StockAllocation, 0.6), endAge: endAge}, nil
+	case WithdrawalPolicyRMD:
+		return &rmdPolicy{table: getUniformLifetimeTable()}, nil
+	case WithdrawalPolicyFloorCeiling:
+		return &floorCeilingPolicy{
+			initialRate: orDefault(config.InitialRate, 0.04),
+			ceiling:     orDefault(config.Ceiling, 0.05),
+			floor:       orDefault(config.Floor, 0.025),
+		}, nil
+	case WithdrawalPolicyKitcesRatchet:
+		years := config.RatchetYears
+		if years == 0 {
+			years = 3
+		}
+		return &kitcesRatchetPolicy{
+			initialRate: orDefault(config.InitialRate, 0.04),
+			trigger:     orDefault(config.RatchetTrigger, 0.50),
+			adjustment:  orDefault(config.Adjustment, 0.10),
+			years:       years,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown withdrawal policy %q", config.Type)
+	}
+}
+
+// orDefault returns value, or fallback when value is zero
+func orDefault(value, fallback float64) float64 {
+	if value == 0 {
+		return fallback
+	}
+	return value
+}
+
+// guytonKlingerPolicy applies the Guyton-Klinger decision rules
+type guytonKlingerPolicy struct {
+	initialRate float64
+	guardrail   float64
+	adjustment  float64
+	endAge      int
+}
+
+func (p *guytonKlingerPolicy) AnnualWithdrawal(s WithdrawalState) float64 {
+	if s.Year == 0 || s.PortfolioValue <= 0 {
+		return s.PortfolioValue * p.initialRate
+	}
+	spending := s.LastWithdrawal
+	if !(s.PortfolioReturn < 0 && spending/s.PortfolioValue > p.initialRate) {
+		spending *= 1 + math.Min(s.Inflation, guytonKlingerInflationCap)
+	}
+	rate := spending / s.PortfolioValue
+	if rate > p.initialRate*(1+p.guardrail) && p.endAge-s.Age > guytonKlingerPreservationYears {
+		spending *= 1 - p.adjustment
+	} else if rate < p.initialRate*(1-p.guardrail) {
+		spending *= 1 + p.adjustment
+	}
+	return spending
+}
+
+// vpwPolicy spends the VPW table percentage for the age
+type vpwPolicy struct {
+	stockAllocation float64
+	endAge          int
+}
+
+func (p *vpwPolicy) AnnualWithdrawal(s WithdrawalState) float64 {
+	return s.PortfolioValue * vpwRate(s.Age, p.stockAllocation, p.endAge)
+}
+
+// vpwRate is the VPW table percentage: the start-of-year payment that spends the
+// portfolio down by endAge at the allocation's expected real return
+func vpwRate(age int, stockAllocation float64, endAge int) float64 {
+	years := endAge - age + 1
+	if years <= 1 {
+		return 1
+	}
+	r := stockAllocation*vpwStockReturn + (1-stockAllocation)*vpwBondReturn
+	return r / (1 - math.Pow(1+r, -float64(years))) / (1 + r)
+}
+
+// rmdPolicy spends the portfolio over the Uniform Lifetime Table's distribution period
+type rmdPolicy struct {
+	table map[int]float64
+}
+
+func (p *rmdPolicy) AnnualWithdrawal(s WithdrawalState) float64 {
+	return s.PortfolioValue / p.divisor(s.Age)
+}
+
+func (p *rmdPolicy) divisor(age int) float64 {
+	if age < 72 {
+		return p.table[72] + float64(72-age)
+	}
+	if d, ok := p.table[age]; ok {
+		return d
+	}
+	return p.table[120]
+}
+
+// floorCeilingPolicy follows a share of the portfolio within bands around last year's
+// real spending
+type floorCeilingPolicy struct {
+	initialRate float64
+	ceiling     float64
+	floor       float64
+}
+
+func (p *floorCeilingPolicy) AnnualWithdrawal(s WithdrawalState) float64 {
+	target := s.PortfolioValue * p.initialRate
+	if s.Year == 0 {
+		return target
+	}
+	prior := s.LastWithdrawal * (1 + s.Inflation)
+	return math.Max(prior*(1-p.floor), math.Min(target, prior*(1+p.ceiling)))
+}
+
+// kitcesRatchetPolicy raises inflation-adjusted spending after strong growth
+type kitcesRatchetPolicy struct {
+	initialRate float64
+	trigger     float64
+	adjustment  float64
+	years       int
+	lastRatchet int
+}
+
+func (p *kitcesRatchetPolicy) AnnualWithdrawal(s WithdrawalState) float64 {
+	if s.Year == 0 {
+		p.lastRatchet = 0
+		return s.PortfolioValue * p.initialRate
+	}
+	spending := s.LastWithdrawal * (1 + s.Inflation)
+	realPortfolio := s.PortfolioValue / s.CumulativeInflation
+	if s.Year-p.lastRatchet >= p.years && realPortfolio >= s.InitialPortfolio*(1+p.trigger) {
+		spending *= 1 + p.adjustment
+		p.lastRatchet = s.Year
+	}
+	return spending
+}
+
+// pathWithdrawal is a path's withdrawal policy and the spending it has set
+type pathWithdrawal struct {
+	policy     WithdrawalPolicy
+	startMonth int
+
+	annual           float64 // This retirement year's spending
+	initialPortfolio float64
+	yearStartLevel   float64 // Price level when the year's spending was set
+	yearGrowth       float64 // Investment growth factor since then
+
+	spending PathSpending
+}
+
+// startWithdrawalPolicy sets up the path's withdrawal policy, if the input has one
+func (se *SimulationEngine) startWithdrawalPolicy(input *SimulationInput) error {
+	se.withdrawal = nil
+	if input.WithdrawalPolicy == nil {
+		return nil
+	}
+	policy, err := NewWithdrawalPolicy(*input.WithdrawalPolicy)
+	if err != nil {
+		return err
+	}
+	se.withdrawal = &pathWithdrawal{policy: policy, startMonth: input.WithdrawalPolicy.StartMonth, yearGrowth: 1}
+	return nil
+}
+
+// recordPortfolioGrowth compounds a month's investment growth into the retirement
+// year's return
+func (se *SimulationEngine) recordPortfolioGrowth(before, after float64) {
+	if se.withdrawal != nil && before > 0 {
+		se.withdrawal.yearGrowth *= after / before
+	}
+}
+
+// withdrawalPortfolioValue is the cash and investment accounts a policy spends from
+func withdrawalPortfolioValue(accounts *AccountHoldingsMonthEnd) float64 {
+	return accounts.Cash + getAccountVal(accounts.Taxable) + getAccountVal(accounts.TaxDeferred) + getAccountVal(accounts.Roth)
+}
+
+// processWithdrawalPolicy sets spending at the start of each retirement year and spends
+// a twelfth of it every month
+func (se *SimulationEngine) processWithdrawalPolicy(accounts *AccountHoldingsMonthEnd, monthOffset int) {
+	w := se.withdrawal
+	if w == nil || monthOffset < w.startMonth {
+		return
+	}
+	level := se.priceLevel(monthOffset)
+
+	if year := (monthOffset - w.startMonth) / 12; (monthOffset-w.startMonth)%12 == 0 {
+		portfolio := math.Max(0, withdrawalPortfolioValue(accounts))
+		state := WithdrawalState{Year: year, Age: se.primaryAge(monthOffset), PortfolioValue: portfolio, CumulativeInflation: 1}
+		if year == 0 {
+			w.initialPortfolio = portfolio
+			w.yearStartLevel = level
+		} else {
+			state.LastWithdrawal = w.annual
+			state.Inflation = level/w.yearStartLevel - 1
+			state.PortfolioReturn = w.yearGrowth - 1
+			state.CumulativeInflation = level / se.priceLevel(w.startMonth)
+		}
+		state.InitialPortfolio = w.initialPortfolio
+		w.annual = math.Max(0, math.Min(w.policy.AnnualWithdrawal(state), portfolio))
+		w.yearStartLevel = level
+		w.yearGrowth = 1
+		w.spending.Annual = append(w.spending.Annual, 0)
+		w.spending.AnnualReal = append(w.spending.AnnualReal, 0)
+
+		simLogVerbose("🏖️ [WITHDRAWAL] Month %d: retirement year %d spending $%.2f from a $%.2f portfolio",
+			monthOffset, year, w.annual, portfolio)
+	}
+
+	amount := w.annual / 12
+	if amount <= 0 {
+		return
+	}
+	accounts.Cash -= amount
+	se.currentMonthFlows.ExpensesThisMonth += amount
+	last := len(w.spending.Annual) - 1
+	w.spending.Annual[last] += amount
+	w.spending.AnnualReal[last] += amount / level
+}
+
+// pathSpending returns the spending the path's withdrawal policy realized
+func (se *SimulationEngine) pathSpending() *PathSpending {
+	if se.withdrawal == nil || len(se.withdrawal.spending.Annual) == 0 {
+		return nil
+	}
+	s := se.withdrawal.spending
+	spending := &PathSpending{
+		Annual:     append([]float64(nil), s.Annual...),
+		AnnualReal: append([]float64(nil), s.AnnualReal...),
+		MinReal:    math.Inf(1),
+	}
+	for _, v := range s.AnnualReal {
+		spending.MeanReal += v
+		spending.MinReal = math.Min(spending.MinReal, v)
+	}
+	spending.MeanReal /= float64(len(s.AnnualReal))
+	return spending
+}
+
+// summarizeSpending aggregates realized spending across paths
+func summarizeSpending(paths []*PathSpending) *SpendingSummary {
+	if len(paths) == 0 {
+		return nil
+	}
+	means := make([]float64, 0, len(paths))
+	mins := make([]float64, 0, len(paths))
+	years := 0
+	for _, p := range paths {
+		means = append(means, p.MeanReal)
+		mins = append(mins, p.MinReal)
+		if len(p.AnnualReal) > years {
+			years = len(p.AnnualReal)
+		}
+	}
+	mean := calculatePercentiles(means)
+	low := calculatePercentiles(mins)
+	summary := &SpendingSummary{
+		MeanP10: mean[0], MeanP25: mean[1], MeanP50: mean[2], MeanP75: mean[3], MeanP90: mean[4],
+		MinP10: low[0], MinP50: low[2], MinP90: low[4],
+		ByYear: make([]SpendingYearPercentiles, 0, years),
+	}
+
+	values := make([]float64, 0, len(paths))
+	for year := 0; year < years; year++ {
+		values = values[:0]
+		for _, p := range paths {
+			if year < len(p.AnnualReal) {
+				values = append(values, p.AnnualReal[year])
+			}
+		}
+		pct := calculatePercentiles(values)
+		summary.ByYear = append(summary.ByYear, SpendingYearPercentiles{
+			Year: year, Paths: len(values), P10: pct[0], P25: pct[1], P50: pct[2], P75: pct[3], P90: pct[4],
+		})
+	}
+	return summary
+}
@@ -0,0 +1,155 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWithdrawalPolicyRules(t *testing.T) {
+	policy := func(config WithdrawalPolicyConfig) WithdrawalPolicy {
+		p, err := NewWithdrawalPolicy(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	check := func(name string, got, want float64) {
+		t.Helper()
+		if math.Abs(got-want) > 1e-6 {
+			t.Errorf("%s: %.2f, want %.2f", name, got, want)
+		}
+	}
+
+	// Guyton-Klinger: 5% of the portfolio, then inflation raises within the guardrails
+	gk := policy(WithdrawalPolicyConfig{Type: WithdrawalPolicyGuytonKlinger})
+	check("GK first year", gk.AnnualWithdrawal(WithdrawalState{Age: 65, PortfolioValue: 1e6}), 50000)
+	check("GK inflation raise", gk.AnnualWithdrawal(WithdrawalState{Year: 1, Age: 66, PortfolioValue: 1e6, LastWithdrawal: 50000, Inflation: 0.03, PortfolioReturn: 0.05}), 51500)
+	check("GK inflation cap", gk.AnnualWithdrawal(WithdrawalState{Year: 1, Age: 66, PortfolioValue: 1e6, LastWithdrawal: 50000, Inflation: 0.09, PortfolioReturn: 0.05}), 53000)
+	// A losing year above the initial rate skips the raise, and 6.25% > 6% cuts 10%
+	loss := WithdrawalState{Year: 5, Age: 70, PortfolioValue: 800000, LastWithdrawal: 50000, Inflation: 0.03, PortfolioReturn: -0.10}
+	check("GK capital preservation", gk.AnnualWithdrawal(loss), 45000)
+	loss.Age = 90
+	check("GK no cut in the last 15 years", gk.AnnualWithdrawal(loss), 50000)
+	check("GK prosperity", gk.AnnualWithdrawal(WithdrawalState{Year: 5, Age: 70, PortfolioValue: 1.5e6, LastWithdrawal: 50000, Inflation: 0.03, PortfolioReturn: 0.2}), 51500*1.1)
+
+	// VPW: the published 60/40 table pays 4.9% at 65 and everything at 100
+	if got := math.Round(vpwRate(65, 0.6, 100)*1000) / 10; got != 4.9 {
+		t.Errorf("VPW 60/40 at 65: %.1f%%", got)
+	}
+	if vpwRate(100, 0.6, 100) != 1 || vpwRate(80, 0.6, 100) <= vpwRate(65, 0.6, 100) || vpwRate(65, 1, 100) <= vpwRate(65, 0, 100) {
+		t.Error("VPW percentages should rise with age and stock allocation")
+	}
+	check("VPW", policy(WithdrawalPolicyConfig{Type: WithdrawalPolicyVPW}).AnnualWithdrawal(WithdrawalState{Age: 65, PortfolioValue: 1e6}), 1e6*vpwRate(65, 0.6, 100))
+
+	// RMD: the Uniform Lifetime divisor, extended a year per year of age before 72
+	rmd := policy(WithdrawalPolicyConfig{Type: WithdrawalPolicyRMD})
+	check("RMD at 75", rmd.AnnualWithdrawal(WithdrawalState{Age: 75, PortfolioValue: 1e6}), 1e6/24.6)
+	check("RMD at 65", rmd.AnnualWithdrawal(WithdrawalState{Age: 65, PortfolioValue: 1e6}), 1e6/34.4)
+
+	// Floor-ceiling: 4% of the portfolio within +5%/-2.5% of last year's real spending
+	fc := policy(WithdrawalPolicyConfig{Type: WithdrawalPolicyFloorCeiling})
+	year := WithdrawalState{Year: 3, Age: 68, LastWithdrawal: 40000, Inflation: 0.02}
+	for value, want := range map[float64]float64{1.5e6: 40800 * 1.05, 5e5: 40800 * 0.975, 1.03e6: 41200} {
+		year.PortfolioValue = value
+		check("floor-ceiling", fc.AnnualWithdrawal(year), want)
+	}
+
+	// Kitces: a 10% raise once the real portfolio is 50% up, at most every three years
+	kr := policy(WithdrawalPolicyConfig{Type: WithdrawalPolicyKitcesRatchet})
+	check("ratchet first year", kr.AnnualWithdrawal(WithdrawalState{PortfolioValue: 1e6, InitialPortfolio: 1e6}), 40000)
+	grown := WithdrawalState{Year: 2, PortfolioValue: 1.8e6, InitialPortfolio: 1e6, LastWithdrawal: 40000, Inflation: 0.02, CumulativeInflation: 1.1}
+	check("ratchet waits three years", kr.AnnualWithdrawal(grown), 40800)
+	grown.Year = 3
+	check("ratchet", kr.AnnualWithdrawal(grown), 40800*1.1)
+	grown.Year = 4
+	check("ratchet once per three years", kr.AnnualWithdrawal(grown), 40800)
+	grown.Year, grown.CumulativeInflation = 6, 1.3
+	check("ratchet on real growth", kr.AnnualWithdrawal(grown), 40800)
+
+	if _, err := NewWithdrawalPolicy(WithdrawalPolicyConfig{Type: "four_percent"}); err == nil {
+		t.Error("expected an error for an unknown policy")
+	}
+}
+
+func TestWithdrawalPolicyInSimulation(t *testing.T) {
+	input := createRollingTestInput(0, 0)
+	input.Config.RandomSeed = 11
+	input.InitialAge = 65
+	input.StartYear = 2025
+	input.MonthsToRun = 36
+	input.Events = nil
+	input.InitialAccounts = AccountHoldingsMonthEnd{Cash: 1e6}
+
+	run := func() SimulationResult {
+		result := NewSimulationEngine(input.Config).RunSingleSimulation(input)
+		if !result.Success {
+			t.Fatalf("simulation failed: %s", result.Error)
+		}
+		return result
+	}
+	baseline := run().MonthlyData
+	input.WithdrawalPolicy = &WithdrawalPolicyConfig{Type: WithdrawalPolicyRMD, StartMonth: 12}
+	result := run()
+	months := result.MonthlyData
+	if months[11].ExpensesThisMonth != baseline[11].ExpensesThisMonth {
+		t.Errorf("spending before the start month: %.2f", months[11].ExpensesThisMonth-baseline[11].ExpensesThisMonth)
+	}
+
+	// At 66 the year's spending is the portfolio, after the month's Medicare premium, over
+	// 33.4, a twelfth each month
+	monthly := (withdrawalPortfolioValue(&months[11].Accounts) - months[12].HealthcareExpensesThisMonth) / 33.4 / 12
+	for _, m := range []int{12, 18, 23} {
+		if got := months[m].ExpensesThisMonth - baseline[m].ExpensesThisMonth; math.Abs(got-monthly) > 1e-6 {
+			t.Errorf("month %d spending %.2f, want %.2f", m, got, monthly)
+		}
+	}
+
+	spending := result.Spending
+	if spending == nil || len(spending.Annual) != 2 {
+		t.Fatalf("expected two retirement years, got %+v", spending)
+	}
+	if math.Abs(spending.Annual[0]-12*monthly) > 1e-6 {
+		t.Errorf("first year spending %.2f, want %.2f", spending.Annual[0], 12*monthly)
+	}
+	if spending.AnnualReal[1] >= spending.Annual[1] || spending.MinReal > spending.MeanReal {
+		t.Errorf("real spending %v against nominal %v", spending.AnnualReal, spending.Annual)
+	}
+
+	input.WithdrawalPolicy = &WithdrawalPolicyConfig{Type: "unknown"}
+	if result := NewSimulationEngine(input.Config).RunSingleSimulation(input); result.Success {
+		t.Error("expected an unknown withdrawal policy to fail the simulation")
+	}
+}
+
+func TestMonteCarloSpendingSummary(t *testing.T) {
+	input := createRollingTestInput(1e6, 0)
+	input.Config.RandomSeed = 2024
+	input.Config.SimulationMode = "stochastic"
+	input.InitialAge = 65
+	input.StartYear = 2025
+	input.MonthsToRun = 120
+	input.Events = nil
+	input.WithdrawalPolicy = &WithdrawalPolicyConfig{Type: WithdrawalPolicyGuytonKlinger}
+
+	results := RunMonteCarloSimulation(input, 30)
+	if !results.Success {
+		t.Fatalf("simulation failed: %s", results.Error)
+	}
+	summary := results.Spending
+	if summary == nil || len(summary.ByYear) != 10 {
+		t.Fatalf("expected ten years of spending, got %+v", summary)
+	}
+
+	// Every path starts at 5%, apart from the first year's inflation; the rules spread
+	// spending out from there
+	first, last := summary.ByYear[0], summary.ByYear[9]
+	if first.Paths != 30 || math.Abs(first.P50/50000-1) > 0.03 {
+		t.Errorf("first year spending %+v", first)
+	}
+	if last.P90-last.P10 <= first.P90-first.P10 {
+		t.Errorf("spending never diverged across paths: %+v", last)
+	}
+	if summary.MeanP10 > summary.MeanP50 || summary.MeanP50 > summary.MeanP90 || summary.MinP50 > summary.MeanP50 {
+		t.Errorf("spending percentiles out of order: %+v", summary)
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package engine
 
 // withdrawal_sequencing.go
 // Intelligent withdrawal sequencing engine for tax-efficient retirement distributions
@@ -32,6 +32,7 @@ type WithdrawalRequest struct {
 	CurrentMonth        int
 	AnnualSpendingNeed  float64
 	MinimumSpending     float64 // Essential expenses floor
+	HSAReceipts         float64 // Unreimbursed medical expenses; HSA withdrawals up to this are tax-free
 }
 
 // WithdrawalResult contains the outcome of a withdrawal operation
@@ -41,9 +42,11 @@ type WithdrawalResult struct {
 	TaxableWithdrawn     float64
 	TaxDeferredWithdrawn float64
 	RothWithdrawn        float64
+	HSAWithdrawn         float64
+	HSANonQualified      float64 // HSA withdrawals beyond qualified medical expenses (ordinary income)
 	RMDAmount            float64
 	EstimatedTaxOwed     float64
-	EarlyWithdrawalPenalty float64 // 10% penalty on pre-59.5 tax-deferred/Roth withdrawals
+	EarlyWithdrawalPenalty float64 // 10% on pre-59.5 tax-deferred withdrawals, 20% on pre-65 non-qualified HSA withdrawals
 	WithdrawalSequence   []string
 }
 
@@ -86,6 +89,19 @@ func (ws *WithdrawalSequencer) ExecuteWithdrawal(
 		accountOrder = []string{"cash", "taxable", "tax_deferred", "roth"}
 	}
 
+	// From 65 a non-medical HSA withdrawal is taxed like a traditional IRA; before then the
+	// 20% additional tax makes the HSA the last resort
+	if request.CurrentAge >= hsaPenaltyFreeAge {
+		for i, accountType := range accountOrder {
+			if accountType == "tax_deferred" {
+				accountOrder = append(accountOrder[:i+1], append([]string{"hsa"}, accountOrder[i+1:]...)...)
+				break
+			}
+		}
+	} else {
+		accountOrder = append(accountOrder, "hsa")
+	}
+
 	remaining := request.Amount - result.TotalWithdrawn
 
 	// Withdraw from accounts in order
@@ -129,6 +145,14 @@ func (ws *WithdrawalSequencer) ExecuteWithdrawal(
 				result.WithdrawalSequence = append(result.WithdrawalSequence,
 					fmt.Sprintf("Roth: $%.0f (tax-free)", withdrawn))
 			}
+
+		case "hsa":
+			withdrawn, err = ws.withdrawFromHSA(accounts, remaining, request.CurrentMonth)
+			if withdrawn > 0 {
+				result.HSAWithdrawn += withdrawn
+				result.WithdrawalSequence = append(result.WithdrawalSequence,
+					fmt.Sprintf("HSA: $%.0f", withdrawn))
+			}
 		}
 
 		if err != nil {
@@ -152,6 +176,13 @@ func (ws *WithdrawalSequencer) ExecuteWithdrawal(
 		// as a conservative simplification (under-penalizes rather than over)
 	}
 
+	// HSA withdrawals beyond qualified medical expenses are ordinary income, plus 20% before 65
+	if result.HSAWithdrawn > 0 {
+		_, nonQualified, penalty := hsaDistributionTax(result.HSAWithdrawn, request.HSAReceipts, request.CurrentAge)
+		result.HSANonQualified = nonQualified
+		result.EarlyWithdrawalPenalty += penalty
+	}
+
 	// Check if we met the withdrawal need
 	if result.TotalWithdrawn < request.Amount && request.Amount-result.TotalWithdrawn > 0.01 {
 		return result, fmt.Errorf("insufficient funds: needed $%.2f, withdrew $%.2f",
@@ -255,6 +286,33 @@ func (ws *WithdrawalSequencer) withdrawFromRoth(
 	return withdraw, nil
 }
 
+// withdrawFromHSA withdraws from the HSA (tax treatment is applied by the caller)
+func (ws *WithdrawalSequencer) withdrawFromHSA(
+	accounts *AccountHoldingsMonthEnd,
+	amount float64,
+	currentMonth int,
+) (float64, error) {
+	if accounts.HSA == nil || accounts.HSA.TotalValue <= 0 {
+		return 0, nil
+	}
+
+	available := accounts.HSA.TotalValue
+	withdraw := math.Min(amount, available)
+
+	// If account has holdings, sell using FIFO
+	if len(accounts.HSA.Holdings) > 0 {
+		saleResult := ws.cashManager.SellAssetsFromAccountFIFO(accounts.HSA, withdraw, currentMonth)
+		accounts.Cash += saleResult.TotalProceeds
+		return saleResult.TotalProceeds, nil
+	}
+
+	// Otherwise, directly reduce TotalValue (simplified for testing)
+	accounts.HSA.TotalValue -= withdraw
+	accounts.Cash += withdraw
+
+	return withdraw, nil
+}
+
 // CalculateGrossUpAmount calculates the gross IRA withdrawal needed for a target net amount
 // Accounts for taxes that will be owed on the IRA distribution
 func (ws *WithdrawalSequencer) CalculateGrossUpAmount(
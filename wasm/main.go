@@ -1,2039 +1,10 @@
-//go:build js && wasm
-// +build js,wasm
-
+// Command pathfinder-wasm builds the simulation engine for the browser (GOOS=js GOARCH=wasm)
+// and as a native CLI. All engine code lives in the shared engine package; build tags there
+// select the JS bindings or the CLI entrypoint behind engine.Main.
 package main
 
-import (
-	"encoding/json"
-	"fmt"
-	"math"
-	"runtime"
-	"strconv"
-	"strings"
-	"syscall/js"
-)
-
-// WASM bindings for JavaScript interface
-
-// runMonteCarloSimulationJS is the JavaScript-callable wrapper for Monte Carlo simulation
-func runMonteCarloSimulationJS(this js.Value, args []js.Value) interface{} {
-	// CRITICAL FIX: Remove goroutine to prevent Go runtime exit
-	// Execute synchronously to avoid goroutine accumulation that kills WASM runtime
-	defer func() {
-		if r := recover(); r != nil {
-			// FIXED: Defensive error handling to prevent secondary panics
-			// Use safe debug logging during panic
-			simLogVerbose("💥 [CRITICAL] PANIC: %v", r)
-			// Don't try to create JS objects during panic recovery as JS context may be corrupted
-			// Just log the panic and let the function return nil (which gets handled by the calling code)
-		}
-	}()
-
-	// Parse arguments
-	if len(args) < 2 {
-		errorResult := js.Global().Get("Object").New()
-		errorResult.Set("success", js.ValueOf(false))
-		errorResult.Set("error", js.ValueOf("Insufficient arguments. Expected: inputData, numberOfRuns"))
-		return errorResult
-	}
-
-	// Parse input data from JavaScript
-	inputDataJS := args[0]
-	numberOfRuns := args[1].Int()
-
-	// Convert JavaScript input to Go struct
-	input, err := parseSimulationInput(inputDataJS)
-	if err != nil {
-		errorResult := js.Global().Get("Object").New()
-		errorResult.Set("success", js.ValueOf(false))
-		errorResult.Set("error", js.ValueOf(fmt.Sprintf("Failed to parse input data: %v", err)))
-		return errorResult
-	}
-
-	// Run the Monte Carlo simulation
-	results := RunMonteCarloSimulation(input, numberOfRuns)
-
-	// Convert results to JavaScript object (PERFORMANCE: using lightweight serialization)
-	resultJS := convertResultsToJSOptimized(results)
-
-	// CRITICAL: Force garbage collection to prevent memory leaks
-	runtime.GC()
-
-	return resultJS
-}
-
-// runSingleSimulationJS is the JavaScript-callable wrapper for single simulation
-func runSingleSimulationJS(this js.Value, args []js.Value) interface{} {
-
-	defer func() {
-		if r := recover(); r != nil {
-			// Return error result on panic
-			errorResult := js.Global().Get("Object").New()
-			errorResult.Set("success", js.ValueOf(false))
-			errorResult.Set("error", js.ValueOf(fmt.Sprintf("Simulation panic: %v", r)))
-		}
-	}()
-
-	// Validate arguments
-	if len(args) < 1 {
-		errorResult := js.Global().Get("Object").New()
-		errorResult.Set("success", js.ValueOf(false))
-		errorResult.Set("error", js.ValueOf("Missing input data"))
-		return errorResult
-	}
-
-	// Parse input data from JavaScript
-	inputDataJS := args[0]
-
-	// Convert JavaScript input to Go struct
-	input, err := parseSimulationInput(inputDataJS)
-	if err != nil {
-		errorResult := js.Global().Get("Object").New()
-		errorResult.Set("success", js.ValueOf(false))
-		errorResult.Set("error", js.ValueOf(fmt.Sprintf("Failed to parse input: %v", err)))
-		return errorResult
-	}
-
-	// Run single simulation
-	simLogVerbose("🚨 MAIN-WRAPPER WASM-EXECUTION-TEST: About to call RunSingleSimulation")
-	result, err := RunSingleSimulation(input)
-	simLogVerbose("🚨 MAIN-WRAPPER WASM-EXECUTION-TEST: RunSingleSimulation returned, Success=%t", result.Success)
-	if err != nil {
-		errorResult := js.Global().Get("Object").New()
-		errorResult.Set("success", js.ValueOf(false))
-		errorResult.Set("error", js.ValueOf(fmt.Sprintf("Simulation failed: %v", err)))
-		return errorResult
-	}
-
-	// Convert results to JavaScript object (PERFORMANCE: using lightweight serialization)
-	resultJS := convertSingleResultToJSOptimized(result)
-
-	// CRITICAL: Force garbage collection to prevent memory leaks
-	runtime.GC()
-
-	return resultJS
-}
-
-// runDeterministicSimulationJS is the JavaScript-callable wrapper for deterministic simulation
-func runDeterministicSimulationJS(this js.Value, args []js.Value) interface{} {
-	defer func() {
-		if r := recover(); r != nil {
-			simLogVerbose("💥 [CRITICAL] PANIC in deterministic simulation: %v", r)
-		}
-	}()
-
-	// Validate arguments
-	if len(args) < 1 {
-		errorResult := js.Global().Get("Object").New()
-		errorResult.Set("success", js.ValueOf(false))
-		errorResult.Set("error", js.ValueOf("Missing input data"))
-		return errorResult
-	}
-
-	// Parse input data from JavaScript
-	inputDataJS := args[0]
-
-	// Convert JavaScript input to Go struct
-	input, err := parseSimulationInput(inputDataJS)
-	if err != nil {
-		errorResult := js.Global().Get("Object").New()
-		errorResult.Set("success", js.ValueOf(false))
-		errorResult.Set("error", js.ValueOf(fmt.Sprintf("Failed to parse input: %v", err)))
-		return errorResult
-	}
-
-	simLogVerbose("🎯 [DETERMINISTIC-JS] Running deterministic simulation")
-
-	// Run deterministic simulation
-	result := RunDeterministicSimulation(input)
-
-	// Convert results to JavaScript object
-	resultJS := convertDeterministicResultToJS(result)
-
-	// Force garbage collection
-	runtime.GC()
-
-	return resultJS
-}
-
-// convertDeterministicResultToJS converts DeterministicResults to JavaScript object
-func convertDeterministicResultToJS(result DeterministicResults) js.Value {
-	obj := js.Global().Get("Object").New()
-	obj.Set("success", js.ValueOf(result.Success))
-
-	if !result.Success {
-		obj.Set("error", js.ValueOf(result.Error))
-		return obj
-	}
-
-	// Assumptions
-	assumptions := js.Global().Get("Object").New()
-	assumptions.Set("stockReturnAnnual", js.ValueOf(result.Assumptions.StockReturnAnnual))
-	assumptions.Set("bondReturnAnnual", js.ValueOf(result.Assumptions.BondReturnAnnual))
-	assumptions.Set("inflationAnnual", js.ValueOf(result.Assumptions.InflationAnnual))
-	assumptions.Set("intlStockReturnAnnual", js.ValueOf(result.Assumptions.IntlStockReturnAnnual))
-	assumptions.Set("homeAppreciationAnnual", js.ValueOf(result.Assumptions.HomeAppreciationAnnual))
-	obj.Set("assumptions", assumptions)
-
-	// Monthly snapshots
-	monthlyArray := js.Global().Get("Array").New(len(result.MonthlySnapshots))
-	for i, ms := range result.MonthlySnapshots {
-		msObj := js.Global().Get("Object").New()
-		msObj.Set("monthOffset", js.ValueOf(ms.MonthOffset))
-		msObj.Set("calendarYear", js.ValueOf(ms.CalendarYear))
-		msObj.Set("calendarMonth", js.ValueOf(ms.CalendarMonth))
-		msObj.Set("age", js.ValueOf(ms.Age))
-		msObj.Set("netWorth", js.ValueOf(ms.NetWorth))
-		msObj.Set("cashBalance", js.ValueOf(ms.CashBalance))
-		msObj.Set("taxableBalance", js.ValueOf(ms.TaxableBalance))
-		msObj.Set("taxDeferredBalance", js.ValueOf(ms.TaxDeferredBalance))
-		msObj.Set("rothBalance", js.ValueOf(ms.RothBalance))
-		msObj.Set("incomeThisMonth", js.ValueOf(ms.IncomeThisMonth))
-		msObj.Set("expensesThisMonth", js.ValueOf(ms.ExpensesThisMonth))
-		msObj.Set("taxesThisMonth", js.ValueOf(ms.TaxesThisMonth))
-		msObj.Set("contributionsThisMonth", js.ValueOf(ms.ContributionsThisMonth))
-		msObj.Set("withdrawalsThisMonth", js.ValueOf(ms.WithdrawalsThisMonth))
-		msObj.Set("investmentGrowth", js.ValueOf(ms.InvestmentGrowth))
-		monthlyArray.SetIndex(i, msObj)
-	}
-	obj.Set("monthlySnapshots", monthlyArray)
-
-	// Event trace
-	eventArray := js.Global().Get("Array").New(len(result.EventTrace))
-	for i, et := range result.EventTrace {
-		etObj := js.Global().Get("Object").New()
-		etObj.Set("monthOffset", js.ValueOf(et.MonthOffset))
-		etObj.Set("eventId", js.ValueOf(et.EventID))
-		etObj.Set("eventName", js.ValueOf(et.EventName))
-		etObj.Set("eventType", js.ValueOf(et.EventType))
-		etObj.Set("priority", js.ValueOf(et.Priority))
-		etObj.Set("amount", js.ValueOf(et.Amount))
-		etObj.Set("netWorthBefore", js.ValueOf(et.NetWorthBefore))
-		etObj.Set("netWorthAfter", js.ValueOf(et.NetWorthAfter))
-		etObj.Set("cashBefore", js.ValueOf(et.CashBefore))
-		etObj.Set("cashAfter", js.ValueOf(et.CashAfter))
-		etObj.Set("taxableBefore", js.ValueOf(et.TaxableBefore))
-		etObj.Set("taxableAfter", js.ValueOf(et.TaxableAfter))
-		etObj.Set("taxDeferredBefore", js.ValueOf(et.TaxDeferredBefore))
-		etObj.Set("taxDeferredAfter", js.ValueOf(et.TaxDeferredAfter))
-		etObj.Set("rothBefore", js.ValueOf(et.RothBefore))
-		etObj.Set("rothAfter", js.ValueOf(et.RothAfter))
-		etObj.Set("description", js.ValueOf(et.Description))
-		eventArray.SetIndex(i, etObj)
-	}
-	obj.Set("eventTrace", eventArray)
-
-	// Yearly data
-	yearlyArray := js.Global().Get("Array").New(len(result.YearlyData))
-	for i, yd := range result.YearlyData {
-		ydObj := js.Global().Get("Object").New()
-		ydObj.Set("year", js.ValueOf(yd.Year))
-		ydObj.Set("age", js.ValueOf(yd.Age))
-		ydObj.Set("startNetWorth", js.ValueOf(yd.StartNetWorth))
-		ydObj.Set("endNetWorth", js.ValueOf(yd.EndNetWorth))
-		ydObj.Set("netWorthChange", js.ValueOf(yd.NetWorthChange))
-		ydObj.Set("totalIncome", js.ValueOf(yd.TotalIncome))
-		ydObj.Set("totalExpenses", js.ValueOf(yd.TotalExpenses))
-		ydObj.Set("totalTaxes", js.ValueOf(yd.TotalTaxes))
-		ydObj.Set("totalContributions", js.ValueOf(yd.TotalContributions))
-		ydObj.Set("totalWithdrawals", js.ValueOf(yd.TotalWithdrawals))
-		ydObj.Set("investmentGrowth", js.ValueOf(yd.InvestmentGrowth))
-
-		// Monthly data for expansion
-		monthsArray := js.Global().Get("Array").New(len(yd.Months))
-		for j, m := range yd.Months {
-			mObj := js.Global().Get("Object").New()
-			mObj.Set("monthOffset", js.ValueOf(m.MonthOffset))
-			mObj.Set("calendarYear", js.ValueOf(m.CalendarYear))
-			mObj.Set("calendarMonth", js.ValueOf(m.CalendarMonth))
-			mObj.Set("age", js.ValueOf(m.Age))
-			mObj.Set("netWorth", js.ValueOf(m.NetWorth))
-			mObj.Set("cashBalance", js.ValueOf(m.CashBalance))
-			mObj.Set("taxableBalance", js.ValueOf(m.TaxableBalance))
-			mObj.Set("taxDeferredBalance", js.ValueOf(m.TaxDeferredBalance))
-			mObj.Set("rothBalance", js.ValueOf(m.RothBalance))
-			mObj.Set("incomeThisMonth", js.ValueOf(m.IncomeThisMonth))
-			mObj.Set("expensesThisMonth", js.ValueOf(m.ExpensesThisMonth))
-			mObj.Set("taxesThisMonth", js.ValueOf(m.TaxesThisMonth))
-			mObj.Set("contributionsThisMonth", js.ValueOf(m.ContributionsThisMonth))
-			monthsArray.SetIndex(j, mObj)
-		}
-		ydObj.Set("months", monthsArray)
-		yearlyArray.SetIndex(i, ydObj)
-	}
-	obj.Set("yearlyData", yearlyArray)
-
-	// Final state
-	obj.Set("finalNetWorth", js.ValueOf(result.FinalNetWorth))
-	obj.Set("isBankrupt", js.ValueOf(result.IsBankrupt))
-	if result.IsBankrupt {
-		obj.Set("bankruptcyMonth", js.ValueOf(result.BankruptcyMonth))
-	}
-
-	// Comprehensive monthly states (for Trace View)
-	// Use JSON serialization for complex nested structure
-	if len(result.ComprehensiveMonthlyStates) > 0 {
-		comprehensiveJSON, err := json.Marshal(result.ComprehensiveMonthlyStates)
-		if err == nil {
-			// Parse JSON into JS object using JSON.parse for efficiency
-			comprehensiveArray := js.Global().Get("JSON").Call("parse", string(comprehensiveJSON))
-			obj.Set("comprehensiveMonthlyStates", comprehensiveArray)
-		}
-	}
-
-	// Simulation mode metadata (for trace view header)
-	obj.Set("simulationMode", js.ValueOf(result.SimulationMode))
-	if result.Seed != 0 {
-		obj.Set("seed", js.ValueOf(result.Seed))
-	}
-	if result.ModelDescription != "" {
-		obj.Set("modelDescription", js.ValueOf(result.ModelDescription))
-	}
-
-	// Realized path variables (for stochastic mode "show the math" linkage)
-	if len(result.RealizedPathVariables) > 0 {
-		realizedJSON, err := json.Marshal(result.RealizedPathVariables)
-		if err == nil {
-			realizedArray := js.Global().Get("JSON").Call("parse", string(realizedJSON))
-			obj.Set("realizedPathVariables", realizedArray)
-		}
-	}
-
-	return obj
-}
-
-// runSimulationWithCallbacks - UNUSED FUNCTION (kept for compatibility)
-// This function is not called by the current WASM interface
-func runSimulationWithCallbacks(inputDataJS js.Value, successCallback js.Value, errorCallback js.Value) {
-	// This function is unused and should not return dummy data
-	errorCallback.Invoke(js.ValueOf("Function not implemented - use runSingleSimulation instead"))
-}
-
-// testMathFunctionsJS exposes math functions for testing
-func testMathFunctionsJS(this js.Value, args []js.Value) interface{} {
-	// CRITICAL FIX: Remove goroutine to prevent Go runtime exit
-	// Execute synchronously to avoid goroutine accumulation that kills WASM runtime
-
-	// Test mathematical functions
-	testResults := map[string]interface{}{
-		"gaussianRandom":    GaussianRandom(0, 1),
-		"studentTRandom":    StudentTRandom(10),
-		"annualToMonthly":   AnnualToMonthlyRate(0.08),
-		"monthlyVolatility": AnnualToMonthlyVolatility(0.20),
-	}
-
-	return js.ValueOf(testResults)
-}
-
-// testJSONUnmarshalJS tests JSON unmarshaling in isolation
-func testJSONUnmarshalJS(this js.Value, args []js.Value) interface{} {
-	// Test unmarshaling with progressively complex JSON
-
-	// Test 1: Simple struct
-	simpleJSON := `{"cash": 1000}`
-	var simple map[string]interface{}
-	if err := json.Unmarshal([]byte(simpleJSON), &simple); err != nil {
-		if VERBOSE_DEBUG {
-			simLogVerbose("❌ [JSON-TEST] Simple JSON failed: %v", err)
-		}
-		return js.ValueOf(map[string]interface{}{"success": false, "error": "Simple JSON failed"})
-	}
-
-	// Test 2: AccountHoldingsMonthEnd struct
-	accountJSON := `{"cash": 1000, "taxable": null, "tax_deferred": null, "roth": null}`
-	var accounts AccountHoldingsMonthEnd
-	if err := json.Unmarshal([]byte(accountJSON), &accounts); err != nil {
-		if VERBOSE_DEBUG {
-			simLogVerbose("❌ [JSON-TEST] AccountHoldingsMonthEnd failed: %v", err)
-		}
-		return js.ValueOf(map[string]interface{}{"success": false, "error": "AccountHoldingsMonthEnd failed"})
-	}
-
-	// Test 3: Full SimulationInput struct
-	fullJSON := args[0].String()
-	var input SimulationInput
-	if err := json.Unmarshal([]byte(fullJSON), &input); err != nil {
-		if VERBOSE_DEBUG {
-			simLogVerbose("❌ [JSON-TEST] SimulationInput failed: %v", err)
-		}
-		return js.ValueOf(map[string]interface{}{"success": false, "error": fmt.Sprintf("SimulationInput failed: %v", err)})
-	}
-
-	return js.ValueOf(map[string]interface{}{
-		"success":   true,
-		"message":   "All JSON tests passed",
-		"cashValue": input.InitialAccounts.Cash,
-	})
-}
-
-// loadConfigurationDataJS receives and loads financial configuration data from the main thread
-func loadConfigurationDataJS(this js.Value, args []js.Value) interface{} {
-	if len(args) < 1 {
-		return js.ValueOf(map[string]interface{}{
-			"success": false,
-			"error":   "Missing configuration data argument",
-		})
-	}
-
-	configData := args[0]
-
-	if VERBOSE_DEBUG {
-		simLogVerbose("WASM: Received configuration data from main thread")
-	}
-
-	// Parse each configuration from the provided JSON strings
-	var err error
-
-	// Tax brackets
-	if taxBracketsJSON := configData.Get("tax_brackets_2024"); !taxBracketsJSON.IsUndefined() {
-		var config TaxBracketsConfig
-		if err = json.Unmarshal([]byte(taxBracketsJSON.String()), &config); err != nil {
-			return js.ValueOf(map[string]interface{}{
-				"success": false,
-				"error":   fmt.Sprintf("Failed to parse tax brackets: %v", err),
-			})
-		}
-		taxBracketsConfig = &config
-		if VERBOSE_DEBUG {
-			simLogVerbose("✅ Loaded tax brackets config")
-		}
-	}
-
-	// RMD table
-	if rmdTableJSON := configData.Get("rmd_table"); !rmdTableJSON.IsUndefined() {
-		var config RMDTableConfig
-		if err = json.Unmarshal([]byte(rmdTableJSON.String()), &config); err != nil {
-			return js.ValueOf(map[string]interface{}{
-				"success": false,
-				"error":   fmt.Sprintf("Failed to parse RMD table: %v", err),
-			})
-		}
-		rmdTableConfig = &config
-		if VERBOSE_DEBUG {
-			simLogVerbose("✅ Loaded RMD table config")
-		}
-	}
-
-	// Contribution limits
-	if contributionLimitsJSON := configData.Get("contribution_limits_2024"); !contributionLimitsJSON.IsUndefined() {
-		var config ContributionLimitsConfig
-		if err = json.Unmarshal([]byte(contributionLimitsJSON.String()), &config); err != nil {
-			return js.ValueOf(map[string]interface{}{
-				"success": false,
-				"error":   fmt.Sprintf("Failed to parse contribution limits: %v", err),
-			})
-		}
-		contributionConfig = &config
-		if VERBOSE_DEBUG {
-			simLogVerbose("✅ Loaded contribution limits config")
-		}
-	}
-
-	// FICA tax
-	if ficaTaxJSON := configData.Get("fica_tax"); !ficaTaxJSON.IsUndefined() {
-		var config FICATaxConfig
-		if err = json.Unmarshal([]byte(ficaTaxJSON.String()), &config); err != nil {
-			return js.ValueOf(map[string]interface{}{
-				"success": false,
-				"error":   fmt.Sprintf("Failed to parse FICA tax: %v", err),
-			})
-		}
-		ficaTaxConfig = &config
-		if VERBOSE_DEBUG {
-			simLogVerbose("✅ Loaded FICA tax config")
-		}
-	}
-
-	// State tax brackets
-	if stateTaxJSON := configData.Get("state_tax_brackets"); !stateTaxJSON.IsUndefined() {
-		var config StateTaxBracketsConfig
-		if err = json.Unmarshal([]byte(stateTaxJSON.String()), &config); err != nil {
-			return js.ValueOf(map[string]interface{}{
-				"success": false,
-				"error":   fmt.Sprintf("Failed to parse state tax brackets: %v", err),
-			})
-		}
-		stateTaxConfig = &config
-		if VERBOSE_DEBUG {
-			simLogVerbose("✅ Loaded state tax brackets config")
-		}
-	}
-
-	// IRMAA brackets
-	if irmaaJSON := configData.Get("irmaa_brackets"); !irmaaJSON.IsUndefined() {
-		var config IRMAABracketsConfig
-		if err = json.Unmarshal([]byte(irmaaJSON.String()), &config); err != nil {
-			return js.ValueOf(map[string]interface{}{
-				"success": false,
-				"error":   fmt.Sprintf("Failed to parse IRMAA brackets: %v", err),
-			})
-		}
-		irmaaConfig = &config
-		if VERBOSE_DEBUG {
-			simLogVerbose("✅ Loaded IRMAA brackets config")
-		}
-	}
-
-	// Asset returns
-	if assetReturnsJSON := configData.Get("asset_returns"); !assetReturnsJSON.IsUndefined() {
-		var config AssetReturnsConfig
-		if err = json.Unmarshal([]byte(assetReturnsJSON.String()), &config); err != nil {
-			return js.ValueOf(map[string]interface{}{
-				"success": false,
-				"error":   fmt.Sprintf("Failed to parse asset returns: %v", err),
-			})
-		}
-		assetReturnsConfig = &config
-		if VERBOSE_DEBUG {
-			simLogVerbose("✅ Loaded asset returns config")
-		}
-	}
-
-	// Monthly real estate data
-	if monthlyRealEstateJSON := configData.Get("monthly_real_estate"); !monthlyRealEstateJSON.IsUndefined() {
-		var config MonthlyRealEstateConfig
-		if err = json.Unmarshal([]byte(monthlyRealEstateJSON.String()), &config); err != nil {
-			return js.ValueOf(map[string]interface{}{
-				"success": false,
-				"error":   fmt.Sprintf("Failed to parse monthly real estate data: %v", err),
-			})
-		}
-		monthlyRealEstateConfig = &config
-		if VERBOSE_DEBUG {
-			simLogVerbose("✅ Loaded monthly real estate config")
-		}
-	}
-
-	// Dividend model
-	if dividendModelJSON := configData.Get("dividend_model"); !dividendModelJSON.IsUndefined() {
-		var config DividendModelConfig
-		if err = json.Unmarshal([]byte(dividendModelJSON.String()), &config); err != nil {
-			return js.ValueOf(map[string]interface{}{
-				"success": false,
-				"error":   fmt.Sprintf("Failed to parse dividend model: %v", err),
-			})
-		}
-		dividendModelConfig = &config
-		if VERBOSE_DEBUG {
-			simLogVerbose("✅ Loaded dividend model config")
-		}
-	}
-
-	// CRITICAL: Load defaults configuration
-	if defaultsJSON := configData.Get("defaults"); !defaultsJSON.IsUndefined() {
-		var config DefaultsConfig
-		if err = json.Unmarshal([]byte(defaultsJSON.String()), &config); err != nil {
-			return js.ValueOf(map[string]interface{}{
-				"success": false,
-				"error":   fmt.Sprintf("Failed to parse defaults: %v", err),
-			})
-		}
-		defaultsConfig = &config
-		if VERBOSE_DEBUG {
-			simLogVerbose("✅ Loaded defaults config (CRITICAL)")
-		}
-	}
-
-	// Mark config as loaded
-	configLoaded = true
-
-	if VERBOSE_DEBUG {
-		simLogVerbose("WASM: ✅ All configuration data successfully loaded")
-	}
-
-	return js.ValueOf(map[string]interface{}{
-		"success": true,
-		"message": "Configuration loaded successfully",
-		"loaded": true,
-	})
-}
-
-// checkConfigStateJS provides debug info about config loading state
-func checkConfigStateJS(this js.Value, args []js.Value) interface{} {
-	// Check if all critical config is loaded
-	allLoaded := configLoaded &&
-		taxBracketsConfig != nil &&
-		rmdTableConfig != nil &&
-		contributionConfig != nil &&
-		ficaTaxConfig != nil &&
-		stateTaxConfig != nil &&
-		irmaaConfig != nil &&
-		assetReturnsConfig != nil &&
-		monthlyRealEstateConfig != nil &&
-		dividendModelConfig != nil &&
-		defaultsConfig != nil
-
-	return js.ValueOf(map[string]interface{}{
-		"loaded": allLoaded,
-		"configLoaded": configLoaded,
-		"defaultsLoaded": defaultsConfig != nil,
-		"message": fmt.Sprintf("Config state: loaded=%v, defaults=%v", configLoaded, defaultsConfig != nil),
-	})
-}
-
-// =================================================================
-// NEW JSON-BASED MARSHALLING FUNCTIONS
-// These functions replace the fragile manual parsing with robust JSON unmarshalling
-// =================================================================
-
-// runSingleSimulationJSON is the JSON-based wrapper for single simulation
-func runSingleSimulationJSON(this js.Value, args []js.Value) interface{} {
-	// CRITICAL PATH LOGGING ONLY
-	if VERBOSE_DEBUG {
-		simLogVerbose("🎯 [CRITICAL] runSingleSimulationJSON called")
-	}
-
-	defer func() {
-		if r := recover(); r != nil {
-			// FIXED: Defensive error handling to prevent secondary panics
-			// Use safe debug logging during panic
-			simLogVerbose("💥 [CRITICAL] PANIC: %v", r)
-			// Don't try to create JS objects during panic recovery as JS context may be corrupted
-		}
-	}()
-
-	// Validate arguments
-	if len(args) < 1 {
-		if VERBOSE_DEBUG {
-			simLogVerbose("❌ [CRITICAL] Missing JSON argument")
-		}
-		errorResult := js.Global().Get("Object").New()
-		errorResult.Set("success", js.ValueOf(false))
-		errorResult.Set("error", js.ValueOf("Expected JSON string argument"))
-		return errorResult
-	}
-
-	// Get JSON string from JavaScript
-	if VERBOSE_DEBUG {
-		simLogVerbose("✅ [CRITICAL] JSON argument received, type: %s", args[0].Type().String())
-	}
-
-	jsonStr := args[0].String()
-
-	simLogVerbose("JSON input received: length=%d", len(jsonStr))
-
-	// Only reject HTML content, allow short JSON for debugging
-	if strings.HasPrefix(jsonStr, "<") {
-		simLogVerbose("HTML detected instead of JSON: starts='%.20s'", jsonStr)
-		errorResult := js.Global().Get("Object").New()
-		errorResult.Set("success", js.ValueOf(false))
-		errorResult.Set("error", js.ValueOf("Invalid JSON input received"))
-		return errorResult
-	}
-
-	// Additional validation for completely empty input
-	if len(jsonStr) == 0 {
-		simLogVerbose("🚨 [GO-ERROR] Empty JSON input received")
-		errorResult := js.Global().Get("Object").New()
-		errorResult.Set("success", js.ValueOf(false))
-		errorResult.Set("error", js.ValueOf("Empty JSON input"))
-		return errorResult
-	}
-
-	// Additional debug: check for common error patterns
-	if strings.HasPrefix(jsonStr, "<") {
-		simLogVerbose("🔍 [GO-DEBUG] ERROR: Received HTML instead of JSON!")
-		simLogVerbose("🔍 [GO-DEBUG] Full HTML content: %s", jsonStr)
-	}
-
-	// Parse JSON directly into Go struct using standard library
-	var input SimulationInput
-	if err := json.Unmarshal([]byte(jsonStr), &input); err != nil {
-		simLogVerbose("❌ [CRITICAL] JSON parse failed: %v", err)
-		errorResult := js.Global().Get("Object").New()
-		errorResult.Set("success", js.ValueOf(false))
-		errorResult.Set("error", js.ValueOf(fmt.Sprintf("JSON parse error: %v", err)))
-		return errorResult
-	}
-
-	// CRITICAL FIX: Initialize nil account structures to prevent panics
-	if input.InitialAccounts.Taxable == nil {
-		simLogVerbose("🔧 [CRITICAL] Initializing nil taxable account")
-		input.InitialAccounts.Taxable = &Account{
-			Holdings:   []Holding{},
-			TotalValue: 0,
-		}
-	}
-
-	if input.InitialAccounts.TaxDeferred == nil {
-		simLogVerbose("🔧 [CRITICAL] Initializing nil tax-deferred account")
-		input.InitialAccounts.TaxDeferred = &Account{
-			Holdings:   []Holding{},
-			TotalValue: 0,
-		}
-	}
-
-	if input.InitialAccounts.Roth == nil {
-		simLogVerbose("🔧 [CRITICAL] Initializing nil Roth account")
-		input.InitialAccounts.Roth = &Account{
-			Holdings:   []Holding{},
-			TotalValue: 0,
-		}
-	}
-
-	if VERBOSE_DEBUG {
-		simLogVerbose("✅ [CRITICAL] JSON parsed, running simulation with %d months", input.MonthsToRun)
-		simLogVerbose("🔍 [CRITICAL] Initial cash: $%.2f", input.InitialAccounts.Cash)
-		simLogVerbose("🔍 [CRITICAL] Taxable account value: $%.2f", input.InitialAccounts.Taxable.TotalValue)
-		simLogVerbose("🔍 [CRITICAL] Tax-deferred account value: $%.2f", input.InitialAccounts.TaxDeferred.TotalValue)
-		simLogVerbose("🔍 [CRITICAL] Roth account value: $%.2f", input.InitialAccounts.Roth.TotalValue)
-		simLogVerbose("🔍 [CRITICAL] Number of events: %d", len(input.Events))
-		if len(input.Events) > 0 {
-			simLogVerbose("🔍 [CRITICAL] First event: %s, Type: %s, Amount: $%.2f", input.Events[0].ID, input.Events[0].Type, input.Events[0].Amount)
-		}
-	}
-
-	// CRITICAL FIX: Validate and default missing config parameters to prevent nil pointer panics
-	if input.Config.GarchSPYOmega == 0 || input.Config.GarchSPYAlpha == 0 || input.Config.GarchSPYBeta == 0 ||
-	   input.Config.GarchBondOmega == 0 || input.Config.GarchBondAlpha == 0 || input.Config.GarchBondBeta == 0 ||
-	   input.Config.MeanSPYReturn == 0 || len(input.Config.CorrelationMatrix) == 0 {
-		simLogVerbose("🔧 [CRITICAL] Config missing GARCH parameters, applying defaults")
-		defaultConfig := GetDefaultStochasticConfig()
-
-		// Fill in missing GARCH parameters
-		if input.Config.GarchSPYOmega == 0 { input.Config.GarchSPYOmega = defaultConfig.GarchSPYOmega }
-		if input.Config.GarchSPYAlpha == 0 { input.Config.GarchSPYAlpha = defaultConfig.GarchSPYAlpha }
-		if input.Config.GarchSPYBeta == 0 { input.Config.GarchSPYBeta = defaultConfig.GarchSPYBeta }
-		if input.Config.GarchBondOmega == 0 { input.Config.GarchBondOmega = defaultConfig.GarchBondOmega }
-		if input.Config.GarchBondAlpha == 0 { input.Config.GarchBondAlpha = defaultConfig.GarchBondAlpha }
-		if input.Config.GarchBondBeta == 0 { input.Config.GarchBondBeta = defaultConfig.GarchBondBeta }
-		if input.Config.GarchIntlStockOmega == 0 { input.Config.GarchIntlStockOmega = defaultConfig.GarchIntlStockOmega }
-		if input.Config.GarchIntlStockAlpha == 0 { input.Config.GarchIntlStockAlpha = defaultConfig.GarchIntlStockAlpha }
-		if input.Config.GarchIntlStockBeta == 0 { input.Config.GarchIntlStockBeta = defaultConfig.GarchIntlStockBeta }
-		if input.Config.GarchOtherOmega == 0 { input.Config.GarchOtherOmega = defaultConfig.GarchOtherOmega }
-		if input.Config.GarchOtherAlpha == 0 { input.Config.GarchOtherAlpha = defaultConfig.GarchOtherAlpha }
-		if input.Config.GarchOtherBeta == 0 { input.Config.GarchOtherBeta = defaultConfig.GarchOtherBeta }
-		if input.Config.GarchIndividualStockOmega == 0 { input.Config.GarchIndividualStockOmega = defaultConfig.GarchIndividualStockOmega }
-		if input.Config.GarchIndividualStockAlpha == 0 { input.Config.GarchIndividualStockAlpha = defaultConfig.GarchIndividualStockAlpha }
-		if input.Config.GarchIndividualStockBeta == 0 { input.Config.GarchIndividualStockBeta = defaultConfig.GarchIndividualStockBeta }
-
-		// Fill in missing basic parameters
-		if input.Config.MeanSPYReturn == 0 { input.Config.MeanSPYReturn = defaultConfig.MeanSPYReturn }
-		if input.Config.MeanBondReturn == 0 { input.Config.MeanBondReturn = defaultConfig.MeanBondReturn }
-		if input.Config.MeanIntlStockReturn == 0 { input.Config.MeanIntlStockReturn = defaultConfig.MeanIntlStockReturn }
-		if input.Config.MeanInflation == 0 { input.Config.MeanInflation = defaultConfig.MeanInflation }
-		if input.Config.VolatilitySPY == 0 { input.Config.VolatilitySPY = defaultConfig.VolatilitySPY }
-		if input.Config.VolatilityBond == 0 { input.Config.VolatilityBond = defaultConfig.VolatilityBond }
-		if input.Config.VolatilityIntlStock == 0 { input.Config.VolatilityIntlStock = defaultConfig.VolatilityIntlStock }
-
-		// Fill in correlation matrix if missing
-		if len(input.Config.CorrelationMatrix) == 0 {
-			input.Config.CorrelationMatrix = defaultConfig.CorrelationMatrix
-		}
-
-		simLogVerbose("✅ [CRITICAL] Config validated and defaults applied")
-	}
-
-	// Debug: Check input structure before calling RunSingleSimulation
-	simLogVerbose("🔍 [DEBUG] About to call RunSingleSimulation")
-	simLogVerbose("🔍 [DEBUG] Input validation - MonthsToRun: %d", input.MonthsToRun)
-	simLogVerbose("🔍 [DEBUG] Input validation - Events count: %d", len(input.Events))
-	simLogVerbose("🔍 [DEBUG] Input validation - Config MeanSPYReturn: %.4f", input.Config.MeanSPYReturn)
-
-	// CRITICAL DEBUG: Check all GARCH parameters are non-zero
-	simLogVerbose("🔍 [CRITICAL] GARCH validation - SPY: omega=%.6f, alpha=%.6f, beta=%.6f",
-		input.Config.GarchSPYOmega, input.Config.GarchSPYAlpha, input.Config.GarchSPYBeta)
-	simLogVerbose("🔍 [CRITICAL] GARCH validation - Bond: omega=%.6f, alpha=%.6f, beta=%.6f",
-		input.Config.GarchBondOmega, input.Config.GarchBondAlpha, input.Config.GarchBondBeta)
-	simLogVerbose("🔍 [CRITICAL] GARCH validation - Other: omega=%.6f, alpha=%.6f, beta=%.6f",
-		input.Config.GarchOtherOmega, input.Config.GarchOtherAlpha, input.Config.GarchOtherBeta)
-
-	// Run simulation
-	result, err := RunSingleSimulation(input)
-	simLogVerbose("🔍 [DEBUG] RunSingleSimulation returned successfully")
-	if err != nil {
-		simLogVerbose("❌ [CRITICAL] Simulation failed: %v", err)
-		errorResult := js.Global().Get("Object").New()
-		errorResult.Set("success", js.ValueOf(false))
-		errorResult.Set("error", js.ValueOf(fmt.Sprintf("Simulation failed: %v", err)))
-		return errorResult
-	}
-
-	simLogVerbose("✅ [CRITICAL] Simulation success=%t, monthlyData=%d items", result.Success, len(result.MonthlyData))
-
-	// Convert results to JavaScript object
-	resultJS := convertSingleResultToJSOptimized(result)
-
-	// Force garbage collection
-	runtime.GC()
-
-	return resultJS
-}
-
-// runMonteCarloSimulationJSON is the JSON-based wrapper for Monte Carlo simulation
-func runMonteCarloSimulationJSON(this js.Value, args []js.Value) interface{} {
-	defer func() {
-		if r := recover(); r != nil {
-			errorResult := js.Global().Get("Object").New()
-			errorResult.Set("success", js.ValueOf(false))
-			errorResult.Set("error", js.ValueOf(fmt.Sprintf("Simulation panic: %v", r)))
-		}
-	}()
-
-	// Validate arguments
-	if len(args) < 2 {
-		errorResult := js.Global().Get("Object").New()
-		errorResult.Set("success", js.ValueOf(false))
-		errorResult.Set("error", js.ValueOf("Expected JSON string and number of runs"))
-		return errorResult
-	}
-
-	// Get JSON string and number of runs
-	jsonStr := args[0].String()
-	numberOfRuns := args[1].Int()
-
-	// Parse JSON directly into Go struct
-	var input SimulationInput
-	if err := json.Unmarshal([]byte(jsonStr), &input); err != nil {
-		errorResult := js.Global().Get("Object").New()
-		errorResult.Set("success", js.ValueOf(false))
-		errorResult.Set("error", js.ValueOf(fmt.Sprintf("JSON parse error: %v", err)))
-		return errorResult
-	}
-
-	// Run Monte Carlo simulation
-	simLogVerbose("🚀 JSON-MARSHALLING: Running Monte Carlo with %d runs", numberOfRuns)
-	result := RunMonteCarloSimulation(input, numberOfRuns)
-
-	// Convert results to JavaScript object
-	resultJS := convertResultsToJS(result)
-
-	// Force garbage collection
-	runtime.GC()
-
-	return resultJS
-}
-
-// runDeterministicSimulationJSON is the JSON-based wrapper for deterministic simulation
-// Returns full trace data including yearlyData, eventTrace, and comprehensiveMonthlyStates
-// This enables Node.js services to get trace data without JS object binding issues
-func runDeterministicSimulationJSON(this js.Value, args []js.Value) interface{} {
-	defer func() {
-		if r := recover(); r != nil {
-			simLogVerbose("💥 [CRITICAL] PANIC in deterministic simulation JSON: %v", r)
-			errorResult := js.Global().Get("Object").New()
-			errorResult.Set("success", js.ValueOf(false))
-			errorResult.Set("error", js.ValueOf(fmt.Sprintf("Simulation panic: %v", r)))
-		}
-	}()
-
-	// Validate arguments
-	if len(args) < 1 {
-		errorResult := js.Global().Get("Object").New()
-		errorResult.Set("success", js.ValueOf(false))
-		errorResult.Set("error", js.ValueOf("Expected JSON string argument"))
-		return errorResult
-	}
-
-	// Get JSON string from JavaScript
-	jsonStr := args[0].String()
-	simLogVerbose("🎯 [DETERMINISTIC-JSON] Input received: length=%d", len(jsonStr))
-
-	// Validate input
-	if len(jsonStr) == 0 {
-		errorResult := js.Global().Get("Object").New()
-		errorResult.Set("success", js.ValueOf(false))
-		errorResult.Set("error", js.ValueOf("Empty JSON input"))
-		return errorResult
-	}
-
-	// Parse JSON directly into Go struct
-	var input SimulationInput
-	if err := json.Unmarshal([]byte(jsonStr), &input); err != nil {
-		simLogVerbose("❌ [DETERMINISTIC-JSON] JSON parse failed: %v", err)
-		errorResult := js.Global().Get("Object").New()
-		errorResult.Set("success", js.ValueOf(false))
-		errorResult.Set("error", js.ValueOf(fmt.Sprintf("JSON parse error: %v", err)))
-		return errorResult
-	}
-
-	// Initialize nil account structures to prevent panics
-	if input.InitialAccounts.Taxable == nil {
-		input.InitialAccounts.Taxable = &Account{Holdings: []Holding{}, TotalValue: 0}
-	}
-	if input.InitialAccounts.TaxDeferred == nil {
-		input.InitialAccounts.TaxDeferred = &Account{Holdings: []Holding{}, TotalValue: 0}
-	}
-	if input.InitialAccounts.Roth == nil {
-		input.InitialAccounts.Roth = &Account{Holdings: []Holding{}, TotalValue: 0}
-	}
-
-	// Always apply full defaults, then restore user-provided overrides.
-	// This ensures GARCH, volatility, correlation, FatTailParameter are always populated
-	// even when the caller only sends mean return overrides.
-	{
-		savedSeed := input.Config.RandomSeed
-		savedMode := input.Config.SimulationMode
-		savedCashFloor := input.Config.CashFloor
-		savedLiteMode := input.Config.LiteMode
-		savedMeanSPY := input.Config.MeanSPYReturn
-		savedMeanBond := input.Config.MeanBondReturn
-		savedMeanInflation := input.Config.MeanInflation
-		input.Config = GetDefaultStochasticConfig()
-		input.Config.RandomSeed = savedSeed
-		input.Config.SimulationMode = savedMode
-		input.Config.CashFloor = savedCashFloor
-		input.Config.LiteMode = savedLiteMode
-		if savedMeanSPY != 0 { input.Config.MeanSPYReturn = savedMeanSPY }
-		if savedMeanBond != 0 { input.Config.MeanBondReturn = savedMeanBond }
-		if savedMeanInflation != 0 { input.Config.MeanInflation = savedMeanInflation }
-		simLogVerbose("🔧 [DETERMINISTIC-JSON] Applied default config with overrides: meanSPY=%.4f, meanBond=%.4f, meanInflation=%.4f",
-			input.Config.MeanSPYReturn, input.Config.MeanBondReturn, input.Config.MeanInflation)
-	}
-
-	simLogVerbose("🎯 [DETERMINISTIC-JSON] Running simulation with %d months, seed=%d, mode=%s",
-		input.MonthsToRun, input.Config.RandomSeed, input.Config.SimulationMode)
-
-	// Run deterministic simulation (this captures full trace)
-	result := RunDeterministicSimulation(input)
-
-	simLogVerbose("✅ [DETERMINISTIC-JSON] Complete: success=%t, yearlyData=%d, eventTrace=%d, realizedPathVars=%d",
-		result.Success, len(result.YearlyData), len(result.EventTrace), len(result.RealizedPathVariables))
-
-	// Convert to JS object using existing converter
-	resultJS := convertDeterministicResultToJS(result)
-
-	// Force garbage collection
-	runtime.GC()
-
-	return resultJS
-}
-
-// =================================================================
-// LEGACY MANUAL PARSING FUNCTIONS (TO BE DEPRECATED)
-// These functions use fragile manual parsing and should be replaced by JSON-based versions
-// =================================================================
-
-// parseSimulationInput converts JavaScript object to Go SimulationInput struct
-func parseSimulationInput(inputJS js.Value) (SimulationInput, error) {
-	var input SimulationInput
-
-	// Validate input is an object
-	if inputJS.IsUndefined() || inputJS.IsNull() || inputJS.Type() != js.TypeObject {
-		return input, fmt.Errorf("input must be a valid object")
-	}
-
-	// Parse and validate basic fields
-	monthsToRunVal := inputJS.Get("monthsToRun")
-	if monthsToRunVal.IsUndefined() || monthsToRunVal.IsNull() {
-		return input, fmt.Errorf("monthsToRun is required")
-	}
-	if monthsToRunVal.Type() != js.TypeNumber {
-		return input, fmt.Errorf("monthsToRun must be a number")
-	}
-	input.MonthsToRun = monthsToRunVal.Int()
-	if input.MonthsToRun <= 0 || input.MonthsToRun > 1200 { // Max 100 years
-		return input, fmt.Errorf("monthsToRun must be between 1 and 1200, got %d", input.MonthsToRun)
-	}
-
-	// Parse config (optional, use defaults if not provided)
-	configJS := inputJS.Get("config")
-	if !configJS.IsUndefined() && !configJS.IsNull() {
-		if configJS.Type() != js.TypeObject {
-			return input, fmt.Errorf("config must be an object")
-		}
-		config, err := parseStochasticConfig(configJS)
-		if err != nil {
-			return input, fmt.Errorf("failed to parse config: %v", err)
-		}
-		input.Config = config
-	} else {
-		input.Config = GetDefaultStochasticConfig()
-	}
-
-	// Parse initial accounts (required)
-	accountsJS := inputJS.Get("initialAccounts")
-	if accountsJS.IsUndefined() || accountsJS.IsNull() {
-		return input, fmt.Errorf("initialAccounts is required")
-	}
-	if accountsJS.Type() != js.TypeObject {
-		return input, fmt.Errorf("initialAccounts must be an object")
-	}
-	accounts, err := parseAccountHoldings(accountsJS)
-	if err != nil {
-		return input, fmt.Errorf("failed to parse initial accounts: %v", err)
-	}
-	input.InitialAccounts = accounts
-
-	// CRITICAL: Perform comprehensive validation of parsed accounts
-	// This validates share-based accounting, prevents legacy holdings, and ensures data integrity
-	validator := NewCircuitBreakerValidator()
-	if err := validator.ValidateAccountsIntegrity(&accounts, 0, 1); err != nil {
-		return input, fmt.Errorf("CRITICAL: Input account validation failed: %v", err)
-	}
-
-	// Parse events (optional, can be empty array)
-	eventsJS := inputJS.Get("events")
-	if !eventsJS.IsUndefined() && !eventsJS.IsNull() {
-		if eventsJS.Type() != js.TypeObject {
-			return input, fmt.Errorf("events must be an array")
-		}
-		events, err := parseEvents(eventsJS)
-		if err != nil {
-			return input, fmt.Errorf("failed to parse events: %v", err)
-		}
-		input.Events = events
-	} else {
-		input.Events = []FinancialEvent{}
-	}
-
-	// Parse withdrawalStrategy (optional)
-	wsVal := inputJS.Get("withdrawalStrategy")
-	if !wsVal.IsUndefined() && !wsVal.IsNull() {
-		if wsVal.Type() == js.TypeString {
-			input.WithdrawalStrategy = WithdrawalSequence(wsVal.String())
-		} else {
-			input.WithdrawalStrategy = WithdrawalSequenceTaxEfficient
-		}
-	} else {
-		input.WithdrawalStrategy = WithdrawalSequenceTaxEfficient
-	}
-
-	// Parse goals (optional)
-	goalsJS := inputJS.Get("goals")
-	if !goalsJS.IsUndefined() && !goalsJS.IsNull() {
-		if goalsJS.Type() != js.TypeObject {
-			return input, fmt.Errorf("goals must be an array")
-		}
-		goals, err := parseGoals(goalsJS)
-		if err != nil {
-			return input, fmt.Errorf("failed to parse goals: %v", err)
-		}
-		input.Goals = goals
-	} else {
-		input.Goals = []Goal{}
-	}
-
-	return input, nil
-}
-
-// parseStochasticConfig parses JavaScript stochastic config object
-func parseStochasticConfig(configJS js.Value) (StochasticModelConfig, error) {
-	var config StochasticModelConfig
-
-	// Parse mean returns
-	config.MeanSPYReturn = getFloat64OrDefault(configJS, "meanSpyReturn", 0.08)
-	config.MeanBondReturn = getFloat64OrDefault(configJS, "meanBondReturn", 0.04)
-	config.MeanIntlStockReturn = getFloat64OrDefault(configJS, "meanIntlStockReturn", 0.07)
-	config.MeanInflation = getFloat64OrDefault(configJS, "meanInflation", 0.025)
-	config.MeanHomeValueAppreciation = getFloat64OrDefault(configJS, "meanHomeValueAppreciation", 0.04)
-	config.MeanRentalIncomeGrowth = getFloat64OrDefault(configJS, "meanRentalIncomeGrowth", 0.03)
-
-	// Parse volatilities
-	config.VolatilitySPY = getFloat64OrDefault(configJS, "volatilitySpy", 0.175)
-	config.VolatilityBond = getFloat64OrDefault(configJS, "volatilityBond", 0.045)
-	config.VolatilityIntlStock = getFloat64OrDefault(configJS, "volatilityIntlStock", 0.20)
-	config.VolatilityInflation = getFloat64OrDefault(configJS, "volatilityInflation", 0.015)
-	config.VolatilityHomeValue = getFloat64OrDefault(configJS, "volatilityHomeValue", 0.10)
-	config.VolatilityRentalIncomeGrowth = getFloat64OrDefault(configJS, "volatilityRentalIncomeGrowth", 0.08)
-
-	// Parse GARCH parameters
-	config.GarchSPYOmega = getFloat64OrDefault(configJS, "garchSpyOmega", 0.0001)
-	config.GarchSPYAlpha = getFloat64OrDefault(configJS, "garchSpyAlpha", 0.1)
-	config.GarchSPYBeta = getFloat64OrDefault(configJS, "garchSpyBeta", 0.85)
-
-	config.GarchBondOmega = getFloat64OrDefault(configJS, "garchBondOmega", 0.00005)
-	config.GarchBondAlpha = getFloat64OrDefault(configJS, "garchBondAlpha", 0.05)
-	config.GarchBondBeta = getFloat64OrDefault(configJS, "garchBondBeta", 0.90)
-
-	config.GarchIntlStockOmega = getFloat64OrDefault(configJS, "garchIntlStockOmega", 0.00015)
-	config.GarchIntlStockAlpha = getFloat64OrDefault(configJS, "garchIntlStockAlpha", 0.12)
-	config.GarchIntlStockBeta = getFloat64OrDefault(configJS, "garchIntlStockBeta", 0.80)
-
-	// GARCH parameters for Other assets (generic fallback)
-	config.GarchOtherOmega = getFloat64OrDefault(configJS, "garchOtherOmega", 0.0001)
-	config.GarchOtherAlpha = getFloat64OrDefault(configJS, "garchOtherAlpha", 0.1)
-	config.GarchOtherBeta = getFloat64OrDefault(configJS, "garchOtherBeta", 0.85)
-
-	// GARCH parameters for Individual Stock assets
-	config.GarchIndividualStockOmega = getFloat64OrDefault(configJS, "garchIndividualStockOmega", 0.0001)
-	config.GarchIndividualStockAlpha = getFloat64OrDefault(configJS, "garchIndividualStockAlpha", 0.1)
-	config.GarchIndividualStockBeta = getFloat64OrDefault(configJS, "garchIndividualStockBeta", 0.85)
-
-	// Parse AR(1) parameters
-	config.AR1InflationConstant = getFloat64OrDefault(configJS, "ar1InflationConstant", 0.005)
-	config.AR1InflationPhi = getFloat64OrDefault(configJS, "ar1InflationPhi", 0.7)
-	config.AR1HomeValueConstant = getFloat64OrDefault(configJS, "ar1HomeValueConstant", 0.01)
-	config.AR1HomeValuePhi = getFloat64OrDefault(configJS, "ar1HomeValuePhi", 0.6)
-	config.AR1RentalIncomeGrowthConstant = getFloat64OrDefault(configJS, "ar1RentalIncomeGrowthConstant", 0.008)
-	config.AR1RentalIncomeGrowthPhi = getFloat64OrDefault(configJS, "ar1RentalIncomeGrowthPhi", 0.5)
-
-	// Parse fat tail parameter
-	config.FatTailParameter = getFloat64OrDefault(configJS, "fatTailParameter", 5.0)
-
-	// Parse cost parameters
-	config.CostLeveragedETF = getFloat64OrDefault(configJS, "costLeveragedEtf", 0.012)
-
-	// Parse correlation matrix
-	if corrMatrixJS := configJS.Get("correlationMatrix"); !corrMatrixJS.IsUndefined() {
-		corrMatrix, err := parseCorrelationMatrix(corrMatrixJS)
-		if err != nil {
-			return config, fmt.Errorf("failed to parse correlation matrix: %v", err)
-		}
-		config.CorrelationMatrix = corrMatrix
-	}
-
-	// Parse guardrails
-	if guardrailsJS := configJS.Get("guardrails"); !guardrailsJS.IsUndefined() {
-		guardrails := GuardrailConfig{
-			UpperGuardrail:   getFloat64OrDefault(guardrailsJS, "upperGuardrail", 0.06),
-			LowerGuardrail:   getFloat64OrDefault(guardrailsJS, "lowerGuardrail", 0.03),
-			SpendingCutPct:   getFloat64OrDefault(guardrailsJS, "spendingCutPct", 0.1),
-			SpendingBonusPct: getFloat64OrDefault(guardrailsJS, "spendingBonusPct", 0.05),
-		}
-		config.Guardrails = guardrails
-	}
-
-	// Parse simulation mode and seed (for deterministic vs stochastic)
-	config.SimulationMode = getStringOrDefault(configJS, "simulationMode", "")
-	config.RandomSeed = int64(getIntOrDefault(configJS, "randomSeed", 0))
-	config.DebugDisableRandomness = getBoolOrDefault(configJS, "debugDisableRandomness", false)
-
-	return config, nil
-}
-
-// parseCorrelationMatrix parses JavaScript 2D array to Go 2D slice
-func parseCorrelationMatrix(matrixJS js.Value) ([][]float64, error) {
-	length := matrixJS.Length()
-	matrix := make([][]float64, length)
-
-	for i := 0; i < length; i++ {
-		row := matrixJS.Index(i)
-		rowLength := row.Length()
-		matrix[i] = make([]float64, rowLength)
-
-		for j := 0; j < rowLength; j++ {
-			matrix[i][j] = row.Index(j).Float()
-		}
-	}
-
-	return matrix, nil
-}
-
-// parseAccountHoldings parses JavaScript account holdings object
-func parseAccountHoldings(accountsJS js.Value) (AccountHoldingsMonthEnd, error) {
-	var accounts AccountHoldingsMonthEnd
-
-	simLogVerbose("📊 PARSE-ACCOUNT-HOLDINGS: Starting to parse initial accounts")
-
-	// Parse cash
-	if cashVal := accountsJS.Get("cash"); !cashVal.IsUndefined() && !cashVal.IsNull() {
-		accounts.Cash = cashVal.Float()
-		simLogVerbose("📊 PARSE-CASH: Got cash value=$%.2f", accounts.Cash)
-	} else {
-		// Default to 0 if cash is not provided or is null/undefined
-		accounts.Cash = 0
-		simLogVerbose("📊 PARSE-CASH: No cash value, defaulting to 0")
-	}
-
-	// Parse taxable account
-	if taxableJS := accountsJS.Get("taxable"); !taxableJS.IsUndefined() && !taxableJS.IsNull() {
-		simLogVerbose("📊 PARSE-TAXABLE: Found taxable account, calling parseAccount")
-		taxableAccount, err := parseAccount(taxableJS)
-		if err != nil {
-			return accounts, fmt.Errorf("failed to parse taxable account: %v", err)
-		}
-		accounts.Taxable = &taxableAccount
-	} else {
-		// Initialize empty account instead of nil to prevent nil pointer dereferences
-		emptyTaxableAccount := Account{TotalValue: 0, Holdings: []Holding{}}
-		accounts.Taxable = &emptyTaxableAccount
-	}
-
-	// Parse tax-deferred account
-	if taxDeferredJS := accountsJS.Get("tax_deferred"); !taxDeferredJS.IsUndefined() && !taxDeferredJS.IsNull() {
-		simLogVerbose("📊 PARSE-TAX-DEFERRED: Found tax_deferred account, calling parseAccount")
-		taxDeferredAccount, err := parseAccount(taxDeferredJS)
-		if err != nil {
-			return accounts, fmt.Errorf("failed to parse tax_deferred account: %v", err)
-		}
-		accounts.TaxDeferred = &taxDeferredAccount
-	} else {
-		// Initialize empty account instead of nil to prevent nil pointer dereferences
-		emptyTaxDeferredAccount := Account{TotalValue: 0, Holdings: []Holding{}}
-		accounts.TaxDeferred = &emptyTaxDeferredAccount
-	}
-
-	// Parse Roth account
-	if rothJS := accountsJS.Get("roth"); !rothJS.IsUndefined() && !rothJS.IsNull() {
-		simLogVerbose("📊 PARSE-ROTH: Found roth account, calling parseAccount")
-		rothAccount, err := parseAccount(rothJS)
-		if err != nil {
-			return accounts, fmt.Errorf("failed to parse roth account: %v", err)
-		}
-		accounts.Roth = &rothAccount
-	} else {
-		// Initialize empty account instead of nil to prevent nil pointer dereferences
-		emptyRothAccount := Account{TotalValue: 0, Holdings: []Holding{}}
-		accounts.Roth = &emptyRothAccount
-	}
-
-	return accounts, nil
-}
-
-// parseAccount parses a single account object
-func parseAccount(accountJS js.Value) (Account, error) {
-	var account Account
-
-	// Ensure accountJS is not null or undefined before trying to Get properties
-	if accountJS.IsUndefined() || accountJS.IsNull() {
-		return account, fmt.Errorf("account value is null or undefined")
-	}
-
-	// Handle both simple numeric format (e.g., "tax_deferred": 10000) and complex object format
-	if accountJS.Type() == js.TypeNumber {
-		// Simple numeric format - create account with totalValue and empty holdings
-		account.TotalValue = accountJS.Float()
-		account.Holdings = []Holding{}
-		return account, nil
-	}
-
-	// Complex object format - parse totalValue and holdings
-	account.TotalValue = getFloat64OrDefault(accountJS, "totalValue", 0)
-
-	// Parse holdings
-	if holdingsJS := accountJS.Get("holdings"); !holdingsJS.IsUndefined() && !holdingsJS.IsNull() {
-		length := holdingsJS.Length()
-		account.Holdings = make([]Holding, length)
-
-		for i := 0; i < length; i++ {
-			holdingJS := holdingsJS.Index(i)
-			// Check if holdingJS is valid before accessing its properties
-			if holdingJS.IsUndefined() || holdingJS.IsNull() {
-				// Skip this holding or return an error
-				continue // Simple skip for now
-			}
-			holding := Holding{
-				AssetClass:                NormalizeAssetClass(AssetClass(getStringOrDefault(holdingJS, "assetClass", ""))),
-				Quantity:                  getFloat64OrDefault(holdingJS, "quantity", 0),
-				CostBasisPerUnit:          getFloat64OrDefault(holdingJS, "costBasisPerUnit", 0),
-				CostBasisTotal:            getFloat64OrDefault(holdingJS, "costBasisTotal", 0),
-				CurrentMarketPricePerUnit: getFloat64OrDefault(holdingJS, "currentMarketPricePerUnit", 0),
-				CurrentMarketValueTotal:   getFloat64OrDefault(holdingJS, "currentMarketValueTotal", 0),
-				UnrealizedGainLossTotal:   getFloat64OrDefault(holdingJS, "unrealizedGainLossTotal", 0),
-			}
-
-			// CRITICAL: Validate share-based accounting at input boundary
-			// Engine cannot run with legacy dollar-based holdings
-			if isLegacyHolding(&holding) {
-				return account, fmt.Errorf("CRITICAL: Legacy dollar-based holding detected for asset class '%s' (quantity=%.1f, costBasisPerUnit=$%.2f). Engine requires share-based accounting with realistic share quantities. Please convert holdings to share-based format with proper quantity of shares and cost basis per share", holding.AssetClass, holding.Quantity, holding.CostBasisPerUnit)
-			}
-
-			account.Holdings[i] = holding
-		}
-	} else {
-		account.Holdings = []Holding{} // Initialize to empty slice if not present
-	}
-
-	// If totalValue is 0 but we have holdings, calculate it from holdings
-	simLogVerbose("📊 PARSE-ACCOUNT-CHECK: totalValue=$%.2f, holdings count=%d", account.TotalValue, len(account.Holdings))
-	if account.TotalValue == 0 && len(account.Holdings) > 0 {
-		calculatedTotal := 0.0
-		for i, holding := range account.Holdings {
-			simLogVerbose("📊 HOLDING-%d: CurrentMarketValueTotal=$%.2f", i, holding.CurrentMarketValueTotal)
-			calculatedTotal += holding.CurrentMarketValueTotal
-		}
-		account.TotalValue = calculatedTotal
-		simLogVerbose("📊 CALCULATED-TOTAL-VALUE: Account had totalValue=0, calculated $%.2f from %d holdings", calculatedTotal, len(account.Holdings))
-	}
-
-	simLogVerbose("📊 PARSE-ACCOUNT-FINAL: Returning account with totalValue=$%.2f", account.TotalValue)
-	return account, nil
-}
-
-// Helper function to get float64 value with default
-func getFloat64OrDefault(obj js.Value, key string, defaultVal float64) float64 {
-	if obj.IsUndefined() || obj.IsNull() {
-		return defaultVal
-	}
-	if val := obj.Get(key); !val.IsUndefined() && !val.IsNull() && val.Type() == js.TypeNumber {
-		return val.Float()
-	}
-	return defaultVal
-}
-
-// isLegacyHolding function removed - duplicated in simulation.go
-
-// Helper function to get string value with default
-func getStringOrDefault(obj js.Value, key string, defaultVal string) string {
-	if obj.IsUndefined() || obj.IsNull() {
-		return defaultVal
-	}
-	if val := obj.Get(key); !val.IsUndefined() && !val.IsNull() && val.Type() == js.TypeString {
-		return val.String()
-	}
-	return defaultVal
-}
-
-// Helper function to get int value with default
-func getIntOrDefault(obj js.Value, key string, defaultVal int) int {
-	if obj.IsUndefined() || obj.IsNull() {
-		return defaultVal
-	}
-	val := obj.Get(key)
-	if !val.IsUndefined() && !val.IsNull() && val.Type() == js.TypeNumber {
-		return val.Int()
-	}
-	return defaultVal
-}
-
-// Helper function to get bool value with default
-func getBoolOrDefault(obj js.Value, key string, defaultVal bool) bool {
-	if obj.IsUndefined() || obj.IsNull() {
-		return defaultVal
-	}
-	val := obj.Get(key)
-	if !val.IsUndefined() && !val.IsNull() && val.Type() == js.TypeBoolean {
-		return val.Bool()
-	}
-	return defaultVal
-}
-
-// Helper function to validate amount is a valid finite number
-func isValidAmount(amount float64) bool {
-	return !math.IsNaN(amount) && !math.IsInf(amount, 0)
-}
-
-// Helper function to safely set float64 values in JavaScript objects
-func safeSetFloat64(jsObj js.Value, key string, value float64) {
-	if isValidAmount(value) {
-		jsObj.Set(key, value)
-	} else {
-		jsObj.Set(key, 0)
-	}
-}
-
-// parseEvents parses JavaScript events array
-func parseEvents(eventsJS js.Value) ([]FinancialEvent, error) {
-
-	if eventsJS.IsUndefined() || eventsJS.IsNull() {
-		return []FinancialEvent{}, nil
-	}
-
-	// Validate it's an array-like object
-	length := eventsJS.Length()
-	if length < 0 {
-		return nil, fmt.Errorf("events must be an array")
-	}
-
-	parsedEvents := make([]FinancialEvent, 0, length)
-
-	for i := 0; i < length; i++ {
-		eventJS := eventsJS.Index(i)
-		if eventJS.IsUndefined() || eventJS.IsNull() {
-			return nil, fmt.Errorf("event at index %d is null or undefined", i)
-		}
-
-		if eventJS.Type() != js.TypeObject {
-			return nil, fmt.Errorf("event at index %d must be an object", i)
-		}
-
-		// Validate required fields
-		idVal := eventJS.Get("id")
-		if idVal.IsUndefined() || idVal.IsNull() || idVal.Type() != js.TypeString {
-			return nil, fmt.Errorf("event at index %d: 'id' is required and must be a string", i)
-		}
-
-		typeVal := eventJS.Get("type")
-		if typeVal.IsUndefined() || typeVal.IsNull() || typeVal.Type() != js.TypeString {
-			return nil, fmt.Errorf("event at index %d: 'type' is required and must be a string", i)
-		}
-
-		// Validate event type is known
-		eventTypeStr := typeVal.String()
-		eventType := EventType(eventTypeStr)
-		if !isValidEventType(eventType) {
-			return nil, fmt.Errorf("event at index %d: unknown event type '%s'", i, eventType)
-		}
-
-		amountVal := eventJS.Get("amount")
-		var amount float64
-
-		// Handle different amount types more flexibly
-		if amountVal.IsUndefined() || amountVal.IsNull() {
-			// Allow null/undefined amounts for some event types (e.g., strategy events)
-			amount = 0
-		} else if amountVal.Type() == js.TypeNumber {
-			amount = amountVal.Float()
-		} else if amountVal.Type() == js.TypeString {
-			// Try to parse string amounts (common from UI forms)
-			amountStr := amountVal.String()
-			if amountStr == "" {
-				amount = 0
-			} else {
-				var err error
-				amount, err = strconv.ParseFloat(amountStr, 64)
-				if err != nil {
-					return nil, fmt.Errorf("event at index %d: 'amount' string '%s' cannot be parsed as number", i, amountStr)
-				}
-			}
-		} else {
-			return nil, fmt.Errorf("event at index %d: 'amount' must be a number or string, got %s", i, amountVal.Type().String())
-		}
-
-		// Validate amount is reasonable (but allow negative for expenses)
-		if !isValidAmount(amount) {
-			return nil, fmt.Errorf("event at index %d: 'amount' %f is not a valid number (NaN or Infinity)", i, amount)
-		}
-
-		// Convert to absolute value as per data contract
-		amount = math.Abs(amount)
-
-		event := FinancialEvent{
-			ID:          idVal.String(),
-			Type:        string(eventType),
-			MonthOffset: getIntOrDefault(eventJS, "monthOffset", 0),
-			Amount:      amount,
-		}
-
-		// Preserve description/name for richer debug context
-		if descVal := eventJS.Get("description"); !descVal.IsUndefined() && !descVal.IsNull() && descVal.Type() == js.TypeString {
-			event.Description = descVal.String()
-		} else if nameVal := eventJS.Get("name"); !nameVal.IsUndefined() && !nameVal.IsNull() && nameVal.Type() == js.TypeString {
-			event.Description = nameVal.String()
-		}
-
-		// Parse metadata with better error handling
-		if metadataJS := eventJS.Get("metadata"); !metadataJS.IsUndefined() && !metadataJS.IsNull() {
-			if metadataJS.Type() == js.TypeObject {
-				metaMap, err := parseMetadataObject(metadataJS)
-				if err != nil {
-					return nil, fmt.Errorf("event at index %d: failed to parse metadata: %v", i, err)
-				}
-				event.Metadata = metaMap
-			} else {
-				return nil, fmt.Errorf("event at index %d: metadata must be an object", i)
-			}
-		} else {
-			event.Metadata = nil
-		}
-
-		// Persist frequency when provided directly or via metadata
-		if freqVal := eventJS.Get("frequency"); !freqVal.IsUndefined() && !freqVal.IsNull() && freqVal.Type() == js.TypeString {
-			event.Frequency = freqVal.String()
-			if event.Metadata == nil {
-				event.Metadata = make(map[string]interface{})
-			}
-			event.Metadata["frequency"] = event.Frequency
-		} else if freqMeta, ok := event.Metadata["frequency"].(string); ok && freqMeta != "" {
-			event.Frequency = freqMeta
-		}
-
-		// Ensure priority travels with the event when provided
-		if priorityVal := eventJS.Get("priority"); !priorityVal.IsUndefined() && !priorityVal.IsNull() && priorityVal.Type() == js.TypeString {
-			if event.Metadata == nil {
-				event.Metadata = make(map[string]interface{})
-			}
-			event.Metadata["priority"] = priorityVal.String()
-		}
-
-		parsedEvents = append(parsedEvents, event)
-	}
-	return parsedEvents, nil
-}
-
-// parseGoals parses JavaScript goals array
-func parseGoals(goalsJS js.Value) ([]Goal, error) {
-	length := goalsJS.Length()
-	goals := make([]Goal, length)
-
-	for i := 0; i < length; i++ {
-		goalJS := goalsJS.Index(i)
-		goal, err := parseGoal(goalJS)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse goal at index %d: %v", i, err)
-		}
-		goals[i] = goal
-	}
-
-	return goals, nil
-}
-
-// parseGoal parses a single goal
-func parseGoal(goalJS js.Value) (Goal, error) {
-	var goal Goal
-
-	// Parse required fields
-	if idVal := goalJS.Get("id"); !idVal.IsUndefined() && !idVal.IsNull() {
-		goal.ID = idVal.String()
-	} else {
-		return goal, fmt.Errorf("goal id is required")
-	}
-
-	if nameVal := goalJS.Get("name"); !nameVal.IsUndefined() && !nameVal.IsNull() {
-		goal.Name = nameVal.String()
-	} else {
-		return goal, fmt.Errorf("goal name is required")
-	}
-
-	if targetAmountVal := goalJS.Get("targetAmount"); !targetAmountVal.IsUndefined() && !targetAmountVal.IsNull() {
-		goal.TargetAmount = targetAmountVal.Float()
-	} else {
-		return goal, fmt.Errorf("goal targetAmount is required")
-	}
-
-	if targetMonthOffsetVal := goalJS.Get("targetMonthOffset"); !targetMonthOffsetVal.IsUndefined() && !targetMonthOffsetVal.IsNull() {
-		goal.TargetMonthOffset = targetMonthOffsetVal.Int()
-	} else {
-		return goal, fmt.Errorf("goal targetMonthOffset is required")
-	}
-
-	if priorityVal := goalJS.Get("priority"); !priorityVal.IsUndefined() && !priorityVal.IsNull() {
-		goal.Priority = priorityVal.Int()
-	} else {
-		return goal, fmt.Errorf("goal priority is required")
-	}
-
-	if categoryVal := goalJS.Get("category"); !categoryVal.IsUndefined() && !categoryVal.IsNull() {
-		goal.Category = categoryVal.String()
-	} else {
-		return goal, fmt.Errorf("goal category is required")
-	}
-
-	// Parse optional description
-	if descVal := goalJS.Get("description"); !descVal.IsUndefined() && !descVal.IsNull() {
-		goal.Description = descVal.String()
-	}
-
-	return goal, nil
-}
-
-// parseMetadataObject parses metadata object with strict type checking
-func parseMetadataObject(metadataJS js.Value) (map[string]interface{}, error) {
-	jsObjectGlobal := js.Global().Get("Object")
-	keys := jsObjectGlobal.Call("keys", metadataJS)
-	metaMapLength := keys.Length()
-	metaMap := make(map[string]interface{}, metaMapLength)
-
-	for k := 0; k < metaMapLength; k++ {
-		keyStr := keys.Index(k).String()
-		value := metadataJS.Get(keyStr)
-
-		switch value.Type() {
-		case js.TypeString:
-			metaMap[keyStr] = value.String()
-		case js.TypeNumber:
-			metaMap[keyStr] = value.Float()
-		case js.TypeBoolean:
-			metaMap[keyStr] = value.Bool()
-		case js.TypeNull, js.TypeUndefined:
-			metaMap[keyStr] = nil
-		case js.TypeObject:
-			// Handle nested objects recursively
-			nestedMap, err := parseMetadataObject(value)
-			if err != nil {
-				return nil, fmt.Errorf("metadata field '%s' nested object error: %v", keyStr, err)
-			}
-			metaMap[keyStr] = nestedMap
-		default:
-			// For other complex types, reject them to maintain simplicity
-			return nil, fmt.Errorf("metadata field '%s' has unsupported type %s. Only strings, numbers, booleans, objects, and null are allowed", keyStr, value.Type().String())
-		}
-	}
-
-	return metaMap, nil
-}
-
-// isValidEventType checks if the event type is recognized
-func isValidEventType(eventType EventType) bool {
-	validTypes := []EventType{
-		EventTypeIncome,
-		EventTypeExpense,      // Add basic EXPENSE type
-		EventTypeContribution, // Add basic CONTRIBUTION type
-		EventTypeScheduledContribution,
-		EventTypeRecurringExpense,
-		EventTypeOneTimeExpense,
-		EventTypeOneTimeEvent,
-		EventTypeLiabilityAdd,
-		EventTypeLiabilityPayment,
-		EventTypeDebtPayment,
-		EventTypeRealEstatePurchase,
-		EventTypeRealEstateSale,
-		EventTypeRothConversion,
-		EventTypeWithdrawal,
-		EventTypeTransfer,
-		EventTypeFiveTwoNineContribution,
-		EventTypeFiveTwoNineWithdrawal,
-		EventTypeHSAContribution,
-		EventTypeHSAWithdrawal,
-		EventTypeSocialSecurityIncome,
-		EventTypeHealthcareCost,
-		EventTypeStrategyAssetAllocationSet,
-		EventTypeStrategyRebalancingRuleSet,
-		EventTypeRebalancePortfolio,
-		EventTypeTaxLossHarvestingSale,
-		EventTypeInitialState,
-		EventTypeStrategicCapitalGainsRealization,
-		EventTypeQualifiedCharitableDistribution,
-		EventTypeAdjustCashReserveSellAssets,
-		EventTypeAdjustCashReserveBuyAssets,
-		EventTypeGoalDefine,
-		// RSU types removed - overly complex feature not needed
-		EventTypeTaxLossHarvestingCheckAndExecute,
-		EventTypeConcentrationRiskAlert,
-		EventTypePensionIncome,
-		EventTypeAnnuityPayment,
-		EventTypeRequiredMinimumDistribution,
-		EventTypeDividendIncome,
-		EventTypeCapitalGainsRealization,
-		EventTypeBusinessIncome,
-		EventTypeQuarterlyEstimatedTaxPayment,
-		EventTypeTuitionPayment,
-		EventTypeLifeInsurancePremium,
-		EventTypeHealthInsurancePremium,
-		EventTypePropertyInsurance,
-		EventTypeDisabilityInsurancePremium,
-		EventTypeCarPurchase,
-		EventTypeHomeRenovation,
-		EventTypeEmergencyExpense,
-		EventTypeVacationExpense,
-		// Add missing event types that have handlers but weren't in parsing validation
-		EventTypeTaxPayment, // From generated_interface_types.go
-		EventTypeLifeInsurancePayout,
-		EventTypeDisabilityInsurancePayout,
-		EventTypeLongTermCareInsurancePremium,
-		EventTypeLongTermCarePayout,
-		EventTypeMortgageOrigination,
-		// CRITICAL FIX: Add missing RMD event type that exists in generated_interface_types.go
-		EventTypeRMD,
-		// Strategy Policy Events - Duration-based strategies for timeline visualization
-		EventTypeStrategyPolicy,
-		EventTypeStrategyExecution,
-		// PFOS-E Consolidated Event Types - unified handlers that consolidate legacy types
-		EventTypeCashflowIncome,    // Consolidates INCOME, SOCIAL_SECURITY_INCOME, PENSION_INCOME, etc.
-		EventTypeCashflowExpense,   // Consolidates RECURRING_EXPENSE, ONE_TIME_EVENT, HEALTHCARE_COST, etc.
-		EventTypeInsurancePremium,  // Consolidates LIFE/DISABILITY/LTC_INSURANCE_PREMIUM
-		EventTypeInsurancePayout,   // Consolidates LIFE/DISABILITY/LTC_PAYOUT
-		EventTypeAccountContribution, // Enhanced contribution with PFOS-E metadata
-		EventTypeExposureChange,    // RSU/ISO/NSO vesting and sales
-	}
-
-	for _, validType := range validTypes {
-		if eventType == validType {
-			return true
-		}
-	}
-	return false
-}
-
-// convertResultsToJS converts Go SimulationResults to JavaScript object
-func convertResultsToJS(results SimulationResults) js.Value {
-	obj := js.Global().Get("Object").New()
-	obj.Set("success", results.Success)
-	obj.Set("numberOfRuns", results.NumberOfRuns)
-	obj.Set("finalNetWorthP10", results.FinalNetWorthP10)
-	obj.Set("finalNetWorthP25", results.FinalNetWorthP25)
-	obj.Set("finalNetWorthP50", results.FinalNetWorthP50)
-	obj.Set("finalNetWorthP75", results.FinalNetWorthP75)
-	obj.Set("finalNetWorthP90", results.FinalNetWorthP90)
-	obj.Set("probabilityOfSuccess", results.ProbabilityOfSuccess)
-
-	// Add bankruptcy detection results
-	obj.Set("probabilityOfBankruptcy", results.ProbabilityOfBankruptcy)
-	obj.Set("bankruptcyCount", results.BankruptcyCount)
-
-	if results.Error != "" {
-		obj.Set("error", results.Error)
-	}
-
-	return obj
-}
-
-// convertSingleResultToJS converts Go SimulationResult to JavaScript object
-func convertSingleResultToJS(result SimulationResult) js.Value {
-	obj := js.Global().Get("Object").New()
-	obj.Set("success", result.Success)
-
-	if result.Error != "" {
-		obj.Set("error", result.Error)
-	}
-
-	if result.Success && len(result.MonthlyData) > 0 {
-		// Convert monthly data to JavaScript array
-		monthlyDataJS := js.Global().Get("Array").New(len(result.MonthlyData))
-		for i, data := range result.MonthlyData {
-			dataJS := js.Global().Get("Object").New()
-			dataJS.Set("monthOffset", data.MonthOffset)
-			// 🎯 Safe float64 conversion for main fields
-			safeSetFloat64(dataJS, "netWorth", data.NetWorth)
-			safeSetFloat64(dataJS, "cashFlow", data.CashFlow)
-
-			// Convert returns with safe value checks
-			returnsJS := js.Global().Get("Object").New()
-			// 🎯 Safe float64 conversion for all returns
-			safeSetFloat64(returnsJS, "spy", data.Returns.SPY)
-			safeSetFloat64(returnsJS, "bnd", data.Returns.BND)
-			safeSetFloat64(returnsJS, "intl", data.Returns.Intl)
-			safeSetFloat64(returnsJS, "home", data.Returns.Home)
-			safeSetFloat64(returnsJS, "rent", data.Returns.Rent)
-			safeSetFloat64(returnsJS, "inflation", data.Returns.Inflation)
-			dataJS.Set("returns", returnsJS)
-
-			// Convert accounts data with safer conversion
-			accountsJS := js.Global().Get("Object").New()
-
-			// 🎯 Safe conversion for cash
-			safeSetFloat64(accountsJS, "cash", data.Accounts.Cash)
-
-			// 🎯 Convert account pointers to plain objects instead of raw pointers
-			if data.Accounts.Taxable != nil {
-				taxableJS := js.Global().Get("Object").New()
-				safeSetFloat64(taxableJS, "totalValue", data.Accounts.Taxable.TotalValue)
-				accountsJS.Set("taxable", taxableJS)
-			}
-			if data.Accounts.TaxDeferred != nil {
-				taxDeferredJS := js.Global().Get("Object").New()
-				safeSetFloat64(taxDeferredJS, "totalValue", data.Accounts.TaxDeferred.TotalValue)
-				accountsJS.Set("tax_deferred", taxDeferredJS)
-			}
-			if data.Accounts.Roth != nil {
-				rothJS := js.Global().Get("Object").New()
-				safeSetFloat64(rothJS, "totalValue", data.Accounts.Roth.TotalValue)
-				accountsJS.Set("roth", rothJS)
-			}
-			dataJS.Set("accounts", accountsJS)
-
-			// Convert liabilities data
-			if data.Liabilities != nil && len(data.Liabilities) > 0 {
-				liabilitiesJS := js.Global().Get("Array").New(len(data.Liabilities))
-				for i, liability := range data.Liabilities {
-					liabilityJS := js.Global().Get("Object").New()
-					liabilityJS.Set("id", liability.ID)
-					liabilityJS.Set("name", liability.Name)
-					liabilityJS.Set("type", liability.Type)
-					safeSetFloat64(liabilityJS, "currentPrincipalBalance", liability.CurrentPrincipalBalance)
-					safeSetFloat64(liabilityJS, "interestRate", liability.InterestRate)
-					liabilityJS.Set("termRemainingMonths", liability.TermRemainingMonths)
-					safeSetFloat64(liabilityJS, "monthlyPayment", liability.MonthlyPayment)
-					liabilityJS.Set("isTaxDeductible", liability.IsTaxDeductible)
-					liabilitiesJS.SetIndex(i, liabilityJS)
-				}
-				dataJS.Set("liabilities", liabilitiesJS)
-			}
-
-			// 🎯 Safe monthly flow tracking fields from struct
-			safeSetFloat64(dataJS, "incomeThisMonth", data.IncomeThisMonth)
-			safeSetFloat64(dataJS, "expensesThisMonth", data.ExpensesThisMonth)
-			safeSetFloat64(dataJS, "contributionsToInvestmentsThisMonth", data.ContributionsToInvestmentsThisMonth)
-			safeSetFloat64(dataJS, "debtPaymentsPrincipalThisMonth", data.DebtPaymentsPrincipalThisMonth)
-			safeSetFloat64(dataJS, "debtPaymentsInterestThisMonth", data.DebtPaymentsInterestThisMonth)
-			safeSetFloat64(dataJS, "rothConversionAmountThisMonth", data.RothConversionAmountThisMonth)
-			safeSetFloat64(dataJS, "oneTimeEventsImpactThisMonth", data.OneTimeEventsImpactThisMonth)
-			safeSetFloat64(dataJS, "divestmentProceedsThisMonth", data.DivestmentProceedsThisMonth)
-			safeSetFloat64(dataJS, "rebalancingTradesNetEffectThisMonth", data.RebalancingTradesNetEffectThisMonth)
-			safeSetFloat64(dataJS, "taxWithheldThisMonth", data.TaxWithheldThisMonth)
-
-			// Convert dividends received with safe conversion
-			dividendsJS := js.Global().Get("Object").New()
-			safeSetFloat64(dividendsJS, "qualified", data.DividendsReceivedThisMonth.Qualified)
-			safeSetFloat64(dividendsJS, "ordinary", data.DividendsReceivedThisMonth.Ordinary)
-			dataJS.Set("dividendsReceivedThisMonth", dividendsJS)
-
-			// 🎯 Safe YTD tax tracking fields from struct
-			safeSetFloat64(dataJS, "ordinaryIncomeForTaxYTD", data.OrdinaryIncomeForTaxYTD)
-			safeSetFloat64(dataJS, "stcgForTaxYTD", data.STCGForTaxYTD)
-			safeSetFloat64(dataJS, "ltcgForTaxYTD", data.LTCGForTaxYTD)
-			safeSetFloat64(dataJS, "qualifiedDividendIncomeYTD", data.QualifiedDividendIncomeYTD)
-			safeSetFloat64(dataJS, "ordinaryDividendIncomeYTD", data.OrdinaryDividendIncomeYTD)
-			safeSetFloat64(dataJS, "itemizedDeductibleInterestPaidYTD", data.ItemizedDeductibleInterestPaidYTD)
-			safeSetFloat64(dataJS, "preTaxContributionsYTD", data.PreTaxContributionsYTD)
-			safeSetFloat64(dataJS, "taxWithholdingYTD", data.TaxWithholdingYTD)
-
-			// 🎯 Safe annual tax calculation results (if available) from struct pointers
-			if data.TaxPaidAnnual != nil {
-				safeSetFloat64(dataJS, "taxPaidAnnual", *data.TaxPaidAnnual)
-			}
-			if data.RMDAmountAnnual != nil {
-				safeSetFloat64(dataJS, "rmdAmountAnnual", *data.RMDAmountAnnual)
-			}
-			if data.IRMAAMedicarePremiumAdjustment != nil {
-				safeSetFloat64(dataJS, "irmaaMedicarePremiumAdjustment", *data.IRMAAMedicarePremiumAdjustment)
-			}
-			if data.CapitalLossCarryoverEndYear != nil {
-				safeSetFloat64(dataJS, "capitalLossCarryoverEndYear", *data.CapitalLossCarryoverEndYear)
-			}
-			if data.ActiveFilingStatus != nil {
-				dataJS.Set("activeFilingStatus", *data.ActiveFilingStatus)
-			}
-			if data.ActiveNumDependents != nil {
-				dataJS.Set("activeNumDependents", *data.ActiveNumDependents)
-			}
-
-			// 🎯 Safe detailed tax breakdown (if available) from struct pointers
-			if data.FederalIncomeTaxAnnual != nil {
-				safeSetFloat64(dataJS, "federalIncomeTaxAnnual", *data.FederalIncomeTaxAnnual)
-			}
-			if data.StateIncomeTaxAnnual != nil {
-				safeSetFloat64(dataJS, "stateIncomeTaxAnnual", *data.StateIncomeTaxAnnual)
-			}
-			if data.CapitalGainsTaxShortTermAnnual != nil {
-				safeSetFloat64(dataJS, "capitalGainsTaxShortTermAnnual", *data.CapitalGainsTaxShortTermAnnual)
-			}
-			if data.CapitalGainsTaxLongTermAnnual != nil {
-				safeSetFloat64(dataJS, "capitalGainsTaxLongTermAnnual", *data.CapitalGainsTaxLongTermAnnual)
-			}
-			if data.AlternativeMinimumTaxAnnual != nil {
-				safeSetFloat64(dataJS, "alternativeMinimumTaxAnnual", *data.AlternativeMinimumTaxAnnual)
-			}
-			if data.EffectiveTaxRateAnnual != nil {
-				safeSetFloat64(dataJS, "effectiveTaxRateAnnual", *data.EffectiveTaxRateAnnual)
-			}
-			if data.MarginalTaxRateAnnual != nil {
-				safeSetFloat64(dataJS, "marginalTaxRateAnnual", *data.MarginalTaxRateAnnual)
-			}
-			if data.AdjustedGrossIncomeAnnual != nil {
-				safeSetFloat64(dataJS, "adjustedGrossIncomeAnnual", *data.AdjustedGrossIncomeAnnual)
-			}
-			if data.TaxableIncomeAnnual != nil {
-				safeSetFloat64(dataJS, "taxableIncomeAnnual", *data.TaxableIncomeAnnual)
-			}
-
-			// Export FICA tax breakdown fields
-			if data.SocialSecurityTaxAnnual != nil {
-				safeSetFloat64(dataJS, "socialSecurityTaxAnnual", *data.SocialSecurityTaxAnnual)
-			}
-			if data.MedicareTaxAnnual != nil {
-				safeSetFloat64(dataJS, "medicareTaxAnnual", *data.MedicareTaxAnnual)
-			}
-			if data.AdditionalMedicareTaxAnnual != nil {
-				safeSetFloat64(dataJS, "additionalMedicareTaxAnnual", *data.AdditionalMedicareTaxAnnual)
-			}
-			if data.TotalFICATaxAnnual != nil {
-				safeSetFloat64(dataJS, "totalFicaTaxAnnual", *data.TotalFICATaxAnnual)
-			}
-
-			// Export savings analysis fields
-			safeSetFloat64(dataJS, "availableForSavings", data.AvailableForSavings)
-			safeSetFloat64(dataJS, "savingsRate", data.SavingsRate)
-			safeSetFloat64(dataJS, "freeCashFlow", data.FreeCashFlow)
-			safeSetFloat64(dataJS, "housingExpensesAnnual", data.HousingExpensesAnnual)
-
-			monthlyDataJS.SetIndex(i, dataJS)
-		}
-		obj.Set("monthlyData", monthlyDataJS)
-
-		// Set final net worth from last month's data
-		if len(result.MonthlyData) > 0 {
-			lastMonth := result.MonthlyData[len(result.MonthlyData)-1]
-			obj.Set("finalNetWorth", lastMonth.NetWorth) // Pass through actual value for simulation fidelity
-		}
-		// If no monthly data, do NOT use hardcoded fallbacks - this indicates a bug
-	}
-
-	// Add enhanced bankruptcy detection results
-	obj.Set("isBankrupt", result.IsBankrupt)
-	if result.BankruptcyMonth > 0 {
-		obj.Set("bankruptcyMonth", result.BankruptcyMonth)
-	}
-
-	// Add enhanced financial stress analytics
-	if result.BankruptcyTrigger != "" {
-		obj.Set("bankruptcyTrigger", result.BankruptcyTrigger)
-	}
-
-	// Bankruptcy is terminal - no timeline or consequences modeling needed
-	if result.MaxFinancialStressLevel > 0 {
-		obj.Set("maxFinancialStressLevel", result.MaxFinancialStressLevel)
-	}
-	if result.MonthsInFinancialStress > 0 {
-		obj.Set("monthsInFinancialStress", result.MonthsInFinancialStress)
-	}
-	if result.MinEmergencyFundMonths >= 0 {
-		safeSetFloat64(obj, "minEmergencyFundMonths", result.MinEmergencyFundMonths)
-	}
-	if result.MaxDebtServiceRatio > 0 {
-		safeSetFloat64(obj, "maxDebtServiceRatio", result.MaxDebtServiceRatio)
-	}
-	// No recovery strategy - bankruptcy is terminal
-
-	// finalNetWorth should always be set by simulation - if undefined, that's a bug
-	// Do NOT use hardcoded fallbacks as they mask simulation errors
-
-	return obj
-}
-
-
-// debugEventProcessingJS is the JavaScript wrapper for the debug function
-func debugEventProcessingJS(this js.Value, args []js.Value) interface{} {
-	// CRITICAL FIX: Remove goroutine to prevent Go runtime exit
-	// Execute synchronously to avoid goroutine accumulation that kills WASM runtime
-	defer func() {
-		if r := recover(); r != nil {
-			// Return error result on panic
-			errorResult := js.Global().Get("Object").New()
-			errorResult.Set("success", js.ValueOf(false))
-			errorResult.Set("error", js.ValueOf(fmt.Sprintf("Debug panic: %v", r)))
-		}
-	}()
-
-	// NOTE: Debug function temporarily disabled due to JS input format compatibility
-	// The issue was attempting to use js.ValueOf on a Go map, which doesn't work
-	// with parseSimulationInput expecting actual JavaScript objects with proper prototypes
-
-	// Return successful debug result without running test
-	testResults := map[string]interface{}{
-		"success": true,
-		"message": "Debug function called - test temporarily disabled",
-	}
-
-	return js.ValueOf(testResults)
-}
-
-// RunSingleSimulation creates an isolated engine for each simulation
-func RunSingleSimulation(input SimulationInput) (SimulationResult, error) {
-	simLogVerbose("🔍 [DEBUG] RunSingleSimulation started in main.go")
-
-	// PROPER DESIGN: Create isolated engine instance for each simulation
-	// This ensures thread safety and prevents state contamination
-	simLogVerbose("🔍 [DEBUG] About to call NewSimulationEngine with config")
-	simLogVerbose("🔍 [CRITICAL] Pre-NewSimulationEngine - Config pointer: %p", &input.Config)
-
-	// Add more defensive checks before engine creation
-	if input.Config.GarchSPYOmega == 0 {
-		return SimulationResult{Success: false, Error: "Config validation failed: GarchSPYOmega is zero"},
-			fmt.Errorf("config validation failed")
-	}
-
-	engine := NewSimulationEngine(input.Config)
-	simLogVerbose("🔍 [DEBUG] NewSimulationEngine completed successfully")
-
-	// Run the simulation with the isolated engine
-	simLogVerbose("🔍 [CRITICAL] About to call engine.RunSingleSimulation")
-	result := engine.RunSingleSimulation(input)
-
-	// Check if the simulation was successful
-	if !result.Success {
-		return result, fmt.Errorf("simulation failed: %s", result.Error)
-	}
-
-	return result, nil
-}
-
-// convertSingleSimulationResultToJS converts a single SimulationResult to JavaScript object
-func convertSingleSimulationResultToJS(result SimulationResult) js.Value {
-	obj := js.Global().Get("Object").New()
-	obj.Set("success", js.ValueOf(result.Success))
-	obj.Set("isBankrupt", js.ValueOf(result.IsBankrupt))
-
-	// CRITICAL BUG FIX: Directly extract finalNetWorth from last month data
-	// The issue is that finalNetWorth=0 despite correct monthly data values
-	var finalNetWorth float64 = 0.0
-
-	if len(result.MonthlyData) > 0 {
-		// Get the actual last month net worth value
-		lastMonthNetWorth := result.MonthlyData[len(result.MonthlyData)-1].NetWorth
-		finalNetWorth = lastMonthNetWorth
-	} else {
-		// Use reasonable defaults for edge cases
-		if result.IsBankrupt {
-			finalNetWorth = -50000.0
-		} else if result.Success {
-			finalNetWorth = 100000.0
-		}
-	}
-
-	// Force set the finalNetWorth with explicit value
-	obj.Set("finalNetWorth", finalNetWorth)
-
-	if result.Error != "" {
-		obj.Set("error", js.ValueOf(result.Error))
-	}
-
-	// Convert monthly data
-	monthlyDataArray := js.Global().Get("Array").New()
-	for _, monthData := range result.MonthlyData {
-		monthItem := js.Global().Get("Object").New()
-		monthItem.Set("monthOffset", js.ValueOf(monthData.MonthOffset))
-		monthItem.Set("netWorth", js.ValueOf(monthData.NetWorth))
-		monthItem.Set("cashFlow", js.ValueOf(monthData.CashFlow))
-		monthItem.Set("incomeThisMonth", js.ValueOf(monthData.IncomeThisMonth))
-		monthItem.Set("expensesThisMonth", js.ValueOf(monthData.ExpensesThisMonth))
-
-		// Account balances from the Accounts field
-		monthItem.Set("cash", js.ValueOf(monthData.Accounts.Cash))
-		if monthData.Accounts.Taxable != nil {
-			monthItem.Set("taxable", js.ValueOf(monthData.Accounts.Taxable.TotalValue))
-		}
-		if monthData.Accounts.TaxDeferred != nil {
-			monthItem.Set("taxDeferred", js.ValueOf(monthData.Accounts.TaxDeferred.TotalValue))
-		}
-		if monthData.Accounts.Roth != nil {
-			monthItem.Set("roth", js.ValueOf(monthData.Accounts.Roth.TotalValue))
-		}
-		if monthData.Accounts.FiveTwoNine != nil {
-			monthItem.Set("fiveTwoNine", js.ValueOf(monthData.Accounts.FiveTwoNine.TotalValue))
-		}
-
-		monthlyDataArray.Call("push", monthItem)
-	}
-	obj.Set("monthlyData", monthlyDataArray)
-
-	return obj
-}
+import "pathfinder-wasm/engine"
 
 func main() {
-    // Configuration will be loaded via JS function call from main thread
-    simLogVerbose("WASM startup: Ready to receive financial configuration from main thread")
-
-    simLogVerbose("WASM startup: Registering configuration function...")
-
-    // Prefer embedded configuration (hard-coded at build time) to avoid runtime loading issues
-    if err := LoadEmbeddedFinancialConfig(); err != nil {
-        simLogVerbose("⚠️  Embedded config load failed: %v", err)
-    } else {
-        simLogVerbose("✅ Embedded configuration loaded successfully")
-    }
-
-    // Register function to receive configuration data from main thread
-    registerJSFunc("loadConfigurationData", loadConfigurationDataJS)
-
-    // Register debug function to check config state
-    registerJSFunc("checkConfigState", checkConfigStateJS)
-
-	simLogVerbose("WASM startup: Registering core simulation functions...")
-
-    // Register core simulation functions synchronously
-    registerJSFunc("runMonteCarloSimulation", runMonteCarloSimulationJS)
-    registerJSFunc("runSingleSimulation", runSingleSimulationJS)
-    registerJSFunc("runDeterministicSimulation", runDeterministicSimulationJS)
-    registerJSFunc("testMathFunctions", testMathFunctionsJS)
-    registerJSFunc("debugEventProcessing", debugEventProcessingJS)
-    registerJSFunc("testJSONUnmarshal", testJSONUnmarshalJS)
-
-	// Register simulation verbosity control functions
-	registerJSFunc("setSimulationVerbosity", setSimulationVerbosityJS)
-	registerJSFunc("getSimulationVerbosity", getSimulationVerbosityJS)
-
-	simLogVerbose("WASM startup: Registering JSON-based functions...")
-
-    // Register new JSON-based marshalling functions
-    registerJSFunc("runSingleSimulationJSON", runSingleSimulationJSON)
-    registerJSFunc("runMonteCarloSimulationJSON", runMonteCarloSimulationJSON)
-    registerJSFunc("runDeterministicSimulationJSON", runDeterministicSimulationJSON)
-
-	simLogVerbose("WASM startup: Registering enhanced WASM functions...")
-
-	// Register enhanced WASM functions from wasm_bindings.go
-	registerWASMFunctions()
-
-	simLogVerbose("WASM startup: Signaling ready state...")
-
-	// Signal that WASM is ready
-	js.Global().Set("wasmReady", true)
-
-	simLogVerbose("WASM startup: Dispatching browser events...")
-
-	// Only dispatch browser events if we're in a browser environment
-	if !js.Global().Get("dispatchEvent").IsUndefined() && !js.Global().Get("CustomEvent").IsUndefined() {
-		js.Global().Call("dispatchEvent", js.Global().Get("CustomEvent").New("wasmLoaded"))
-		simLogVerbose("WASM startup: Browser event dispatched")
-	} else {
-		simLogVerbose("WASM startup: Browser event dispatch skipped (not in browser environment)")
-	}
-
-	simLogVerbose("WASM startup: Entering main loop to keep program alive...")
-
-	// Keep the program alive - this is the correct pattern for WASM
-	// The program must stay running to handle function calls from JavaScript
-	select {}
+	engine.Main()
 }